@@ -0,0 +1,290 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package bundler turns a specification that is split across files and remote documents into a single,
+// self-contained specification.
+//
+// Every referenced component is moved into the root document's components (or definitions, parameters and
+// responses for Swagger), and the references to it are rewritten into local references. Components with the same
+// content are only added once, and components that reference themselves stay circular.
+package bundler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/internal/nodes"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// Bundle creates a bundled copy of the specification indexed by idx, the specification held by the index is not
+// changed. File and remote references are looked up when the index is built, so the index must have been created
+// with a SpecIndexConfig that allows them. Polymorphic references are not looked up when the index is built, any
+// that point to a document that has not been indexed are looked up while bundling, which adds it to the index.
+func Bundle(idx *index.SpecIndex) (*yaml.Node, error) {
+	if idx == nil || idx.GetRootNode() == nil {
+		return nil, errors.New("unable to bundle specification, there is no document to bundle")
+	}
+	doc := nodes.Clone(idx.GetRootNode(), nil)
+	root := documentContent(doc)
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("unable to bundle specification, the document is not an object")
+	}
+	b := &bundler{
+		root:      root,
+		rootIndex: idx,
+		bundled:   make(map[string]string),
+		hashes:    make(map[string]string),
+		names:     make(map[string]map[string]bool),
+		inlining:  make(map[string]bool),
+	}
+	if nodes.MapValue(root, "swagger") != nil {
+		b.swagger = true
+	}
+	if openapi := nodes.MapValue(root, "openapi"); openapi != nil && !strings.HasPrefix(openapi.Value, "3.0") {
+		b.pathItems = true
+	}
+	b.registerComponents()
+	if err := b.walk(root, nil, idx); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// BundleYAML bundles the specification indexed by idx and renders it as YAML.
+func BundleYAML(idx *index.SpecIndex) ([]byte, error) {
+	bundled, err := Bundle(idx)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(bundled)
+}
+
+// BundleJSON bundles the specification indexed by idx and renders it as JSON, keeping the order of every object.
+func BundleJSON(idx *index.SpecIndex) ([]byte, error) {
+	bundled, err := Bundle(idx)
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSON(bundled)
+}
+
+// BundleBytes indexes a specification using config, and bundles it. The bundled specification is rendered in the
+// same format as spec, JSON or YAML.
+func BundleBytes(spec []byte, config *index.SpecIndexConfig) ([]byte, error) {
+	info, err := datamodel.ExtractSpecInfo(spec)
+	if err != nil {
+		return nil, err
+	}
+	idx := index.NewSpecIndexWithConfig(info.RootNode, config)
+	if info.SpecFileType == datamodel.JSONFileType {
+		return BundleJSON(idx)
+	}
+	return BundleYAML(idx)
+}
+
+// bundler holds the state of a single bundle, components are added to root as references are found.
+type bundler struct {
+	root      *yaml.Node
+	rootIndex *index.SpecIndex
+	swagger   bool
+	pathItems bool
+
+	// bundled holds the local reference of every component that has been bundled, by location and fragment.
+	bundled map[string]string
+
+	// hashes holds the local reference of every component by section and the hash of its content.
+	hashes map[string]string
+
+	// names holds the names taken in each section.
+	names map[string]map[string]bool
+
+	// inlining holds the components that are being inlined, to catch circular references that cannot be inlined.
+	inlining map[string]bool
+}
+
+// registerComponents reserves the names of the components the root document already has, so bundled components
+// never replace them, and records their hashes, so identical components are not added again.
+func (b *bundler) registerComponents() {
+	for _, kind := range componentKinds {
+		section := b.section(kind)
+		if section == "" {
+			continue
+		}
+		components := b.findSection(section)
+		if components == nil || components.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i < len(components.Content)-1; i += 2 {
+			name := components.Content[i].Value
+			b.takeName(section, name)
+			b.hashes[section+":"+hashComponent(components.Content[i+1], b.rootIndex)] = localReference(section, name)
+		}
+	}
+}
+
+// walk finds every reference beneath node, and bundles it. keys is the path of node from the root of the
+// specification (a sequence item has the key '[]'), it decides what kind of component a reference is. doc is the
+// index of the document the node comes from, references are relative to it.
+func (b *bundler) walk(node *yaml.Node, keys []string, doc *index.SpecIndex) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if ref := nodes.MapValue(node, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+			return b.bundleReference(node, ref, keys, doc)
+		}
+		// bundled components are appended to the root while it is walked, they are walked when they are added.
+		content := append([]*yaml.Node(nil), node.Content...)
+		for i := 0; i < len(content)-1; i += 2 {
+			if isData(keys, content[i].Value, content[i+1]) {
+				continue
+			}
+			if err := b.walk(content[i+1], appendKey(keys, content[i].Value), doc); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range append([]*yaml.Node(nil), node.Content...) {
+			if err := b.walk(item, appendKey(keys, "[]"), doc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bundleReference rewrites a reference into a local reference to a component in the root document, adding the
+// component if it has not been added yet. Components that cannot be held by the components of the root document are
+// inlined instead.
+func (b *bundler) bundleReference(node, ref *yaml.Node, keys []string, doc *index.SpecIndex) error {
+	file, fragment := splitReference(ref.Value)
+	if file == "" && doc == b.rootIndex {
+		return nil // local references of the root document are already local.
+	}
+	targetIndex := doc
+	if file != "" {
+		if _, targetIndex = doc.FindIndexedComponent(file); targetIndex == nil {
+			// polymorphic references are not looked up when a document is indexed, so look it up now.
+			doc.FindComponent(ref.Value, node)
+			targetIndex = doc.GetAllExternalIndexes()[file]
+		}
+		if targetIndex == nil {
+			return fmt.Errorf("unable to bundle reference '%s', the document it references cannot be found", ref.Value)
+		}
+	}
+	target := findFragment(targetIndex.GetRootNode(), fragment)
+	if target == nil {
+		return fmt.Errorf("unable to bundle reference '%s', the component it references cannot be found", ref.Value)
+	}
+
+	id := targetIndex.GetLocation() + "#" + fragment
+	if local, ok := b.bundled[id]; ok {
+		ref.Value = local
+		return nil
+	}
+	kind := componentKind(fragment, keys)
+	section := b.section(kind)
+	if section == "" {
+		return b.inline(node, ref.Value, target, id, keys, targetIndex)
+	}
+	hash := section + ":" + hashComponent(target, targetIndex)
+	if local, ok := b.hashes[hash]; ok {
+		b.bundled[id] = local
+		ref.Value = local
+		return nil
+	}
+
+	// the component is registered before it is walked, so references back to it (circular references) find it.
+	name := b.uniqueName(section, componentName(fragment, targetIndex.GetLocation()))
+	local := localReference(section, name)
+	b.bundled[id] = local
+	b.hashes[hash] = local
+	ref.Value = local
+
+	component := nodes.Clone(target, nil)
+	components := b.createSection(section)
+	components.Content = append(components.Content, nodes.NewString(name), component)
+	return b.walk(component, appendKey(strings.Split(section, "/"), name), targetIndex)
+}
+
+// inline replaces a reference with a copy of the component it references, for components the root document cannot
+// hold, such as path items in OpenAPI 3.0 or headers in Swagger.
+func (b *bundler) inline(node *yaml.Node, ref string, target *yaml.Node, id string, keys []string,
+	targetIndex *index.SpecIndex) error {
+	if b.inlining[id] {
+		return fmt.Errorf("unable to bundle reference '%s', it is circular and cannot be inlined", ref)
+	}
+	b.inlining[id] = true
+	defer delete(b.inlining, id)
+	*node = *nodes.Clone(target, nil)
+	return b.walk(node, keys, targetIndex)
+}
+
+// section returns the path of the section of the root document that holds a kind of component, or an empty string
+// if the root document cannot hold it.
+func (b *bundler) section(kind string) string {
+	if b.swagger {
+		switch kind {
+		case schemas:
+			return "definitions"
+		case parameters, responses:
+			return kind
+		}
+		return ""
+	}
+	if kind == pathItems && !b.pathItems {
+		return ""
+	}
+	return "components/" + kind
+}
+
+// findSection returns the mapping node of a section of the root document, or nil if it does not exist.
+func (b *bundler) findSection(section string) *yaml.Node {
+	node := b.root
+	for _, key := range strings.Split(section, "/") {
+		if node = nodes.MapValue(node, key); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// createSection returns the mapping node of a section of the root document, creating it if it does not exist.
+func (b *bundler) createSection(section string) *yaml.Node {
+	node := b.root
+	for _, key := range strings.Split(section, "/") {
+		value := nodes.MapValue(node, key)
+		if value == nil {
+			value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, nodes.NewString(key), value)
+		}
+		node = value
+	}
+	return node
+}
+
+// takeName reserves a name in a section, it returns false if the name has already been taken.
+func (b *bundler) takeName(section, name string) bool {
+	if b.names[section] == nil {
+		b.names[section] = make(map[string]bool)
+	}
+	if b.names[section][name] {
+		return false
+	}
+	b.names[section][name] = true
+	return true
+}
+
+// uniqueName reserves a name for a component in a section. If the name has been taken, it is given the lowest
+// numbered suffix that has not, so the same specification is always bundled the same way.
+func (b *bundler) uniqueName(section, name string) string {
+	if b.takeName(section, name) {
+		return name
+	}
+	for i := 1; ; i++ {
+		if candidate := fmt.Sprintf("%s_%d", name, i); b.takeName(section, candidate) {
+			return candidate
+		}
+	}
+}
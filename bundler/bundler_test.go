@@ -0,0 +1,322 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"encoding/json"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/resolver"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+	"testing/fstest"
+)
+
+var rootSpec = `openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: 'common.yaml#/components/parameters/Limit'
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: 'schemas/pet.yaml#/Pet'
+        "404":
+          $ref: 'common.yaml#/components/responses/NotFound'
+        default:
+          description: error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /owners:
+    $ref: 'paths/owners.yaml'
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        code:
+          type: integer`
+
+var petSchemas = `Pet:
+  type: object
+  properties:
+    name:
+      type: string
+    owner:
+      $ref: 'owner.yaml'
+    tag:
+      $ref: '#/Tag'
+Tag:
+  type: string`
+
+var ownerSchema = `type: object
+properties:
+  pets:
+    type: array
+    items:
+      $ref: 'pet.yaml#/Pet'
+  tag:
+    $ref: '../common.yaml#/components/schemas/Tag'`
+
+var commonSpec = `openapi: 3.0.3
+components:
+  schemas:
+    Tag:
+      type: string
+    Error:
+      type: string
+      description: what went wrong
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  responses:
+    NotFound:
+      description: not found
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Error'`
+
+var ownersPath = `get:
+  responses:
+    "200":
+      description: owners
+      content:
+        application/json:
+          schema:
+            type: array
+            items:
+              $ref: '../schemas/owner.yaml'`
+
+func newTestIndex(t *testing.T, spec string) *index.SpecIndex {
+	config := index.CreateClosedAPIIndexConfig()
+	config.AllowFileLookup = true
+	config.BasePath = "specs"
+	config.FS = fstest.MapFS{
+		"specs/schemas/pet.yaml":   &fstest.MapFile{Data: []byte(petSchemas)},
+		"specs/schemas/owner.yaml": &fstest.MapFile{Data: []byte(ownerSchema)},
+		"specs/common.yaml":        &fstest.MapFile{Data: []byte(commonSpec)},
+		"specs/paths/owners.yaml":  &fstest.MapFile{Data: []byte(ownersPath)},
+	}
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &rootNode))
+	idx := index.NewSpecIndexWithConfig(&rootNode, config)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	return idx
+}
+
+func TestBundle(t *testing.T) {
+	idx := newTestIndex(t, rootSpec)
+	bundled, err := Bundle(idx)
+	assert.NoError(t, err)
+
+	root := documentContent(bundled)
+	schemas := findFragment(root, "/components/schemas")
+	assert.Equal(t, "#/components/schemas/Pet", findFragment(root,
+		"/paths/~1pets/get/responses/200/content/application~1json/schema/items/$ref").Value)
+	assert.Equal(t, "#/components/parameters/Limit",
+		findFragment(root, "/paths/~1pets/get/parameters/0/$ref").Value)
+	assert.Equal(t, "#/components/responses/NotFound", findFragment(root, "/paths/~1pets/get/responses/404/$ref").Value)
+
+	// a whole document is named after the document, and circular references stay circular.
+	assert.Equal(t, "#/components/schemas/owner", findFragment(schemas, "/Pet/properties/owner/$ref").Value)
+	assert.Equal(t, "#/components/schemas/Pet", findFragment(schemas, "/owner/properties/pets/items/$ref").Value)
+
+	// the same tag schema in two documents is only bundled once.
+	assert.Equal(t, "#/components/schemas/Tag", findFragment(schemas, "/Pet/properties/tag/$ref").Value)
+	assert.Equal(t, "#/components/schemas/Tag", findFragment(schemas, "/owner/properties/tag/$ref").Value)
+
+	// the error schema of the root document is kept, the different one from common.yaml is renamed.
+	assert.Equal(t, "object", findFragment(schemas, "/Error/type").Value)
+	assert.Equal(t, "string", findFragment(schemas, "/Error_1/type").Value)
+	assert.Equal(t, "#/components/schemas/Error_1",
+		findFragment(root, "/components/responses/NotFound/content/application~1json/schema/$ref").Value)
+
+	// path items cannot be components in OpenAPI 3.0, so they are inlined.
+	assert.Equal(t, "#/components/schemas/owner", findFragment(root,
+		"/paths/~1owners/get/responses/200/content/application~1json/schema/items/$ref").Value)
+
+	var keys []string
+	for i := 0; i < len(schemas.Content); i += 2 {
+		keys = append(keys, schemas.Content[i].Value)
+	}
+	assert.Equal(t, []string{"Error", "Pet", "owner", "Tag", "Error_1"}, keys)
+
+	// the specification held by the index is not changed.
+	assert.Equal(t, "schemas/pet.yaml#/Pet", findFragment(idx.GetRootNode(),
+		"/paths/~1pets/get/responses/200/content/application~1json/schema/items/$ref").Value)
+}
+
+func TestBundle_SelfContained(t *testing.T) {
+	spec, err := BundleYAML(newTestIndex(t, rootSpec))
+	assert.NoError(t, err)
+
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal(spec, &rootNode))
+	idx := index.NewSpecIndexWithConfig(&rootNode, index.CreateClosedAPIIndexConfig())
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Empty(t, idx.GetAllExternalIndexes())
+
+	res := resolver.NewResolver(idx)
+	res.CheckForCircularReferences()
+	assert.Len(t, res.GetCircularErrors(), 1)
+
+	// bundling is deterministic.
+	again, err := BundleYAML(newTestIndex(t, rootSpec))
+	assert.NoError(t, err)
+	assert.Equal(t, string(spec), string(again))
+}
+
+func TestBundle_OpenAPI31PathItems(t *testing.T) {
+	bundled, err := Bundle(newTestIndex(t, `openapi: 3.1.0
+paths:
+  /owners:
+    $ref: 'paths/owners.yaml'`))
+	assert.NoError(t, err)
+	root := documentContent(bundled)
+	assert.Equal(t, "#/components/pathItems/owners", findFragment(root, "/paths/~1owners/$ref").Value)
+	assert.NotNil(t, findFragment(root, "/components/pathItems/owners/get"))
+	assert.NotNil(t, findFragment(root, "/components/schemas/Pet"))
+}
+
+func TestBundle_Swagger(t *testing.T) {
+	bundled, err := Bundle(newTestIndex(t, `swagger: "2.0"
+paths:
+  /pets:
+    post:
+      parameters:
+        - in: body
+          name: pet
+          schema:
+            $ref: 'schemas/pet.yaml#/Pet'
+      responses:
+        "404":
+          $ref: 'common.yaml#/components/responses/NotFound'`))
+	assert.NoError(t, err)
+	root := documentContent(bundled)
+	assert.Equal(t, "#/definitions/Pet", findFragment(root, "/paths/~1pets/post/parameters/0/schema/$ref").Value)
+	assert.Equal(t, "#/responses/NotFound", findFragment(root, "/paths/~1pets/post/responses/404/$ref").Value)
+	assert.Equal(t, "#/definitions/Pet", findFragment(root, "/definitions/owner/properties/pets/items/$ref").Value)
+	assert.Equal(t, "#/definitions/Error",
+		findFragment(root, "/responses/NotFound/content/application~1json/schema/$ref").Value)
+	assert.Nil(t, findFragment(root, "/components"))
+}
+
+func TestIsData(t *testing.T) {
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	assert.True(t, isData([]string{"components", "schemas", "Pet"}, "example", mapping))
+	assert.True(t, isData([]string{"components", "schemas", "Pet"}, "enum", seq))
+	assert.True(t, isData([]string{"components", "schemas", "Pet"}, "examples", seq))
+	assert.True(t, isData([]string{"components", "examples", "Pet"}, "value", mapping))
+	assert.False(t, isData([]string{"components", "schemas", "Pet", "properties"}, "example", mapping))
+	assert.False(t, isData([]string{"paths", "/pets", "get", "responses"}, "default", mapping))
+	assert.False(t, isData([]string{"components", "parameters", "Limit"}, "examples", mapping))
+	assert.False(t, isData([]string{"components", "schemas", "Pet", "properties"}, "value", mapping))
+}
+
+func TestBundleBytes_JSON(t *testing.T) {
+	config := index.CreateOpenAPIIndexConfig()
+	config.FS = fstest.MapFS{"pet.json": &fstest.MapFile{Data: []byte(`{"Pet": {"type": "object"}}`)}}
+
+	spec, err := BundleBytes([]byte(`{"openapi": "3.0.3", "paths": {"/pets": {"get": {"responses": {
+  "200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "pet.json#/Pet"}}}}}}}}}`),
+		config)
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(spec))
+	assert.Equal(t, `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/Pet"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object"
+      }
+    }
+  }
+}`, string(spec))
+}
+
+func TestBundle_MissingDocument(t *testing.T) {
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(`openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      $ref: 'missing.yaml#/Pet'`), &rootNode))
+	idx := index.NewSpecIndexWithConfig(&rootNode, index.CreateClosedAPIIndexConfig())
+
+	_, err := Bundle(idx)
+	assert.EqualError(t, err, "unable to bundle reference 'missing.yaml#/Pet', the document it references cannot be found")
+
+	_, err = Bundle(nil)
+	assert.Error(t, err)
+}
+
+func TestBundle_PolymorphicReferences(t *testing.T) {
+	bundled, err := Bundle(newTestIndex(t, `openapi: 3.0.3
+components:
+  schemas:
+    Named:
+      oneOf:
+        - $ref: 'schemas/pet.yaml#/Pet'
+        - $ref: 'common.yaml#/components/schemas/Error'`))
+	assert.NoError(t, err)
+	root := documentContent(bundled)
+	assert.Equal(t, "#/components/schemas/Pet", findFragment(root, "/components/schemas/Named/oneOf/0/$ref").Value)
+	assert.Equal(t, "#/components/schemas/Error", findFragment(root, "/components/schemas/Named/oneOf/1/$ref").Value)
+	assert.Equal(t, "#/components/schemas/owner",
+		findFragment(root, "/components/schemas/Pet/properties/owner/$ref").Value)
+}
+
+func TestBundle_PolymorphicReferences_Indexed(t *testing.T) {
+	idx := newTestIndex(t, `openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      $ref: 'schemas/pet.yaml#/Pet'
+    Named:
+      oneOf:
+        - $ref: './schemas/pet.yaml#/Pet'`)
+	external := len(idx.GetAllExternalIndexes())
+
+	// the polymorphic reference is to a document that is already indexed, so nothing is looked up.
+	bundled, err := Bundle(idx)
+	assert.NoError(t, err)
+	root := documentContent(bundled)
+	assert.Equal(t, findFragment(root, "/components/schemas/Pet/$ref").Value,
+		findFragment(root, "/components/schemas/Named/oneOf/0/$ref").Value)
+	assert.Len(t, idx.GetAllExternalIndexes(), external)
+}
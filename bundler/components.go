@@ -0,0 +1,194 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/internal/nodes"
+	"gopkg.in/yaml.v3"
+	"hash"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// the kinds of component, named after the section of the components object that holds them.
+const (
+	schemas         = "schemas"
+	responses       = "responses"
+	parameters      = "parameters"
+	examples        = "examples"
+	requestBodies   = "requestBodies"
+	headers         = "headers"
+	securitySchemes = "securitySchemes"
+	links           = "links"
+	callbacks       = "callbacks"
+	pathItems       = "pathItems"
+)
+
+var componentKinds = []string{schemas, responses, parameters, examples, requestBodies, headers, securitySchemes,
+	links, callbacks, pathItems}
+
+// schemaKeys are the keys that hold a single schema, in a schema or a parameter, header or media type.
+var schemaKeys = map[string]bool{
+	"schema": true, "items": true, "additionalProperties": true, "not": true, "if": true, "then": true, "else": true,
+	"contains": true, "propertyNames": true, "additionalItems": true, "unevaluatedItems": true,
+	"unevaluatedProperties": true, "contentSchema": true,
+}
+
+// schemaMapKeys are the keys that hold a map of schemas.
+var schemaMapKeys = map[string]bool{
+	"schemas": true, "properties": true, "patternProperties": true, "definitions": true, "$defs": true,
+	"dependentSchemas": true,
+}
+
+// schemaListKeys are the keys that hold a list of schemas.
+var schemaListKeys = map[string]bool{"allOf": true, "anyOf": true, "oneOf": true, "prefixItems": true}
+
+// invalidNameCharacters are the characters that cannot be used in the name of a component.
+var invalidNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// componentKind decides the kind of component a reference is. A reference to a component of another document
+// (such as '#/components/schemas/Pet', or '#/definitions/Pet') keeps its kind, otherwise the kind is decided by
+// where the reference is, using keys (the path of the reference from the root of the specification).
+func componentKind(fragment string, keys []string) string {
+	segments := fragmentSegments(fragment)
+	switch {
+	case len(segments) == 3 && segments[0] == "components":
+		for _, kind := range componentKinds {
+			if segments[1] == kind {
+				return kind
+			}
+		}
+	case len(segments) == 2:
+		switch segments[0] {
+		case "definitions":
+			return schemas
+		case parameters, responses:
+			return segments[0]
+		case "paths":
+			return pathItems
+		}
+	}
+	return contextKind(keys)
+}
+
+// contextKind decides the kind of component a reference is by where it is.
+func contextKind(keys []string) string {
+	key := func(i int) string {
+		if i := len(keys) - i; i >= 0 {
+			return keys[i]
+		}
+		return ""
+	}
+	parent, grandparent := key(1), key(2)
+	switch {
+	case schemaMapKeys[grandparent], schemaKeys[parent]:
+		return schemas
+	case parent == "[]" && schemaListKeys[grandparent]:
+		return schemas
+	case grandparent == parameters:
+		return parameters
+	case parent == "requestBody", grandparent == requestBodies:
+		return requestBodies
+	case grandparent == responses:
+		return responses
+	case grandparent == headers:
+		return headers
+	case grandparent == examples:
+		return examples
+	case grandparent == links:
+		return links
+	case grandparent == callbacks:
+		return callbacks
+	case grandparent == securitySchemes:
+		return securitySchemes
+	case grandparent == "paths", grandparent == pathItems, grandparent == "webhooks", key(3) == callbacks:
+		return pathItems
+	}
+	return schemas
+}
+
+// componentName returns the name a bundled component is given: the last segment of the fragment of the reference,
+// or the name of the document without an extension, when the whole document is referenced.
+func componentName(fragment, location string) string {
+	var name string
+	if segments := fragmentSegments(fragment); len(segments) > 0 {
+		name = segments[len(segments)-1]
+	} else {
+		if i := strings.IndexAny(location, "?#"); i >= 0 {
+			location = location[:i]
+		}
+		name = path.Base(location)
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+	name = strings.Trim(invalidNameCharacters.ReplaceAllString(name, "_"), "_")
+	if name == "" || name == "." {
+		return "component"
+	}
+	return name
+}
+
+// localReference returns a reference to a component in a section of the root document.
+func localReference(section, name string) string {
+	return fmt.Sprintf("#/%s/%s", section, name)
+}
+
+// isData returns true if the value of a key in the mapping at keys is data (an example, a default or an enum)
+// rather than part of the specification, so any '$ref' inside it is not a reference.
+func isData(keys []string, key string, value *yaml.Node) bool {
+	if len(keys) > 0 && (schemaMapKeys[keys[len(keys)-1]] || keys[len(keys)-1] == responses) {
+		return false // the key is the name of a property or a response code.
+	}
+	switch key {
+	case "example", "default", "enum", "const":
+		return true
+	case "value":
+		return len(keys) > 1 && keys[len(keys)-2] == examples
+	case examples:
+		// a schema holds a list of examples, everything else holds a map of example objects.
+		return value.Kind == yaml.SequenceNode
+	}
+	return false
+}
+
+// hashComponent returns the hash of the content of a component, from the document indexed by doc. References are
+// hashed by the location they resolve to, so the same reference from documents in different places does not look the
+// same.
+func hashComponent(node *yaml.Node, doc *index.SpecIndex) string {
+	h := sha256.New()
+	writeHash(h, node, doc)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func writeHash(h hash.Hash, node *yaml.Node, doc *index.SpecIndex) {
+	node = nodes.Resolve(node)
+	if node == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(h, "%d:%s:%d:%q;", node.Kind, node.ShortTag(), len(node.Content), node.Value)
+	for i, child := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 1 && node.Content[i-1].Value == "$ref" &&
+			child.Kind == yaml.ScalarNode {
+			_, _ = fmt.Fprintf(h, "ref:%q;", resolvedReference(child.Value, doc))
+			continue
+		}
+		writeHash(h, child, doc)
+	}
+}
+
+// resolvedReference returns the location and fragment a reference in the document indexed by doc resolves to.
+func resolvedReference(ref string, doc *index.SpecIndex) string {
+	file, fragment := splitReference(ref)
+	location := doc.GetLocation()
+	if file != "" {
+		location = file
+		if external := doc.GetAllExternalIndexes()[file]; external != nil {
+			location = external.GetLocation()
+		}
+	}
+	return location + "#" + fragment
+}
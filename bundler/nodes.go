@@ -0,0 +1,142 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/pb33f/libopenapi/internal/nodes"
+	"gopkg.in/yaml.v3"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// documentContent returns the content of a document node, or the node itself if it is not a document.
+func documentContent(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+	return node
+}
+
+// appendKey returns a copy of keys with key added, so paths that share a parent never share a slice.
+func appendKey(keys []string, key string) []string {
+	path := make([]string, len(keys), len(keys)+1)
+	copy(path, keys)
+	return append(path, key)
+}
+
+// splitReference splits a reference into the document it references, and the fragment (a JSON pointer) to the
+// component in that document.
+func splitReference(ref string) (string, string) {
+	if i := strings.Index(ref, "#"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// fragmentSegments returns the unescaped segments of a fragment, an empty or '/' fragment has no segments.
+func fragmentSegments(fragment string) []string {
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		fragment = unescaped
+	}
+	fragment = strings.Trim(fragment, "/")
+	if fragment == "" {
+		return nil
+	}
+	segments := strings.Split(fragment, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+	}
+	return segments
+}
+
+// findFragment returns the node a fragment points to in a document, or nil if there is no such node.
+func findFragment(root *yaml.Node, fragment string) *yaml.Node {
+	node := nodes.Resolve(documentContent(root))
+	for _, segment := range fragmentSegments(fragment) {
+		if node == nil {
+			return nil
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = nodes.MapValue(node, segment)
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil
+			}
+			node = nodes.Resolve(node.Content[i])
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+// marshalJSON renders a node as indented JSON. Unlike decoding the node and encoding the result, the order of
+// every object is kept.
+func marshalJSON(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, documentContent(node)); err != nil {
+		return nil, err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+func writeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	node = nodes.Resolve(node)
+	if node == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		var value interface{} = node.Value
+		switch node.ShortTag() {
+		case "!!null":
+			value = nil
+		case "!!bool", "!!int", "!!float":
+			if err := node.Decode(&value); err != nil {
+				return err
+			}
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package converter converts Swagger (OpenAPI 2) specifications into OpenAPI 3.0 specifications.
+//
+// The conversion works on the rendered Swagger specification, so any changes made to the high-level Swagger model
+// are converted. Everything in the Swagger specification has an equivalent in OpenAPI 3.0, apart from the 'tsv'
+// collectionFormat (which is converted as if it were 'csv') and the name of a body parameter.
+package converter
+
+import (
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/internal/nodes"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// OpenAPIVersion is the version of OpenAPI that Swagger specifications are converted into.
+const OpenAPIVersion = "3.0.3"
+
+// defaultMediaType is used for request bodies and responses when the Swagger specification does not define
+// 'consumes' or 'produces'.
+const defaultMediaType = "application/json"
+
+// ConvertSwagger will convert a high-level Swagger document into an OpenAPI 3.0 Document. The converted
+// specification is returned as YAML alongside the Document, which has been built (and indexed) from it.
+//
+// If the converted specification cannot be built, no Document is returned, instead a slice of errors will explain
+// everything that failed.
+func ConvertSwagger(swagger *v2high.Swagger) (*v3high.Document, []byte, []error) {
+	if swagger == nil {
+		return nil, nil, []error{fmt.Errorf("unable to convert swagger document, no document has been supplied")}
+	}
+	root, err := ConvertSwaggerNode(swagger.RenderDocument())
+	if err != nil {
+		return nil, nil, []error{err}
+	}
+	spec, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, nil, []error{fmt.Errorf("unable to convert swagger document, cannot render: %s", err.Error())}
+	}
+	info, err := datamodel.ExtractSpecInfo(spec)
+	if err != nil {
+		return nil, spec, []error{err}
+	}
+	lowDoc, errs := v3low.CreateDocument(info)
+	if errs != nil {
+		return nil, spec, errs
+	}
+	return v3high.NewDocument(lowDoc), spec, nil
+}
+
+// ConvertLowSwagger will convert a low-level Swagger document into an OpenAPI 3.0 Document, see ConvertSwagger.
+func ConvertLowSwagger(swagger *v2low.Swagger) (*v3high.Document, []byte, []error) {
+	if swagger == nil {
+		return nil, nil, []error{fmt.Errorf("unable to convert swagger document, no document has been supplied")}
+	}
+	return ConvertSwagger(v2high.NewSwaggerDocument(swagger))
+}
+
+// ConvertSwaggerNode will convert the root node of a Swagger specification into the root node of an OpenAPI 3.0
+// specification. The Swagger specification is not modified, every node in the OpenAPI specification is new.
+//
+// An error is returned if the node is not a Swagger specification.
+func ConvertSwaggerNode(root *yaml.Node) (*yaml.Node, error) {
+	root = nodes.Resolve(root)
+	if root == nil || root.Kind != yaml.MappingNode || !strings.HasPrefix(mapString(root, "swagger"), "2") {
+		return nil, fmt.Errorf("unable to convert swagger document, the node is not a swagger specification")
+	}
+	c := &converter{
+		root:       root,
+		parameters: nodes.MapValue(root, "parameters"),
+		consumes:   mapStrings(root, "consumes"),
+		produces:   mapStrings(root, "produces"),
+		schemes:    mapStrings(root, "schemes"),
+	}
+	return c.document(), nil
+}
+
+// converter holds the parts of a Swagger specification that are used throughout the conversion.
+type converter struct {
+	root       *yaml.Node
+	parameters *yaml.Node
+	consumes   []string
+	produces   []string
+	schemes    []string
+}
+
+func (c *converter) document() *yaml.Node {
+	doc := newMap()
+	set(doc, "openapi", nodes.NewString(OpenAPIVersion))
+	set(doc, "info", nodes.Clone(nodes.MapValue(c.root, "info"), nil))
+	if servers := c.servers(c.schemes); !isEmpty(servers) {
+		set(doc, "servers", servers)
+	}
+	forEach(c.root, func(key string, value *yaml.Node) {
+		switch key {
+		case "tags", "externalDocs", "security":
+			set(doc, key, nodes.Clone(value, nil))
+		case "paths":
+			set(doc, key, c.paths(value))
+		default:
+			if isExtension(key) {
+				set(doc, key, nodes.Clone(value, c.rewriteRef))
+			}
+		}
+	})
+	if nodes.MapValue(doc, "paths") == nil {
+		set(doc, "paths", newMap())
+	}
+	if components := c.components(); !isEmpty(components) {
+		set(doc, "components", components)
+	}
+	return doc
+}
+
+// servers converts the host, basePath and schemes of the specification into servers. If there is no host, a single
+// server with the basePath is returned. If there is neither, no servers are returned.
+func (c *converter) servers(schemes []string) *yaml.Node {
+	host, basePath := mapString(c.root, "host"), mapString(c.root, "basePath")
+	if basePath == "/" {
+		basePath = ""
+	}
+	servers := newSeq()
+	server := func(url string) {
+		s := newMap()
+		set(s, "url", nodes.NewString(url))
+		servers.Content = append(servers.Content, s)
+	}
+	switch {
+	case host == "" && basePath == "":
+		return nil
+	case host == "":
+		server(basePath)
+	case len(schemes) == 0:
+		server("//" + host + basePath)
+	default:
+		for _, scheme := range schemes {
+			server(scheme + "://" + host + basePath)
+		}
+	}
+	return servers
+}
+
+func (c *converter) components() *yaml.Node {
+	components := newMap()
+	if definitions := nodes.MapValue(c.root, "definitions"); definitions != nil {
+		schemas := newMap()
+		forEach(definitions, func(name string, schema *yaml.Node) {
+			set(schemas, name, c.schema(schema))
+		})
+		set(components, "schemas", schemas)
+	}
+	if responses := nodes.MapValue(c.root, "responses"); responses != nil {
+		converted := newMap()
+		forEach(responses, func(name string, response *yaml.Node) {
+			set(converted, name, c.response(response, c.produces))
+		})
+		set(components, "responses", converted)
+	}
+	parameters, requestBodies := newMap(), newMap()
+	forEach(c.parameters, func(name string, param *yaml.Node) {
+		switch mapString(param, "in") {
+		case "body":
+			set(requestBodies, name, c.requestBody(param, c.consumes))
+		case "formData":
+			// form data parameters are merged into the request body of each operation that uses them.
+		default:
+			set(parameters, name, c.parameter(param))
+		}
+	})
+	if !isEmpty(parameters) {
+		set(components, "parameters", parameters)
+	}
+	if !isEmpty(requestBodies) {
+		set(components, "requestBodies", requestBodies)
+	}
+	if definitions := nodes.MapValue(c.root, "securityDefinitions"); definitions != nil {
+		schemes := newMap()
+		forEach(definitions, func(name string, scheme *yaml.Node) {
+			set(schemes, name, c.securityScheme(scheme))
+		})
+		set(components, "securitySchemes", schemes)
+	}
+	return components
+}
+
+// oauthFlows maps the Swagger OAuth2 flows to their OpenAPI 3 names.
+var oauthFlows = map[string]string{
+	"implicit":    "implicit",
+	"password":    "password",
+	"application": "clientCredentials",
+	"accessCode":  "authorizationCode",
+}
+
+func (c *converter) securityScheme(scheme *yaml.Node) *yaml.Node {
+	converted := newMap()
+	forEach(scheme, func(key string, value *yaml.Node) {
+		switch key {
+		case "type":
+			switch value.Value {
+			case "basic":
+				set(converted, "type", nodes.NewString("http"))
+				set(converted, "scheme", nodes.NewString("basic"))
+			default:
+				set(converted, "type", nodes.Clone(value, nil))
+			}
+		case "flow":
+			flow := newMap()
+			for _, k := range []string{"authorizationUrl", "tokenUrl"} {
+				if v := nodes.MapValue(scheme, k); v != nil {
+					set(flow, k, nodes.Clone(v, nil))
+				}
+			}
+			scopes := nodes.Clone(nodes.MapValue(scheme, "scopes"), nil)
+			if scopes == nil {
+				scopes = newMap()
+			}
+			set(flow, "scopes", scopes)
+			flows := newMap()
+			set(flows, oauthFlows[value.Value], flow)
+			set(converted, "flows", flows)
+		case "authorizationUrl", "tokenUrl", "scopes":
+			// moved into the flow.
+		default:
+			set(converted, key, nodes.Clone(value, nil))
+		}
+	})
+	return converted
+}
+
+// rewriteRef rewrites a reference to a Swagger definition, parameter or response into a reference to the equivalent
+// OpenAPI 3 component. References to body parameters in this specification become references to request bodies.
+func (c *converter) rewriteRef(ref string) string {
+	file, fragment, found := strings.Cut(ref, "#")
+	if !found {
+		return ref
+	}
+	switch {
+	case strings.HasPrefix(fragment, "/definitions/"):
+		fragment = "/components/schemas/" + strings.TrimPrefix(fragment, "/definitions/")
+	case strings.HasPrefix(fragment, "/responses/"):
+		fragment = "/components/responses/" + strings.TrimPrefix(fragment, "/responses/")
+	case strings.HasPrefix(fragment, "/parameters/"):
+		name := strings.TrimPrefix(fragment, "/parameters/")
+		if file == "" && mapString(nodes.MapValue(c.parameters, name), "in") == "body" {
+			fragment = "/components/requestBodies/" + name
+		} else {
+			fragment = "/components/parameters/" + name
+		}
+	}
+	return file + "#" + fragment
+}
+
+// localParameter returns the parameter a reference points to, if it is defined in this specification.
+func (c *converter) localParameter(ref string) *yaml.Node {
+	if !strings.HasPrefix(ref, "#/parameters/") {
+		return nil
+	}
+	return nodes.MapValue(c.parameters, strings.TrimPrefix(ref, "#/parameters/"))
+}
+
+// schema converts a Swagger schema into an OpenAPI 3.0 schema. References are rewritten, 'x-nullable' becomes
+// 'nullable', a discriminator becomes an object, and files become binary strings.
+func (c *converter) schema(schema *yaml.Node) *yaml.Node {
+	converted := nodes.Clone(schema, c.rewriteRef)
+	convertSchema(converted)
+	return converted
+}
+
+func convertSchema(schema *yaml.Node) {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(schema.Content)-1; i += 2 {
+		key, value := schema.Content[i], schema.Content[i+1]
+		switch key.Value {
+		case "x-nullable":
+			key.Value = "nullable"
+		case "discriminator":
+			if value.Kind == yaml.ScalarNode {
+				d := newMap()
+				set(d, "propertyName", nodes.NewString(value.Value))
+				schema.Content[i+1] = d
+			}
+		case "type":
+			if value.Value == "file" {
+				value.Value = "string"
+				set(schema, "format", nodes.NewString("binary"))
+			}
+		case "properties":
+			for j := 1; j < len(value.Content); j += 2 {
+				convertSchema(value.Content[j])
+			}
+		case "allOf":
+			for _, s := range value.Content {
+				convertSchema(s)
+			}
+		case "items", "additionalProperties":
+			convertSchema(value)
+		}
+	}
+}
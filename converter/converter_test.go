@@ -0,0 +1,240 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/internal/nodes"
+	"github.com/pb33f/libopenapi/validation"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"testing"
+)
+
+var swaggerSpec = `swagger: "2.0"
+info:
+  title: pets
+  version: 1.0.0
+host: pb33f.io
+basePath: /api
+schemes: [https]
+consumes: [application/json]
+produces: [application/json, application/xml]
+x-team: pets
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        type: integer
+        format: int64
+    put:
+      operationId: updatePet
+      parameters:
+        - $ref: '#/parameters/PetBody'
+        - name: tags
+          in: query
+          type: array
+          collectionFormat: pipes
+          items:
+            type: string
+      responses:
+        "200":
+          description: updated
+          headers:
+            Rate-Limit:
+              type: integer
+              description: requests left
+          schema:
+            $ref: '#/definitions/Pet'
+          examples:
+            application/json:
+              name: chuffy
+        default:
+          $ref: '#/responses/Error'
+    post:
+      operationId: uploadPhoto
+      schemes: [http]
+      consumes: [multipart/form-data]
+      parameters:
+        - name: photo
+          in: formData
+          type: file
+          required: true
+        - name: sizes
+          in: formData
+          type: array
+          items:
+            type: integer
+      responses:
+        "204":
+          description: uploaded
+parameters:
+  PetBody:
+    name: pet
+    in: body
+    required: true
+    schema:
+      $ref: '#/definitions/Pet'
+  Limit:
+    name: limit
+    in: query
+    type: integer
+responses:
+  Error:
+    description: something went wrong
+    schema:
+      type: object
+      properties:
+        message:
+          type: string
+definitions:
+  Pet:
+    type: object
+    discriminator: kind
+    required: [name, kind]
+    properties:
+      kind:
+        type: string
+      name:
+        type: string
+      nickname:
+        type: string
+        x-nullable: true
+securityDefinitions:
+  basic:
+    type: basic
+  robots:
+    type: oauth2
+    flow: application
+    tokenUrl: https://pb33f.io/token
+    scopes:
+      pets: all the pets`
+
+func convertTestSpec(t *testing.T, spec string) *v2high.Swagger {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	assert.NoError(t, err)
+	lowDoc, errs := v2low.CreateDocument(info)
+	assert.Empty(t, errs)
+	return v2high.NewSwaggerDocument(lowDoc)
+}
+
+func TestConvertSwagger(t *testing.T) {
+	doc, spec, errs := ConvertSwagger(convertTestSpec(t, swaggerSpec))
+	assert.Empty(t, errs)
+	assert.NotNil(t, doc)
+	assert.Equal(t, OpenAPIVersion, doc.Version)
+	assert.Equal(t, "pets", doc.Info.Title)
+	assert.Equal(t, "https://pb33f.io/api", doc.Servers[0].URL)
+	assert.Equal(t, "pets", doc.Extensions["x-team"])
+	assert.NotNil(t, doc.Index)
+
+	// path level parameters stay on the path item.
+	pathItem := doc.Paths.PathItems["/pets/{petId}"]
+	assert.Equal(t, "petId", pathItem.Parameters[0].Name)
+	assert.Equal(t, "integer", pathItem.Parameters[0].Schema.Schema().Type[0])
+
+	// body parameters become request bodies, collection formats become styles.
+	put := pathItem.Put
+	assert.Len(t, put.Parameters, 1)
+	assert.Equal(t, "pipeDelimited", put.Parameters[0].Style)
+	assert.False(t, put.Parameters[0].Explode)
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal(spec, &root))
+	putNode := nodes.MapValue(nodes.MapValue(nodes.MapValue(&root, "paths"), "/pets/{petId}"), "put")
+	body := nodes.MapValue(putNode, "requestBody")
+	assert.Equal(t, "#/components/requestBodies/PetBody", mapString(body, "$ref"))
+	assert.Len(t, put.RequestBody.Content, 1)
+	assert.True(t, put.RequestBody.Required)
+
+	// responses produce every media type, headers have schemas, examples move to their media type.
+	ok := put.Responses.Codes["200"]
+	assert.Len(t, ok.Content, 2)
+	assert.Equal(t, "chuffy", mapString(ok.Content["application/json"].GoLow().Example.ValueNode, "name"))
+	assert.Equal(t, "requests left", ok.Headers["Rate-Limit"].Description)
+	assert.Equal(t, "integer", ok.Headers["Rate-Limit"].Schema.Schema().Type[0])
+	assert.Equal(t, "something went wrong", put.Responses.Default.Description)
+
+	// form data becomes an object schema.
+	post := pathItem.Post
+	assert.Equal(t, "http://pb33f.io/api", post.Servers[0].URL)
+	form := post.RequestBody.Content["multipart/form-data"]
+	assert.NotNil(t, form)
+	photo := form.Schema.Schema().Properties["photo"].Schema()
+	assert.Equal(t, "binary", photo.Format)
+	assert.Equal(t, []string{"photo"}, form.Schema.Schema().Required)
+	assert.Equal(t, "form", form.Encoding["sizes"].Style)
+
+	// definitions, parameters, responses and security definitions become components.
+	pet := doc.Components.Schemas["Pet"].Schema()
+	assert.Equal(t, "kind", pet.Discriminator.PropertyName)
+	assert.True(t, *pet.Properties["nickname"].Schema().Nullable)
+	assert.Equal(t, "limit", doc.Components.Parameters["Limit"].Name)
+	assert.NotNil(t, doc.Components.RequestBodies["PetBody"])
+	assert.NotNil(t, doc.Components.Responses["Error"].Content["application/xml"])
+	assert.Equal(t, "http", doc.Components.SecuritySchemes["basic"].Type)
+	assert.Equal(t, "basic", doc.Components.SecuritySchemes["basic"].Scheme)
+	robots := doc.Components.SecuritySchemes["robots"].Flows.ClientCredentials
+	assert.Equal(t, "https://pb33f.io/token", robots.TokenUrl)
+	assert.Equal(t, "all the pets", robots.Scopes["pets"])
+
+	// the converted specification is valid OpenAPI 3.0.
+	info, _ := datamodel.ExtractSpecInfo(spec)
+	validationErrors, err := validation.ValidateDocument(info)
+	assert.NoError(t, err)
+	assert.Empty(t, validationErrors)
+}
+
+func TestConvertSwagger_Petstore(t *testing.T) {
+	spec, _ := ioutil.ReadFile("../test_specs/petstorev2.json")
+	swagger := convertTestSpec(t, string(spec))
+
+	// changes to the high-level model are converted.
+	swagger.Info.Title = "Converted Petstore"
+
+	doc, converted, errs := ConvertLowSwagger(swagger.GoLow())
+	assert.Empty(t, errs)
+	assert.Equal(t, "Swagger Petstore", doc.Info.Title)
+	assert.Len(t, doc.Paths.PathItems, 14)
+	assert.Len(t, doc.Components.Schemas, 6)
+
+	doc, converted, errs = ConvertSwagger(swagger)
+	assert.Empty(t, errs)
+	assert.Equal(t, "Converted Petstore", doc.Info.Title)
+
+	info, _ := datamodel.ExtractSpecInfo(converted)
+	validationErrors, err := validation.ValidateDocument(info)
+	assert.NoError(t, err)
+	assert.Empty(t, validationErrors)
+}
+
+func TestConvertSwagger_Errors(t *testing.T) {
+	_, _, errs := ConvertSwagger(nil)
+	assert.Len(t, errs, 1)
+	_, _, errs = ConvertLowSwagger(nil)
+	assert.Len(t, errs, 1)
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.0.3"), &root)
+	_, err := ConvertSwaggerNode(&root)
+	assert.Error(t, err)
+	_, err = ConvertSwaggerNode(nil)
+	assert.Error(t, err)
+}
+
+func TestConvertSwaggerNode_NoServers(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("swagger: '2.0'\ninfo:\n  title: empty\n  version: 1.0.0\nbasePath: /"), &root)
+	converted, err := ConvertSwaggerNode(&root)
+	assert.NoError(t, err)
+	assert.Nil(t, nodes.MapValue(converted, "servers"))
+	assert.NotNil(t, nodes.MapValue(converted, "paths"))
+
+	// the original specification is untouched.
+	assert.Equal(t, "2.0", mapString(&root, "swagger"))
+}
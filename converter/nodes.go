@@ -0,0 +1,80 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"github.com/pb33f/libopenapi/internal/nodes"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// mapString returns the string value of a key in a mapping node, or an empty string if the key does not exist.
+func mapString(node *yaml.Node, key string) string {
+	if v := nodes.MapValue(node, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+// mapStrings returns the values of a sequence of strings held by a key in a mapping node.
+func mapStrings(node *yaml.Node, key string) []string {
+	var values []string
+	if v := nodes.MapValue(node, key); v != nil && v.Kind == yaml.SequenceNode {
+		for _, item := range v.Content {
+			if item = nodes.Resolve(item); item.Kind == yaml.ScalarNode {
+				values = append(values, item.Value)
+			}
+		}
+	}
+	return values
+}
+
+// forEach calls fn with every key and value of a mapping node, in order.
+func forEach(node *yaml.Node, fn func(key string, value *yaml.Node)) {
+	node = nodes.Resolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		fn(node.Content[i].Value, nodes.Resolve(node.Content[i+1]))
+	}
+}
+
+func isExtension(key string) bool {
+	return strings.HasPrefix(strings.ToLower(key), "x-")
+}
+
+func newMap() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func newSeq() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+}
+
+func newBool(value bool) *yaml.Node {
+	if value {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}
+}
+
+// set adds a key and value to a mapping node, replacing the value if the key already exists. Nil values are not set.
+func set(node *yaml.Node, key string, value *yaml.Node) {
+	if value == nil {
+		return
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, nodes.NewString(key), value)
+}
+
+// isEmpty returns true if a mapping or sequence node has no content.
+func isEmpty(node *yaml.Node) bool {
+	return node == nil || len(node.Content) == 0
+}
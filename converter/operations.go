@@ -0,0 +1,404 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"github.com/pb33f/libopenapi/internal/nodes"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+	"strings"
+)
+
+// schemaFields are the fields of a Swagger parameter, header or items object that describe its schema.
+var schemaFields = []string{"type", "format", "items", "default", "maximum", "exclusiveMaximum", "minimum",
+	"exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum",
+	"multipleOf"}
+
+// collectionStyles maps a Swagger collectionFormat to the equivalent OpenAPI 3 style of a query parameter.
+var collectionStyles = map[string]string{
+	"csv":   "form",
+	"tsv":   "form",
+	"ssv":   "spaceDelimited",
+	"pipes": "pipeDelimited",
+	"multi": "form",
+}
+
+func (c *converter) paths(paths *yaml.Node) *yaml.Node {
+	converted := newMap()
+	forEach(paths, func(path string, pathItem *yaml.Node) {
+		if isExtension(path) {
+			set(converted, path, nodes.Clone(pathItem, nil))
+			return
+		}
+		set(converted, path, c.pathItem(pathItem))
+	})
+	return converted
+}
+
+// pathItem converts a path item. Body and form data parameters defined on the path item are moved into the request
+// body of every operation, as OpenAPI 3 has no path level request bodies.
+func (c *converter) pathItem(pathItem *yaml.Node) *yaml.Node {
+	converted := newMap()
+	pathParams := c.collectParameters(nodes.MapValue(pathItem, "parameters"))
+	forEach(pathItem, func(key string, value *yaml.Node) {
+		switch {
+		case key == "$ref":
+			set(converted, key, nodes.NewString(c.rewriteRef(value.Value)))
+		case key == "parameters":
+			if params := c.parameterList(pathParams.params); !isEmpty(params) {
+				set(converted, key, params)
+			}
+		case utils.IsHttpVerb(key):
+			set(converted, key, c.operation(value, pathParams))
+		default:
+			set(converted, key, nodes.Clone(value, c.rewriteRef))
+		}
+	})
+	return converted
+}
+
+func (c *converter) operation(op *yaml.Node, pathParams *parameters) *yaml.Node {
+	converted := newMap()
+	params := c.collectParameters(nodes.MapValue(op, "parameters"))
+	consumes, produces := c.consumes, c.produces
+	if nodes.MapValue(op, "consumes") != nil {
+		consumes = mapStrings(op, "consumes")
+	}
+	if nodes.MapValue(op, "produces") != nil {
+		produces = mapStrings(op, "produces")
+	}
+	requestBody := func() *yaml.Node {
+		body := params.body
+		if body == nil {
+			body = pathParams.body
+		}
+		if body != nil {
+			return c.bodyReference(body, consumes)
+		}
+		return c.formDataBody(pathParams.merge(params.formData), consumes)
+	}
+	bodyDone := false
+	forEach(op, func(key string, value *yaml.Node) {
+		switch key {
+		case "consumes", "produces":
+			// converted into the content of request bodies and responses.
+		case "schemes":
+			if servers := c.servers(mapStrings(op, "schemes")); !isEmpty(servers) {
+				set(converted, "servers", servers)
+			}
+		case "parameters":
+			if list := c.parameterList(params.params); !isEmpty(list) {
+				set(converted, key, list)
+			}
+			set(converted, "requestBody", requestBody())
+			bodyDone = true
+		case "responses":
+			if !bodyDone {
+				set(converted, "requestBody", requestBody())
+				bodyDone = true
+			}
+			set(converted, key, c.responses(value, produces))
+		default:
+			set(converted, key, nodes.Clone(value, c.rewriteRef))
+		}
+	})
+	if !bodyDone {
+		set(converted, "requestBody", requestBody())
+	}
+	return converted
+}
+
+// parameters are the parameters of a path item or operation, split into those that stay parameters, the body
+// parameter, and the form data parameters.
+type parameters struct {
+	params   []*yaml.Node
+	body     *yaml.Node
+	formData []*yaml.Node
+}
+
+// collectParameters splits a sequence of parameters into parameters, the body and form data. References to body and
+// form data parameters in this specification are resolved.
+func (c *converter) collectParameters(list *yaml.Node) *parameters {
+	p := new(parameters)
+	if list == nil {
+		return p
+	}
+	for _, param := range list.Content {
+		param = nodes.Resolve(param)
+		resolved := param
+		if ref := mapString(param, "$ref"); ref != "" {
+			if local := c.localParameter(ref); local != nil {
+				resolved = local
+			}
+		}
+		switch mapString(resolved, "in") {
+		case "body":
+			p.body = param
+		case "formData":
+			p.formData = append(p.formData, resolved)
+		default:
+			p.params = append(p.params, param)
+		}
+	}
+	return p
+}
+
+// merge returns the form data parameters of a path item, overridden by the form data parameters of an operation.
+func (p *parameters) merge(formData []*yaml.Node) []*yaml.Node {
+	var merged []*yaml.Node
+	for _, param := range p.formData {
+		overridden := false
+		for _, override := range formData {
+			if mapString(param, "name") == mapString(override, "name") {
+				overridden = true
+			}
+		}
+		if !overridden {
+			merged = append(merged, param)
+		}
+	}
+	return append(merged, formData...)
+}
+
+func (c *converter) parameterList(params []*yaml.Node) *yaml.Node {
+	list := newSeq()
+	for _, param := range params {
+		list.Content = append(list.Content, c.parameter(param))
+	}
+	return list
+}
+
+// parameter converts a parameter that is not a body or form data parameter. The schema fields become a schema, and
+// the collectionFormat of an array becomes a style.
+func (c *converter) parameter(param *yaml.Node) *yaml.Node {
+	if ref := mapString(param, "$ref"); ref != "" {
+		converted := newMap()
+		set(converted, "$ref", nodes.NewString(c.rewriteRef(ref)))
+		return converted
+	}
+	converted := newMap()
+	in := mapString(param, "in")
+	forEach(param, func(key string, value *yaml.Node) {
+		switch {
+		case key == "allowEmptyValue" && in != "query":
+			// only query parameters can be empty in OpenAPI 3.
+		case key == "name", key == "in", key == "description", key == "required", key == "allowEmptyValue",
+			isExtension(key):
+			set(converted, key, nodes.Clone(value, nil))
+		}
+	})
+	if mapString(param, "type") == "array" && in == "query" {
+		format := mapString(param, "collectionFormat")
+		if format == "" {
+			format = "csv"
+		}
+		set(converted, "style", nodes.NewString(collectionStyles[format]))
+		set(converted, "explode", newBool(format == "multi"))
+	}
+	set(converted, "schema", schemaFromFields(param))
+	return converted
+}
+
+// schemaFromFields creates a schema from the schema fields of a parameter, header or items object.
+func schemaFromFields(node *yaml.Node) *yaml.Node {
+	schema := newMap()
+	for _, field := range schemaFields {
+		value := nodes.MapValue(node, field)
+		if value == nil {
+			continue
+		}
+		if field == "items" {
+			set(schema, field, schemaFromFields(value))
+			continue
+		}
+		set(schema, field, nodes.Clone(value, nil))
+	}
+	convertSchema(schema)
+	return schema
+}
+
+// bodyReference converts a body parameter, or a reference to one, into a request body.
+func (c *converter) bodyReference(body *yaml.Node, consumes []string) *yaml.Node {
+	if ref := mapString(body, "$ref"); ref != "" {
+		converted := newMap()
+		set(converted, "$ref", nodes.NewString(c.rewriteRef(ref)))
+		return converted
+	}
+	return c.requestBody(body, consumes)
+}
+
+// requestBody converts a body parameter into a request body, with the schema of the body used for every media type
+// the operation consumes. Examples in 'x-examples' become the examples of their media type.
+func (c *converter) requestBody(body *yaml.Node, consumes []string) *yaml.Node {
+	converted := newMap()
+	if description := nodes.MapValue(body, "description"); description != nil {
+		set(converted, "description", nodes.Clone(description, nil))
+	}
+	content := newMap()
+	examples := nodes.MapValue(body, "x-examples")
+	for _, mediaType := range mediaTypes(consumes) {
+		mt := newMap()
+		set(mt, "schema", c.schema(nodes.MapValue(body, "schema")))
+		set(mt, "example", nodes.Clone(nodes.MapValue(examples, mediaType), nil))
+		set(content, mediaType, mt)
+	}
+	set(converted, "content", content)
+	forEach(body, func(key string, value *yaml.Node) {
+		if key == "required" || (isExtension(key) && key != "x-examples") {
+			set(converted, key, nodes.Clone(value, nil))
+		}
+	})
+	return converted
+}
+
+// formDataBody converts form data parameters into a request body with an object schema. The media types are the form
+// media types the operation consumes, or 'multipart/form-data' when files are uploaded and
+// 'application/x-www-form-urlencoded' otherwise.
+func (c *converter) formDataBody(formData []*yaml.Node, consumes []string) *yaml.Node {
+	if len(formData) == 0 {
+		return nil
+	}
+	schema, properties, required := newMap(), newMap(), newSeq()
+	encoding := newMap()
+	files := false
+	for _, param := range formData {
+		name := mapString(param, "name")
+		property := schemaFromFields(param)
+		if description := nodes.MapValue(param, "description"); description != nil {
+			set(property, "description", nodes.Clone(description, nil))
+		}
+		set(properties, name, property)
+		if mapString(param, "required") == "true" {
+			required.Content = append(required.Content, nodes.NewString(name))
+		}
+		if mapString(param, "type") == "file" {
+			files = true
+		}
+		if format := mapString(param, "collectionFormat"); mapString(param, "type") == "array" && format != "multi" {
+			if format == "" {
+				format = "csv"
+			}
+			e := newMap()
+			set(e, "style", nodes.NewString(collectionStyles[format]))
+			set(e, "explode", newBool(false))
+			set(encoding, name, e)
+		}
+	}
+	set(schema, "type", nodes.NewString("object"))
+	set(schema, "properties", properties)
+	if !isEmpty(required) {
+		set(schema, "required", required)
+	}
+	var forms []string
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+			forms = append(forms, mediaType)
+		}
+	}
+	if len(forms) == 0 {
+		forms = []string{"application/x-www-form-urlencoded"}
+		if files {
+			forms = []string{"multipart/form-data"}
+		}
+	}
+	content := newMap()
+	for _, mediaType := range forms {
+		mt := newMap()
+		set(mt, "schema", nodes.Clone(schema, nil))
+		if !isEmpty(encoding) {
+			set(mt, "encoding", nodes.Clone(encoding, nil))
+		}
+		set(content, mediaType, mt)
+	}
+	converted := newMap()
+	set(converted, "content", content)
+	return converted
+}
+
+func (c *converter) responses(responses *yaml.Node, produces []string) *yaml.Node {
+	converted := newMap()
+	forEach(responses, func(code string, response *yaml.Node) {
+		if isExtension(code) {
+			set(converted, code, nodes.Clone(response, nil))
+			return
+		}
+		set(converted, code, c.response(response, produces))
+	})
+	return converted
+}
+
+// response converts a response, the schema of the response is used for every media type the operation produces.
+// The examples of a response become the examples of their media type.
+func (c *converter) response(response *yaml.Node, produces []string) *yaml.Node {
+	converted := newMap()
+	if ref := mapString(response, "$ref"); ref != "" {
+		set(converted, "$ref", nodes.NewString(c.rewriteRef(ref)))
+		return converted
+	}
+	schema, examples := nodes.MapValue(response, "schema"), nodes.MapValue(response, "examples")
+	types := mediaTypes(produces)
+	forEach(examples, func(mediaType string, _ *yaml.Node) {
+		found := false
+		for _, t := range types {
+			found = found || t == mediaType
+		}
+		if !found {
+			types = append(types, mediaType)
+		}
+	})
+	forEach(response, func(key string, value *yaml.Node) {
+		switch {
+		case key == "description" || isExtension(key):
+			set(converted, key, nodes.Clone(value, nil))
+		case key == "headers":
+			headers := newMap()
+			forEach(value, func(name string, header *yaml.Node) {
+				set(headers, name, c.header(header))
+			})
+			set(converted, key, headers)
+		}
+	})
+	if nodes.MapValue(converted, "description") == nil {
+		set(converted, "description", nodes.NewString(""))
+	}
+	if schema == nil && examples == nil {
+		return converted
+	}
+	content := newMap()
+	for _, mediaType := range types {
+		mt := newMap()
+		if schema != nil {
+			set(mt, "schema", c.schema(schema))
+		}
+		set(mt, "example", nodes.Clone(nodes.MapValue(examples, mediaType), nil))
+		set(content, mediaType, mt)
+	}
+	set(converted, "content", content)
+	return converted
+}
+
+func (c *converter) header(header *yaml.Node) *yaml.Node {
+	converted := newMap()
+	forEach(header, func(key string, value *yaml.Node) {
+		if key == "description" || isExtension(key) {
+			set(converted, key, nodes.Clone(value, nil))
+		}
+	})
+	set(converted, "schema", schemaFromFields(header))
+	return converted
+}
+
+// mediaTypes returns the media types of a request body or response, or the default media type if there are none.
+func mediaTypes(types []string) []string {
+	var result []string
+	for _, t := range types {
+		if t = strings.TrimSpace(t); t != "" {
+			result = append(result, t)
+		}
+	}
+	if len(result) == 0 {
+		return []string{defaultMediaType}
+	}
+	return result
+}
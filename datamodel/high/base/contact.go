@@ -11,9 +11,9 @@ import (
 //  v2 - https://swagger.io/specification/v2/#contactObject
 //  v3 - https://spec.openapis.org/oas/v3.1.0#contact-object
 type Contact struct {
-	Name  string
-	URL   string
-	Email string
+	Name  string `yaml:"name,omitempty"`
+	URL   string `yaml:"url,omitempty"`
+	Email string `yaml:"email,omitempty"`
 	low   *low.Contact
 }
 
@@ -27,6 +27,11 @@ func NewContact(contact *low.Contact) *Contact {
 	return c
 }
 
+// CreateContact creates a new high-level Contact from scratch, with a name, URL and email address.
+func CreateContact(name, url, email string) *Contact {
+	return &Contact{Name: name, URL: url, Email: email}
+}
+
 // GoLow returns the low level Contact object used to create the high-level one.
 func (c *Contact) GoLow() *low.Contact {
 	return c.low
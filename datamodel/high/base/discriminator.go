@@ -16,8 +16,8 @@ import (
 // When using the discriminator, inline schemas will not be considered.
 //  v3 - https://spec.openapis.org/oas/v3.1.0#discriminator-object
 type Discriminator struct {
-	PropertyName string
-	Mapping      map[string]string
+	PropertyName string            `yaml:"propertyName,omitempty"`
+	Mapping      map[string]string `yaml:"mapping,omitempty"`
 	low          *low.Discriminator
 }
 
@@ -34,6 +34,12 @@ func NewDiscriminator(disc *low.Discriminator) *Discriminator {
 	return d
 }
 
+// CreateDiscriminator creates a new high-level Discriminator from scratch, with the required property name. Every
+// map is initialized.
+func CreateDiscriminator(propertyName string) *Discriminator {
+	return &Discriminator{PropertyName: propertyName, Mapping: make(map[string]string)}
+}
+
 // GoLow returns the low-level Discriminator used to build the high-level one.
 func (d *Discriminator) GoLow() *low.Discriminator {
 	return d.low
@@ -12,11 +12,11 @@ import (
 // Example represents a high-level Example object as defined by OpenAPI 3+
 //  v3 - https://spec.openapis.org/oas/v3.1.0#example-object
 type Example struct {
-	Summary       string
-	Description   string
-	Value         any
-	ExternalValue string
-	Extensions    map[string]any
+	Summary       string         `yaml:"summary,omitempty"`
+	Description   string         `yaml:"description,omitempty"`
+	Value         any            `yaml:"value,omitempty"`
+	ExternalValue string         `yaml:"externalValue,omitempty"`
+	Extensions    map[string]any `yaml:",inline"`
 	low           *low.Example
 }
 
@@ -32,6 +32,11 @@ func NewExample(example *low.Example) *Example {
 	return e
 }
 
+// CreateExample creates a new high-level Example from scratch, with a value. Every map is initialized.
+func CreateExample(value any) *Example {
+	return &Example{Value: value, Extensions: make(map[string]any)}
+}
+
 // GoLow will return the low-level Example used to build the high level one.
 func (e *Example) GoLow() *low.Example {
 	return e.low
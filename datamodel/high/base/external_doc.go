@@ -14,9 +14,9 @@ import (
 //  v2 - https://swagger.io/specification/v2/#externalDocumentationObject
 //  v3 - https://spec.openapis.org/oas/v3.1.0#external-documentation-object
 type ExternalDoc struct {
-	Description string
-	URL         string
-	Extensions  map[string]any
+	Description string         `yaml:"description,omitempty"`
+	URL         string         `yaml:"url,omitempty"`
+	Extensions  map[string]any `yaml:",inline"`
 	low         *low.ExternalDoc
 }
 
@@ -34,6 +34,12 @@ func NewExternalDoc(extDoc *low.ExternalDoc) *ExternalDoc {
 	return d
 }
 
+// CreateExternalDoc creates a new high-level ExternalDoc from scratch, with the required URL. Every map is
+// initialized.
+func CreateExternalDoc(url string) *ExternalDoc {
+	return &ExternalDoc{URL: url, Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low-level ExternalDoc instance used to create the high-level one.
 func (e *ExternalDoc) GoLow() *low.ExternalDoc {
 	return e.low
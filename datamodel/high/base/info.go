@@ -15,12 +15,13 @@ import (
 //  v2 - https://swagger.io/specification/v2/#infoObject
 //  v3 - https://spec.openapis.org/oas/v3.1.0#info-object
 type Info struct {
-	Title          string
-	Description    string
-	TermsOfService string
-	Contact        *Contact
-	License        *License
-	Version        string
+	Title          string   `yaml:"title,omitempty"`
+	Summary        string   `yaml:"summary,omitempty"`
+	Description    string   `yaml:"description,omitempty"`
+	TermsOfService string   `yaml:"termsOfService,omitempty"`
+	Contact        *Contact `yaml:"contact,omitempty"`
+	License        *License `yaml:"license,omitempty"`
+	Version        string   `yaml:"version,omitempty"`
 	low            *low.Info
 }
 
@@ -31,6 +32,9 @@ func NewInfo(info *low.Info) *Info {
 	if !info.Title.IsEmpty() {
 		i.Title = info.Title.Value
 	}
+	if !info.Summary.IsEmpty() {
+		i.Summary = info.Summary.Value
+	}
 	if !info.Description.IsEmpty() {
 		i.Description = info.Description.Value
 	}
@@ -49,6 +53,12 @@ func NewInfo(info *low.Info) *Info {
 	return i
 }
 
+// CreateInfo creates a new high-level Info object from scratch (without a low-level model backing it), with the
+// required title and version of the API.
+func CreateInfo(title, version string) *Info {
+	return &Info{Title: title, Version: version}
+}
+
 // GoLow will return the low-level Info instance that was used to create the high-level one.
 func (i *Info) GoLow() *low.Info {
 	return i.low
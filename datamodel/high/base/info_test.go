@@ -50,6 +50,29 @@ version: 99.99`
 
 }
 
+func TestNewInfo_31(t *testing.T) {
+
+	var cNode yaml.Node
+
+	yml := `title: chicken
+summary: a chicken nugget
+license:
+  name: MIT
+  identifier: MIT`
+
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	var lowInfo lowbase.Info
+	_ = lowmodel.BuildModel(&cNode, &lowInfo)
+	_ = lowInfo.Build(cNode.Content[0], nil)
+
+	highInfo := NewInfo(&lowInfo)
+
+	assert.Equal(t, "a chicken nugget", highInfo.Summary)
+	assert.Equal(t, "MIT", highInfo.License.Identifier)
+	assert.Empty(t, highInfo.License.URL)
+}
+
 func ExampleNewInfo() {
 
 	// create an example info object (including contact and license)
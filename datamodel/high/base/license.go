@@ -11,9 +11,10 @@ import (
 //  v2 - https://swagger.io/specification/v2/#licenseObject
 //  v3 - https://spec.openapis.org/oas/v3.1.0#license-object
 type License struct {
-	Name string
-	URL  string
-	low  *low.License
+	Name       string `yaml:"name,omitempty"`
+	Identifier string `yaml:"identifier,omitempty"`
+	URL        string `yaml:"url,omitempty"`
+	low        *low.License
 }
 
 // NewLicense will create a new high-level License instance from a low-level one.
@@ -26,9 +27,17 @@ func NewLicense(license *low.License) *License {
 	if !license.Name.IsEmpty() {
 		l.Name = license.Name.Value
 	}
+	if !license.Identifier.IsEmpty() {
+		l.Identifier = license.Identifier.Value
+	}
 	return l
 }
 
+// CreateLicense creates a new high-level License from scratch, with the required name.
+func CreateLicense(name string) *License {
+	return &License{Name: name}
+}
+
 // GoLow will return the low-level License used to create the high-level one.
 func (l *License) GoLow() *low.License {
 	return l.low
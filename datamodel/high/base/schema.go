@@ -22,65 +22,88 @@ import (
 type Schema struct {
 
     // 3.1 only, used to define a dialect for this schema, label is '$schema'.
-    SchemaTypeRef string
+    SchemaTypeRef string `yaml:"$schema,omitempty"`
 
     // In versions 2 and 3.0, this ExclusiveMaximum can only be a boolean.
-    ExclusiveMaximumBool *bool
+    ExclusiveMaximumBool *bool `yaml:"exclusiveMaximum,omitempty"`
 
-    // In version 3.1, ExclusiveMaximum is an integer.
-    ExclusiveMaximum *int64
+    // In version 3.1, ExclusiveMaximum is a number.
+    ExclusiveMaximum *float64 `yaml:"exclusiveMaximum,omitempty"`
 
-    // In versions 2 and 3.0, this ExclusiveMinimum can only be a boolean.
-    ExclusiveMinimum *int64
+    // In version 3.1, ExclusiveMinimum is a number.
+    ExclusiveMinimum *float64 `yaml:"exclusiveMinimum,omitempty"`
 
-    // In version 3.1, ExclusiveMinimum is an integer.
-    ExclusiveMinimumBool *bool
+    // In versions 2 and 3.0, this ExclusiveMinimum can only be a boolean.
+    ExclusiveMinimumBool *bool `yaml:"exclusiveMinimum,omitempty"`
 
     // In versions 2 and 3.0, this Type is a single value, so array will only ever have one value
     // in version 3.1, Type can be multiple values
-    Type []string
+    Type []string `yaml:"type,omitempty" render:"single"`
 
     // Schemas are resolved on demand using a SchemaProxy
-    AllOf []*SchemaProxy
+    AllOf []*SchemaProxy `yaml:"allOf,omitempty"`
 
     // Polymorphic Schemas are only available in version 3+
-    OneOf         []*SchemaProxy
-    AnyOf         []*SchemaProxy
-    Discriminator *Discriminator
+    OneOf         []*SchemaProxy `yaml:"oneOf,omitempty"`
+    AnyOf         []*SchemaProxy `yaml:"anyOf,omitempty"`
+    Discriminator *Discriminator `yaml:"discriminator,omitempty"`
 
     // in 3.1 examples can be an array (which is recommended)
-    Examples []any
+    Examples []any `yaml:"examples,omitempty"`
+
+    // JSON Schema 2020-12 keywords, only available in version 3.1
+    Id                string                  `yaml:"$id,omitempty"`
+    Anchor            string                  `yaml:"$anchor,omitempty"`
+    DynamicAnchor     string                  `yaml:"$dynamicAnchor,omitempty"`
+    DynamicRef        string                  `yaml:"$dynamicRef,omitempty"`
+    Defs              map[string]*SchemaProxy `yaml:"$defs,omitempty"`
+    Const             any                     `yaml:"const,omitempty"`
+    Contains          *SchemaProxy            `yaml:"contains,omitempty"`
+    MinContains       *int64                  `yaml:"minContains,omitempty"`
+    MaxContains       *int64                  `yaml:"maxContains,omitempty"`
+    PrefixItems       []*SchemaProxy          `yaml:"prefixItems,omitempty"`
+    If                *SchemaProxy            `yaml:"if,omitempty"`
+    Then              *SchemaProxy            `yaml:"then,omitempty"`
+    Else              *SchemaProxy            `yaml:"else,omitempty"`
+    DependentSchemas  map[string]*SchemaProxy `yaml:"dependentSchemas,omitempty"`
+    DependentRequired map[string][]string     `yaml:"dependentRequired,omitempty"`
+    PatternProperties map[string]*SchemaProxy `yaml:"patternProperties,omitempty"`
+    PropertyNames     *SchemaProxy            `yaml:"propertyNames,omitempty"`
+
+    // UnevaluatedItems and UnevaluatedProperties are either a *SchemaProxy or a bool.
+    UnevaluatedItems      any `yaml:"unevaluatedItems,omitempty"`
+    UnevaluatedProperties any `yaml:"unevaluatedProperties,omitempty"`
 
     // Compatible with all versions
-    Not                  []*SchemaProxy
-    Items                []*SchemaProxy
-    Properties           map[string]*SchemaProxy
-    Title                string
-    MultipleOf           *int64
-    Maximum              *int64
-    Minimum              *int64
-    MaxLength            *int64
-    MinLength            *int64
-    Pattern              string
-    Format               string
-    MaxItems             *int64
-    MinItems             *int64
-    UniqueItems          *int64
-    MaxProperties        *int64
-    MinProperties        *int64
-    Required             []string
-    Enum                 []string
-    AdditionalProperties any
-    Description          string
-    Default              any
-    Nullable             *bool
-    ReadOnly             *bool
-    WriteOnly            *bool
-    XML                  *XML
-    ExternalDocs         *ExternalDoc
-    Example              any
-    Deprecated           *bool
-    Extensions           map[string]any
+    Not                  []*SchemaProxy          `yaml:"not,omitempty" render:"single"`
+    Items                []*SchemaProxy          `yaml:"items,omitempty" render:"single"`
+    Properties           map[string]*SchemaProxy `yaml:"properties,omitempty"`
+    Title                string                  `yaml:"title,omitempty"`
+    MultipleOf           *float64                `yaml:"multipleOf,omitempty"`
+    Maximum              *float64                `yaml:"maximum,omitempty"`
+    Minimum              *float64                `yaml:"minimum,omitempty"`
+    MaxLength            *int64                  `yaml:"maxLength,omitempty"`
+    MinLength            *int64                  `yaml:"minLength,omitempty"`
+    Pattern              string                  `yaml:"pattern,omitempty"`
+    Format               string                  `yaml:"format,omitempty"`
+    MaxItems             *int64                  `yaml:"maxItems,omitempty"`
+    MinItems             *int64                  `yaml:"minItems,omitempty"`
+    UniqueItems          *int64                  `yaml:"uniqueItems,omitempty"`
+    MaxProperties        *int64                  `yaml:"maxProperties,omitempty"`
+    MinProperties        *int64                  `yaml:"minProperties,omitempty"`
+    Required             []string                `yaml:"required,omitempty"`
+    Enum                 []any                   `yaml:"enum,omitempty"`
+    AdditionalProperties any                     `yaml:"additionalProperties,omitempty"`
+    Description          string                  `yaml:"description,omitempty"`
+    Default              any                     `yaml:"default,omitempty"`
+    Nullable             *bool                   `yaml:"nullable,omitempty"`
+    ReadOnly             *bool                   `yaml:"readOnly,omitempty"`
+    WriteOnly            *bool                   `yaml:"writeOnly,omitempty"`
+    XML                  *XML                    `yaml:"xml,omitempty"`
+    ExternalDocs         *ExternalDoc            `yaml:"externalDocs,omitempty"`
+    Example              any                     `yaml:"example,omitempty"`
+    Deprecated           *bool                   `yaml:"deprecated,omitempty"`
+    Extensions           map[string]any          `yaml:",inline"`
     low                  *base.Schema
 }
 
@@ -96,7 +119,7 @@ func NewSchema(schema *base.Schema) *Schema {
     if !schema.ExclusiveMaximum.IsEmpty() && schema.ExclusiveMaximum.Value.IsA() {
         s.ExclusiveMaximumBool = &schema.ExclusiveMaximum.Value.A
     }
-    // if we're dealing with a 3.1 spec using an int
+    // if we're dealing with a 3.1 spec using a number
     if !schema.ExclusiveMaximum.IsEmpty() && schema.ExclusiveMaximum.Value.IsB() {
         s.ExclusiveMaximum = &schema.ExclusiveMaximum.Value.B
     }
@@ -104,7 +127,7 @@ func NewSchema(schema *base.Schema) *Schema {
     if !schema.ExclusiveMinimum.IsEmpty() && schema.ExclusiveMinimum.Value.IsA() {
         s.ExclusiveMinimumBool = &schema.ExclusiveMinimum.Value.A
     }
-    // if we're dealing with a 3.1 spec, using an int
+    // if we're dealing with a 3.1 spec, using a number
     if !schema.ExclusiveMinimum.IsEmpty() && schema.ExclusiveMinimum.Value.IsB() {
         s.ExclusiveMinimum = &schema.ExclusiveMinimum.Value.B
     }
@@ -171,12 +194,47 @@ func NewSchema(schema *base.Schema) *Schema {
     }
     s.Required = req
 
-    var enum []string
+    var enum []any
     for i := range schema.Enum.Value {
         enum = append(enum, schema.Enum.Value[i].Value)
     }
     s.Enum = enum
 
+    // JSON Schema 2020-12 keywords (3.1)
+    s.Id = schema.Id.Value
+    s.Anchor = schema.Anchor.Value
+    s.DynamicAnchor = schema.DynamicAnchor.Value
+    s.DynamicRef = schema.DynamicRef.Value
+    s.Const = schema.Const.Value
+    if !schema.MinContains.IsEmpty() {
+        s.MinContains = &schema.MinContains.Value
+    }
+    if !schema.MaxContains.IsEmpty() {
+        s.MaxContains = &schema.MaxContains.Value
+    }
+    s.Defs = newSchemaProxyMap(schema.Defs.Value)
+    s.DependentSchemas = newSchemaProxyMap(schema.DependentSchemas.Value)
+    s.PatternProperties = newSchemaProxyMap(schema.PatternProperties.Value)
+    s.Contains = newSubSchemaProxy(schema.Contains)
+    s.If = newSubSchemaProxy(schema.If)
+    s.Then = newSubSchemaProxy(schema.Then)
+    s.Else = newSubSchemaProxy(schema.Else)
+    s.PropertyNames = newSubSchemaProxy(schema.PropertyNames)
+    for i := range schema.PrefixItems.Value {
+        s.PrefixItems = append(s.PrefixItems, &SchemaProxy{schema: &lowmodel.NodeReference[*base.SchemaProxy]{
+            ValueNode: schema.PrefixItems.Value[i].ValueNode,
+            Value:     schema.PrefixItems.Value[i].Value,
+        }})
+    }
+    if len(schema.DependentRequired.Value) > 0 {
+        s.DependentRequired = make(map[string][]string)
+        for k, v := range schema.DependentRequired.Value {
+            s.DependentRequired[k.Value] = v.Value
+        }
+    }
+    s.UnevaluatedItems = newDynamicSchemaValue(schema.UnevaluatedItems)
+    s.UnevaluatedProperties = newDynamicSchemaValue(schema.UnevaluatedProperties)
+
     // async work.
     // any polymorphic properties need to be handled in their own threads
     // any properties each need to be processed in their own thread.
@@ -287,7 +345,62 @@ func NewSchema(schema *base.Schema) *Schema {
     return s
 }
 
+// CreateSchema creates a new high-level Schema from scratch (without a low-level model backing it), for any number
+// of types (for example 'object'). Every map is initialized, wrap the Schema using CreateSchemaProxy to use it.
+func CreateSchema(schemaType ...string) *Schema {
+    return &Schema{
+        Type:              schemaType,
+        Defs:              make(map[string]*SchemaProxy),
+        DependentSchemas:  make(map[string]*SchemaProxy),
+        DependentRequired: make(map[string][]string),
+        PatternProperties: make(map[string]*SchemaProxy),
+        Properties:        make(map[string]*SchemaProxy),
+        Extensions:        make(map[string]any),
+    }
+}
+
 // GoLow will return the low-level instance of Schema that was used to create the high level one.
 func (s *Schema) GoLow() *base.Schema {
     return s.low
 }
+
+// newSubSchemaProxy creates a high-level SchemaProxy for a single sub-schema, or nil if the sub-schema is not set.
+func newSubSchemaProxy(sch lowmodel.NodeReference[*base.SchemaProxy]) *SchemaProxy {
+    if sch.Value == nil {
+        return nil
+    }
+    return &SchemaProxy{schema: &lowmodel.NodeReference[*base.SchemaProxy]{
+        Value:     sch.Value,
+        KeyNode:   sch.KeyNode,
+        ValueNode: sch.ValueNode,
+    }}
+}
+
+// newSchemaProxyMap creates a map of high-level SchemaProxy instances from a low-level map of sub-schemas.
+func newSchemaProxyMap(schemas map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*base.SchemaProxy]) map[string]*SchemaProxy {
+    if len(schemas) == 0 {
+        return nil
+    }
+    m := make(map[string]*SchemaProxy)
+    for k, v := range schemas {
+        m[k.Value] = &SchemaProxy{schema: &lowmodel.NodeReference[*base.SchemaProxy]{
+            Value:     v.Value,
+            KeyNode:   k.KeyNode,
+            ValueNode: v.ValueNode,
+        }}
+    }
+    return m
+}
+
+// newDynamicSchemaValue returns a high-level SchemaProxy or a bool from a value that can be either, or nil if the
+// value is not set.
+func newDynamicSchemaValue(sch lowmodel.NodeReference[base.SchemaDynamicValue[*base.SchemaProxy, bool]]) any {
+    if sch.ValueNode == nil {
+        return nil
+    }
+    if sch.Value.IsB() {
+        return sch.Value.B
+    }
+    return newSubSchemaProxy(lowmodel.NodeReference[*base.SchemaProxy]{
+        Value: sch.Value.A, KeyNode: sch.KeyNode, ValueNode: sch.ValueNode})
+}
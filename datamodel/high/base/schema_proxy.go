@@ -4,8 +4,10 @@
 package base
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"gopkg.in/yaml.v3"
 )
 
 // SchemaProxy exists as a stub that will create a Schema once (and only once) the Schema() method is called. An
@@ -44,6 +46,8 @@ import (
 type SchemaProxy struct {
 	schema     *low.NodeReference[*base.SchemaProxy]
 	buildError error
+	rendered   *Schema
+	refStr     string
 }
 
 // NewSchemaProxy creates a new high-level SchemaProxy from a low-level one.
@@ -51,19 +55,104 @@ func NewSchemaProxy(schema *low.NodeReference[*base.SchemaProxy]) *SchemaProxy {
 	return &SchemaProxy{schema: schema}
 }
 
+// CreateSchemaProxy creates a new high-level SchemaProxy for a Schema that has been created from scratch (without a
+// low-level model backing it). The Schema is returned by Schema() and rendered in place of the proxy.
+func CreateSchemaProxy(schema *Schema) *SchemaProxy {
+	return &SchemaProxy{rendered: schema}
+}
+
+// CreateSchemaProxyRef creates a new high-level SchemaProxy that is a reference to another Schema, for example
+// '#/components/schemas/Burger'. The reference is rendered, there is no Schema available from Schema().
+func CreateSchemaProxyRef(ref string) *SchemaProxy {
+	return &SchemaProxy{refStr: ref}
+}
+
 // Schema will create a new Schema instance using NewSchema from the low-level SchemaProxy backing this high-level one.
 // If there is a problem building the Schema, then this method will return nil. Use GetBuildError to gain access
 // to that building error.
+//
+// The Schema is only created once, any changes made to it are kept by the proxy and will be rendered.
 func (sp *SchemaProxy) Schema() *Schema {
+	if sp.rendered != nil || sp.schema == nil {
+		return sp.rendered
+	}
 	s := sp.schema.Value.Schema()
 	if s == nil {
 		sp.buildError = sp.schema.Value.GetBuildError()
 		return nil
 	}
-	return NewSchema(s)
+	sp.rendered = NewSchema(s)
+	return sp.rendered
+}
+
+// GoLow returns the low-level SchemaProxy used to create the high-level one. A SchemaProxy that was created from
+// scratch has no low-level SchemaProxy, and nil is returned.
+func (sp *SchemaProxy) GoLow() *base.SchemaProxy {
+	if sp.schema == nil {
+		return nil
+	}
+	return sp.schema.Value
 }
 
 // GetBuildError returns any error that was thrown when calling Schema()
 func (sp *SchemaProxy) GetBuildError() error {
 	return sp.buildError
 }
+
+// IsReference returns true if the SchemaProxy is a reference to another Schema.
+func (sp *SchemaProxy) IsReference() bool {
+	if sp.refStr != "" {
+		return true
+	}
+	return sp.schema != nil && sp.schema.Value != nil && sp.schema.Value.IsSchemaReference()
+}
+
+// GetReference returns the reference value of the SchemaProxy, if it is a reference, otherwise an empty string.
+func (sp *SchemaProxy) GetReference() string {
+	if !sp.IsReference() {
+		return ""
+	}
+	if sp.refStr != "" {
+		return sp.refStr
+	}
+	return sp.schema.Value.GetSchemaReference()
+}
+
+// RenderNode renders the SchemaProxy. A Schema that has never been created cannot have been changed, so the original
+// node is kept (or a reference is rendered if there is no original). Otherwise, the Schema is rendered, which keeps
+// the original reference if the Schema has not been changed. A proxy created using CreateSchemaProxyRef always
+// renders its reference.
+func (sp *SchemaProxy) RenderNode(r *high.Renderer, original *yaml.Node) *yaml.Node {
+	if sp.refStr != "" {
+		if original != nil && original.Kind == yaml.MappingNode && len(original.Content) == 2 &&
+			original.Content[0].Value == "$ref" && original.Content[1].Value == sp.refStr {
+			return original
+		}
+		return renderReference(sp.refStr)
+	}
+	if sp.rendered == nil {
+		if original != nil {
+			return original
+		}
+		if sp.IsReference() {
+			return renderReference(sp.GetReference())
+		}
+		if sp.schema == nil {
+			return nil
+		}
+	}
+	s := sp.Schema()
+	if s == nil {
+		// a schema that cannot be built is never changed.
+		return original
+	}
+	return r.Render(s, original)
+}
+
+// renderReference renders a mapping node containing a single reference.
+func renderReference(ref string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "$ref"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: ref},
+	}}
+}
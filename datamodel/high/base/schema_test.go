@@ -5,6 +5,7 @@ package base
 
 import (
 	"fmt"
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
@@ -315,8 +316,8 @@ required: [cake, fish]`
 
 	assert.True(t, *compiled.ExclusiveMaximumBool)
 	assert.False(t, *compiled.ExclusiveMinimumBool)
-	assert.Equal(t, int64(123), *compiled.Properties["somethingB"].Schema().ExclusiveMinimum)
-	assert.Equal(t, int64(334), *compiled.Properties["somethingB"].Schema().ExclusiveMaximum)
+	assert.Equal(t, float64(123), *compiled.Properties["somethingB"].Schema().ExclusiveMinimum)
+	assert.Equal(t, float64(334), *compiled.Properties["somethingB"].Schema().ExclusiveMaximum)
 	assert.Len(t, compiled.Properties["somethingB"].Schema().Properties["somethingBProp"].Schema().Type, 2)
 
 	wentLow := compiled.GoLow()
@@ -391,3 +392,158 @@ properties:
 	// Output: this is an integer property
 
 }
+
+func TestCreateSchemaProxy(t *testing.T) {
+	schema := &Schema{Type: []string{"string"}, Description: "a name"}
+	sp := CreateSchemaProxy(schema)
+	assert.Same(t, schema, sp.Schema())
+	assert.False(t, sp.IsReference())
+
+	rendered, _ := yaml.Marshal(high.NewRenderer(nil).Render(sp, nil))
+	assert.Equal(t, "type: string\ndescription: a name\n", string(rendered))
+}
+
+func TestCreateSchemaProxyRef(t *testing.T) {
+	sp := CreateSchemaProxyRef("#/components/schemas/Burger")
+	assert.Nil(t, sp.Schema())
+	assert.True(t, sp.IsReference())
+	assert.Equal(t, "#/components/schemas/Burger", sp.GetReference())
+
+	// replacing a schema with a reference renders the reference.
+	var original yaml.Node
+	_ = yaml.Unmarshal([]byte("type: string"), &original)
+	rendered, _ := yaml.Marshal(high.NewRenderer(nil).Render(sp, original.Content[0]))
+	assert.Equal(t, "$ref: '#/components/schemas/Burger'\n", string(rendered))
+}
+
+func TestNewSchema_JSONSchemaKeywords(t *testing.T) {
+	yml := `$id: https://pb33f.io/pet
+$anchor: pet
+$defs:
+  tag:
+    type: string
+const: pickles
+contains:
+  type: integer
+minContains: 1
+maxContains: 5
+prefixItems:
+  - type: string
+  - type: integer
+if:
+  required: [cat]
+then:
+  required: [meow]
+else:
+  required: [bark]
+dependentSchemas:
+  collar:
+    required: [owner]
+dependentRequired:
+  collar: [owner, phone]
+patternProperties:
+  ^x-:
+    type: string
+propertyNames:
+  pattern: ^[a-z]+$
+unevaluatedItems:
+  type: boolean
+unevaluatedProperties: false`
+
+	var compNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &compNode)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(compNode.Content[0], nil))
+	schemaProxy := &SchemaProxy{schema: &low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: compNode.Content[0],
+	}}
+	s := schemaProxy.Schema()
+
+	assert.Equal(t, "https://pb33f.io/pet", s.Id)
+	assert.Equal(t, "pet", s.Anchor)
+	assert.Equal(t, "string", s.Defs["tag"].Schema().Type[0])
+	assert.Equal(t, "pickles", s.Const)
+	assert.Equal(t, "integer", s.Contains.Schema().Type[0])
+	assert.Equal(t, int64(1), *s.MinContains)
+	assert.Equal(t, int64(5), *s.MaxContains)
+	assert.Len(t, s.PrefixItems, 2)
+	assert.Equal(t, "integer", s.PrefixItems[1].Schema().Type[0])
+	assert.Equal(t, []string{"cat"}, s.If.Schema().Required)
+	assert.Equal(t, []string{"meow"}, s.Then.Schema().Required)
+	assert.Equal(t, []string{"bark"}, s.Else.Schema().Required)
+	assert.Equal(t, []string{"owner"}, s.DependentSchemas["collar"].Schema().Required)
+	assert.Equal(t, []string{"owner", "phone"}, s.DependentRequired["collar"])
+	assert.Equal(t, "string", s.PatternProperties["^x-"].Schema().Type[0])
+	assert.Equal(t, "^[a-z]+$", s.PropertyNames.Schema().Pattern)
+	assert.Equal(t, "boolean", s.UnevaluatedItems.(*SchemaProxy).Schema().Type[0])
+	assert.Equal(t, false, s.UnevaluatedProperties)
+
+	// an unchanged schema renders the original node.
+	r := high.NewRenderer(&compNode)
+	assert.Same(t, compNode.Content[0], r.Render(schemaProxy, compNode.Content[0]))
+
+	s.UnevaluatedProperties = true
+	s.Then.Schema().Required = []string{"purr"}
+	rendered, _ := yaml.Marshal(r.Render(schemaProxy, compNode.Content[0]))
+	assert.Contains(t, string(rendered), "unevaluatedProperties: true")
+	assert.Contains(t, string(rendered), "required: [purr]")
+}
+
+func TestNewSchema_DecimalConstraints(t *testing.T) {
+	yml := `type: number
+maximum: 99.99
+minimum: 10
+multipleOf: 0.01
+exclusiveMaximum: 100.5`
+
+	var compNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &compNode)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(compNode.Content[0], nil))
+	schemaProxy := &SchemaProxy{schema: &low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: compNode.Content[0],
+	}}
+	s := schemaProxy.Schema()
+
+	assert.Equal(t, 99.99, *s.Maximum)
+	assert.Equal(t, float64(10), *s.Minimum)
+	assert.Equal(t, 0.01, *s.MultipleOf)
+	assert.Equal(t, 100.5, *s.ExclusiveMaximum)
+
+	// unchanged numbers keep their original literal, changed numbers are rendered.
+	r := high.NewRenderer(&compNode)
+	max := 49.95
+	s.Maximum = &max
+	rendered, _ := yaml.Marshal(r.Render(schemaProxy, compNode.Content[0]))
+	assert.Equal(t, "type: number\nmaximum: 49.95\nminimum: 10\nmultipleOf: 0.01\nexclusiveMaximum: 100.5\n", string(rendered))
+}
+
+func TestNewSchema_EnumTyped(t *testing.T) {
+	yml := `enum: [pickles, 1, true, null, {name: cat}]`
+
+	var compNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &compNode)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(compNode.Content[0], nil))
+	schemaProxy := &SchemaProxy{schema: &low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: compNode.Content[0],
+	}}
+	s := schemaProxy.Schema()
+	assert.Equal(t, []any{"pickles", int64(1), true, nil, map[string]any{"name": "cat"}}, s.Enum)
+
+	// an unchanged enum renders the original node, typed values are rendered with their type.
+	r := high.NewRenderer(&compNode)
+	assert.Same(t, compNode.Content[0], r.Render(schemaProxy, compNode.Content[0]))
+
+	s.Enum = append(s.Enum, "2", false)
+	rendered, _ := yaml.Marshal(r.Render(schemaProxy, compNode.Content[0]))
+	var out map[string]any
+	_ = yaml.Unmarshal(rendered, &out)
+	assert.Equal(t, []any{"pickles", 1, true, nil, map[string]any{"name": "cat"}, "2", false}, out["enum"])
+}
@@ -15,10 +15,10 @@ import (
 //  - v2: https://swagger.io/specification/v2/#tagObject
 //  - v3: https://swagger.io/specification/#tag-object
 type Tag struct {
-    Name         string
-    Description  string
-    ExternalDocs *ExternalDoc
-    Extensions   map[string]any
+    Name         string         `yaml:"name,omitempty"`
+    Description  string         `yaml:"description,omitempty"`
+    ExternalDocs *ExternalDoc   `yaml:"externalDocs,omitempty"`
+    Extensions   map[string]any `yaml:",inline"`
     low          *low.Tag
 }
 
@@ -39,6 +39,11 @@ func NewTag(tag *low.Tag) *Tag {
     return t
 }
 
+// CreateTag creates a new high-level Tag from scratch, with the required name. Every map is initialized.
+func CreateTag(name string) *Tag {
+    return &Tag{Name: name, Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low-level Tag instance used to create the high-level one.
 func (t *Tag) GoLow() *low.Tag {
     return t.low
@@ -18,12 +18,12 @@ import (
 //  v2 - https://swagger.io/specification/v2/#xmlObject
 //  v3 - https://swagger.io/specification/#xml-object
 type XML struct {
-    Name       string
-    Namespace  string
-    Prefix     string
-    Attribute  bool
-    Wrapped    bool
-    Extensions map[string]any
+    Name       string         `yaml:"name,omitempty"`
+    Namespace  string         `yaml:"namespace,omitempty"`
+    Prefix     string         `yaml:"prefix,omitempty"`
+    Attribute  bool           `yaml:"attribute,omitempty"`
+    Wrapped    bool           `yaml:"wrapped,omitempty"`
+    Extensions map[string]any `yaml:",inline"`
     low        *low.XML
 }
 
@@ -40,6 +40,11 @@ func NewXML(xml *low.XML) *XML {
     return x
 }
 
+// CreateXML creates a new, empty high-level XML object from scratch, with every map initialized.
+func CreateXML() *XML {
+    return &XML{Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low level XML reference used to create the high level one.
 func (x *XML) GoLow() *low.XML {
     return x.low
@@ -0,0 +1,676 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// NodeRenderer is implemented by high-level models that are not rendered field by field, for example a SchemaProxy,
+// which renders either a reference or the schema it represents.
+type NodeRenderer interface {
+
+	// RenderNode renders the model into a yaml.Node using the supplied Renderer. The original node is the node
+	// that was used to build the model, and may be nil if the model is new.
+	RenderNode(r *Renderer, original *yaml.Node) *yaml.Node
+}
+
+// Renderer writes the state of high-level models back into yaml.Node trees.
+//
+// Rendering is non-destructive: a high-level model is rendered against the original node it was built from, any
+// value that has not changed keeps its original node, so comments, styles and the order of keys are all preserved.
+// Keys unknown to the model are left untouched, new keys are appended to the end of a mapping. If nothing has changed
+// in a node (or any of its children), the exact same original node is returned.
+//
+// When an original node is a local reference ($ref) and the model built from it has not changed, the reference is
+// kept. If the model has been changed, then the reference is replaced with the rendered model.
+//
+// Changes made to the low-level model after the high-level model was built (for example using Mutate) are kept as
+// well, as long as the values of the nodes were recorded (see RecordNodeValues) when the high-level model was built.
+type Renderer struct {
+	root  *yaml.Node
+	built NodeValues
+}
+
+// NodeValues holds the value of every scalar node in a document, when a high-level model was built from it.
+type NodeValues map[*yaml.Node]string
+
+// RecordNodeValues records the value of every scalar node beneath a root node. A value node of the low-level model
+// that no longer has the recorded value has been changed using the low-level model.
+func RecordNodeValues(root *yaml.Node) NodeValues {
+	values := make(NodeValues)
+	var record func(n *yaml.Node)
+	record = func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode {
+			values[n] = n.Value
+		}
+		for _, c := range n.Content {
+			record(c)
+		}
+	}
+	if root != nil {
+		record(root)
+	}
+	return values
+}
+
+// NewRenderer creates a new Renderer for a document. The root node is used to look up local references,
+// it may be nil if there is no original document.
+func NewRenderer(root *yaml.Node) *Renderer {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	return &Renderer{root: root}
+}
+
+// RenderDocument renders a high-level document model against the root node of the original document, and returns
+// a new document node. The original root node is never modified. The values recorded when the model was built may
+// be nil, in which case changes to the high-level model always win over changes to the low-level model.
+func RenderDocument(model any, root *yaml.Node, built NodeValues) *yaml.Node {
+	r := NewRenderer(root)
+	r.built = built
+	var original *yaml.Node
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		original = root.Content[0]
+	} else if root != nil && root.Kind != yaml.DocumentNode {
+		original = root
+	}
+	rendered := r.Render(model, original)
+	if rendered == nil {
+		rendered = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{rendered}}
+	if root != nil && root.Kind == yaml.DocumentNode {
+		doc.HeadComment, doc.LineComment, doc.FootComment = root.HeadComment, root.LineComment, root.FootComment
+	}
+	return doc
+}
+
+// Render renders a value against the original node it was built from. A nil node is returned if the value is empty
+// and there is nothing to render.
+func (r *Renderer) Render(value any, original *yaml.Node) *yaml.Node {
+	return r.render(reflect.ValueOf(value), original, false)
+}
+
+func (r *Renderer) render(v reflect.Value, original *yaml.Node, single bool) *yaml.Node {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		if nr, ok := v.Interface().(NodeRenderer); ok {
+			if v.Kind() == reflect.Pointer && v.IsNil() {
+				return nil
+			}
+			return nr.RenderNode(r, original)
+		}
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			if !isModel(v.Elem().Type()) {
+				return opaque(v, original)
+			}
+			return r.followReference(original, func(n *yaml.Node) *yaml.Node {
+				return r.renderStruct(v.Elem(), n, goLow(v))
+			})
+		}
+		return r.renderScalar(v.Elem(), original, true)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		// a value held by an interface has been explicitly set, even if it's a zero value (like false).
+		if isBasic(v.Elem().Kind()) {
+			return r.renderScalar(v.Elem(), original, true)
+		}
+		return r.render(v.Elem(), original, single)
+	case reflect.Struct:
+		if !isModel(v.Type()) {
+			return opaque(v, original)
+		}
+		return r.renderStruct(v, original, reflect.Value{})
+	case reflect.Map:
+		if v.Len() == 0 {
+			return emptyCollection(original, yaml.MappingNode)
+		}
+		return r.followReference(original, func(n *yaml.Node) *yaml.Node {
+			return r.renderMapping(n, nil, []reflect.Value{v}, reflect.Value{})
+		})
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return r.renderScalar(v, original, false)
+		}
+		if v.Len() == 0 {
+			return emptyCollection(original, yaml.SequenceNode)
+		}
+		if v.Len() == 1 && (single && original == nil || original != nil && original.Kind != yaml.SequenceNode) {
+			return r.render(v.Index(0), original, false)
+		}
+		return r.renderSequence(v, original)
+	default:
+		return r.renderScalar(v, original, false)
+	}
+}
+
+// followReference renders a model (or map) against the target of an original local reference. If nothing has
+// changed, the original reference is kept, otherwise the rendered model replaces it.
+func (r *Renderer) followReference(original *yaml.Node, render func(n *yaml.Node) *yaml.Node) *yaml.Node {
+	ref := referenceValue(original)
+	if ref == "" {
+		return render(original)
+	}
+	target := r.lookup(ref)
+	if target == nil || target.Kind != yaml.MappingNode {
+		// external (or broken) references cannot be compared, so they are always kept.
+		return original
+	}
+	rendered := render(target)
+	if rendered == target {
+		return original
+	}
+	return rendered
+}
+
+// lookup finds the node a local JSON pointer reference (for example #/components/schemas/Pet) points to.
+func (r *Renderer) lookup(ref string) *yaml.Node {
+	if r.root == nil || !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	n := r.root
+	for _, seg := range strings.Split(ref[2:], "/") {
+		seg = strings.ReplaceAll(strings.ReplaceAll(seg, "~1", "/"), "~0", "~")
+		_, value := findKey(n, seg)
+		if value == nil {
+			if i, err := strconv.Atoi(seg); err == nil && n.Kind == yaml.SequenceNode && i < len(n.Content) {
+				value = n.Content[i]
+			} else {
+				return nil
+			}
+		}
+		n = value
+	}
+	return n
+}
+
+// field is a struct field that is rendered as a key of a mapping. The low value node is the node the low-level
+// model used to build the field.
+type field struct {
+	key      string
+	value    reflect.Value
+	single   bool
+	low      reflect.Value
+	lowValue *yaml.Node
+}
+
+// renderStruct renders a high-level model into a mapping, using the yaml tags of each field to determine keys.
+// Inline maps are rendered as keys of the same mapping, a map named Extensions owns all the 'x-' keys. The low-level
+// model (if there is one) is used to find the nodes each field was originally built from.
+func (r *Renderer) renderStruct(v reflect.Value, original *yaml.Node, low reflect.Value) *yaml.Node {
+	var fields []field
+	var inline []reflect.Value
+	var extensions reflect.Value
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("yaml")
+		if !f.IsExported() || !ok || tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			if f.Type.Kind() != reflect.Map {
+				continue
+			}
+			if f.Name == "Extensions" {
+				extensions = v.Field(i)
+			} else {
+				inline = append(inline, v.Field(i))
+			}
+			continue
+		}
+		lf := lowField(low, f.Name)
+		fields = append(fields, field{key: name, value: v.Field(i), single: f.Tag.Get("render") == "single",
+			low: lowValue(lf), lowValue: lowValueNode(lf)})
+	}
+	return r.renderMapping(original, fields, inline, extensions)
+}
+
+// renderMapping renders named fields and the entries of maps into a mapping node, merging them with the original.
+func (r *Renderer) renderMapping(original *yaml.Node, fields []field, maps []reflect.Value,
+	extensions reflect.Value) *yaml.Node {
+
+	if original != nil && original.Kind != yaml.MappingNode {
+		original = nil
+	}
+	rendered := make(map[string]*yaml.Node)
+	named := make(map[string]bool)
+	var order []string
+	add := func(key string, n *yaml.Node) {
+		if _, ok := rendered[key]; ok {
+			return
+		}
+		if n != nil {
+			rendered[key] = n
+			order = append(order, key)
+		}
+	}
+
+	// named fields, the first field to render a value wins when fields share a key.
+	for _, f := range fields {
+		named[f.key] = true
+		_, o := findKey(original, f.key)
+		if r.lowChanged(f.lowValue, o) {
+			// the low-level model has been changed since the high-level model was built, so the high-level value is
+			// out of date, and the low-level change is kept.
+			add(f.key, f.lowValue)
+			continue
+		}
+		if sameValue(f.value, f.low) {
+			// the value is the same value the low-level model was built with, so it has not changed.
+			add(f.key, o)
+			continue
+		}
+		if o == nil && original != nil && f.lowValue != nil {
+			// the low-level model found a value that is not a key of the original (building models is not
+			// strict), it's only rendered if it has been changed.
+			if n := r.render(f.value, f.lowValue, false); n != f.lowValue {
+				add(f.key, n)
+			}
+			continue
+		}
+		add(f.key, r.render(f.value, o, f.single))
+	}
+
+	// entries of maps, added in a stable order.
+	for _, m := range append(append([]reflect.Value{}, maps...), extensions) {
+		if !m.IsValid() || m.Len() == 0 {
+			continue
+		}
+		keys := m.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return keyString(keys[i]) < keyString(keys[j])
+		})
+		for _, k := range keys {
+			_, o := findKey(original, keyString(k))
+			n := r.render(m.MapIndex(k), o, false)
+			if n == nil {
+				// an entry is always rendered, even when its value is empty.
+				n = opaque(m.MapIndex(k), nil)
+			}
+			add(keyString(k), n)
+		}
+	}
+
+	// keys of the original are owned by named fields, by the extensions (x-), or by any other map.
+	owned := func(key string) bool {
+		if named[key] {
+			return true
+		}
+		if strings.HasPrefix(strings.ToLower(key), "x-") {
+			return extensions.IsValid()
+		}
+		return len(maps) > 0
+	}
+
+	changed := original == nil
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	used := make(map[string]bool)
+	if original != nil {
+		copied := *original
+		out = &copied
+		out.Content = nil
+		for i := 0; i+1 < len(original.Content); i += 2 {
+			k, val := original.Content[i], original.Content[i+1]
+			if n, ok := rendered[k.Value]; ok && !used[k.Value] {
+				used[k.Value] = true
+				if n != val {
+					changed = true
+				}
+				out.Content = append(out.Content, k, n)
+				continue
+			}
+			if owned(k.Value) {
+				changed = true
+				continue
+			}
+			out.Content = append(out.Content, k, val)
+		}
+	}
+	for _, key := range order {
+		if used[key] {
+			continue
+		}
+		changed = true
+		out.Content = append(out.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, rendered[key])
+	}
+	if !changed {
+		return original
+	}
+	return out
+}
+
+// lowChanged returns true if the value node of a low-level model field has been changed since the high-level model
+// was built, either by changing its value, or by replacing the original node with a new one.
+func (r *Renderer) lowChanged(lowValue, original *yaml.Node) bool {
+	if r.built == nil || lowValue == nil {
+		return false
+	}
+	value, known := r.built[lowValue]
+	if !known {
+		return original != nil && original != lowValue && lowValue.Kind == yaml.ScalarNode
+	}
+	return value != lowValue.Value
+}
+
+// renderSequence renders a slice into a sequence node, each item is rendered against the original at the same index.
+func (r *Renderer) renderSequence(v reflect.Value, original *yaml.Node) *yaml.Node {
+	if original != nil && original.Kind != yaml.SequenceNode {
+		original = nil
+	}
+	changed := original == nil || len(original.Content) != v.Len()
+	out := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	if original != nil {
+		copied := *original
+		out = &copied
+	}
+	out.Content = nil
+	for i := 0; i < v.Len(); i++ {
+		var o *yaml.Node
+		if original != nil && i < len(original.Content) {
+			o = original.Content[i]
+		}
+		n := r.render(v.Index(i), o, false)
+		if n == nil && o != nil && o.Kind == yaml.ScalarNode && o.Tag == "!!null" {
+			n = o
+		}
+		if n == nil {
+			n = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+		}
+		if n != o {
+			changed = true
+		}
+		out.Content = append(out.Content, n)
+	}
+	if !changed {
+		return original
+	}
+	return out
+}
+
+// renderScalar renders a value that is not a model (a string, number, boolean or plain data held by an any).
+// If the value is equal to the original, the original is returned. Zero values are not rendered, unless they
+// are explicitly set (a pointer) or they were present in the original.
+func (r *Renderer) renderScalar(v reflect.Value, original *yaml.Node, explicit bool) *yaml.Node {
+	if n, ok := v.Interface().(*yaml.Node); ok {
+		return n
+	}
+	if n, ok := v.Interface().(yaml.Node); ok {
+		return &n
+	}
+	encoded := new(yaml.Node)
+	if err := encoded.Encode(v.Interface()); err != nil {
+		return original
+	}
+	if original != nil && equalNodes(original, encoded) {
+		return original
+	}
+	if original != nil && original.Kind == yaml.ScalarNode && v.Kind() == reflect.String &&
+		original.Value == v.String() {
+		// models hold some values (such as enums) as strings, regardless of the type in the original.
+		return original
+	}
+	if !explicit && v.IsZero() {
+		return nil
+	}
+	if original == nil {
+		return encoded
+	}
+	// keep comments, and the style of the original if the value is still a scalar.
+	if original.Kind == yaml.ScalarNode && encoded.Kind == yaml.ScalarNode {
+		copied := *original
+		copied.Value, copied.Tag = encoded.Value, encoded.Tag
+		if original.Style == 0 || encoded.Style != 0 {
+			copied.Style = encoded.Style
+		}
+		return &copied
+	}
+	encoded.HeadComment, encoded.LineComment, encoded.FootComment =
+		original.HeadComment, original.LineComment, original.FootComment
+	return encoded
+}
+
+// emptyCollection renders an empty map or slice. An empty collection is only kept if the original was also empty,
+// otherwise nothing is rendered.
+func emptyCollection(original *yaml.Node, kind yaml.Kind) *yaml.Node {
+	if original != nil && original.Kind == kind && len(original.Content) == 0 {
+		return original
+	}
+	return nil
+}
+
+// opaque renders a value that is not a high-level model (for example a low-level object leaking through an any),
+// the original is always kept, as there is no way to know how the value should be rendered.
+func opaque(v reflect.Value, original *yaml.Node) *yaml.Node {
+	if original != nil {
+		return original
+	}
+	encoded := new(yaml.Node)
+	if err := encoded.Encode(v.Interface()); err != nil {
+		return nil
+	}
+	return encoded
+}
+
+// goLow returns the low-level model of a high-level model, using its GoLow() method. An invalid value is returned
+// if there is no low-level model.
+func goLow(v reflect.Value) reflect.Value {
+	m := v.MethodByName("GoLow")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return reflect.Value{}
+	}
+	l := m.Call(nil)[0]
+	if l.Kind() != reflect.Pointer || l.IsNil() || l.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return l.Elem()
+}
+
+// lowField returns a field of a low-level model, if it's a NodeReference or a ValueReference.
+func lowField(low reflect.Value, name string) reflect.Value {
+	if !low.IsValid() {
+		return reflect.Value{}
+	}
+	f := low.FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.Struct || !f.FieldByName("ValueNode").IsValid() ||
+		!f.FieldByName("Value").IsValid() {
+		return reflect.Value{}
+	}
+	return f
+}
+
+// lowValue returns the value held by a low-level model field.
+func lowValue(f reflect.Value) reflect.Value {
+	if !f.IsValid() {
+		return reflect.Value{}
+	}
+	return f.FieldByName("Value")
+}
+
+// lowValueNode returns the value node held by a low-level model field.
+func lowValueNode(f reflect.Value) *yaml.Node {
+	if !f.IsValid() || !f.FieldByName("ValueNode").CanInterface() {
+		return nil
+	}
+	n, _ := f.FieldByName("ValueNode").Interface().(*yaml.Node)
+	return n
+}
+
+// sameValue returns true if a high-level value is the same as the low-level value it was created from. Only
+// strings, booleans, numbers (or pointers to them) and plain data are compared, anything else is only the same
+// if both values are empty. A nil pointer is the same as a zero value.
+func sameValue(high, low reflect.Value) bool {
+	if !high.IsValid() || !low.IsValid() {
+		return false
+	}
+	if isEmpty(high) && isEmpty(low) {
+		return true
+	}
+	if high.Kind() == reflect.Pointer {
+		if !isBasic(high.Type().Elem().Kind()) {
+			return false
+		}
+		if high.IsNil() {
+			high = reflect.Zero(high.Type().Elem())
+		} else {
+			high = high.Elem()
+		}
+	}
+	if high.Kind() == reflect.Interface || low.Kind() == reflect.Interface {
+		if high.Kind() != reflect.Interface || low.Kind() != reflect.Interface || !high.CanInterface() ||
+			!low.CanInterface() {
+			return false
+		}
+		return reflect.DeepEqual(high.Interface(), low.Interface())
+	}
+	if !isBasic(high.Kind()) || !isBasic(low.Kind()) {
+		return false
+	}
+	switch {
+	case isNumber(high.Kind()) && isNumber(low.Kind()):
+		return toFloat(high) == toFloat(low)
+	case high.Kind() == reflect.String && low.Kind() == reflect.String:
+		return high.String() == low.String()
+	case high.Kind() == reflect.Bool && low.Kind() == reflect.Bool:
+		return high.Bool() == low.Bool()
+	}
+	return false
+}
+
+// isEmpty returns true for nil pointers, interfaces, maps and slices, empty maps and slices, and zero values.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func isBasic(k reflect.Kind) bool {
+	return k == reflect.String || k == reflect.Bool || isNumber(k)
+}
+
+func isNumber(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return float64(v.Int())
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+// isModel returns true if a struct type has any fields with a yaml tag, which makes it a high-level model.
+func isModel(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("yaml"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceValue returns the value of the $ref key of a mapping node, or an empty string.
+func referenceValue(n *yaml.Node) string {
+	if _, v := findKey(n, "$ref"); v != nil {
+		return v.Value
+	}
+	return ""
+}
+
+// findKey returns the key and value nodes for a key held by a mapping node.
+func findKey(n *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i], n.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// keyString returns a map key as a string.
+func keyString(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+	if k.CanInterface() {
+		if s, ok := k.Interface().(interface{ String() string }); ok {
+			return s.String()
+		}
+	}
+	return strconv.Quote(k.String())
+}
+
+// equalNodes compares two nodes by value only, ignoring styles, comments, positions and the order of mapping keys.
+// Numbers are compared numerically, so 1.50 and 1.5 are equal.
+func equalNodes(a, b *yaml.Node) bool {
+	if a.Kind == yaml.AliasNode && a.Alias != nil {
+		return equalNodes(a.Alias, b)
+	}
+	if a.Kind != b.Kind {
+		return false
+	}
+	switch a.Kind {
+	case yaml.ScalarNode:
+		at, bt := a.ShortTag(), b.ShortTag()
+		if isNumberTag(at) && isNumberTag(bt) {
+			af, aErr := strconv.ParseFloat(a.Value, 64)
+			bf, bErr := strconv.ParseFloat(b.Value, 64)
+			return aErr == nil && bErr == nil && af == bf
+		}
+		return at == bt && a.Value == b.Value
+	case yaml.MappingNode:
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := 0; i+1 < len(a.Content); i += 2 {
+			_, bv := findKey(b, a.Content[i].Value)
+			if bv == nil || !equalNodes(a.Content[i+1], bv) {
+				return false
+			}
+		}
+		return true
+	default:
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := range a.Content {
+			if !equalNodes(a.Content[i], b.Content[i]) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func isNumberTag(tag string) bool {
+	return tag == "!!int" || tag == "!!float"
+}
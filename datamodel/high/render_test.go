@@ -0,0 +1,158 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+type renderPet struct {
+	Name       string            `yaml:"name,omitempty"`
+	Age        int               `yaml:"age,omitempty"`
+	Tags       []string          `yaml:"tags,omitempty"`
+	Owner      *renderOwner      `yaml:"owner,omitempty"`
+	Extensions map[string]any    `yaml:"-,inline"`
+	Toys       map[string]string `yaml:"toys,omitempty"`
+}
+
+type renderOwner struct {
+	Name string `yaml:"name,omitempty"`
+}
+
+func parseRenderYAML(t *testing.T, yml string) *yaml.Node {
+	var n yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(yml), &n))
+	return &n
+}
+
+func marshalRendered(t *testing.T, n *yaml.Node) string {
+	b, err := yaml.Marshal(n)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func TestRenderDocument_Unchanged(t *testing.T) {
+	yml := `# a pet
+name: pickles # the name
+age: 12
+unknown: kept
+tags: [cute, fluffy]
+`
+	root := parseRenderYAML(t, yml)
+	pet := &renderPet{Name: "pickles", Age: 12, Tags: []string{"cute", "fluffy"}}
+
+	rendered := RenderDocument(pet, root, nil)
+	assert.Same(t, root.Content[0], rendered.Content[0])
+}
+
+func TestRenderDocument_Modified(t *testing.T) {
+	yml := `# a pet
+name: pickles # the name
+age: 12
+unknown: kept
+x-color: orange
+`
+	root := parseRenderYAML(t, yml)
+	pet := &renderPet{Name: "chicken", Age: 12, Extensions: map[string]any{"x-color": "orange"}}
+	pet.Owner = &renderOwner{Name: "quobix"}
+
+	expected := `# a pet
+name: chicken # the name
+age: 12
+unknown: kept
+x-color: orange
+owner:
+    name: quobix
+`
+	assert.Equal(t, expected, marshalRendered(t, RenderDocument(pet, root, nil)))
+
+	// the original document is never modified.
+	assert.Equal(t, yml, marshalRendered(t, root))
+}
+
+func TestRenderDocument_RemovedValues(t *testing.T) {
+	yml := `name: pickles
+age: 12
+x-color: orange
+toys:
+  ball: red
+  bone: white
+`
+	root := parseRenderYAML(t, yml)
+	pet := &renderPet{Name: "pickles", Extensions: map[string]any{}, Toys: map[string]string{"ball": "red"}}
+
+	expected := `name: pickles
+toys:
+    ball: red
+`
+	assert.Equal(t, expected, marshalRendered(t, RenderDocument(pet, root, nil)))
+}
+
+func TestRenderDocument_NoOriginal(t *testing.T) {
+	pet := &renderPet{Name: "pickles", Tags: []string{"cute"}, Toys: map[string]string{"bone": "white", "ball": "red"}}
+
+	expected := `name: pickles
+tags:
+    - cute
+toys:
+    ball: red
+    bone: white
+`
+	assert.Equal(t, expected, marshalRendered(t, RenderDocument(pet, nil, nil)))
+}
+
+func TestRenderer_Render_Reference(t *testing.T) {
+	yml := `pets:
+  pickles:
+    $ref: '#/components/pickles'
+components:
+  pickles:
+    name: pickles
+    age: 12
+`
+	type pets struct {
+		Pets map[string]*renderPet `yaml:"pets,omitempty"`
+	}
+	root := parseRenderYAML(t, yml)
+	r := NewRenderer(root)
+	original := root.Content[0].Content[1]
+
+	p := pets{Pets: map[string]*renderPet{"pickles": {Name: "pickles", Age: 12}}}
+	assert.Same(t, original, r.Render(p.Pets, original))
+
+	// a changed reference is rendered in place of the reference.
+	p.Pets["pickles"].Age = 13
+	expected := `pickles:
+    name: pickles
+    age: 13
+`
+	assert.Equal(t, expected, marshalRendered(t, r.Render(p.Pets, original)))
+}
+
+func TestRenderer_Render_Scalars(t *testing.T) {
+	root := parseRenderYAML(t, "age: 12 # years\nname: \"pickles\"\n")
+	r := NewRenderer(root)
+
+	type pet struct {
+		Age   int      `yaml:"age,omitempty"`
+		Name  string   `yaml:"name,omitempty"`
+		Score *float64 `yaml:"score,omitempty"`
+	}
+	score := 1.5
+	assert.Same(t, root.Content[0], r.Render(&pet{Age: 12, Name: "pickles"}, root.Content[0]))
+
+	// comments and quoting styles are kept.
+	rendered := r.Render(&pet{Age: 13, Name: "chicken", Score: &score}, root.Content[0])
+	assert.Equal(t, "age: 13 # years\nname: \"chicken\"\nscore: 1.5\n", marshalRendered(t, rendered))
+}
+
+func TestEqualNodes(t *testing.T) {
+	a := parseRenderYAML(t, "{a: 1, b: [x, y]}")
+	b := parseRenderYAML(t, "b:\n  - x\n  - y\na: 1.0 # one\n")
+	c := parseRenderYAML(t, "{a: 2, b: [x, y]}")
+	assert.True(t, equalNodes(a, b))
+	assert.False(t, equalNodes(a, c))
+}
@@ -16,7 +16,7 @@ import (
 // arrays or models.
 //  - https://swagger.io/specification/v2/#definitionsObject
 type Definitions struct {
-	Definitions map[string]*highbase.SchemaProxy
+	Definitions map[string]*highbase.SchemaProxy `yaml:",inline"`
 	low         *low.Definitions
 }
 
@@ -34,6 +34,11 @@ func NewDefinitions(definitions *low.Definitions) *Definitions {
 	return rd
 }
 
+// CreateDefinitions creates a new, empty high-level Definitions object from scratch, with every map initialized.
+func CreateDefinitions() *Definitions {
+	return &Definitions{Definitions: make(map[string]*highbase.SchemaProxy)}
+}
+
 // GoLow returns the low-level Definitions object used to create the high-level one.
 func (d *Definitions) GoLow() *low.Definitions {
 	return d.low
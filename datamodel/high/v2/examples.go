@@ -9,7 +9,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // Allows sharing examples for operation responses
 //  - https://swagger.io/specification/v2/#exampleObject
 type Example struct {
-	Values map[string]any
+	Values map[string]any `yaml:",inline"`
 	low    *low.Examples
 }
 
@@ -27,6 +27,11 @@ func NewExample(examples *low.Examples) *Example {
 	return e
 }
 
+// CreateExample creates a new, empty high-level Example from scratch, with every map initialized.
+func CreateExample() *Example {
+	return &Example{Values: make(map[string]any)}
+}
+
 // GoLow returns the low-level Example used to create the high-level one.
 func (e *Example) GoLow() *low.Examples {
 	return e.low
@@ -12,25 +12,25 @@ import (
 // A Header is essentially identical to a Parameter, except it does not contain 'name' or 'in' properties.
 //  - https://swagger.io/specification/v2/#headerObject
 type Header struct {
-	Type             string
-	Format           string
-	Description      string
-	Items            *Items
-	CollectionFormat string
-	Default          any
-	Maximum          int
-	ExclusiveMaximum bool
-	Minimum          int
-	ExclusiveMinimum bool
-	MaxLength        int
-	MinLength        int
-	Pattern          string
-	MaxItems         int
-	MinItems         int
-	UniqueItems      bool
-	Enum             []string
-	MultipleOf       int
-	Extensions       map[string]any
+	Type             string         `yaml:"type,omitempty"`
+	Format           string         `yaml:"format,omitempty"`
+	Description      string         `yaml:"description,omitempty"`
+	Items            *Items         `yaml:"items,omitempty"`
+	CollectionFormat string         `yaml:"collectionFormat,omitempty"`
+	Default          any            `yaml:"default,omitempty"`
+	Maximum          int            `yaml:"maximum,omitempty"`
+	ExclusiveMaximum bool           `yaml:"exclusiveMaximum,omitempty"`
+	Minimum          int            `yaml:"minimum,omitempty"`
+	ExclusiveMinimum bool           `yaml:"exclusiveMinimum,omitempty"`
+	MaxLength        int            `yaml:"maxLength,omitempty"`
+	MinLength        int            `yaml:"minLength,omitempty"`
+	Pattern          string         `yaml:"pattern,omitempty"`
+	MaxItems         int            `yaml:"maxItems,omitempty"`
+	MinItems         int            `yaml:"minItems,omitempty"`
+	UniqueItems      bool           `yaml:"uniqueItems,omitempty"`
+	Enum             []string       `yaml:"enum,omitempty"`
+	MultipleOf       int            `yaml:"multipleOf,omitempty"`
+	Extensions       map[string]any `yaml:",inline"`
 	low              *low.Header
 }
 
@@ -43,7 +43,7 @@ func NewHeader(header *low.Header) *Header {
 		h.Type = header.Type.Value
 	}
 	if !header.Format.IsEmpty() {
-		h.Format = header.Type.Value
+		h.Format = header.Format.Value
 	}
 	if !header.Description.IsEmpty() {
 		h.Description = header.Description.Value
@@ -100,6 +100,11 @@ func NewHeader(header *low.Header) *Header {
 	return h
 }
 
+// CreateHeader creates a new high-level Header from scratch, with the required type. Every map is initialized.
+func CreateHeader(headerType string) *Header {
+	return &Header{Type: headerType, Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low-level header used to create the high-level one.
 func (h *Header) GoLow() *low.Header {
 	return h.low
@@ -10,23 +10,23 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // located in "body"
 //  - https://swagger.io/specification/v2/#itemsObject
 type Items struct {
-	Type             string
-	Format           string
-	CollectionFormat string
-	Items            *Items
-	Default          any
-	Maximum          int
-	ExclusiveMaximum bool
-	Minimum          int
-	ExclusiveMinimum bool
-	MaxLength        int
-	MinLength        int
-	Pattern          string
-	MaxItems         int
-	MinItems         int
-	UniqueItems      bool
-	Enum             []string
-	MultipleOf       int
+	Type             string   `yaml:"type,omitempty"`
+	Format           string   `yaml:"format,omitempty"`
+	CollectionFormat string   `yaml:"collectionFormat,omitempty"`
+	Items            *Items   `yaml:"items,omitempty"`
+	Default          any      `yaml:"default,omitempty"`
+	Maximum          int      `yaml:"maximum,omitempty"`
+	ExclusiveMaximum bool     `yaml:"exclusiveMaximum,omitempty"`
+	Minimum          int      `yaml:"minimum,omitempty"`
+	ExclusiveMinimum bool     `yaml:"exclusiveMinimum,omitempty"`
+	MaxLength        int      `yaml:"maxLength,omitempty"`
+	MinLength        int      `yaml:"minLength,omitempty"`
+	Pattern          string   `yaml:"pattern,omitempty"`
+	MaxItems         int      `yaml:"maxItems,omitempty"`
+	MinItems         int      `yaml:"minItems,omitempty"`
+	UniqueItems      bool     `yaml:"uniqueItems,omitempty"`
+	Enum             []string `yaml:"enum,omitempty"`
+	MultipleOf       int      `yaml:"multipleOf,omitempty"`
 	low              *low.Items
 }
 
@@ -92,6 +92,11 @@ func NewItems(items *low.Items) *Items {
 	return i
 }
 
+// CreateItems creates a new high-level Items object from scratch, with the required type.
+func CreateItems(itemsType string) *Items {
+	return &Items{Type: itemsType}
+}
+
 // GoLow returns the low-level Items object that was used to create the high-level one.
 func (i *Items) GoLow() *low.Items {
 	return i.low
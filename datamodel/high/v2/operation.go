@@ -13,19 +13,19 @@ import (
 // It describes a single API operation on a path.
 //  - https://swagger.io/specification/v2/#operationObject
 type Operation struct {
-	Tags         []string
-	Summary      string
-	Description  string
-	ExternalDocs *base.ExternalDoc
-	OperationId  string
-	Consumes     []string
-	Produces     []string
-	Parameters   []*Parameter
-	Responses    *Responses
-	Schemes      []string
-	Deprecated   bool
-	Security     []*SecurityRequirement
-	Extensions   map[string]any
+	Tags         []string               `yaml:"tags,omitempty"`
+	Summary      string                 `yaml:"summary,omitempty"`
+	Description  string                 `yaml:"description,omitempty"`
+	ExternalDocs *base.ExternalDoc      `yaml:"externalDocs,omitempty"`
+	OperationId  string                 `yaml:"operationId,omitempty"`
+	Consumes     []string               `yaml:"consumes,omitempty"`
+	Produces     []string               `yaml:"produces,omitempty"`
+	Parameters   []*Parameter           `yaml:"parameters,omitempty"`
+	Responses    *Responses             `yaml:"responses,omitempty"`
+	Schemes      []string               `yaml:"schemes,omitempty"`
+	Deprecated   bool                   `yaml:"deprecated,omitempty"`
+	Security     []*SecurityRequirement `yaml:"security,omitempty"`
+	Extensions   map[string]any         `yaml:",inline"`
 	low          *low.Operation
 }
 
@@ -97,6 +97,16 @@ func NewOperation(operation *low.Operation) *Operation {
 	return o
 }
 
+// CreateOperation creates a new high-level Operation from scratch, with an operationId. The required Responses are
+// created empty, and every map is initialized.
+func CreateOperation(operationId string) *Operation {
+	return &Operation{
+		OperationId: operationId,
+		Responses:   CreateResponses(),
+		Extensions:  make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level operation used to create the high-level one.
 func (o *Operation) GoLow() *low.Operation {
 	return o.low
@@ -41,30 +41,30 @@ import (
 //                           submit-name. This type of form parameters is more commonly used for file transfers
 // https://swagger.io/specification/v2/#parameterObject
 type Parameter struct {
-	Name             string
-	In               string
-	Type             string
-	Format           string
-	Description      string
-	Required         bool
-	AllowEmptyValue  bool
-	Schema           *base.SchemaProxy
-	Items            *Items
-	CollectionFormat string
-	Default          any
-	Maximum          int
-	ExclusiveMaximum bool
-	Minimum          int
-	ExclusiveMinimum bool
-	MaxLength        int
-	MinLength        int
-	Pattern          string
-	MaxItems         int
-	MinItems         int
-	UniqueItems      bool
-	Enum             []string
-	MultipleOf       int
-	Extensions       map[string]any
+	Name             string            `yaml:"name,omitempty"`
+	In               string            `yaml:"in,omitempty"`
+	Type             string            `yaml:"type,omitempty"`
+	Format           string            `yaml:"format,omitempty"`
+	Description      string            `yaml:"description,omitempty"`
+	Required         bool              `yaml:"required,omitempty"`
+	AllowEmptyValue  bool              `yaml:"allowEmptyValue,omitempty"`
+	Schema           *base.SchemaProxy `yaml:"schema,omitempty"`
+	Items            *Items            `yaml:"items,omitempty"`
+	CollectionFormat string            `yaml:"collectionFormat,omitempty"`
+	Default          any               `yaml:"default,omitempty"`
+	Maximum          int               `yaml:"maximum,omitempty"`
+	ExclusiveMaximum bool              `yaml:"exclusiveMaximum,omitempty"`
+	Minimum          int               `yaml:"minimum,omitempty"`
+	ExclusiveMinimum bool              `yaml:"exclusiveMinimum,omitempty"`
+	MaxLength        int               `yaml:"maxLength,omitempty"`
+	MinLength        int               `yaml:"minLength,omitempty"`
+	Pattern          string            `yaml:"pattern,omitempty"`
+	MaxItems         int               `yaml:"maxItems,omitempty"`
+	MinItems         int               `yaml:"minItems,omitempty"`
+	UniqueItems      bool              `yaml:"uniqueItems,omitempty"`
+	Enum             []string          `yaml:"enum,omitempty"`
+	MultipleOf       int               `yaml:"multipleOf,omitempty"`
+	Extensions       map[string]any    `yaml:",inline"`
 	low              *low.Parameter
 }
 
@@ -149,6 +149,17 @@ func NewParameter(parameter *low.Parameter) *Parameter {
 	return p
 }
 
+// CreateParameter creates a new high-level Parameter from scratch, with a name and a location ('query', 'header',
+// 'path', 'formData' or 'body'). A path parameter is always required, every map is initialized.
+func CreateParameter(name, in string) *Parameter {
+	return &Parameter{
+		Name:       name,
+		In:         in,
+		Required:   in == "path",
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Parameter used to create the high-level one.
 func (p *Parameter) GoLow() *low.Parameter {
 	return p.low
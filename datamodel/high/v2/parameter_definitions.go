@@ -12,7 +12,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // referenced to the ones defined here. It does not define global operation parameters
 //  - https://swagger.io/specification/v2/#parametersDefinitionsObject
 type ParameterDefinitions struct {
-	Definitions map[string]*Parameter
+	Definitions map[string]*Parameter `yaml:",inline"`
 	low         *low.ParameterDefinitions
 }
 
@@ -45,6 +45,12 @@ func NewParametersDefinitions(parametersDefinitions *low.ParameterDefinitions) *
 	return pd
 }
 
+// CreateParameterDefinitions creates a new, empty high-level ParameterDefinitions object from scratch, with every
+// map initialized.
+func CreateParameterDefinitions() *ParameterDefinitions {
+	return &ParameterDefinitions{Definitions: make(map[string]*Parameter)}
+}
+
 // GoLow returns the low-level ParameterDefinitions instance that backs the low-level one.
 func (p *ParameterDefinitions) GoLow() *low.ParameterDefinitions {
 	return p.low
@@ -15,16 +15,16 @@ import (
 // are available.
 //  - https://swagger.io/specification/v2/#pathItemObject
 type PathItem struct {
-	Ref        string
-	Get        *Operation
-	Put        *Operation
-	Post       *Operation
-	Delete     *Operation
-	Options    *Operation
-	Head       *Operation
-	Patch      *Operation
-	Parameters []*Parameter
-	Extensions map[string]any
+	Ref        string         `yaml:"$ref,omitempty"`
+	Get        *Operation     `yaml:"get,omitempty"`
+	Put        *Operation     `yaml:"put,omitempty"`
+	Post       *Operation     `yaml:"post,omitempty"`
+	Delete     *Operation     `yaml:"delete,omitempty"`
+	Options    *Operation     `yaml:"options,omitempty"`
+	Head       *Operation     `yaml:"head,omitempty"`
+	Patch      *Operation     `yaml:"patch,omitempty"`
+	Parameters []*Parameter   `yaml:"parameters,omitempty"`
+	Extensions map[string]any `yaml:",inline"`
 	low        *low.PathItem
 }
 
@@ -108,6 +108,11 @@ func NewPathItem(pathItem *low.PathItem) *PathItem {
 	return p
 }
 
+// CreatePathItem creates a new, empty high-level PathItem from scratch, with every map initialized.
+func CreatePathItem() *PathItem {
+	return &PathItem{Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low-level PathItem used to create the high-level one.
 func (p *PathItem) GoLow() *low.PathItem {
 	return p.low
@@ -10,8 +10,8 @@ import (
 
 // Paths represents a high-level Swagger / OpenAPI Paths object, backed by a low-level one.
 type Paths struct {
-	PathItems  map[string]*PathItem
-	Extensions map[string]any
+	PathItems  map[string]*PathItem `yaml:",inline"`
+	Extensions map[string]any       `yaml:",inline"`
 	low        *low.Paths
 }
 
@@ -47,6 +47,14 @@ func NewPaths(paths *low.Paths) *Paths {
 	return p
 }
 
+// CreatePaths creates a new, empty high-level Paths object from scratch, with every map initialized.
+func CreatePaths() *Paths {
+	return &Paths{
+		PathItems:  make(map[string]*PathItem),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Paths instance that backs the high level one.
 func (p *Paths) GoLow() *low.Paths {
 	return p.low
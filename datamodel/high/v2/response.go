@@ -13,11 +13,11 @@ import (
 // Response describes a single response from an API Operation
 //  - https://swagger.io/specification/v2/#responseObject
 type Response struct {
-	Description string
-	Schema      *base.SchemaProxy
-	Headers     map[string]*Header
-	Examples    *Example
-	Extensions  map[string]any
+	Description string             `yaml:"description,omitempty"`
+	Schema      *base.SchemaProxy  `yaml:"schema,omitempty"`
+	Headers     map[string]*Header `yaml:"headers,omitempty"`
+	Examples    *Example           `yaml:"examples,omitempty"`
+	Extensions  map[string]any     `yaml:",inline"`
 	low         *low.Response
 }
 
@@ -45,6 +45,16 @@ func NewResponse(response *low.Response) *Response {
 	return r
 }
 
+// CreateResponse creates a new high-level Response from scratch, with the required description. Every map is
+// initialized.
+func CreateResponse(description string) *Response {
+	return &Response{
+		Description: description,
+		Headers:     make(map[string]*Header),
+		Extensions:  make(map[string]any),
+	}
+}
+
 // GoLow will return the low-level Response instance used to create the high level one.
 func (r *Response) GoLow() *low.Response {
 	return r.low
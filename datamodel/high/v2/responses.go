@@ -10,9 +10,9 @@ import (
 
 // Responses is a high-level representation of a Swagger / OpenAPI 2 Responses object, backed by a low level one.
 type Responses struct {
-	Codes      map[string]*Response
-	Default    *Response
-	Extensions map[string]any
+	Codes      map[string]*Response `yaml:",inline"`
+	Default    *Response            `yaml:"default,omitempty"`
+	Extensions map[string]any       `yaml:",inline"`
 	low        *low.Responses
 }
 
@@ -55,6 +55,14 @@ func NewResponses(responses *low.Responses) *Responses {
 	return r
 }
 
+// CreateResponses creates a new, empty high-level Responses object from scratch, with every map initialized.
+func CreateResponses() *Responses {
+	return &Responses{
+		Codes:      make(map[string]*Response),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow will return the low-level object used to create the high-level one.
 func (r *Responses) GoLow() *low.Responses {
 	return r.low
@@ -12,7 +12,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // referenced to the ones defined here. It does not define global operation responses
 //  - https://swagger.io/specification/v2/#responsesDefinitionsObject
 type ResponsesDefinitions struct {
-	Definitions map[string]*Response
+	Definitions map[string]*Response `yaml:",inline"`
 	low         *low.ResponsesDefinitions
 }
 
@@ -46,6 +46,12 @@ func NewResponsesDefinitions(responsesDefinitions *low.ResponsesDefinitions) *Re
 	return rd
 }
 
+// CreateResponsesDefinitions creates a new, empty high-level ResponsesDefinitions object from scratch, with every
+// map initialized.
+func CreateResponsesDefinitions() *ResponsesDefinitions {
+	return &ResponsesDefinitions{Definitions: make(map[string]*Response)}
+}
+
 // GoLow returns the low-level ResponsesDefinitions used to create the high-level one.
 func (r *ResponsesDefinitions) GoLow() *low.ResponsesDefinitions {
 	return r.low
@@ -12,7 +12,7 @@ import (
 // Scopes lists the available scopes for an OAuth2 security scheme.
 //  - https://swagger.io/specification/v2/#scopesObject
 type Scopes struct {
-	Values map[string]string
+	Values map[string]string `yaml:",inline"`
 	low    *low.Scopes
 }
 
@@ -28,6 +28,11 @@ func NewScopes(scopes *low.Scopes) *Scopes {
 	return s
 }
 
+// CreateScopes creates a new, empty high-level Scopes object from scratch, with every map initialized.
+func CreateScopes() *Scopes {
+	return &Scopes{Values: make(map[string]string)}
+}
+
 // GoLow returns the low-level instance of Scopes used to create the high-level one.
 func (s *Scopes) GoLow() *low.Scopes {
 	return s.low
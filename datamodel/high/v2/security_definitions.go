@@ -12,7 +12,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // schemes on the operations and only serves to provide the relevant details for each scheme
 //  - https://swagger.io/specification/v2/#securityDefinitionsObject
 type SecurityDefinitions struct {
-	Definitions map[string]*SecurityScheme
+	Definitions map[string]*SecurityScheme `yaml:",inline"`
 	low         *low.SecurityDefinitions
 }
 
@@ -28,6 +28,12 @@ func NewSecurityDefinitions(definitions *low.SecurityDefinitions) *SecurityDefin
 	return sd
 }
 
+// CreateSecurityDefinitions creates a new, empty high-level SecurityDefinitions object from scratch, with every map
+// initialized.
+func CreateSecurityDefinitions() *SecurityDefinitions {
+	return &SecurityDefinitions{Definitions: make(map[string]*SecurityScheme)}
+}
+
 // GoLow returns the low-level SecurityDefinitions instance used to create the high-level one.
 func (sd *SecurityDefinitions) GoLow() *low.SecurityDefinitions {
 	return sd.low
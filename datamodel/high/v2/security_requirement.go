@@ -13,7 +13,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // The name used for each property MUST correspond to a security scheme declared in the Security Definitions
 //  - https://swagger.io/specification/v2/#securityDefinitionsObject
 type SecurityRequirement struct {
-	Requirements map[string][]string
+	Requirements map[string][]string `yaml:",inline"`
 	low          *low.SecurityRequirement
 }
 
@@ -34,6 +34,12 @@ func NewSecurityRequirement(req *low.SecurityRequirement) *SecurityRequirement {
 	return r
 }
 
+// CreateSecurityRequirement creates a new, empty high-level SecurityRequirement from scratch, with every map
+// initialized.
+func CreateSecurityRequirement() *SecurityRequirement {
+	return &SecurityRequirement{Requirements: make(map[string][]string)}
+}
+
 // GoLow returns the low-level SecurityRequirement used to create the high-level one.
 func (s *SecurityRequirement) GoLow() *low.SecurityRequirement {
 	return s.low
@@ -16,15 +16,15 @@ import (
 // (implicit, password, application and access code)
 //  - https://swagger.io/specification/v2/#securityDefinitionsObject
 type SecurityScheme struct {
-	Type             string
-	Description      string
-	Name             string
-	In               string
-	Flow             string
-	AuthorizationUrl string
-	TokenUrl         string
-	Scopes           *Scopes
-	Extensions       map[string]any
+	Type             string         `yaml:"type,omitempty"`
+	Description      string         `yaml:"description,omitempty"`
+	Name             string         `yaml:"name,omitempty"`
+	In               string         `yaml:"in,omitempty"`
+	Flow             string         `yaml:"flow,omitempty"`
+	AuthorizationUrl string         `yaml:"authorizationUrl,omitempty"`
+	TokenUrl         string         `yaml:"tokenUrl,omitempty"`
+	Scopes           *Scopes        `yaml:"scopes,omitempty"`
+	Extensions       map[string]any `yaml:",inline"`
 	low              *low.SecurityScheme
 }
 
@@ -60,6 +60,12 @@ func NewSecurityScheme(securityScheme *low.SecurityScheme) *SecurityScheme {
 	return s
 }
 
+// CreateSecurityScheme creates a new high-level SecurityScheme from scratch, with the required type ('basic',
+// 'apiKey' or 'oauth2'). Every map is initialized.
+func CreateSecurityScheme(schemeType string) *SecurityScheme {
+	return &SecurityScheme{Type: schemeType, Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low-level SecurityScheme that was used to create the high-level one.
 func (s *SecurityScheme) GoLow() *low.SecurityScheme {
 	return s.low
@@ -8,6 +8,10 @@
 // object. 'Going Low' allows engineers to transition from a high-level or 'porcelain' API, to a low-level 'plumbing'
 // API, which provides fine grain detail to the underlying AST powering the data, lines, columns, raw nodes etc.
 //
+// High-level models can also be created from scratch using struct literals or a Create function (for example
+// CreateSwaggerDocument or CreateOperation), without any low-level model backing them. A Swagger document created
+// from scratch can be rendered into YAML or JSON using Render() or RenderJSON().
+//
 // IMPORTANT: As a general rule, Swagger / OpenAPI 2 should be avoided for new projects.
 package v2
 
@@ -15,89 +19,95 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
 )
 
 // Swagger represents a high-level Swagger / OpenAPI 2 document. An instance of Swagger is the root of the specification.
 type Swagger struct {
 
 	// Swagger is the version of Swagger / OpenAPI being used, extracted from the 'swagger: 2.x' definition.
-	Swagger string
+	Swagger string `yaml:"swagger,omitempty"`
 
 	// Info represents a specification Info definition.
 	// Provides metadata about the API. The metadata can be used by the clients if needed.
 	// - https://swagger.io/specification/v2/#infoObject
-	Info *base.Info
+	Info *base.Info `yaml:"info,omitempty"`
 
 	// Host is The host (name or ip) serving the API. This MUST be the host only and does not include the scheme nor
 	// sub-paths. It MAY include a port. If the host is not included, the host serving the documentation is to be used
 	// (including the port). The host does not support path templating.
-	Host string
+	Host string `yaml:"host,omitempty"`
 
 	// BasePath is The base path on which the API is served, which is relative to the host. If it is not included, the API is
 	// served directly under the host. The value MUST start with a leading slash (/).
 	// The basePath does not support path templating.
-	BasePath string
+	BasePath string `yaml:"basePath,omitempty"`
 
 	// Schemes represents the transfer protocol of the API. Values MUST be from the list: "http", "https", "ws", "wss".
 	// If the schemes is not included, the default scheme to be used is the one used to access
 	// the Swagger definition itself.
-	Schemes []string
+	Schemes []string `yaml:"schemes,omitempty"`
 
 	// Consumes is a list of MIME types the APIs can consume. This is global to all APIs but can be overridden on
 	// specific API calls. Value MUST be as described under Mime Types.
-	Consumes []string
+	Consumes []string `yaml:"consumes,omitempty"`
 
 	// Produces is a list of MIME types the APIs can produce. This is global to all APIs but can be overridden on
 	// specific API calls. Value MUST be as described under Mime Types.
-	Produces []string
+	Produces []string `yaml:"produces,omitempty"`
 
 	// Paths are the paths and operations for the API. Perhaps the most important part of the specification.
 	//  - https://swagger.io/specification/v2/#pathsObject
-	Paths *Paths
+	Paths *Paths `yaml:"paths,omitempty"`
 
 	// Definitions is an object to hold data types produced and consumed by operations. It's composed of Schema instances
 	//  - https://swagger.io/specification/v2/#definitionsObject
-	Definitions *Definitions
+	Definitions *Definitions `yaml:"definitions,omitempty"`
 
 	// Parameters is an object to hold parameters that can be used across operations.
 	// This property does not define global parameters for all operations.
 	//  - https://swagger.io/specification/v2/#parametersDefinitionsObject
-	Parameters *ParameterDefinitions
+	Parameters *ParameterDefinitions `yaml:"parameters,omitempty"`
 
 	// Responses is an object to hold responses that can be used across operations.
 	// This property does not define global responses for all operations.
 	//  - https://swagger.io/specification/v2/#responsesDefinitionsObject
-	Responses *ResponsesDefinitions
+	Responses *ResponsesDefinitions `yaml:"responses,omitempty"`
 
 	// SecurityDefinitions represents security scheme definitions that can be used across the specification.
 	//  - https://swagger.io/specification/v2/#securityDefinitionsObject
-	SecurityDefinitions *SecurityDefinitions
+	SecurityDefinitions *SecurityDefinitions `yaml:"securityDefinitions,omitempty"`
 
 	// Security is a declaration of which security schemes are applied for the API as a whole. The list of values
 	// describes alternative security schemes that can be used (that is, there is a logical OR between the security
 	// requirements). Individual operations can override this definition.
 	//  - https://swagger.io/specification/v2/#securityRequirementObject
-	Security []*SecurityRequirement
+	Security []*SecurityRequirement `yaml:"security,omitempty"`
 
 	// Tags are A list of tags used by the specification with additional metadata.
 	// The order of the tags can be used to reflect on their order by the parsing tools. Not all tags that are used
 	// by the Operation Object must be declared. The tags that are not declared may be organized randomly or based
 	// on the tools' logic. Each tag name in the list MUST be unique.
 	//  - https://swagger.io/specification/v2/#tagObject
-	Tags []*base.Tag
+	Tags []*base.Tag `yaml:"tags,omitempty"`
 
 	// ExternalDocs is an instance of base.ExternalDoc for.. well, obvious really, innit.
-	ExternalDocs *base.ExternalDoc
+	ExternalDocs *base.ExternalDoc `yaml:"externalDocs,omitempty"`
 
 	// Extensions contains all custom extensions defined for the top-level document.
-	Extensions map[string]any
+	Extensions map[string]any `yaml:",inline"`
 	low        *low.Swagger
+	built      high.NodeValues // the values of the specification when the document was built.
 }
 
 // NewSwaggerDocument will create a new high-level Swagger document from a low-level one.
 func NewSwaggerDocument(document *low.Swagger) *Swagger {
 	d := new(Swagger)
 	d.low = document
+	if document.Index != nil {
+		d.built = high.RecordNodeValues(document.Index.GetRootNode())
+	}
 	d.Extensions = high.ExtractExtensions(document.Extensions)
 	if !document.Info.IsEmpty() {
 		d.Info = base.NewInfo(document.Info.Value)
@@ -169,12 +179,52 @@ func NewSwaggerDocument(document *low.Swagger) *Swagger {
 	return d
 }
 
+// CreateSwaggerDocument creates a new high-level Swagger document from scratch (without a low-level model backing
+// it), for an Info object. Paths are created empty, and every map is initialized, ready to be populated and
+// rendered. Definitions are not created, as empty definitions are rendered, use CreateDefinitions.
+func CreateSwaggerDocument(info *base.Info) *Swagger {
+	return &Swagger{
+		Swagger:    "2.0",
+		Info:       info,
+		Paths:      CreatePaths(),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Swagger instance that was used to create the high-level one.
 func (s *Swagger) GoLow() *low.Swagger {
 	return s.low
 }
 
 // everything is build async, this little gem holds the results.
+// Render will render the high-level Swagger document back into YAML. Any changes made to the high-level model are
+// rendered into the original specification, everything that has not been changed (including comments and the order
+// of keys) is preserved.
+func (s *Swagger) Render() ([]byte, error) {
+	return yaml.Marshal(s.RenderDocument())
+}
+
+// RenderJSON will render the high-level Swagger document into JSON. Any changes made to the high-level model are rendered
+// into the original specification. Comments and the order of keys cannot be kept in JSON.
+func (s *Swagger) RenderJSON() ([]byte, error) {
+	yamlData, err := s.Render()
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertYAMLtoJSON(yamlData)
+}
+
+// RenderDocument will render the high-level Swagger document into a new yaml.Node tree, using the original
+// specification the document was created from (if any). The original specification is not modified. Changes made
+// to the low-level model since the document was created are kept as well.
+func (s *Swagger) RenderDocument() *yaml.Node {
+	var root *yaml.Node
+	if s.low != nil && s.low.Index != nil {
+		root = s.low.Index.GetRootNode()
+	}
+	return high.RenderDocument(s, root, s.built)
+}
+
 type asyncResult[T any] struct {
 	key    string
 	result T
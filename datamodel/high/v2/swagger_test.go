@@ -5,8 +5,10 @@ package v2
 
 import (
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 
 	"io/ioutil"
 	"testing"
@@ -278,3 +280,197 @@ func TestNewSwaggerDocument_Responses(t *testing.T) {
 	assert.Equal(t, 11, wentLower.Schema.KeyNode.Column)
 
 }
+
+func TestSwagger_Render_Unchanged(t *testing.T) {
+	initTest()
+	h := NewSwaggerDocument(doc)
+	original, _ := yaml.Marshal(doc.Index.GetRootNode())
+	rendered, err := h.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, string(original), string(rendered))
+}
+
+func TestSwagger_Render_Modified(t *testing.T) {
+	initTest()
+	h := NewSwaggerDocument(doc)
+	h.Host = "pizza.pb33f.io"
+	h.Info.Title = "Pizza Store"
+	delete(h.Paths.PathItems, "/pet/{petId}/uploadImage")
+
+	rendered, err := h.Render()
+	assert.NoError(t, err)
+	info, _ := datamodel.ExtractSpecInfo(rendered)
+	rebuilt, errs := v2.CreateDocument(info)
+	assert.Empty(t, errs)
+
+	r := NewSwaggerDocument(rebuilt)
+	assert.Equal(t, "pizza.pb33f.io", r.Host)
+	assert.Equal(t, "Pizza Store", r.Info.Title)
+	assert.Nil(t, r.Paths.PathItems["/pet/{petId}/uploadImage"])
+	assert.Len(t, r.Paths.PathItems, len(h.Paths.PathItems))
+	assert.Equal(t, "fresh", r.Paths.Extensions["x-minty"])
+}
+
+func TestSwagger_Render_FromScratch(t *testing.T) {
+	s := &Swagger{
+		Swagger: "2.0",
+		Info:    &base.Info{Title: "Pet Store", Version: "1.0.0"},
+		Host:    "petstore.pb33f.io",
+		Paths: &Paths{
+			PathItems: map[string]*PathItem{
+				"/pets/{petId}": {
+					Get: &Operation{
+						OperationId: "getPet",
+						Parameters:  []*Parameter{{Name: "petId", In: "path", Type: "string", Required: true}},
+						Responses: &Responses{
+							Codes: map[string]*Response{
+								"200": {
+									Description: "a pet",
+									Schema:      base.CreateSchemaProxyRef("#/definitions/Pet"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Definitions: &Definitions{
+			Definitions: map[string]*base.SchemaProxy{
+				"Pet": base.CreateSchemaProxy(&base.Schema{Type: []string{"object"}}),
+			},
+		},
+	}
+
+	rendered, err := s.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, `swagger: "2.0"
+info:
+    title: Pet Store
+    version: 1.0.0
+host: petstore.pb33f.io
+paths:
+    /pets/{petId}:
+        get:
+            operationId: getPet
+            parameters:
+                - name: petId
+                  in: path
+                  type: string
+                  required: true
+            responses:
+                "200":
+                    description: a pet
+                    schema:
+                        $ref: '#/definitions/Pet'
+definitions:
+    Pet:
+        type: object
+`, string(rendered))
+
+	info, _ := datamodel.ExtractSpecInfo(rendered)
+	lowDoc, errs := v2.CreateDocument(info)
+	assert.Empty(t, errs)
+	r := NewSwaggerDocument(lowDoc)
+	assert.Equal(t, "petstore.pb33f.io", r.Host)
+	assert.Equal(t, "object", r.Definitions.Definitions["Pet"].Schema().Type[0])
+
+	json, err := s.RenderJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(json), `"host":"petstore.pb33f.io"`)
+}
+
+func TestSwagger_Render_Constructors(t *testing.T) {
+	s := CreateSwaggerDocument(base.CreateInfo("Pet Store", "1.0.0"))
+	s.Definitions = &Definitions{Definitions: map[string]*base.SchemaProxy{
+		"Pet": base.CreateSchemaProxy(&base.Schema{Type: []string{"object"}}),
+	}}
+
+	op := CreateOperation("getPet")
+	param := CreateParameter("petId", "path")
+	param.Type = "string"
+	op.Parameters = append(op.Parameters, param)
+	ok := CreateResponse("a pet")
+	ok.Schema = base.CreateSchemaProxyRef("#/definitions/Pet")
+	op.Responses.Codes["200"] = ok
+
+	pathItem := CreatePathItem()
+	pathItem.Get = op
+	s.Paths.PathItems["/pets/{petId}"] = pathItem
+
+	// empty maps are not rendered.
+	rendered, err := s.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, `swagger: "2.0"
+info:
+    title: Pet Store
+    version: 1.0.0
+paths:
+    /pets/{petId}:
+        get:
+            operationId: getPet
+            parameters:
+                - name: petId
+                  in: path
+                  type: string
+                  required: true
+            responses:
+                "200":
+                    description: a pet
+                    schema:
+                        $ref: '#/definitions/Pet'
+definitions:
+    Pet:
+        type: object
+`, string(rendered))
+}
+
+func TestSwagger_Render_Constructors_RoundTrip(t *testing.T) {
+	s := CreateSwaggerDocument(base.CreateInfo("Pet Store", "1.0.0"))
+	s.Definitions = CreateDefinitions()
+	s.Definitions.Definitions["Pet"] = base.CreateSchemaProxy(base.CreateSchema("object"))
+
+	limit := CreateParameter("limit", "query")
+	limit.Type = "array"
+	limit.Items = CreateItems("integer")
+	s.Parameters = CreateParameterDefinitions()
+	s.Parameters.Definitions["Limit"] = limit
+
+	notFound := CreateResponse("not found")
+	notFound.Headers["Rate-Limit"] = CreateHeader("integer")
+	notFound.Examples = CreateExample()
+	notFound.Examples.Values["application/json"] = "no pets"
+	s.Responses = CreateResponsesDefinitions()
+	s.Responses.Definitions["NotFound"] = notFound
+
+	oauth := CreateSecurityScheme("oauth2")
+	oauth.Flow = "implicit"
+	oauth.AuthorizationUrl = "https://pb33f.io/auth"
+	oauth.Scopes = CreateScopes()
+	oauth.Scopes.Values["read:pets"] = "read pets"
+	s.SecurityDefinitions = CreateSecurityDefinitions()
+	s.SecurityDefinitions.Definitions["OAuth"] = oauth
+
+	requirement := CreateSecurityRequirement()
+	requirement.Requirements["OAuth"] = []string{"read:pets"}
+	s.Security = append(s.Security, requirement)
+
+	rendered, err := s.Render()
+	assert.NoError(t, err)
+
+	// the rendered document is built back into the same model.
+	info, _ := datamodel.ExtractSpecInfo(rendered)
+	lowDoc, errs := v2.CreateDocument(info)
+	assert.Empty(t, errs)
+	r := NewSwaggerDocument(lowDoc)
+	assert.Equal(t, "object", r.Definitions.Definitions["Pet"].Schema().Type[0])
+	assert.Equal(t, "integer", r.Parameters.Definitions["Limit"].Items.Type)
+	assert.Equal(t, "integer", r.Responses.Definitions["NotFound"].Headers["Rate-Limit"].Type)
+	assert.Equal(t, "no pets", r.Responses.Definitions["NotFound"].Examples.Values["application/json"])
+	assert.Equal(t, "read pets", r.SecurityDefinitions.Definitions["OAuth"].Scopes.Values["read:pets"])
+	assert.Equal(t, []string{"read:pets"}, r.Security[0].Requirements["OAuth"])
+
+	// rendering the built document again produces the same specification.
+	again, err := r.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, string(rendered), string(again))
+}
@@ -13,8 +13,8 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v3"
 // that identifies a URL to use for the callback operation.
 //  - https://spec.openapis.org/oas/v3.1.0#callback-object
 type Callback struct {
-	Expression map[string]*PathItem
-	Extensions map[string]any
+	Expression map[string]*PathItem `yaml:",inline"`
+	Extensions map[string]any       `yaml:",inline"`
 	low        *low.Callback
 }
 
@@ -33,6 +33,14 @@ func NewCallback(lowCallback *low.Callback) *Callback {
 	return n
 }
 
+// CreateCallback creates a new, empty high-level Callback from scratch, with every map initialized.
+func CreateCallback() *Callback {
+	return &Callback{
+		Expression: make(map[string]*PathItem),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Callback instance used to create the high-level one.
 func (c *Callback) GoLow() *low.Callback {
 	return c.low
@@ -21,6 +21,7 @@ const (
 	securitySchemes
 	links
 	callbacks
+	pathItems
 )
 
 // Components represents a high-level OpenAPI 3+ Components Object, that is backed by a low-level one.
@@ -29,16 +30,17 @@ const (
 // will have no effect on the API unless they are explicitly referenced from properties outside the components object.
 //  - https://spec.openapis.org/oas/v3.1.0#components-object
 type Components struct {
-	Schemas         map[string]*highbase.SchemaProxy
-	Responses       map[string]*Response
-	Parameters      map[string]*Parameter
-	Examples        map[string]*highbase.Example
-	RequestBodies   map[string]*RequestBody
-	Headers         map[string]*Header
-	SecuritySchemes map[string]*SecurityScheme
-	Links           map[string]*Link
-	Callbacks       map[string]*Callback
-	Extensions      map[string]any
+	Schemas         map[string]*highbase.SchemaProxy `yaml:"schemas,omitempty"`
+	Responses       map[string]*Response             `yaml:"responses,omitempty"`
+	Parameters      map[string]*Parameter            `yaml:"parameters,omitempty"`
+	Examples        map[string]*highbase.Example     `yaml:"examples,omitempty"`
+	RequestBodies   map[string]*RequestBody          `yaml:"requestBodies,omitempty"`
+	Headers         map[string]*Header               `yaml:"headers,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme       `yaml:"securitySchemes,omitempty"`
+	Links           map[string]*Link                 `yaml:"links,omitempty"`
+	Callbacks       map[string]*Callback             `yaml:"callbacks,omitempty"`
+	PathItems       map[string]*PathItem             `yaml:"pathItems,omitempty"`
+	Extensions      map[string]any                   `yaml:",inline"`
 	low             *low.Components
 }
 
@@ -59,6 +61,7 @@ func NewComponents(comp *low.Components) *Components {
 	requestBodyMap := make(map[string]*RequestBody)
 	headerMap := make(map[string]*Header)
 	securitySchemeMap := make(map[string]*SecurityScheme)
+	pathItemMap := make(map[string]*PathItem)
 	schemas := make(map[string]*highbase.SchemaProxy)
 	schemaChan := make(chan componentResult[*highbase.SchemaProxy])
 	cbChan := make(chan componentResult[*Callback])
@@ -69,6 +72,7 @@ func NewComponents(comp *low.Components) *Components {
 	requestBodyChan := make(chan componentResult[*RequestBody])
 	headerChan := make(chan componentResult[*Header])
 	securitySchemeChan := make(chan componentResult[*SecurityScheme])
+	pathItemChan := make(chan componentResult[*PathItem])
 
 	// build all components asynchronously.
 	for k, v := range comp.Callbacks.Value {
@@ -97,13 +101,17 @@ func NewComponents(comp *low.Components) *Components {
 		go buildComponent[*SecurityScheme, *low.SecurityScheme](securitySchemes, k.Value, v.Value,
 			securitySchemeChan, NewSecurityScheme)
 	}
+	for k, v := range comp.PathItems.Value {
+		go buildComponent[*PathItem, *low.PathItem](pathItems, k.Value, v.Value, pathItemChan, NewPathItem)
+	}
 	for k, v := range comp.Schemas.Value {
 		go buildSchema(k, v, schemaChan)
 	}
 
 	totalComponents := len(comp.Callbacks.Value) + len(comp.Links.Value) + len(comp.Responses.Value) +
 		len(comp.Parameters.Value) + len(comp.Examples.Value) + len(comp.RequestBodies.Value) +
-		len(comp.Headers.Value) + len(comp.SecuritySchemes.Value) + len(comp.Schemas.Value)
+		len(comp.Headers.Value) + len(comp.SecuritySchemes.Value) + len(comp.Schemas.Value) +
+		len(comp.PathItems.Value)
 
 	processedComponents := 0
 	for processedComponents < totalComponents {
@@ -135,6 +143,9 @@ func NewComponents(comp *low.Components) *Components {
 		case ssRes := <-securitySchemeChan:
 			processedComponents++
 			securitySchemeMap[ssRes.key] = ssRes.res
+		case piRes := <-pathItemChan:
+			processedComponents++
+			pathItemMap[piRes.key] = piRes.res
 		}
 	}
 	c.Schemas = schemas
@@ -146,9 +157,27 @@ func NewComponents(comp *low.Components) *Components {
 	c.RequestBodies = requestBodyMap
 	c.Examples = exampleMap
 	c.SecuritySchemes = securitySchemeMap
+	c.PathItems = pathItemMap
 	return c
 }
 
+// CreateComponents creates a new, empty high-level Components object from scratch, with every map initialized.
+func CreateComponents() *Components {
+	return &Components{
+		Schemas:         make(map[string]*highbase.SchemaProxy),
+		Responses:       make(map[string]*Response),
+		Parameters:      make(map[string]*Parameter),
+		Examples:        make(map[string]*highbase.Example),
+		RequestBodies:   make(map[string]*RequestBody),
+		Headers:         make(map[string]*Header),
+		SecuritySchemes: make(map[string]*SecurityScheme),
+		Links:           make(map[string]*Link),
+		Callbacks:       make(map[string]*Callback),
+		PathItems:       make(map[string]*PathItem),
+		Extensions:      make(map[string]any),
+	}
+}
+
 // contains a component build result.
 type componentResult[T any] struct {
 	res  T
@@ -7,6 +7,12 @@
 // High-level models are backed by low-level ones. There is a 'GoLow()' method available on every high level
 // object. 'Going Low' allows engineers to transition from a high-level or 'porcelain' API, to a low-level 'plumbing'
 // API, which provides fine grain detail to the underlying AST powering the data, lines, columns, raw nodes etc.
+//
+// High-level models can also be created from scratch, without any low-level model (or specification) backing them.
+// Every model is a plain struct that can be created using a struct literal, or a Create function (for example
+// CreateDocument or CreateOperation) which initializes every map. Schemas are wrapped using base.CreateSchemaProxy
+// or base.CreateSchemaProxyRef. A Document created from scratch has no 'GoLow()' model and
+// can be rendered into YAML or JSON using Render() or RenderJSON().
 package v3
 
 import (
@@ -14,6 +20,8 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
 )
 
 // Document represents a high-level OpenAPI 3 document (both 3.0 & 3.1). A Document is the root of the specification.
@@ -21,26 +29,26 @@ type Document struct {
 
 	// Version is the version of OpenAPI being used, extracted from the 'openapi: x.x.x' definition.
 	// This is not a standard property of the OpenAPI model, it's a convenience mechanism only.
-	Version string
+	Version string `yaml:"openapi,omitempty"`
 
 	// Info represents a specification Info definitions
 	// Provides metadata about the API. The metadata MAY be used by tooling as required.
 	// - https://spec.openapis.org/oas/v3.1.0#info-object
-	Info *base.Info
+	Info *base.Info `yaml:"info,omitempty"`
 
 	// Servers is a slice of Server instances which provide connectivity information to a target server. If the servers
 	// property is not provided, or is an empty array, the default value would be a Server Object with a url value of /.
 	// - https://spec.openapis.org/oas/v3.1.0#server-object
-	Servers []*Server
+	Servers []*Server `yaml:"servers,omitempty"`
 
 	// Paths contains all the PathItem definitions for the specification.
 	// The available paths and operations for the API, The most important part of ths spec.
 	// - https://spec.openapis.org/oas/v3.1.0#paths-object
-	Paths *Paths
+	Paths *Paths `yaml:"paths,omitempty"`
 
 	// Components is an element to hold various schemas for the document.
 	// - https://spec.openapis.org/oas/v3.1.0#components-object
-	Components *Components
+	Components *Components `yaml:"components,omitempty"`
 
 	// Security contains global security requirements/roles for the specification
 	// A declaration of which security mechanisms can be used across the API. The list of values includes alternative
@@ -48,7 +56,7 @@ type Document struct {
 	// to authorize a request. Individual operations can override this definition. To make security optional,
 	// an empty security requirement ({}) can be included in the array.
 	// - https://spec.openapis.org/oas/v3.1.0#security-requirement-object
-	Security *SecurityRequirement
+	Security *SecurityRequirement `yaml:"security,omitempty"`
 
 	// Tags is a slice of base.Tag instances defined by the specification
 	// A list of tags used by the document with additional metadata. The order of the tags can be used to reflect on
@@ -56,20 +64,20 @@ type Document struct {
 	// The tags that are not declared MAY be organized randomly or based on the tools’ logic.
 	// Each tag name in the list MUST be unique.
 	// - https://spec.openapis.org/oas/v3.1.0#tag-object
-	Tags []*base.Tag
+	Tags []*base.Tag `yaml:"tags,omitempty"`
 
 	// ExternalDocs is an instance of base.ExternalDoc for.. well, obvious really, innit.
 	// - https://spec.openapis.org/oas/v3.1.0#external-documentation-object
-	ExternalDocs *base.ExternalDoc
+	ExternalDocs *base.ExternalDoc `yaml:"externalDocs,omitempty"`
 
 	// Extensions contains all custom extensions defined for the top-level document.
-	Extensions map[string]any
+	Extensions map[string]any `yaml:",inline"`
 
 	// JsonSchemaDialect is a 3.1+ property that sets the dialect to use for validating *base.Schema definitions
 	// The default value for the $schema keyword within Schema Objects contained within this OAS document.
 	// This MUST be in the form of a URI.
 	// - https://spec.openapis.org/oas/v3.1.0#schema-object
-	JsonSchemaDialect string
+	JsonSchemaDialect string `yaml:"jsonSchemaDialect,omitempty"`
 
 	// Webhooks is a 3.1+ property that is similar to callbacks, except, this defines incoming webhooks.
 	// The incoming webhooks that MAY be received as part of this API and that the API consumer MAY choose to implement.
@@ -77,15 +85,16 @@ type Document struct {
 	// for example by an out-of-band registration. The key name is a unique string to refer to each webhook,
 	// while the (optionally referenced) Path Item Object describes a request that may be initiated by the API provider
 	// and the expected responses. An example is available.
-	Webhooks map[string]*PathItem
+	Webhooks map[string]*PathItem `yaml:"webhooks,omitempty"`
 
 	// Index is a reference to the *index.SpecIndex that was created for the document and used
 	// as a guide when building out the Document. Ideal if further processing is required on the model and
 	// the original details are required to continue the work.
 	//
 	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
-	Index *index.SpecIndex
+	Index *index.SpecIndex `yaml:"-"`
 	low   *low.Document
+	built high.NodeValues // the values of the specification when the Document was built.
 }
 
 // NewDocument will create a new high-level Document from a low-level one.
@@ -93,6 +102,9 @@ func NewDocument(document *low.Document) *Document {
 	d := new(Document)
 	d.low = document
 	d.Index = document.Index
+	if document.Index != nil {
+		d.built = high.RecordNodeValues(document.Index.GetRootNode())
+	}
 	if !document.Info.IsEmpty() {
 		d.Info = base.NewInfo(document.Info.Value)
 	}
@@ -118,6 +130,9 @@ func NewDocument(document *low.Document) *Document {
 	if !document.Components.IsEmpty() {
 		d.Components = NewComponents(document.Components.Value)
 	}
+	if !document.Security.IsEmpty() {
+		d.Security = NewSecurityRequirement(document.Security.Value)
+	}
 	if !document.Paths.IsEmpty() {
 		d.Paths = NewPaths(document.Paths.Value)
 	}
@@ -134,7 +149,48 @@ func NewDocument(document *low.Document) *Document {
 	return d
 }
 
+// CreateDocument creates a new high-level Document from scratch (without a low-level model backing it), for an
+// OpenAPI version (for example '3.1.0') and Info. Paths are created empty, and every map is initialized, ready to
+// be populated and rendered. Components are not created, as empty Components are rendered, use CreateComponents.
+func CreateDocument(version string, info *base.Info) *Document {
+	return &Document{
+		Version:    version,
+		Info:       info,
+		Paths:      CreatePaths(),
+		Webhooks:   make(map[string]*PathItem),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Document that was used to create the high level one.
 func (d *Document) GoLow() *low.Document {
 	return d.low
 }
+
+// Render will render the high-level Document back into YAML. Any changes made to the high-level model are rendered
+// into the original specification, everything that has not been changed (including comments and the order of keys)
+// is preserved.
+func (d *Document) Render() ([]byte, error) {
+	return yaml.Marshal(d.RenderDocument())
+}
+
+// RenderJSON will render the high-level Document into JSON. Any changes made to the high-level model are rendered
+// into the original specification. Comments and the order of keys cannot be kept in JSON.
+func (d *Document) RenderJSON() ([]byte, error) {
+	yamlData, err := d.Render()
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertYAMLtoJSON(yamlData)
+}
+
+// RenderDocument will render the high-level Document into a new yaml.Node tree, using the original specification
+// the Document was created from (if any). The original specification is not modified. Changes made to the low-level
+// model since the Document was created are kept as well.
+func (d *Document) RenderDocument() *yaml.Node {
+	var root *yaml.Node
+	if d.low != nil && d.low.Index != nil {
+		root = d.low.Index.GetRootNode()
+	}
+	return high.RenderDocument(d, root, d.built)
+}
@@ -6,8 +6,10 @@ package v3
 import (
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 	"io/ioutil"
 	"testing"
 )
@@ -136,6 +138,32 @@ func TestNewDocument_Webhooks(t *testing.T) {
 	assert.Equal(t, "Information about a new burger", h.Webhooks["someHook"].Post.RequestBody.Description)
 }
 
+func TestNewDocument_Components_PathItems(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    $ref: '#/components/pathItems/Pets'
+webhooks:
+  newPet:
+    $ref: '#/components/pathItems/Pets'
+components:
+  pathItems:
+    Pets:
+      description: all the pets
+      post:
+        description: create a pet`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lDoc, err := lowv3.CreateDocument(info)
+	assert.Len(t, err, 0)
+
+	h := NewDocument(lDoc)
+	assert.Len(t, h.Components.PathItems, 1)
+	assert.Equal(t, "all the pets", h.Components.PathItems["Pets"].Description)
+	assert.Equal(t, "create a pet", h.Paths.PathItems["/pets"].Post.Description)
+	assert.Equal(t, "create a pet", h.Webhooks["newPet"].Post.Description)
+}
+
 func TestNewDocument_Components_Links(t *testing.T) {
 	initTest()
 	h := NewDocument(lowDoc)
@@ -394,3 +422,322 @@ func TestCircularReferencesDoc(t *testing.T) {
 	assert.Len(t, d.Components.Schemas, 9)
 	assert.Len(t, d.Index.GetCircularReferences(), 3)
 }
+
+func renderAndRebuild(t *testing.T, d *Document) *Document {
+	rendered, err := d.Render()
+	assert.NoError(t, err)
+	info, _ := datamodel.ExtractSpecInfo(rendered)
+	rebuilt, errs := lowv3.CreateDocument(info)
+	assert.Empty(t, errs)
+	return NewDocument(rebuilt)
+}
+
+func TestDocument_Render_Unchanged(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+	original, _ := yaml.Marshal(lowDoc.Index.GetRootNode())
+	rendered, err := h.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, string(original), string(rendered))
+}
+
+func TestDocument_Render_Modified(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+	h.Info.Title = "Pizza Shop"
+	h.Paths.PathItems["/pizza"] = &PathItem{Description: "all the pizza"}
+	delete(h.Paths.PathItems, "/dressings")
+
+	r := renderAndRebuild(t, h)
+	assert.Equal(t, "Pizza Shop", r.Info.Title)
+	assert.Equal(t, "all the pizza", r.Paths.PathItems["/pizza"].Description)
+	assert.Nil(t, r.Paths.PathItems["/dressings"])
+	assert.Len(t, r.Paths.PathItems, len(h.Paths.PathItems))
+	assert.Equal(t, "meaty", r.Paths.PathItems["/burgers"].Extensions["x-burger-meta"])
+	assert.Equal(t, "milky", r.Paths.Extensions["x-milky-milk"])
+}
+
+func TestDocument_Render_ModifiedSchema(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+	burger := h.Components.Schemas["Burger"].Schema()
+	burger.Description = "a sandwich"
+	burger.Required = append(burger.Required, "fries")
+
+	r := renderAndRebuild(t, h)
+	burger = r.Components.Schemas["Burger"].Schema()
+	assert.Equal(t, "a sandwich", burger.Description)
+	assert.Equal(t, []string{"name", "numPatties", "fries"}, burger.Required)
+	assert.Equal(t, "string", burger.Properties["name"].Schema().Type[0])
+}
+
+func TestDocument_Render_PreserveComments(t *testing.T) {
+	yml := `# the best api
+openapi: 3.1.0
+info:
+  title: pizza # the title
+  version: 1.0.0
+paths:
+  # pizza goes here
+  /pizza:
+    get:
+      description: get pizza
+`
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, _ := lowv3.CreateDocument(info)
+	h := NewDocument(lowDoc)
+	h.Info.Title = "burgers"
+	h.Paths.PathItems["/pizza"].Get.Description = "get all the pizza"
+
+	rendered, err := h.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, `# the best api
+openapi: 3.1.0
+info:
+    title: burgers # the title
+    version: 1.0.0
+paths:
+    # pizza goes here
+    /pizza:
+        get:
+            description: get all the pizza
+`, string(rendered))
+}
+
+func TestDocument_Render_FromScratch(t *testing.T) {
+	burger := &base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"name"},
+		Properties: map[string]*base.SchemaProxy{
+			"name":       base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"numPatties": base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}}),
+		},
+	}
+	d := &Document{
+		Version: "3.1.0",
+		Info:    &base.Info{Title: "Burger Shop", Version: "1.0.0"},
+		Servers: []*Server{{URL: "https://api.pb33f.io"}},
+		Paths: &Paths{
+			PathItems: map[string]*PathItem{
+				"/burgers": {
+					Get: &Operation{
+						OperationId: "listBurgers",
+						Responses: &Responses{
+							Codes: map[string]*Response{
+								"200": {
+									Description: "all the burgers",
+									Content: map[string]*MediaType{
+										"application/json": {
+											Schema: base.CreateSchemaProxyRef("#/components/schemas/Burger"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Components: &Components{
+			Schemas: map[string]*base.SchemaProxy{"Burger": base.CreateSchemaProxy(burger)},
+		},
+		Extensions: map[string]any{"x-burger": "meaty"},
+	}
+
+	rendered, err := d.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: Burger Shop
+    version: 1.0.0
+servers:
+    - url: https://api.pb33f.io
+paths:
+    /burgers:
+        get:
+            operationId: listBurgers
+            responses:
+                "200":
+                    description: all the burgers
+                    content:
+                        application/json:
+                            schema:
+                                $ref: '#/components/schemas/Burger'
+components:
+    schemas:
+        Burger:
+            type: object
+            properties:
+                name:
+                    type: string
+                numPatties:
+                    type: integer
+            required:
+                - name
+x-burger: meaty
+`, string(rendered))
+
+	// the rendered document is a valid document.
+	info, _ := datamodel.ExtractSpecInfo(rendered)
+	lowDoc, errs := lowv3.CreateDocument(info)
+	assert.Empty(t, errs)
+	r := NewDocument(lowDoc)
+	assert.Equal(t, "Burger Shop", r.Info.Title)
+	op := r.Paths.PathItems["/burgers"].Get
+	assert.Equal(t, "listBurgers", op.OperationId)
+	schema := op.Responses.Codes["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/Burger", schema.GetReference())
+	assert.Equal(t, "integer", schema.Schema().Properties["numPatties"].Schema().Type[0])
+
+	json, err := d.RenderJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(json), `"openapi":"3.1.0"`)
+	assert.Contains(t, string(json), `"$ref":"#/components/schemas/Burger"`)
+}
+
+func TestDocument_Render_Constructors(t *testing.T) {
+	d := CreateDocument("3.1.0", base.CreateInfo("Burger Shop", "1.0.0"))
+	d.Components = CreateComponents()
+	d.Components.Schemas["Burger"] = base.CreateSchemaProxy(&base.Schema{Type: []string{"object"}})
+
+	op := CreateOperation("getBurger")
+	param := CreateParameter("burgerId", "path")
+	param.Schema = base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})
+	op.Parameters = append(op.Parameters, param)
+	ok := CreateResponse("a burger")
+	ok.Content["application/json"] = CreateMediaType(base.CreateSchemaProxyRef("#/components/schemas/Burger"))
+	op.Responses.Codes["200"] = ok
+
+	pathItem := CreatePathItem()
+	pathItem.Get = op
+	d.Paths.PathItems["/burgers/{burgerId}"] = pathItem
+
+	// empty maps are not rendered.
+	rendered, err := d.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: Burger Shop
+    version: 1.0.0
+paths:
+    /burgers/{burgerId}:
+        get:
+            operationId: getBurger
+            parameters:
+                - name: burgerId
+                  in: path
+                  required: true
+                  schema:
+                    type: string
+            responses:
+                "200":
+                    description: a burger
+                    content:
+                        application/json:
+                            schema:
+                                $ref: '#/components/schemas/Burger'
+components:
+    schemas:
+        Burger:
+            type: object
+`, string(rendered))
+
+	// an empty document still has the required paths.
+	rendered, _ = CreateDocument("3.1.0", base.CreateInfo("Burger Shop", "1.0.0")).Render()
+	assert.Contains(t, string(rendered), "paths: {}")
+}
+
+func TestDocument_Render_Constructors_RoundTrip(t *testing.T) {
+	d := CreateDocument("3.1.0", base.CreateInfo("Burger Shop", "1.0.0"))
+	d.Info.Contact = base.CreateContact("pb33f", "https://pb33f.io", "buckaroo@pb33f.io")
+	d.Info.License = base.CreateLicense("MIT")
+	d.ExternalDocs = base.CreateExternalDoc("https://pb33f.io/docs")
+	tag := base.CreateTag("burgers")
+	tag.ExternalDocs = base.CreateExternalDoc("https://pb33f.io/burgers")
+	d.Tags = append(d.Tags, tag)
+
+	server := CreateServer("https://{region}.pb33f.io")
+	region := CreateServerVariable("eu")
+	region.Enum = []string{"eu", "us"}
+	server.Variables["region"] = region
+	d.Servers = append(d.Servers, server)
+
+	burger := base.CreateSchema("object")
+	burger.Properties["name"] = base.CreateSchemaProxy(base.CreateSchema("string"))
+	burger.XML = base.CreateXML()
+	burger.XML.Name = "burger"
+	burger.Discriminator = base.CreateDiscriminator("name")
+	burger.Discriminator.Mapping["big"] = "#/components/schemas/Burger"
+
+	d.Components = CreateComponents()
+	d.Components.Schemas["Burger"] = base.CreateSchemaProxy(burger)
+	d.Components.Examples["Big"] = base.CreateExample("big mac")
+
+	body := CreateRequestBody()
+	body.Required = true
+	burgerType := CreateMediaType(base.CreateSchemaProxyRef("#/components/schemas/Burger"))
+	encoding := CreateEncoding()
+	encoding.ContentType = "text/plain"
+	encoding.Headers["Burger"] = CreateHeader()
+	encoding.Headers["Burger"].Description = "the burger"
+	burgerType.Encoding["name"] = encoding
+	body.Content["multipart/form-data"] = burgerType
+	d.Components.RequestBodies["Burger"] = body
+
+	header := CreateHeader()
+	header.Schema = base.CreateSchemaProxy(base.CreateSchema("integer"))
+	d.Components.Headers["Patties"] = header
+
+	link := CreateLink()
+	link.OperationId = "getBurger"
+	link.Parameters["burgerId"] = "$response.body#/id"
+	d.Components.Links["Burger"] = link
+
+	callback := CreateCallback()
+	callback.Expression["{$request.body#/callback}"] = CreatePathItem()
+	callback.Expression["{$request.body#/callback}"].Post = CreateOperation("burgerReady")
+	callback.Expression["{$request.body#/callback}"].Post.Responses.Codes["200"] = CreateResponse("ok")
+	d.Components.Callbacks["Ready"] = callback
+
+	oauth := CreateSecurityScheme("oauth2")
+	oauth.Flows = CreateOAuthFlows()
+	oauth.Flows.Implicit = CreateOAuthFlow()
+	oauth.Flows.Implicit.AuthorizationUrl = "https://pb33f.io/auth"
+	oauth.Flows.Implicit.Scopes["eat:burgers"] = "eat burgers"
+	d.Components.SecuritySchemes["OAuth"] = oauth
+
+	rendered, err := d.Render()
+	assert.NoError(t, err)
+
+	// the rendered document is built back into the same model.
+	info, _ := datamodel.ExtractSpecInfo(rendered)
+	lowDoc, errs := lowv3.CreateDocument(info)
+	assert.Empty(t, errs)
+	r := NewDocument(lowDoc)
+	assert.Equal(t, "buckaroo@pb33f.io", r.Info.Contact.Email)
+	assert.Equal(t, "MIT", r.Info.License.Name)
+	assert.Equal(t, "https://pb33f.io/docs", r.ExternalDocs.URL)
+	assert.Equal(t, "https://pb33f.io/burgers", r.Tags[0].ExternalDocs.URL)
+	assert.Equal(t, []string{"eu", "us"}, r.Servers[0].Variables["region"].Enum)
+
+	s := r.Components.Schemas["Burger"].Schema()
+	assert.Equal(t, "string", s.Properties["name"].Schema().Type[0])
+	assert.Equal(t, "burger", s.XML.Name)
+	assert.Equal(t, "#/components/schemas/Burger", s.Discriminator.Mapping["big"])
+	assert.Equal(t, "big mac", r.Components.Examples["Big"].Value)
+
+	rb := r.Components.RequestBodies["Burger"]
+	assert.True(t, rb.Required)
+	assert.Equal(t, "text/plain", rb.Content["multipart/form-data"].Encoding["name"].ContentType)
+	assert.Equal(t, "the burger", rb.Content["multipart/form-data"].Encoding["name"].Headers["Burger"].Description)
+	assert.Equal(t, "integer", r.Components.Headers["Patties"].Schema.Schema().Type[0])
+	assert.Equal(t, "$response.body#/id", r.Components.Links["Burger"].Parameters["burgerId"])
+	assert.Equal(t, "burgerReady",
+		r.Components.Callbacks["Ready"].Expression["{$request.body#/callback}"].Post.OperationId)
+	assert.Equal(t, "eat burgers", r.Components.SecuritySchemes["OAuth"].Flows.Implicit.Scopes["eat:burgers"])
+
+	// rendering the built document again produces the same specification.
+	again, err := r.Render()
+	assert.NoError(t, err)
+	assert.Equal(t, string(rendered), string(again))
+}
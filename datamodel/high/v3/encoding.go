@@ -11,11 +11,11 @@ import (
 // Encoding represents an OpenAPI 3+ Encoding object
 //  - https://spec.openapis.org/oas/v3.1.0#encoding-object
 type Encoding struct {
-	ContentType   string
-	Headers       map[string]*Header
-	Style         string
-	Explode       bool
-	AllowReserved bool
+	ContentType   string             `yaml:"contentType,omitempty"`
+	Headers       map[string]*Header `yaml:"headers,omitempty"`
+	Style         string             `yaml:"style,omitempty"`
+	Explode       bool               `yaml:"explode,omitempty"`
+	AllowReserved bool               `yaml:"allowReserved,omitempty"`
 	low           *low.Encoding
 }
 
@@ -31,6 +31,11 @@ func NewEncoding(encoding *low.Encoding) *Encoding {
 	return e
 }
 
+// CreateEncoding creates a new, empty high-level Encoding from scratch, with every map initialized.
+func CreateEncoding() *Encoding {
+	return &Encoding{Headers: make(map[string]*Header)}
+}
+
 // GoLow returns the low-level Encoding instance used to create the high-level one.
 func (e *Encoding) GoLow() *low.Encoding {
 	return e.low
@@ -13,18 +13,18 @@ import (
 // Header represents a high-level OpenAPI 3+ Header object that is backed by a low-level one.
 //  - https://spec.openapis.org/oas/v3.1.0#header-object
 type Header struct {
-	Description     string
-	Required        bool
-	Deprecated      bool
-	AllowEmptyValue bool
-	Style           string
-	Explode         bool
-	AllowReserved   bool
-	Schema          *highbase.SchemaProxy
-	Example         any
-	Examples        map[string]*highbase.Example
-	Content         map[string]*MediaType
-	Extensions      map[string]any
+	Description     string                       `yaml:"description,omitempty"`
+	Required        bool                         `yaml:"required,omitempty"`
+	Deprecated      bool                         `yaml:"deprecated,omitempty"`
+	AllowEmptyValue bool                         `yaml:"allowEmptyValue,omitempty"`
+	Style           string                       `yaml:"style,omitempty"`
+	Explode         bool                         `yaml:"explode,omitempty"`
+	AllowReserved   bool                         `yaml:"allowReserved,omitempty"`
+	Schema          *highbase.SchemaProxy        `yaml:"schema,omitempty"`
+	Example         any                          `yaml:"example,omitempty"`
+	Examples        map[string]*highbase.Example `yaml:"examples,omitempty"`
+	Content         map[string]*MediaType        `yaml:"content,omitempty"`
+	Extensions      map[string]any               `yaml:",inline"`
 	low             *low.Header
 }
 
@@ -52,6 +52,15 @@ func NewHeader(header *low.Header) *Header {
 	return h
 }
 
+// CreateHeader creates a new, empty high-level Header from scratch, with every map initialized.
+func CreateHeader() *Header {
+	return &Header{
+		Examples:   make(map[string]*highbase.Example),
+		Content:    make(map[string]*MediaType),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Header instance used to create the high-level one.
 func (h *Header) GoLow() *low.Header {
 	return h.low
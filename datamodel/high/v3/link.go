@@ -21,13 +21,13 @@ import (
 // in an operation and using them as parameters while invoking the linked operation.
 //  - https://spec.openapis.org/oas/v3.1.0#link-object
 type Link struct {
-	OperationRef string
-	OperationId  string
-	Parameters   map[string]string
-	RequestBody  string
-	Description  string
-	Server       *Server
-	Extensions   map[string]any
+	OperationRef string            `yaml:"operationRef,omitempty"`
+	OperationId  string            `yaml:"operationId,omitempty"`
+	Parameters   map[string]string `yaml:"parameters,omitempty"`
+	RequestBody  string            `yaml:"requestBody,omitempty"`
+	Description  string            `yaml:"description,omitempty"`
+	Server       *Server           `yaml:"server,omitempty"`
+	Extensions   map[string]any    `yaml:",inline"`
 	low          *low.Link
 }
 
@@ -51,6 +51,14 @@ func NewLink(link *low.Link) *Link {
 	return l
 }
 
+// CreateLink creates a new, empty high-level Link from scratch, with every map initialized.
+func CreateLink() *Link {
+	return &Link{
+		Parameters: make(map[string]string),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow will return the low-level Link instance used to create the high-level one.
 func (l *Link) GoLow() *low.Link {
 	return l.low
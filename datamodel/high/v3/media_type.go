@@ -16,11 +16,11 @@ import (
 // Each Media Type Object provides schema and examples for the media type identified by its key.
 //  - https://spec.openapis.org/oas/v3.1.0#media-type-object
 type MediaType struct {
-	Schema     *base.SchemaProxy
-	Example    any
-	Examples   map[string]*base.Example
-	Encoding   map[string]*Encoding
-	Extensions map[string]any
+	Schema     *base.SchemaProxy        `yaml:"schema,omitempty"`
+	Example    any                      `yaml:"example,omitempty"`
+	Examples   map[string]*base.Example `yaml:"examples,omitempty"`
+	Encoding   map[string]*Encoding     `yaml:"encoding,omitempty"`
+	Extensions map[string]any           `yaml:",inline"`
 	low        *low.MediaType
 }
 
@@ -31,13 +31,24 @@ func NewMediaType(mediaType *low.MediaType) *MediaType {
 	if !mediaType.Schema.IsEmpty() {
 		m.Schema = base.NewSchemaProxy(&mediaType.Schema)
 	}
-	m.Example = mediaType.Example
+	m.Example = mediaType.Example.Value
 	m.Examples = base.ExtractExamples(mediaType.Examples.Value)
 	m.Extensions = high.ExtractExtensions(mediaType.Extensions)
 	m.Encoding = ExtractEncoding(mediaType.Encoding.Value)
 	return m
 }
 
+// CreateMediaType creates a new high-level MediaType from scratch, for a Schema (which can be nil). Every map is
+// initialized.
+func CreateMediaType(schema *base.SchemaProxy) *MediaType {
+	return &MediaType{
+		Schema:     schema,
+		Examples:   make(map[string]*base.Example),
+		Encoding:   make(map[string]*Encoding),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow will return the low-level instance of MediaType used to create the high-level one.
 func (m *MediaType) GoLow() *low.MediaType {
 	return m.low
@@ -11,11 +11,11 @@ import (
 // OAuthFlow represents a high-level OpenAPI 3+ OAuthFlow object that is backed by a low-level one.
 //  - https://spec.openapis.org/oas/v3.1.0#oauth-flow-object
 type OAuthFlow struct {
-	AuthorizationUrl string
-	TokenUrl         string
-	RefreshUrl       string
-	Scopes           map[string]string
-	Extensions       map[string]any
+	AuthorizationUrl string            `yaml:"authorizationUrl,omitempty"`
+	TokenUrl         string            `yaml:"tokenUrl,omitempty"`
+	RefreshUrl       string            `yaml:"refreshUrl,omitempty"`
+	Scopes           map[string]string `yaml:"scopes,omitempty"`
+	Extensions       map[string]any    `yaml:",inline"`
 	low              *low.OAuthFlow
 }
 
@@ -35,6 +35,14 @@ func NewOAuthFlow(flow *low.OAuthFlow) *OAuthFlow {
 	return o
 }
 
+// CreateOAuthFlow creates a new, empty high-level OAuthFlow from scratch, with every map initialized.
+func CreateOAuthFlow() *OAuthFlow {
+	return &OAuthFlow{
+		Scopes:     make(map[string]string),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level OAuthFlow instance used to create the high-level one.
 func (o *OAuthFlow) GoLow() *low.OAuthFlow {
 	return o.low
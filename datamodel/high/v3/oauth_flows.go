@@ -11,11 +11,11 @@ import (
 // OAuthFlows represents a high-level OpenAPI 3+ OAuthFlows object that is backed by a low-level one.
 //  - https://spec.openapis.org/oas/v3.1.0#oauth-flows-object
 type OAuthFlows struct {
-	Implicit          *OAuthFlow
-	Password          *OAuthFlow
-	ClientCredentials *OAuthFlow
-	AuthorizationCode *OAuthFlow
-	Extensions        map[string]any
+	Implicit          *OAuthFlow     `yaml:"implicit,omitempty"`
+	Password          *OAuthFlow     `yaml:"password,omitempty"`
+	ClientCredentials *OAuthFlow     `yaml:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow     `yaml:"authorizationCode,omitempty"`
+	Extensions        map[string]any `yaml:",inline"`
 	low               *low.OAuthFlows
 }
 
@@ -42,6 +42,11 @@ func NewOAuthFlows(flows *low.OAuthFlows) *OAuthFlows {
 	return o
 }
 
+// CreateOAuthFlows creates a new, empty high-level OAuthFlows object from scratch, with every map initialized.
+func CreateOAuthFlows() *OAuthFlows {
+	return &OAuthFlows{Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low-level OAuthFlows instance used to create the high-level one.
 func (o *OAuthFlows) GoLow() *low.OAuthFlows {
 	return o.low
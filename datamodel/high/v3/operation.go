@@ -4,6 +4,7 @@
 package v3
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -14,19 +15,19 @@ import (
 // happens here. The entire being for existence of this library and the specification, is this Operation.
 //  - https://spec.openapis.org/oas/v3.1.0#operation-object
 type Operation struct {
-	Tags         []string
-	Summary      string
-	Description  string
-	ExternalDocs *base.ExternalDoc
-	OperationId  string
-	Parameters   []*Parameter
-	RequestBody  *RequestBody
-	Responses    *Responses
-	Callbacks    map[string]*Callback
-	Deprecated   bool
-	Security     *SecurityRequirement
-	Servers      []*Server
-	Extensions   map[string]any
+	Tags         []string             `yaml:"tags,omitempty"`
+	Summary      string               `yaml:"summary,omitempty"`
+	Description  string               `yaml:"description,omitempty"`
+	ExternalDocs *base.ExternalDoc    `yaml:"externalDocs,omitempty"`
+	OperationId  string               `yaml:"operationId,omitempty"`
+	Parameters   []*Parameter         `yaml:"parameters,omitempty"`
+	RequestBody  *RequestBody         `yaml:"requestBody,omitempty"`
+	Responses    *Responses           `yaml:"responses,omitempty"`
+	Callbacks    map[string]*Callback `yaml:"callbacks,omitempty"`
+	Deprecated   bool                 `yaml:"deprecated,omitempty"`
+	Security     *SecurityRequirement `yaml:"security,omitempty"`
+	Servers      []*Server            `yaml:"servers,omitempty"`
+	Extensions   map[string]any       `yaml:",inline"`
 	low          *low.Operation
 }
 
@@ -60,9 +61,18 @@ func NewOperation(operation *low.Operation) *Operation {
 	if !operation.Responses.IsEmpty() {
 		o.Responses = NewResponses(operation.Responses.Value)
 	}
+	if !operation.Callbacks.IsEmpty() {
+		callbacks := make(map[string]*Callback)
+		for k, v := range operation.Callbacks.Value {
+			callbacks[k.Value] = NewCallback(v.Value)
+		}
+		o.Callbacks = callbacks
+	}
+	o.Deprecated = operation.Deprecated.Value
 	if !operation.Security.IsEmpty() {
 		o.Security = NewSecurityRequirement(operation.Security.Value)
 	}
+	o.Extensions = high.ExtractExtensions(operation.Extensions)
 	var servers []*Server
 	for i := range operation.Servers.Value {
 		servers = append(servers, NewServer(operation.Servers.Value[i].Value))
@@ -71,6 +81,17 @@ func NewOperation(operation *low.Operation) *Operation {
 	return o
 }
 
+// CreateOperation creates a new high-level Operation from scratch, with an operationId. The required Responses are
+// created empty, and every map is initialized.
+func CreateOperation(operationId string) *Operation {
+	return &Operation{
+		OperationId: operationId,
+		Responses:   CreateResponses(),
+		Callbacks:   make(map[string]*Callback),
+		Extensions:  make(map[string]any),
+	}
+}
+
 // GoLow will return the low-level Operation instance that was used to create the high-level one.
 func (o *Operation) GoLow() *low.Operation {
 	return o.low
@@ -14,20 +14,20 @@ import (
 // A unique parameter is defined by a combination of a name and location.
 //  - https://spec.openapis.org/oas/v3.1.0#parameter-object
 type Parameter struct {
-	Name            string
-	In              string
-	Description     string
-	Required        bool
-	Deprecated      bool
-	AllowEmptyValue bool
-	Style           string
-	Explode         bool
-	AllowReserved   bool
-	Schema          *base.SchemaProxy
-	Example         any
-	Examples        map[string]*base.Example
-	Content         map[string]*MediaType
-	Extensions      map[string]any
+	Name            string                   `yaml:"name,omitempty"`
+	In              string                   `yaml:"in,omitempty"`
+	Description     string                   `yaml:"description,omitempty"`
+	Required        bool                     `yaml:"required,omitempty"`
+	Deprecated      bool                     `yaml:"deprecated,omitempty"`
+	AllowEmptyValue bool                     `yaml:"allowEmptyValue,omitempty"`
+	Style           string                   `yaml:"style,omitempty"`
+	Explode         bool                     `yaml:"explode,omitempty"`
+	AllowReserved   bool                     `yaml:"allowReserved,omitempty"`
+	Schema          *base.SchemaProxy        `yaml:"schema,omitempty"`
+	Example         any                      `yaml:"example,omitempty"`
+	Examples        map[string]*base.Example `yaml:"examples,omitempty"`
+	Content         map[string]*MediaType    `yaml:"content,omitempty"`
+	Extensions      map[string]any           `yaml:",inline"`
 	low             *low.Parameter
 }
 
@@ -54,6 +54,19 @@ func NewParameter(param *low.Parameter) *Parameter {
 	return p
 }
 
+// CreateParameter creates a new high-level Parameter from scratch, with a name and a location ('query', 'header',
+// 'path' or 'cookie'). A path parameter is always required, every map is initialized.
+func CreateParameter(name, in string) *Parameter {
+	return &Parameter{
+		Name:       name,
+		In:         in,
+		Required:   in == "path",
+		Examples:   make(map[string]*base.Example),
+		Content:    make(map[string]*MediaType),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Parameter used to create the high-level one.
 func (p *Parameter) GoLow() *low.Parameter {
 	return p.low
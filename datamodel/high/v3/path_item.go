@@ -26,19 +26,19 @@ const (
 // are available.
 //  - https://spec.openapis.org/oas/v3.1.0#path-item-object
 type PathItem struct {
-	Description string
-	Summary     string
-	Get         *Operation
-	Put         *Operation
-	Post        *Operation
-	Delete      *Operation
-	Options     *Operation
-	Head        *Operation
-	Patch       *Operation
-	Trace       *Operation
-	Servers     []*Server
-	Parameters  []*Parameter
-	Extensions  map[string]any
+	Description string         `yaml:"description,omitempty"`
+	Summary     string         `yaml:"summary,omitempty"`
+	Get         *Operation     `yaml:"get,omitempty"`
+	Put         *Operation     `yaml:"put,omitempty"`
+	Post        *Operation     `yaml:"post,omitempty"`
+	Delete      *Operation     `yaml:"delete,omitempty"`
+	Options     *Operation     `yaml:"options,omitempty"`
+	Head        *Operation     `yaml:"head,omitempty"`
+	Patch       *Operation     `yaml:"patch,omitempty"`
+	Trace       *Operation     `yaml:"trace,omitempty"`
+	Servers     []*Server      `yaml:"servers,omitempty"`
+	Parameters  []*Parameter   `yaml:"parameters,omitempty"`
+	Extensions  map[string]any `yaml:",inline"`
 	low         *low.PathItem
 }
 
@@ -118,6 +118,11 @@ func NewPathItem(pathItem *low.PathItem) *PathItem {
 	return pi
 }
 
+// CreatePathItem creates a new, empty high-level PathItem from scratch, with every map initialized.
+func CreatePathItem() *PathItem {
+	return &PathItem{Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low level instance of PathItem, used to build the high-level one.
 func (p *PathItem) GoLow() *low.PathItem {
 	return p.low
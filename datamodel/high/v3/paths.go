@@ -15,8 +15,8 @@ import (
 // constraints.
 //  - https://spec.openapis.org/oas/v3.1.0#paths-object
 type Paths struct {
-	PathItems  map[string]*PathItem
-	Extensions map[string]any
+	PathItems  map[string]*PathItem `yaml:",inline"`
+	Extensions map[string]any       `yaml:",inline"`
 	low        *low.Paths
 }
 
@@ -51,6 +51,14 @@ func NewPaths(paths *low.Paths) *Paths {
 	return p
 }
 
+// CreatePaths creates a new, empty high-level Paths object from scratch, with every map initialized.
+func CreatePaths() *Paths {
+	return &Paths{
+		PathItems:  make(map[string]*PathItem),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Paths instance used to create the high-level one.
 func (p *Paths) GoLow() *low.Paths {
 	return p.low
@@ -11,10 +11,10 @@ import (
 // RequestBody represents a high-level OpenAPI 3+ RequestBody object, backed by a low-level one.
 //  - https://spec.openapis.org/oas/v3.1.0#request-body-object
 type RequestBody struct {
-	Description string
-	Content     map[string]*MediaType
-	Required    bool
-	Extensions  map[string]any
+	Description string                `yaml:"description,omitempty"`
+	Content     map[string]*MediaType `yaml:"content,omitempty"`
+	Required    bool                  `yaml:"required,omitempty"`
+	Extensions  map[string]any        `yaml:",inline"`
 	low         *low.RequestBody
 }
 
@@ -29,6 +29,14 @@ func NewRequestBody(rb *low.RequestBody) *RequestBody {
 	return r
 }
 
+// CreateRequestBody creates a new, empty high-level RequestBody from scratch, with every map initialized.
+func CreateRequestBody() *RequestBody {
+	return &RequestBody{
+		Content:    make(map[string]*MediaType),
+		Extensions: make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level RequestBody instance used to create the high-level one.
 func (r *RequestBody) GoLow() *low.RequestBody {
 	return r.low
@@ -14,11 +14,11 @@ import (
 // operations based on the response.
 //  - https://spec.openapis.org/oas/v3.1.0#response-object
 type Response struct {
-	Description string
-	Headers     map[string]*Header
-	Content     map[string]*MediaType
-	Extensions  map[string]any
-	Links       map[string]*Link
+	Description string                `yaml:"description,omitempty"`
+	Headers     map[string]*Header    `yaml:"headers,omitempty"`
+	Content     map[string]*MediaType `yaml:"content,omitempty"`
+	Extensions  map[string]any        `yaml:",inline"`
+	Links       map[string]*Link      `yaml:"links,omitempty"`
 	low         *low.Response
 }
 
@@ -44,6 +44,18 @@ func NewResponse(response *low.Response) *Response {
 	return r
 }
 
+// CreateResponse creates a new high-level Response from scratch, with the required description. Every map is
+// initialized.
+func CreateResponse(description string) *Response {
+	return &Response{
+		Description: description,
+		Headers:     make(map[string]*Header),
+		Content:     make(map[string]*MediaType),
+		Links:       make(map[string]*Link),
+		Extensions:  make(map[string]any),
+	}
+}
+
 // GoLow returns the low-level Response object that was used to create the high-level one.
 func (r *Response) GoLow() *low.Response {
 	return r.low
@@ -23,8 +23,8 @@ import (
 // be the response for a successful operation call.
 //  - https://spec.openapis.org/oas/v3.1.0#responses-object
 type Responses struct {
-	Codes   map[string]*Response
-	Default *Response
+	Codes   map[string]*Response `yaml:",inline"`
+	Default *Response            `yaml:"default,omitempty"`
 	low     *low.Responses
 }
 
@@ -65,6 +65,11 @@ func NewResponses(response *low.Responses) *Responses {
 	return r
 }
 
+// CreateResponses creates a new, empty high-level Responses object from scratch, with every map initialized.
+func CreateResponses() *Responses {
+	return &Responses{Codes: make(map[string]*Response)}
+}
+
 // FindResponseByCode is a shortcut for looking up code by an integer vs. a string
 func (r *Responses) FindResponseByCode(code int) *Response {
 	return r.Codes[fmt.Sprintf("%d", code)]
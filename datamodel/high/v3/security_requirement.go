@@ -3,7 +3,11 @@
 
 package v3
 
-import low "github.com/pb33f/libopenapi/datamodel/low/v3"
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"gopkg.in/yaml.v3"
+)
 
 // SecurityRequirement is a high-level representation of an OpenAPI 3+ SecurityRequirement object that is backed
 // by a low-level one.
@@ -19,7 +23,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v3"
 // Security Requirement Objects in the list needs to be satisfied to authorize the request.
 //  - https://spec.openapis.org/oas/v3.1.0#security-requirement-object
 type SecurityRequirement struct {
-	ValueRequirements []map[string][]string
+	ValueRequirements []map[string][]string `yaml:"-"`
 	low               *low.SecurityRequirement
 }
 
@@ -47,3 +51,8 @@ func NewSecurityRequirement(req *low.SecurityRequirement) *SecurityRequirement {
 func (s *SecurityRequirement) GoLow() *low.SecurityRequirement {
 	return s.low
 }
+
+// RenderNode renders the SecurityRequirement as a sequence of requirements.
+func (s *SecurityRequirement) RenderNode(r *high.Renderer, original *yaml.Node) *yaml.Node {
+	return r.Render(s.ValueRequirements, original)
+}
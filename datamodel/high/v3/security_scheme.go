@@ -19,15 +19,15 @@ import (
 // Recommended for most use case is Authorization Code Grant flow with PKCE.
 //  - https://spec.openapis.org/oas/v3.1.0#security-scheme-object
 type SecurityScheme struct {
-	Type             string
-	Description      string
-	Name             string
-	In               string
-	Scheme           string
-	BearerFormat     string
-	Flows            *OAuthFlows
-	OpenIdConnectUrl string
-	Extensions       map[string]any
+	Type             string         `yaml:"type,omitempty"`
+	Description      string         `yaml:"description,omitempty"`
+	Name             string         `yaml:"name,omitempty"`
+	In               string         `yaml:"in,omitempty"`
+	Scheme           string         `yaml:"scheme,omitempty"`
+	BearerFormat     string         `yaml:"bearerFormat,omitempty"`
+	Flows            *OAuthFlows    `yaml:"flows,omitempty"`
+	OpenIdConnectUrl string         `yaml:"openIdConnectUrl,omitempty"`
+	Extensions       map[string]any `yaml:",inline"`
 	low              *low.SecurityScheme
 }
 
@@ -49,6 +49,12 @@ func NewSecurityScheme(ss *low.SecurityScheme) *SecurityScheme {
 	return s
 }
 
+// CreateSecurityScheme creates a new high-level SecurityScheme from scratch, with the required type ('apiKey',
+// 'http', 'mutualTLS', 'oauth2' or 'openIdConnect'). Every map is initialized.
+func CreateSecurityScheme(schemeType string) *SecurityScheme {
+	return &SecurityScheme{Type: schemeType, Extensions: make(map[string]any)}
+}
+
 // GoLow returns the low-level SecurityScheme that was used to create the high-level one.
 func (s *SecurityScheme) GoLow() *low.SecurityScheme {
 	return s.low
@@ -8,9 +8,9 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v3"
 // Server represents a high-level OpenAPI 3+ Server object, that is backed by a low level one.
 //  - https://spec.openapis.org/oas/v3.1.0#server-object
 type Server struct {
-	URL         string
-	Description string
-	Variables   map[string]*ServerVariable
+	URL         string                     `yaml:"url,omitempty"`
+	Description string                     `yaml:"description,omitempty"`
+	Variables   map[string]*ServerVariable `yaml:"variables,omitempty"`
 	low         *low.Server
 }
 
@@ -28,6 +28,11 @@ func NewServer(server *low.Server) *Server {
 	return s
 }
 
+// CreateServer creates a new high-level Server from scratch, with the required URL. Every map is initialized.
+func CreateServer(url string) *Server {
+	return &Server{URL: url, Variables: make(map[string]*ServerVariable)}
+}
+
 // GoLow returns the low-level Server instance that was used to create the high-level one
 func (s *Server) GoLow() *low.Server {
 	return s.low
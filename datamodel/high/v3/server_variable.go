@@ -10,9 +10,9 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v3"
 // ServerVariable is an object representing a Server Variable for server URL template substitution.
 // - https://spec.openapis.org/oas/v3.1.0#server-variable-object
 type ServerVariable struct {
-	Enum        []string
-	Default     string
-	Description string
+	Enum        []string `yaml:"enum,omitempty"`
+	Default     string   `yaml:"default,omitempty"`
+	Description string   `yaml:"description,omitempty"`
 	low         *low.ServerVariable
 }
 
@@ -32,6 +32,11 @@ func NewServerVariable(variable *low.ServerVariable) *ServerVariable {
 	return v
 }
 
+// CreateServerVariable creates a new high-level ServerVariable from scratch, with the required default value.
+func CreateServerVariable(defaultValue string) *ServerVariable {
+	return &ServerVariable{Default: defaultValue}
+}
+
 // GoLow returns the low-level ServerVariable used to to create the high\-level one.
 func (s *ServerVariable) GoLow() *low.ServerVariable {
 	return s.low
@@ -5,26 +5,46 @@ package base
 
 // Constants for labels used to look up values within OpenAPI specifications.
 const (
-	TagsLabel                 = "tags"
-	ExternalDocsLabel         = "externalDocs"
-	ExamplesLabel             = "examples"
-	ExampleLabel              = "example"
-	ValueLabel                = "value"
-	InfoLabel                 = "info"
-	ContactLabel              = "contact"
-	LicenseLabel              = "license"
-	PropertiesLabel           = "properties"
-	AdditionalPropertiesLabel = "additionalProperties"
-	XMLLabel                  = "xml"
-	ItemsLabel                = "items"
-	AllOfLabel                = "allOf"
-	AnyOfLabel                = "anyOf"
-	OneOfLabel                = "oneOf"
-	NotLabel                  = "not"
-	TypeLabel                 = "type"
-	DiscriminatorLabel        = "discriminator"
-	ExclusiveMinimumLabel     = "exclusiveMinimum"
-	ExclusiveMaximumLabel     = "exclusiveMaximum"
-	SchemaLabel               = "schema"
-	SchemaTypeLabel           = "$schema"
+	TagsLabel                  = "tags"
+	ExternalDocsLabel          = "externalDocs"
+	ExamplesLabel              = "examples"
+	ExampleLabel               = "example"
+	ValueLabel                 = "value"
+	InfoLabel                  = "info"
+	ContactLabel               = "contact"
+	LicenseLabel               = "license"
+	PropertiesLabel            = "properties"
+	AdditionalPropertiesLabel  = "additionalProperties"
+	XMLLabel                   = "xml"
+	ItemsLabel                 = "items"
+	AllOfLabel                 = "allOf"
+	AnyOfLabel                 = "anyOf"
+	OneOfLabel                 = "oneOf"
+	NotLabel                   = "not"
+	TypeLabel                  = "type"
+	DiscriminatorLabel         = "discriminator"
+	ExclusiveMinimumLabel      = "exclusiveMinimum"
+	ExclusiveMaximumLabel      = "exclusiveMaximum"
+	SchemaLabel                = "schema"
+	SchemaTypeLabel            = "$schema"
+	IdLabel                    = "$id"
+	AnchorLabel                = "$anchor"
+	DynamicAnchorLabel         = "$dynamicAnchor"
+	DynamicRefLabel            = "$dynamicRef"
+	DefsLabel                  = "$defs"
+	EnumLabel                  = "enum"
+	ConstLabel                 = "const"
+	ContainsLabel              = "contains"
+	MinContainsLabel           = "minContains"
+	MaxContainsLabel           = "maxContains"
+	PrefixItemsLabel           = "prefixItems"
+	IfLabel                    = "if"
+	ThenLabel                  = "then"
+	ElseLabel                  = "else"
+	DependentSchemasLabel      = "dependentSchemas"
+	DependentRequiredLabel     = "dependentRequired"
+	PatternPropertiesLabel     = "patternProperties"
+	PropertyNamesLabel         = "propertyNames"
+	UnevaluatedItemsLabel      = "unevaluatedItems"
+	UnevaluatedPropertiesLabel = "unevaluatedProperties"
 )
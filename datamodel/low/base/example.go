@@ -87,3 +87,28 @@ func ExtractExampleValue(exp *yaml.Node) any {
 	}
 	return exp.Value
 }
+
+// ExtractValue will extract a typed value from a node. Scalars are extracted as primitives (like ExtractExampleValue),
+// null values are extracted as nil, maps are extracted as map[string]any and sequences as []any.
+func ExtractValue(node *yaml.Node) any {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return ExtractValue(node.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			m[node.Content[i].Value] = ExtractValue(node.Content[i+1])
+		}
+		return m
+	case yaml.SequenceNode:
+		s := make([]any, len(node.Content))
+		for i := range node.Content {
+			s[i] = ExtractValue(node.Content[i])
+		}
+		return s
+	}
+	if node.Tag == "!!null" {
+		return nil
+	}
+	return ExtractExampleValue(node)
+}
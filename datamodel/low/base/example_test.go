@@ -130,3 +130,10 @@ func TestExtractExampleValue(t *testing.T) {
 	assert.Equal(t, 33.2, ExtractExampleValue(&yaml.Node{Tag: "!!float", Value: "33.2"}).(float64))
 
 }
+
+func TestExtractValue(t *testing.T) {
+	var n yaml.Node
+	_ = yaml.Unmarshal([]byte(`[1, 1.5, true, null, pickles, {a: [2, b]}]`), &n)
+	v := ExtractValue(n.Content[0])
+	assert.Equal(t, []any{int64(1), 1.5, true, nil, "pickles", map[string]any{"a": []any{int64(2), "b"}}}, v)
+}
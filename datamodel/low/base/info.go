@@ -18,6 +18,7 @@ import (
 //  v3 - https://spec.openapis.org/oas/v3.1.0#info-object
 type Info struct {
 	Title          low.NodeReference[string]
+	Summary        low.NodeReference[string]
 	Description    low.NodeReference[string]
 	TermsOfService low.NodeReference[string]
 	Contact        low.NodeReference[*Contact]
@@ -32,7 +33,10 @@ func (i *Info) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	i.Contact = contact
 
 	// extract license
-	lic, _ := low.ExtractObject[*License](LicenseLabel, root, idx)
+	lic, err := low.ExtractObject[*License](LicenseLabel, root, idx)
+	if err != nil {
+		return err
+	}
 	i.License = lic
 	return nil
 }
@@ -62,3 +62,44 @@ func TestLicense_Build(t *testing.T) {
 	k := n.Build(nil, nil)
 	assert.Nil(t, k)
 }
+
+func TestInfo_Build_31(t *testing.T) {
+
+	yml := `title: pizza
+summary: a pie
+license:
+  name: MIT
+  identifier: MIT`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Info
+	_ = low.BuildModel(&idxNode, &n)
+	assert.NoError(t, n.Build(idxNode.Content[0], idx))
+
+	assert.Equal(t, "a pie", n.Summary.Value)
+	assert.Equal(t, "MIT", n.License.Value.Identifier.Value)
+}
+
+func TestInfo_Build_LicenseIdentifierAndURL(t *testing.T) {
+
+	yml := `title: pizza
+license:
+  name: MIT
+  identifier: MIT
+  url: https://opensource.org/licenses/MIT`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Info
+	_ = low.BuildModel(&idxNode, &n)
+	assert.NoError(t, n.Build(idxNode.Content[0], idx))
+
+	// the license is kept, validation reports that identifier and url are mutually exclusive.
+	assert.Equal(t, "MIT", n.License.Value.Identifier.Value)
+	assert.Equal(t, "https://opensource.org/licenses/MIT", n.License.Value.URL.Value)
+}
@@ -13,11 +13,14 @@ import (
 //  v2 - https://swagger.io/specification/v2/#licenseObject
 //  v3 - https://spec.openapis.org/oas/v3.1.0#license-object
 type License struct {
-	Name low.NodeReference[string]
-	URL  low.NodeReference[string]
+	Name       low.NodeReference[string]
+	Identifier low.NodeReference[string]
+	URL        low.NodeReference[string]
 }
 
-// Build is not implemented for License (there is nothing to build)
+// Build is not implemented for License (there is nothing to build). An Identifier (3.1) and a URL are mutually
+// exclusive, a License with both is still built, the violation is reported by validating the document.
 func (l *License) Build(root *yaml.Node, idx *index.SpecIndex) error {
+	// not implemented.
 	return nil
 }
@@ -51,10 +51,12 @@ type Schema struct {
 	SchemaTypeRef low.NodeReference[string]
 
 	// In versions 2 and 3.0, this ExclusiveMaximum can only be a boolean.
-	ExclusiveMaximum low.NodeReference[SchemaDynamicValue[bool, int64]]
+	// In version 3.1, ExclusiveMaximum is a number.
+	ExclusiveMaximum low.NodeReference[SchemaDynamicValue[bool, float64]]
 
 	// In versions 2 and 3.0, this ExclusiveMinimum can only be a boolean.
-	ExclusiveMinimum low.NodeReference[SchemaDynamicValue[bool, int64]]
+	// In version 3.1, ExclusiveMinimum is a number.
+	ExclusiveMinimum low.NodeReference[SchemaDynamicValue[bool, float64]]
 
 	// In versions 2 and 3.0, this Type is a single value, so array will only ever have one value
 	// in version 3.1, Type can be multiple values
@@ -71,11 +73,32 @@ type Schema struct {
 	// in 3.1 examples can be an array (which is recommended)
 	Examples low.NodeReference[[]low.ValueReference[any]]
 
+	// JSON Schema 2020-12 keywords, only available in version 3.1
+	Id                    low.NodeReference[string]
+	Anchor                low.NodeReference[string]
+	DynamicAnchor         low.NodeReference[string]
+	DynamicRef            low.NodeReference[string]
+	Defs                  low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]
+	Const                 low.NodeReference[any]
+	Contains              low.NodeReference[*SchemaProxy]
+	MinContains           low.NodeReference[int64]
+	MaxContains           low.NodeReference[int64]
+	PrefixItems           low.NodeReference[[]low.ValueReference[*SchemaProxy]]
+	If                    low.NodeReference[*SchemaProxy]
+	Then                  low.NodeReference[*SchemaProxy]
+	Else                  low.NodeReference[*SchemaProxy]
+	DependentSchemas      low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]
+	DependentRequired     low.NodeReference[map[low.KeyReference[string]]low.ValueReference[[]string]]
+	PatternProperties     low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]
+	PropertyNames         low.NodeReference[*SchemaProxy]
+	UnevaluatedItems      low.NodeReference[SchemaDynamicValue[*SchemaProxy, bool]]
+	UnevaluatedProperties low.NodeReference[SchemaDynamicValue[*SchemaProxy, bool]]
+
 	// Compatible with all versions
 	Title                low.NodeReference[string]
-	MultipleOf           low.NodeReference[int64]
-	Maximum              low.NodeReference[int64]
-	Minimum              low.NodeReference[int64]
+	MultipleOf           low.NodeReference[float64]
+	Maximum              low.NodeReference[float64]
+	Minimum              low.NodeReference[float64]
 	MaxLength            low.NodeReference[int64]
 	MinLength            low.NodeReference[int64]
 	Pattern              low.NodeReference[string]
@@ -86,7 +109,7 @@ type Schema struct {
 	MaxProperties        low.NodeReference[int64]
 	MinProperties        low.NodeReference[int64]
 	Required             low.NodeReference[[]low.ValueReference[string]]
-	Enum                 low.NodeReference[[]low.ValueReference[string]]
+	Enum                 low.NodeReference[[]low.ValueReference[any]]
 	Not                  low.NodeReference[[]low.ValueReference[*SchemaProxy]]
 	Items                low.NodeReference[[]low.ValueReference[*SchemaProxy]]
 	Properties           low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]
@@ -143,7 +166,7 @@ func (s *Schema) Hash() [32]byte {
 		d = append(d, s.Required.Value[i].Value)
 	}
 	for i := range s.Enum.Value {
-		d = append(d, s.Enum.Value[i].Value)
+		d = append(d, low.GenerateTypedHashString(s.Enum.Value[i].Value))
 	}
 	propertyKeys := make([]string, 0, len(s.Properties.Value))
 	for i := range s.Properties.Value {
@@ -269,6 +292,39 @@ func (s *Schema) Hash() [32]byte {
 		d = append(d, low.GenerateHashString(s.Examples.Value[w]))
 	}
 
+	// add JSON Schema 2020-12 keywords to hash
+	d = append(d, s.Id.Value, s.Anchor.Value, s.DynamicAnchor.Value, s.DynamicRef.Value,
+		fmt.Sprintf(v, s.MinContains.Value), fmt.Sprintf(v, s.MaxContains.Value))
+	if s.Const.ValueNode != nil {
+		d = append(d, low.GenerateTypedHashString(s.Const.Value))
+	}
+	d = append(d, hashSchemaProxyMap(s.Defs.Value)...)
+	d = append(d, hashSchemaProxyMap(s.DependentSchemas.Value)...)
+	d = append(d, hashSchemaProxyMap(s.PatternProperties.Value)...)
+	for i := range s.PrefixItems.Value {
+		d = append(d, hashSchemaProxy(s.PrefixItems.Value[i].Value))
+	}
+	for _, sp := range []*SchemaProxy{s.Contains.Value, s.If.Value, s.Then.Value, s.Else.Value, s.PropertyNames.Value} {
+		d = append(d, hashSchemaProxy(sp))
+	}
+	for _, u := range []SchemaDynamicValue[*SchemaProxy, bool]{s.UnevaluatedItems.Value, s.UnevaluatedProperties.Value} {
+		if u.IsA() {
+			d = append(d, hashSchemaProxy(u.A))
+		} else {
+			d = append(d, fmt.Sprintf(v, u.B))
+		}
+	}
+	dependentKeys := make([]string, 0, len(s.DependentRequired.Value))
+	dependentRequired := make(map[string][]string)
+	for k := range s.DependentRequired.Value {
+		dependentKeys = append(dependentKeys, k.Value)
+		dependentRequired[k.Value] = s.DependentRequired.Value[k].Value
+	}
+	sort.Strings(dependentKeys)
+	for _, k := range dependentKeys {
+		d = append(d, fmt.Sprintf("%s:%s", k, strings.Join(dependentRequired[k], ",")))
+	}
+
 	return sha256.Sum256([]byte(strings.Join(d, "|")))
 }
 
@@ -283,6 +339,7 @@ func (s *Schema) FindProperty(name string) *low.ValueReference[*SchemaProxy] {
 //  - Extensions
 //  - Type
 //  - ExclusiveMinimum and ExclusiveMaximum
+//  - Enum
 //  - Examples
 //  - AdditionalProperties
 //  - Discriminator
@@ -292,6 +349,7 @@ func (s *Schema) FindProperty(name string) *low.ValueReference[*SchemaProxy] {
 //  - AllOf, OneOf, AnyOf
 //  - Not
 //  - Items
+//  - JSON Schema 2020-12 keywords ($defs, $id, $anchor, const, contains, if/then/else etc.)
 func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	if h, _, _ := utils.IsNodeRefValue(root); h {
 		ref, err := low.LocateRefNode(root, idx)
@@ -337,48 +395,62 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		}
 	}
 
-	// determine exclusive minimum type, bool (3.0) or int (3.1)
+	// determine exclusive minimum type, bool (3.0) or number (3.1)
 	_, exMinLabel, exMinValue := utils.FindKeyNodeFullTop(ExclusiveMinimumLabel, root.Content)
 	if exMinValue != nil {
 		if utils.IsNodeBoolValue(exMinValue) {
 			val, _ := strconv.ParseBool(exMinValue.Value)
-			s.ExclusiveMinimum = low.NodeReference[SchemaDynamicValue[bool, int64]]{
+			s.ExclusiveMinimum = low.NodeReference[SchemaDynamicValue[bool, float64]]{
 				KeyNode:   exMinLabel,
 				ValueNode: exMinValue,
-				Value:     SchemaDynamicValue[bool, int64]{N: 0, A: val},
+				Value:     SchemaDynamicValue[bool, float64]{N: 0, A: val},
 			}
 		}
-		if utils.IsNodeIntValue(exMinValue) {
-			val, _ := strconv.ParseInt(exMinValue.Value, 10, 64)
-			s.ExclusiveMinimum = low.NodeReference[SchemaDynamicValue[bool, int64]]{
+		if utils.IsNodeIntValue(exMinValue) || utils.IsNodeFloatValue(exMinValue) {
+			val, _ := strconv.ParseFloat(exMinValue.Value, 64)
+			s.ExclusiveMinimum = low.NodeReference[SchemaDynamicValue[bool, float64]]{
 				KeyNode:   exMinLabel,
 				ValueNode: exMinValue,
-				Value:     SchemaDynamicValue[bool, int64]{N: 1, B: val},
+				Value:     SchemaDynamicValue[bool, float64]{N: 1, B: val},
 			}
 		}
 	}
 
-	// determine exclusive maximum type, bool (3.0) or int (3.1)
+	// determine exclusive maximum type, bool (3.0) or number (3.1)
 	_, exMaxLabel, exMaxValue := utils.FindKeyNodeFullTop(ExclusiveMaximumLabel, root.Content)
 	if exMaxValue != nil {
 		if utils.IsNodeBoolValue(exMaxValue) {
 			val, _ := strconv.ParseBool(exMaxValue.Value)
-			s.ExclusiveMaximum = low.NodeReference[SchemaDynamicValue[bool, int64]]{
+			s.ExclusiveMaximum = low.NodeReference[SchemaDynamicValue[bool, float64]]{
 				KeyNode:   exMaxLabel,
 				ValueNode: exMaxValue,
-				Value:     SchemaDynamicValue[bool, int64]{N: 0, A: val},
+				Value:     SchemaDynamicValue[bool, float64]{N: 0, A: val},
 			}
 		}
-		if utils.IsNodeIntValue(exMaxValue) {
-			val, _ := strconv.ParseInt(exMaxValue.Value, 10, 64)
-			s.ExclusiveMaximum = low.NodeReference[SchemaDynamicValue[bool, int64]]{
+		if utils.IsNodeIntValue(exMaxValue) || utils.IsNodeFloatValue(exMaxValue) {
+			val, _ := strconv.ParseFloat(exMaxValue.Value, 64)
+			s.ExclusiveMaximum = low.NodeReference[SchemaDynamicValue[bool, float64]]{
 				KeyNode:   exMaxLabel,
 				ValueNode: exMaxValue,
-				Value:     SchemaDynamicValue[bool, int64]{N: 1, B: val},
+				Value:     SchemaDynamicValue[bool, float64]{N: 1, B: val},
 			}
 		}
 	}
 
+	// extract enum values, these can be of any type.
+	_, enumLabel, enumValue := utils.FindKeyNodeFullTop(EnumLabel, root.Content)
+	if enumValue != nil && utils.IsNodeArray(enumValue) {
+		var enums []low.ValueReference[any]
+		for _, e := range enumValue.Content {
+			enums = append(enums, low.ValueReference[any]{Value: ExtractValue(e), ValueNode: e})
+		}
+		s.Enum = low.NodeReference[[]low.ValueReference[any]]{
+			KeyNode:   enumLabel,
+			ValueNode: enumValue,
+			Value:     enums,
+		}
+	}
+
 	// handle schema reference type if set. (3.1)
 	_, schemaRefLabel, schemaRefNode := utils.FindKeyNodeFullTop(SchemaTypeLabel, root.Content)
 	if schemaRefNode != nil {
@@ -451,6 +523,11 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		s.XML = low.NodeReference[*XML]{Value: &xml, KeyNode: xmlLabel, ValueNode: xmlNode}
 	}
 
+	// handle JSON Schema 2020-12 keywords if set. (3.1)
+	if err := s.buildJSONSchemaKeywords(root, idx); err != nil {
+		return err
+	}
+
 	// for property, build in a new thread!
 	bChan := make(chan schemaProxyBuildResult)
 
@@ -770,3 +847,180 @@ func ExtractSchema(root *yaml.Node, idx *index.SpecIndex) (*low.NodeReference[*S
 	}
 	return nil, nil
 }
+
+// buildJSONSchemaKeywords extracts all JSON Schema 2020-12 keywords (3.1) from the root node of a schema. Sub-schemas
+// are not built, they are wrapped in a SchemaProxy, the same as every other sub-schema.
+func (s *Schema) buildJSONSchemaKeywords(root *yaml.Node, idx *index.SpecIndex) error {
+	for label, ref := range map[string]*low.NodeReference[string]{
+		IdLabel:            &s.Id,
+		AnchorLabel:        &s.Anchor,
+		DynamicAnchorLabel: &s.DynamicAnchor,
+		DynamicRefLabel:    &s.DynamicRef,
+	} {
+		if _, kn, vn := utils.FindKeyNodeFullTop(label, root.Content); vn != nil {
+			*ref = low.NodeReference[string]{Value: vn.Value, KeyNode: kn, ValueNode: vn}
+		}
+	}
+
+	// BuildModel looks for keys in child nodes, so these are always set from the schema itself.
+	for label, ref := range map[string]*low.NodeReference[int64]{
+		MinContainsLabel: &s.MinContains,
+		MaxContainsLabel: &s.MaxContains,
+	} {
+		*ref = low.NodeReference[int64]{}
+		if _, kn, vn := utils.FindKeyNodeFullTop(label, root.Content); vn != nil {
+			val, _ := strconv.ParseInt(vn.Value, 10, 64)
+			*ref = low.NodeReference[int64]{Value: val, KeyNode: kn, ValueNode: vn}
+		}
+	}
+	s.Const = low.NodeReference[any]{}
+	if _, kn, vn := utils.FindKeyNodeFullTop(ConstLabel, root.Content); vn != nil {
+		s.Const = low.NodeReference[any]{Value: ExtractValue(vn), KeyNode: kn, ValueNode: vn}
+	}
+
+	var err error
+	for label, ref := range map[string]*low.NodeReference[*SchemaProxy]{
+		ContainsLabel:      &s.Contains,
+		IfLabel:            &s.If,
+		ThenLabel:          &s.Then,
+		ElseLabel:          &s.Else,
+		PropertyNamesLabel: &s.PropertyNames,
+	} {
+		if *ref, err = buildSchemaProxy(label, root, idx); err != nil {
+			return err
+		}
+	}
+	for label, ref := range map[string]*low.NodeReference[SchemaDynamicValue[*SchemaProxy, bool]]{
+		UnevaluatedItemsLabel:      &s.UnevaluatedItems,
+		UnevaluatedPropertiesLabel: &s.UnevaluatedProperties,
+	} {
+		_, kn, vn := utils.FindKeyNodeFullTop(label, root.Content)
+		if vn == nil {
+			continue
+		}
+		if utils.IsNodeBoolValue(vn) {
+			b, _ := strconv.ParseBool(vn.Value)
+			*ref = low.NodeReference[SchemaDynamicValue[*SchemaProxy, bool]]{KeyNode: kn, ValueNode: vn,
+				Value: SchemaDynamicValue[*SchemaProxy, bool]{N: 1, B: b}}
+			continue
+		}
+		sp, serr := newSchemaProxy(kn, vn, idx)
+		if serr != nil {
+			return serr
+		}
+		*ref = low.NodeReference[SchemaDynamicValue[*SchemaProxy, bool]]{KeyNode: kn, ValueNode: sp.vn,
+			Value: SchemaDynamicValue[*SchemaProxy, bool]{N: 0, A: sp}}
+	}
+	for label, ref := range map[string]*low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]{
+		DefsLabel:              &s.Defs,
+		DependentSchemasLabel:  &s.DependentSchemas,
+		PatternPropertiesLabel: &s.PatternProperties,
+	} {
+		if *ref, err = buildSchemaProxyMap(label, root, idx); err != nil {
+			return err
+		}
+	}
+
+	// prefixItems is ordered, each item validates the item in the same position of an array.
+	if _, kn, vn := utils.FindKeyNodeFullTop(PrefixItemsLabel, root.Content); utils.IsNodeArray(vn) {
+		var items []low.ValueReference[*SchemaProxy]
+		for i := range vn.Content {
+			sp, serr := newSchemaProxy(vn.Content[i], vn.Content[i], idx)
+			if serr != nil {
+				return serr
+			}
+			items = append(items, low.ValueReference[*SchemaProxy]{Value: sp, ValueNode: sp.vn})
+		}
+		s.PrefixItems = low.NodeReference[[]low.ValueReference[*SchemaProxy]]{Value: items, KeyNode: kn, ValueNode: vn}
+	}
+
+	if _, kn, vn := utils.FindKeyNodeFullTop(DependentRequiredLabel, root.Content); utils.IsNodeMap(vn) {
+		dependents := make(map[low.KeyReference[string]]low.ValueReference[[]string])
+		for i := 0; i+1 < len(vn.Content); i += 2 {
+			var required []string
+			for _, r := range vn.Content[i+1].Content {
+				required = append(required, r.Value)
+			}
+			dependents[low.KeyReference[string]{Value: vn.Content[i].Value, KeyNode: vn.Content[i]}] =
+				low.ValueReference[[]string]{Value: required, ValueNode: vn.Content[i+1]}
+		}
+		s.DependentRequired = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[[]string]]{
+			Value: dependents, KeyNode: kn, ValueNode: vn}
+	}
+	return nil
+}
+
+// newSchemaProxy creates a SchemaProxy for a sub-schema node. If the node is a reference, the reference is located
+// and the proxy will build the referenced schema when asked.
+func newSchemaProxy(kn, vn *yaml.Node, idx *index.SpecIndex) (*SchemaProxy, error) {
+	sp := &SchemaProxy{kn: kn, vn: vn, idx: idx}
+	if h, _, refLocation := utils.IsNodeRefValue(vn); h {
+		ref, _ := low.LocateRefNode(vn, idx)
+		if ref == nil {
+			return nil, fmt.Errorf("build schema failed: reference cannot be found: %s, line %d, col %d",
+				vn.Content[1].Value, vn.Content[1].Line, vn.Content[1].Column)
+		}
+		sp.vn = ref
+		sp.isReference = true
+		sp.referenceLookup = refLocation
+	}
+	return sp, nil
+}
+
+// buildSchemaProxy creates a SchemaProxy for a single sub-schema found under a label, for example 'contains'.
+func buildSchemaProxy(label string, root *yaml.Node, idx *index.SpecIndex) (low.NodeReference[*SchemaProxy], error) {
+	_, kn, vn := utils.FindKeyNodeFullTop(label, root.Content)
+	if vn == nil {
+		return low.NodeReference[*SchemaProxy]{}, nil
+	}
+	sp, err := newSchemaProxy(kn, vn, idx)
+	if err != nil {
+		return low.NodeReference[*SchemaProxy]{}, err
+	}
+	return low.NodeReference[*SchemaProxy]{Value: sp, KeyNode: kn, ValueNode: sp.vn}, nil
+}
+
+// buildSchemaProxyMap creates a SchemaProxy for every sub-schema of a map found under a label, for example '$defs'.
+func buildSchemaProxyMap(label string, root *yaml.Node, idx *index.SpecIndex) (
+	low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]], error) {
+
+	_, kn, vn := utils.FindKeyNodeFullTop(label, root.Content)
+	if !utils.IsNodeMap(vn) {
+		return low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]{}, nil
+	}
+	schemas := make(map[low.KeyReference[string]]low.ValueReference[*SchemaProxy])
+	for i := 0; i+1 < len(vn.Content); i += 2 {
+		sp, err := newSchemaProxy(vn.Content[i], vn.Content[i+1], idx)
+		if err != nil {
+			return low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]{}, err
+		}
+		schemas[low.KeyReference[string]{Value: vn.Content[i].Value, KeyNode: vn.Content[i]}] =
+			low.ValueReference[*SchemaProxy]{Value: sp, ValueNode: sp.vn}
+	}
+	return low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]{
+		Value: schemas, KeyNode: kn, ValueNode: vn}, nil
+}
+
+// hashSchemaProxy returns a hash of a SchemaProxy, references are hashed using the reference, not the schema.
+func hashSchemaProxy(sp *SchemaProxy) string {
+	if sp == nil {
+		return ""
+	}
+	if sp.IsSchemaReference() {
+		return sp.GetSchemaReference()
+	}
+	if s := sp.Schema(); s != nil {
+		return fmt.Sprintf("%x", s.Hash())
+	}
+	return ""
+}
+
+// hashSchemaProxyMap returns the hashes of every SchemaProxy in a map, sorted by key.
+func hashSchemaProxyMap(schemas map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]) []string {
+	var hashes []string
+	for k, v := range schemas {
+		hashes = append(hashes, fmt.Sprintf("%s:%s", k.Value, hashSchemaProxy(v.Value)))
+	}
+	sort.Strings(hashes)
+	return hashes
+}
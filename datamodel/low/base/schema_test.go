@@ -259,8 +259,8 @@ examples:
 	assert.True(t, sch.ExclusiveMinimum.Value.IsB())
 	assert.False(t, sch.ExclusiveMinimum.Value.IsA())
 	assert.True(t, sch.ExclusiveMaximum.Value.IsB())
-	assert.Equal(t, int64(12), sch.ExclusiveMinimum.Value.B)
-	assert.Equal(t, int64(13), sch.ExclusiveMaximum.Value.B)
+	assert.Equal(t, float64(12), sch.ExclusiveMinimum.Value.B)
+	assert.Equal(t, float64(13), sch.ExclusiveMaximum.Value.B)
 	assert.Len(t, sch.Examples.Value, 1)
 	assert.Equal(t, "testing", sch.Examples.Value[0].Value)
 	assert.Equal(t, "fish64", sch.ContentEncoding.Value)
@@ -1305,3 +1305,190 @@ func TestSchema_Hash_EqualJumbled(t *testing.T) {
 	assert.True(t, low.AreEqual(lDoc.Value.Schema(), rDoc.Value.Schema()))
 
 }
+
+func Test_Schema_31_JSONSchemaKeywords(t *testing.T) {
+	yml := `components:
+  schemas:
+    Name:
+      type: string
+    Pet:
+      $id: https://pb33f.io/pet
+      $anchor: pet
+      $dynamicAnchor: node
+      $dynamicRef: '#node'
+      $defs:
+        tag:
+          type: string
+      const: pickles
+      contains:
+        type: integer
+      minContains: 1
+      maxContains: 5
+      prefixItems:
+        - $ref: '#/components/schemas/Name'
+        - type: integer
+      if:
+        properties:
+          kind:
+            const: cat
+      then:
+        required: [meow]
+      else:
+        required: [bark]
+      dependentSchemas:
+        collar:
+          required: [owner]
+      dependentRequired:
+        collar: [owner, phone]
+      patternProperties:
+        ^x-:
+          type: string
+      propertyNames:
+        pattern: ^[a-z]+$
+      unevaluatedItems:
+        type: boolean
+      unevaluatedProperties: false`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	petNode := idxNode.Content[0].Content[1].Content[1].Content[3]
+	var sch Schema
+	assert.NoError(t, low.BuildModel(petNode, &sch))
+	assert.NoError(t, sch.Build(petNode, idx))
+
+	assert.Equal(t, "https://pb33f.io/pet", sch.Id.Value)
+	assert.Equal(t, "pet", sch.Anchor.Value)
+	assert.Equal(t, "node", sch.DynamicAnchor.Value)
+	assert.Equal(t, "#node", sch.DynamicRef.Value)
+	assert.Equal(t, "pickles", sch.Const.Value)
+	assert.Equal(t, int64(1), sch.MinContains.Value)
+	assert.Equal(t, int64(5), sch.MaxContains.Value)
+	assert.Equal(t, "integer", sch.Contains.Value.Schema().Type.Value.A)
+	assert.Equal(t, "string", sch.Defs.Value[low.KeyReference[string]{Value: "tag",
+		KeyNode: sch.Defs.ValueNode.Content[0]}].Value.Schema().Type.Value.A)
+
+	assert.Len(t, sch.PrefixItems.Value, 2)
+	assert.True(t, sch.PrefixItems.Value[0].Value.IsSchemaReference())
+	assert.Equal(t, "string", sch.PrefixItems.Value[0].Value.Schema().Type.Value.A)
+	assert.Equal(t, "integer", sch.PrefixItems.Value[1].Value.Schema().Type.Value.A)
+
+	assert.NotNil(t, sch.If.Value.Schema().FindProperty("kind"))
+	assert.Equal(t, "meow", sch.Then.Value.Schema().Required.Value[0].Value)
+	assert.Equal(t, "bark", sch.Else.Value.Schema().Required.Value[0].Value)
+	assert.Len(t, sch.DependentSchemas.Value, 1)
+	assert.Len(t, sch.PatternProperties.Value, 1)
+	for k, v := range sch.DependentRequired.Value {
+		assert.Equal(t, "collar", k.Value)
+		assert.Equal(t, []string{"owner", "phone"}, v.Value)
+	}
+	assert.Equal(t, "^[a-z]+$", sch.PropertyNames.Value.Schema().Pattern.Value)
+	assert.True(t, sch.UnevaluatedItems.Value.IsA())
+	assert.Equal(t, "boolean", sch.UnevaluatedItems.Value.A.Schema().Type.Value.A)
+	assert.True(t, sch.UnevaluatedProperties.Value.IsB())
+	assert.False(t, sch.UnevaluatedProperties.Value.B)
+
+	// keywords are part of the hash.
+	hash := sch.Hash()
+	sch.UnevaluatedProperties.Value.B = true
+	assert.NotEqual(t, hash, sch.Hash())
+	sch.UnevaluatedProperties.Value.B = false
+	assert.Equal(t, hash, sch.Hash())
+	sch.Const.Value = "chicken"
+	assert.NotEqual(t, hash, sch.Hash())
+}
+
+func Test_Schema_31_JSONSchemaKeywords_BadRef(t *testing.T) {
+	yml := `contains:
+  $ref: '#/no/where'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var sch Schema
+	assert.Error(t, sch.Build(idxNode.Content[0], idx))
+}
+
+func Test_Schema_31_JSONSchemaKeywords_ChildValues(t *testing.T) {
+	yml := `if:
+  required: [cat]
+then:
+  description: meow
+properties:
+  title:
+    type: string
+required: [dog]`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var sch Schema
+	_ = low.BuildModel(idxNode.Content[0], &sch)
+	assert.NoError(t, sch.Build(idxNode.Content[0], idx))
+
+	// values of child schemas do not belong to the parent.
+	assert.Len(t, sch.Required.Value, 1)
+	assert.Equal(t, "dog", sch.Required.Value[0].Value)
+	assert.True(t, sch.Description.IsEmpty())
+	assert.True(t, sch.Title.IsEmpty())
+	assert.Equal(t, "meow", sch.Then.Value.Schema().Description.Value)
+}
+
+func Test_Schema_DecimalConstraints(t *testing.T) {
+	yml := `maximum: 99.99
+minimum: 10
+multipleOf: 0.01
+exclusiveMinimum: 0.5`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var sch Schema
+	_ = low.BuildModel(idxNode.Content[0], &sch)
+	assert.NoError(t, sch.Build(idxNode.Content[0], nil))
+
+	assert.Equal(t, 99.99, sch.Maximum.Value)
+	assert.Equal(t, float64(10), sch.Minimum.Value)
+	assert.Equal(t, 0.01, sch.MultipleOf.Value)
+	assert.True(t, sch.ExclusiveMinimum.Value.IsB())
+	assert.Equal(t, 0.5, sch.ExclusiveMinimum.Value.B)
+
+	// the original literal is kept.
+	assert.Equal(t, "99.99", sch.Maximum.ValueNode.Value)
+	assert.Equal(t, "0.01", sch.MultipleOf.ValueNode.Value)
+}
+
+func Test_Schema_EnumTyped(t *testing.T) {
+	yml := `enum: [pickles, 1, 2.5, true, null, {name: cat}]
+const: {name: dog}`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var sch Schema
+	_ = low.BuildModel(idxNode.Content[0], &sch)
+	assert.NoError(t, sch.Build(idxNode.Content[0], nil))
+
+	assert.Len(t, sch.Enum.Value, 6)
+	assert.Equal(t, "pickles", sch.Enum.Value[0].Value)
+	assert.Equal(t, int64(1), sch.Enum.Value[1].Value)
+	assert.Equal(t, 2.5, sch.Enum.Value[2].Value)
+	assert.Equal(t, true, sch.Enum.Value[3].Value)
+	assert.Nil(t, sch.Enum.Value[4].Value)
+	assert.Equal(t, map[string]any{"name": "cat"}, sch.Enum.Value[5].Value)
+	assert.Equal(t, "1", sch.Enum.Value[1].ValueNode.Value)
+	assert.Equal(t, map[string]any{"name": "dog"}, sch.Const.Value)
+
+	// a string that looks like a number is a different value.
+	var otherNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`enum: [pickles, "1", 2.5, true, null, {name: cat}]
+const: {name: dog}`), &otherNode)
+
+	var other Schema
+	_ = low.BuildModel(otherNode.Content[0], &other)
+	assert.NoError(t, other.Build(otherNode.Content[0], nil))
+	assert.NotEqual(t, sch.Hash(), other.Hash())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
 	"gopkg.in/yaml.v3"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -43,50 +44,60 @@ func generateIndexCollection(idx *index.SpecIndex) []func() map[string]*index.Re
 		idx.GetAllRequestBodies,
 		idx.GetAllResponses,
 		idx.GetAllSecuritySchemes,
+		idx.GetAllComponentPathItems,
 	}
 }
 
-// LocateRefNode will perform a complete lookup for a $ref node. This function searches the entire index for
-// the reference being supplied. If there is a match found, the reference *yaml.Node is returned.
+// LocateRefNode will perform a complete lookup for a $ref node. References are relative to the document that
+// contains the node, so the index of that document is found first (searching the index and every external index
+// beneath it), and then searched for the reference being supplied. If there is a match found, the reference
+// *yaml.Node is returned.
 func LocateRefNode(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, error) {
 	if rf, _, rv := utils.IsNodeRefValue(root); rf {
+		doc := idx.FindReferenceIndex(root)
+		if doc == nil {
+			doc = idx
+		}
+
 		// run through everything and return as soon as we find a match.
 		// this operates as fast as possible as ever
-		collections := generateIndexCollection(idx)
-
-		// if there are any external indexes being used by remote
-		// documents, then we need to search through them also.
-		externalIndexes := idx.GetAllExternalIndexes()
-		if len(externalIndexes) > 0 {
-			var extCollection []func() map[string]*index.Reference
-			for _, extIndex := range externalIndexes {
-				extCollection = generateIndexCollection(extIndex)
-				collections = append(collections, extCollection...)
-			}
-		}
+		collections := generateIndexCollection(doc)
 
+		var foundNode *yaml.Node
 		var found map[string]*index.Reference
 		for _, collection := range collections {
 			found = collection()
 			if found != nil && found[rv] != nil {
+				foundNode = found[rv].Node
+				break
+			}
+		}
 
-				// if this is a ref node, we need to keep diving
-				// until we hit something that isn't a ref.
-				if jh, _, _ := utils.IsNodeRefValue(found[rv].Node); jh {
+		// references to other documents (including polymorphic references, which are not mapped) are looked up
+		// in the index of the document they point to.
+		if foundNode == nil {
+			if ref, _ := doc.FindIndexedComponent(rv); ref != nil {
+				foundNode = ref.Node
+			}
+		}
 
-					// if this node is circular, stop drop and roll.
-					if !IsCircular(found[rv].Node, idx) {
-						return LocateRefNode(found[rv].Node, idx)
-					} else {
-						return found[rv].Node, fmt.Errorf("circular reference '%s' found during lookup at line "+
-							"%d, column %d, It cannot be resolved",
-							GetCircularReferenceResult(found[rv].Node, idx).GenerateJourneyPath(),
-							found[rv].Node.Line,
-							found[rv].Node.Column)
-					}
+		if foundNode != nil {
+			// if this is a ref node, we need to keep diving
+			// until we hit something that isn't a ref.
+			if jh, _, _ := utils.IsNodeRefValue(foundNode); jh {
+
+				// if this node is circular, stop drop and roll.
+				if !IsCircular(foundNode, idx) {
+					return LocateRefNode(foundNode, idx)
+				} else {
+					return foundNode, fmt.Errorf("circular reference '%s' found during lookup at line "+
+						"%d, column %d, It cannot be resolved",
+						GetCircularReferenceResult(foundNode, idx).GenerateJourneyPath(),
+						foundNode.Line,
+						foundNode.Column)
 				}
-				return found[rv].Node, nil
 			}
+			return foundNode, nil
 		}
 
 		// cant be found? last resort is to try a path lookup
@@ -96,7 +107,7 @@ func LocateRefNode(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, error) {
 		yamlPath := fmt.Sprintf("$.paths.%s", cleaned)
 		path, err := yamlpath.NewPath(yamlPath)
 		if err == nil {
-			nodes, fErr := path.Find(idx.GetRootNode())
+			nodes, fErr := path.Find(doc.GetRootNode())
 			if fErr == nil {
 				if len(nodes) > 0 {
 					return nodes[0], nil
@@ -555,3 +566,39 @@ func AreEqual(l, r Hashable) bool {
 func GenerateHashString(v any) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(v))))
 }
+
+// GenerateTypedHashString will generate a SHA256 hash of a value extracted from a node (a primitive, nil,
+// map[string]any or []any), that includes the type of the value. The string "1" and the number 1 have different
+// hashes, integers and floats are both numbers, so 1 and 1.0 have the same hash.
+func GenerateTypedHashString(v any) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(typedString(v))))
+}
+
+func typedString(v any) string {
+	switch n := v.(type) {
+	case int64, float64:
+		return fmt.Sprintf("number:%v", n)
+	case map[string]any:
+		keys := make([]string, 0, len(n))
+		for k := range n {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("map:{")
+		for _, k := range keys {
+			b.WriteString(fmt.Sprintf("%q:%s,", k, typedString(n[k])))
+		}
+		b.WriteString("}")
+		return b.String()
+	case []any:
+		var b strings.Builder
+		b.WriteString("array:[")
+		for i := range n {
+			b.WriteString(typedString(n[i]) + ",")
+		}
+		b.WriteString("]")
+		return b.String()
+	}
+	return fmt.Sprintf("%T:%v", v, v)
+}
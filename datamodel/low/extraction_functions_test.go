@@ -1143,8 +1143,7 @@ func TestExtractMapFlat_DoubleRef(t *testing.T) {
 	yml := `components:
   schemas:
     stank:
-      things:
-        almostWork: 99`
+      almostWork: 99`
 
 	var idxNode yaml.Node
 	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
@@ -1489,3 +1488,14 @@ x-tacos: [1,2,3]`
 		}
 	}
 }
+
+func TestGenerateTypedHashString(t *testing.T) {
+	assert.Equal(t, GenerateTypedHashString(int64(1)), GenerateTypedHashString(1.0))
+	assert.NotEqual(t, GenerateTypedHashString(int64(1)), GenerateTypedHashString("1"))
+	assert.NotEqual(t, GenerateTypedHashString(true), GenerateTypedHashString("true"))
+	assert.NotEqual(t, GenerateTypedHashString(nil), GenerateTypedHashString(""))
+	assert.Equal(t, GenerateTypedHashString(map[string]any{"a": int64(1), "b": []any{"c"}}),
+		GenerateTypedHashString(map[string]any{"b": []any{"c"}, "a": 1.0}))
+	assert.NotEqual(t, GenerateTypedHashString(map[string]any{"a": true}),
+		GenerateTypedHashString(map[string]any{"a": "true"}))
+}
@@ -25,6 +25,11 @@ func BuildModel(node *yaml.Node, model interface{}) error {
 	if reflect.ValueOf(model).Type().Kind() != reflect.Pointer {
 		return fmt.Errorf("cannot build model on non-pointer: %v", reflect.ValueOf(model).Type().Kind())
 	}
+	// only the keys of the object itself are used, keys of child objects (like a property named 'title') are not.
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
 	v := reflect.ValueOf(model).Elem()
 	num := v.NumField()
 	for i := 0; i < num; i++ {
@@ -46,7 +51,7 @@ func BuildModel(node *yaml.Node, model interface{}) error {
 
 		var vn, kn *yaml.Node
 		for _, tryCase := range cases {
-			kn, vn = utils.FindKeyNode(utils.ConvertCase(fName, tryCase), node.Content)
+			kn, vn = utils.FindKeyNodeTop(utils.ConvertCase(fName, tryCase), node.Content)
 			if vn != nil {
 				break
 			}
@@ -237,7 +242,7 @@ func SetField(field reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) err
 		break
 	case reflect.TypeOf(NodeReference[float64]{}):
 		if valueNode != nil {
-			if utils.IsNodeFloatValue(valueNode) {
+			if utils.IsNodeFloatValue(valueNode) || utils.IsNodeIntValue(valueNode) {
 				if field.CanSet() {
 					fv, _ := strconv.ParseFloat(valueNode.Value, 64)
 					nr := NodeReference[float64]{
@@ -22,4 +22,12 @@ const (
 	SecurityLabel            = "security"
 	ScopesLabel              = "scopes"
 	ResponsesLabel           = "responses"
+	SwaggerLabel             = "swagger"
+	HostLabel                = "host"
+	BasePathLabel            = "basePath"
+	SchemesLabel             = "schemes"
+	ConsumesLabel            = "consumes"
+	ProducesLabel            = "produces"
+	CollectionFormatLabel    = "collectionFormat"
+	FlowLabel                = "flow"
 )
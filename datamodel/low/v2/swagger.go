@@ -116,13 +116,21 @@ func (s *Swagger) FindExtension(ext string) *low.ValueReference[any] {
 	return low.FindItemInMap[any](ext, s.Extensions)
 }
 
+// CreateDocument will create a new low-level Swagger document from a specification. File and remote references
+// are looked up relative to the working directory, use CreateDocumentFromConfig to control how they are looked up.
 func CreateDocument(info *datamodel.SpecInfo) (*Swagger, []error) {
+	return CreateDocumentFromConfig(info, index.CreateOpenAPIIndexConfig())
+}
+
+// CreateDocumentFromConfig will create a new low-level Swagger document from a specification, the index of the
+// document is created using the supplied index.SpecIndexConfig.
+func CreateDocumentFromConfig(info *datamodel.SpecInfo, config *index.SpecIndexConfig) (*Swagger, []error) {
 
 	doc := Swagger{Swagger: low.ValueReference[string]{Value: info.Version, ValueNode: info.RootNode}}
 	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
 
 	// build an index
-	idx := index.NewSpecIndex(info.RootNode)
+	idx := index.NewSpecIndexWithConfig(info.RootNode, config)
 	doc.Index = idx
 	doc.SpecInfo = info
 
@@ -7,6 +7,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"gopkg.in/yaml.v3"
+	"strings"
 )
 
 // Callback represents a low-level Callback object for OpenAPI 3+.
@@ -39,6 +40,9 @@ func (cb *Callback) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			currentCB = callbackNode
 			continue
 		}
+		if strings.HasPrefix(strings.ToLower(currentCB.Value), "x-") {
+			continue // extensions are not expressions.
+		}
 		callback, eErr := low.ExtractObjectRaw[*PathItem](callbackNode, idx)
 		if eErr != nil {
 			return eErr
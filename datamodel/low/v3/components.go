@@ -28,6 +28,7 @@ type Components struct {
 	SecuritySchemes low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SecurityScheme]]
 	Links           low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Link]]
 	Callbacks       low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Callback]]
+	PathItems       low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*PathItem]]
 	Extensions      map[low.KeyReference[string]]low.ValueReference[any]
 }
 
@@ -77,6 +78,11 @@ func (co *Components) FindCallback(callback string) *low.ValueReference[*Callbac
 	return low.FindItemInMap[*Callback](callback, co.Callbacks.Value)
 }
 
+// FindPathItem attempts to locate a PathItem from 'pathItems' with a specific name (3.1)
+func (co *Components) FindPathItem(pathItem string) *low.ValueReference[*PathItem] {
+	return low.FindItemInMap[*PathItem](pathItem, co.PathItems.Value)
+}
+
 func (co *Components) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	co.Extensions = low.ExtractExtensions(root)
 
@@ -92,6 +98,7 @@ func (co *Components) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	securitySchemesChan := make(chan low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SecurityScheme]])
 	linkChan := make(chan low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Link]])
 	callbackChan := make(chan low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Callback]])
+	pathItemChan := make(chan low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*PathItem]])
 
 	go extractComponentValues[*base.SchemaProxy](SchemasLabel, root, skipChan, errorChan, schemaChan, idx)
 	go extractComponentValues[*Parameter](ParametersLabel, root, skipChan, errorChan, paramChan, idx)
@@ -102,9 +109,10 @@ func (co *Components) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	go extractComponentValues[*SecurityScheme](SecuritySchemesLabel, root, skipChan, errorChan, securitySchemesChan, idx)
 	go extractComponentValues[*Link](LinksLabel, root, skipChan, errorChan, linkChan, idx)
 	go extractComponentValues[*Callback](CallbacksLabel, root, skipChan, errorChan, callbackChan, idx)
+	go extractComponentValues[*PathItem](PathItemsLabel, root, skipChan, errorChan, pathItemChan, idx)
 
 	n := 0
-	total := 9
+	total := 10
 
 	for n < total {
 		select {
@@ -139,6 +147,9 @@ func (co *Components) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		case callbacks := <-callbackChan:
 			co.Callbacks = callbacks
 			n++
+		case pathItems := <-pathItemChan:
+			co.PathItems = pathItems
+			n++
 		}
 	}
 	return nil
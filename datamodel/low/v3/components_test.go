@@ -60,7 +60,13 @@ var testComponentsYaml = `components:
     eighteen:
       '{raference}':
         post:
-          description: eighteen of many`
+          description: eighteen of many
+  pathItems:
+    nineteen:
+      get:
+        description: nineteen of many
+    twenty:
+      $ref: '#/components/pathItems/nineteen'`
 
 func TestComponents_Build_Success(t *testing.T) {
 
@@ -93,9 +99,11 @@ func TestComponents_Build_Success(t *testing.T) {
 	assert.Equal(t, "fifteen of many", n.FindLink("fifteen").Value.Description.Value)
 	assert.Equal(t, "sixteen of many", n.FindLink("sixteen").Value.Description.Value)
 	assert.Equal(t, "seventeen of many",
-		n.FindCallback("seventeen").Value.FindExpression("{reference}").Value.Description.Value)
+		n.FindCallback("seventeen").Value.FindExpression("{reference}").Value.Post.Value.Description.Value)
 	assert.Equal(t, "eighteen of many",
-		n.FindCallback("eighteen").Value.FindExpression("{raference}").Value.Description.Value)
+		n.FindCallback("eighteen").Value.FindExpression("{raference}").Value.Post.Value.Description.Value)
+	assert.Equal(t, "nineteen of many", n.FindPathItem("nineteen").Value.Get.Value.Description.Value)
+	assert.Equal(t, "nineteen of many", n.FindPathItem("twenty").Value.Get.Value.Description.Value)
 
 }
 
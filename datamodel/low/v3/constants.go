@@ -5,92 +5,131 @@ package v3
 
 // Label definitions used to look up vales in yaml.Node tree.
 const (
-	ComponentsLabel           = "components"
-	SchemasLabel              = "schemas"
-	EncodingLabel             = "encoding"
-	HeadersLabel              = "headers"
-	ParametersLabel           = "parameters"
-	RequestBodyLabel          = "requestBody"
-	RequestBodiesLabel        = "requestBodies"
-	ResponsesLabel            = "responses"
-	CallbacksLabel            = "callbacks"
-	ContentLabel              = "content"
-	PathsLabel                = "paths"
-	WebhooksLabel             = "webhooks"
-	JSONSchemaDialectLabel    = "jsonSchemaDialect"
-	GetLabel                  = "get"
-	PostLabel                 = "post"
-	PatchLabel                = "patch"
-	PutLabel                  = "put"
-	DeleteLabel               = "delete"
-	OptionsLabel              = "options"
-	HeadLabel                 = "head"
-	TraceLabel                = "trace"
-	LinksLabel                = "links"
-	DefaultLabel              = "default"
-	SecurityLabel             = "security"
-	SecuritySchemesLabel      = "securitySchemes"
-	OAuthFlowsLabel           = "flows"
-	VariablesLabel            = "variables"
-	ServersLabel              = "servers"
-	ServerLabel               = "server"
-	ImplicitLabel             = "implicit"
-	PasswordLabel             = "password"
-	ClientCredentialsLabel    = "clientCredentials"
-	AuthorizationCodeLabel    = "authorizationCode"
-	DescriptionLabel          = "description"
-	URLLabel                  = "url"
-	NameLabel                 = "name"
-	EmailLabel                = "email"
-	TitleLabel                = "title"
-	TermsOfServiceLabel       = "termsOfService"
-	VersionLabel              = "version"
-	LicenseLabel              = "license"
-	ContactLabel              = "contact"
-	NamespaceLabel            = "namespace"
-	PrefixLabel               = "prefix"
-	AttributeLabel            = "attribute"
-	WrappedLabel              = "wrapped"
-	PropertyNameLabel         = "propertyName"
-	SummaryLabel              = "summary"
-	ValueLabel                = "value"
-	ExternalValue             = "externalValue"
-	SchemaDialectLabel        = "$schema"
-	ExclusiveMaximumLabel     = "exclusiveMaximum"
-	ExclusiveMinimumLabel     = "exclusiveMinimum"
-	TypeLabel                 = "type"
-	MultipleOfLabel           = "multipleOf"
-	MaximumLabel              = "maximum"
-	MinimumLabel              = "minimum"
-	MaxLengthLabel            = "maxLength"
-	MinLengthLabel            = "minLength"
-	PatternLabel              = "pattern"
-	FormatLabel               = "format"
-	MaxItemsLabel             = "maxItems"
-	ExamplesLabel             = "examples"
-	MinItemsLabel             = "minItems"
-	UniqueItemsLabel          = "uniqueItems"
-	MaxPropertiesLabel        = "maxProperties"
-	MinPropertiesLabel        = "minProperties"
-	RequiredLabel             = "required"
-	EnumLabel                 = "enum"
-	SchemaLabel               = "schema"
-	NotLabel                  = "not"
-	ItemsLabel                = "items"
-	PropertiesLabel           = "properties"
-	AllOfLabel                = "allOf"
-	AnyOfLabel                = "anyOf"
-	OneOfLabel                = "oneOf"
-	AdditionalPropertiesLabel = "additionalProperties"
-	ContentEncodingLabel      = "contentEncoding"
-	ContentMediaType          = "contentMediaType"
-	NullableLabel             = "nullable"
-	ReadOnlyLabel             = "readOnly"
-	WriteOnlyLabel            = "writeOnly"
-	XMLLabel                  = "xml"
-	DeprecatedLabel           = "deprecated"
-	ExampleLabel              = "example"
-	RefLabel                  = "$ref"
-	DiscriminatorLabel        = "discriminator"
-	ExternalDocsLabel         = "externalDocs"
+	ComponentsLabel            = "components"
+	SchemasLabel               = "schemas"
+	EncodingLabel              = "encoding"
+	HeadersLabel               = "headers"
+	ParametersLabel            = "parameters"
+	RequestBodyLabel           = "requestBody"
+	RequestBodiesLabel         = "requestBodies"
+	ResponsesLabel             = "responses"
+	CallbacksLabel             = "callbacks"
+	PathItemsLabel             = "pathItems"
+	ContentLabel               = "content"
+	PathsLabel                 = "paths"
+	WebhooksLabel              = "webhooks"
+	JSONSchemaDialectLabel     = "jsonSchemaDialect"
+	OpenAPILabel               = "openapi"
+	GetLabel                   = "get"
+	PostLabel                  = "post"
+	PatchLabel                 = "patch"
+	PutLabel                   = "put"
+	DeleteLabel                = "delete"
+	OptionsLabel               = "options"
+	HeadLabel                  = "head"
+	TraceLabel                 = "trace"
+	LinksLabel                 = "links"
+	DefaultLabel               = "default"
+	SecurityLabel              = "security"
+	SecuritySchemesLabel       = "securitySchemes"
+	OAuthFlowsLabel            = "flows"
+	VariablesLabel             = "variables"
+	ServersLabel               = "servers"
+	ServerLabel                = "server"
+	ImplicitLabel              = "implicit"
+	PasswordLabel              = "password"
+	ClientCredentialsLabel     = "clientCredentials"
+	AuthorizationCodeLabel     = "authorizationCode"
+	DescriptionLabel           = "description"
+	URLLabel                   = "url"
+	IdentifierLabel            = "identifier"
+	NameLabel                  = "name"
+	EmailLabel                 = "email"
+	TitleLabel                 = "title"
+	TermsOfServiceLabel        = "termsOfService"
+	VersionLabel               = "version"
+	LicenseLabel               = "license"
+	ContactLabel               = "contact"
+	NamespaceLabel             = "namespace"
+	PrefixLabel                = "prefix"
+	AttributeLabel             = "attribute"
+	WrappedLabel               = "wrapped"
+	PropertyNameLabel          = "propertyName"
+	SummaryLabel               = "summary"
+	ValueLabel                 = "value"
+	ExternalValue              = "externalValue"
+	SchemaDialectLabel         = "$schema"
+	ExclusiveMaximumLabel      = "exclusiveMaximum"
+	ExclusiveMinimumLabel      = "exclusiveMinimum"
+	TypeLabel                  = "type"
+	MultipleOfLabel            = "multipleOf"
+	MaximumLabel               = "maximum"
+	MinimumLabel               = "minimum"
+	MaxLengthLabel             = "maxLength"
+	MinLengthLabel             = "minLength"
+	PatternLabel               = "pattern"
+	FormatLabel                = "format"
+	MaxItemsLabel              = "maxItems"
+	ExamplesLabel              = "examples"
+	MinItemsLabel              = "minItems"
+	UniqueItemsLabel           = "uniqueItems"
+	MaxPropertiesLabel         = "maxProperties"
+	MinPropertiesLabel         = "minProperties"
+	RequiredLabel              = "required"
+	EnumLabel                  = "enum"
+	SchemaLabel                = "schema"
+	NotLabel                   = "not"
+	ItemsLabel                 = "items"
+	PropertiesLabel            = "properties"
+	AllOfLabel                 = "allOf"
+	AnyOfLabel                 = "anyOf"
+	OneOfLabel                 = "oneOf"
+	AdditionalPropertiesLabel  = "additionalProperties"
+	ContentEncodingLabel       = "contentEncoding"
+	ContentMediaType           = "contentMediaType"
+	NullableLabel              = "nullable"
+	ReadOnlyLabel              = "readOnly"
+	WriteOnlyLabel             = "writeOnly"
+	XMLLabel                   = "xml"
+	DeprecatedLabel            = "deprecated"
+	ExampleLabel               = "example"
+	RefLabel                   = "$ref"
+	DiscriminatorLabel         = "discriminator"
+	ExternalDocsLabel          = "externalDocs"
+	InLabel                    = "in"
+	StyleLabel                 = "style"
+	ExplodeLabel               = "explode"
+	AllowReservedLabel         = "allowReserved"
+	AllowEmptyValueLabel       = "allowEmptyValue"
+	ContentTypeLabel           = "contentType"
+	TagsLabel                  = "tags"
+	OperationIdLabel           = "operationId"
+	OperationRefLabel          = "operationRef"
+	SchemeLabel                = "scheme"
+	BearerFormatLabel          = "bearerFormat"
+	OpenIdConnectUrlLabel      = "openIdConnectUrl"
+	AuthorizationUrlLabel      = "authorizationUrl"
+	TokenUrlLabel              = "tokenUrl"
+	RefreshUrlLabel            = "refreshUrl"
+	ScopesLabel                = "scopes"
+	ExpressionLabel            = "expression"
+	IdLabel                    = "$id"
+	AnchorLabel                = "$anchor"
+	DynamicAnchorLabel         = "$dynamicAnchor"
+	DynamicRefLabel            = "$dynamicRef"
+	DefsLabel                  = "$defs"
+	ConstLabel                 = "const"
+	ContainsLabel              = "contains"
+	MinContainsLabel           = "minContains"
+	MaxContainsLabel           = "maxContains"
+	PrefixItemsLabel           = "prefixItems"
+	IfLabel                    = "if"
+	ThenLabel                  = "then"
+	ElseLabel                  = "else"
+	DependentSchemasLabel      = "dependentSchemas"
+	DependentRequiredLabel     = "dependentRequired"
+	PatternPropertiesLabel     = "patternProperties"
+	PropertyNamesLabel         = "propertyNames"
+	UnevaluatedItemsLabel      = "unevaluatedItems"
+	UnevaluatedPropertiesLabel = "unevaluatedProperties"
 )
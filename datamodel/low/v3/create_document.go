@@ -10,12 +10,20 @@ import (
 	"sync"
 )
 
+// CreateDocument will create a new low-level OpenAPI 3+ Document from a specification. File and remote references
+// are looked up relative to the working directory, use CreateDocumentFromConfig to control how they are looked up.
 func CreateDocument(info *datamodel.SpecInfo) (*Document, []error) {
+	return CreateDocumentFromConfig(info, index.CreateOpenAPIIndexConfig())
+}
+
+// CreateDocumentFromConfig will create a new low-level OpenAPI 3+ Document from a specification, the index of the
+// document is created using the supplied index.SpecIndexConfig.
+func CreateDocumentFromConfig(info *datamodel.SpecInfo, config *index.SpecIndexConfig) (*Document, []error) {
 
 	doc := Document{Version: low.ValueReference[string]{Value: info.Version, ValueNode: info.RootNode}}
 
 	// build an index
-	idx := index.NewSpecIndex(info.RootNode)
+	idx := index.NewSpecIndexWithConfig(info.RootNode, config)
 	doc.Index = idx
 
 	// create resolver and check for circular references.
@@ -31,7 +39,7 @@ func CreateDocument(info *datamodel.SpecInfo) (*Document, []error) {
 	_, dialectLabel, dialectNode := utils.FindKeyNodeFull(JSONSchemaDialectLabel, info.RootNode.Content)
 	if dialectNode != nil {
 		doc.JsonSchemaDialect = low.NodeReference[string]{
-			Value: dialectNode.Value, KeyNode: dialectLabel, ValueNode: dialectLabel}
+			Value: dialectNode.Value, KeyNode: dialectLabel, ValueNode: dialectNode}
 	}
 
 	var runExtraction = func(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex,
@@ -68,9 +76,10 @@ func extractInfo(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex)
 	if vn != nil {
 		ir := base.Info{}
 		_ = low.BuildModel(vn, &ir)
-		_ = ir.Build(vn, idx)
+		err := ir.Build(vn, idx)
 		nr := low.NodeReference[*base.Info]{Value: &ir, ValueNode: vn, KeyNode: ln}
 		doc.Info = nr
+		return err
 	}
 	return nil
 }
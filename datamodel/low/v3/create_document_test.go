@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"testing"
+	"testing/fstest"
 )
 
 var doc *Document
@@ -102,6 +104,57 @@ func TestCreateDocument(t *testing.T) {
 	assert.Equal(t, "https://pb33f.io/schema", doc.JsonSchemaDialect.Value)
 }
 
+func TestCreateDocumentFromConfig(t *testing.T) {
+	yml := `openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/Pet'`
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+
+	config := index.CreateOpenAPIIndexConfig()
+	config.FS = fstest.MapFS{"pet.yaml": &fstest.MapFile{Data: []byte("Pet:\n  description: a pet")}}
+	d, err := CreateDocumentFromConfig(info, config)
+	assert.Empty(t, err)
+	assert.Equal(t, config, d.Index.GetConfig())
+	assert.Empty(t, d.Index.GetReferenceIndexErrors())
+	assert.NotNil(t, d.Index.GetMappedReferences()["pet.yaml#/Pet"])
+}
+
+func TestCreateDocumentFromConfig_RelativeReferences(t *testing.T) {
+	yml := `openapi: 3.0.3
+components:
+  schemas:
+    X:
+      $ref: 'a/x.yaml#/X'
+    Y:
+      $ref: 'b/c/y.yaml#/Y'
+    Z:
+      $ref: 'd/e/z.yaml#/Z'`
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+
+	// the same relative reference in a/x.yaml and b/c/y.yaml points to two different documents.
+	config := index.CreateClosedAPIIndexConfig()
+	config.AllowFileLookup = true
+	config.BasePath = "spec"
+	config.FS = fstest.MapFS{
+		"spec/a/x.yaml":        &fstest.MapFile{Data: []byte("X:\n  $ref: '../common.yaml#/E'")},
+		"spec/common.yaml":     &fstest.MapFile{Data: []byte("E:\n  type: string")},
+		"spec/b/c/y.yaml":      &fstest.MapFile{Data: []byte("Y:\n  $ref: '../common.yaml#/E'")},
+		"spec/b/common.yaml":   &fstest.MapFile{Data: []byte("E:\n  type: integer")},
+		"spec/d/e/z.yaml":      &fstest.MapFile{Data: []byte("Z:\n  $ref: 'deeper.yaml#/D'")},
+		"spec/d/e/deeper.yaml": &fstest.MapFile{Data: []byte("D:\n  $ref: '../../b/common.yaml#/E'")},
+	}
+	d, err := CreateDocumentFromConfig(info, config)
+	assert.Empty(t, err)
+	assert.Empty(t, d.Index.GetReferenceIndexErrors())
+
+	components := d.Components.Value
+	assert.Equal(t, "string", components.FindSchema("X").Value.Schema().Type.Value.A)
+	assert.Equal(t, "integer", components.FindSchema("Y").Value.Schema().Type.Value.A)
+	assert.Equal(t, "integer", components.FindSchema("Z").Value.Schema().Type.Value.A)
+}
+
 func TestCreateDocument_Info(t *testing.T) {
 	initTest()
 	assert.Equal(t, "https://pb33f.io", doc.Info.Value.TermsOfService.Value)
@@ -112,6 +165,26 @@ func TestCreateDocument_Info(t *testing.T) {
 	assert.Equal(t, "https://pb33f.io/made-up", doc.Info.Value.License.Value.URL.Value)
 }
 
+func TestCreateDocument_Info_LicenseIdentifierAndURL(t *testing.T) {
+	yml := `openapi: 3.1.0
+info:
+  title: pizza
+  summary: a pie
+  license:
+    name: MIT
+    identifier: MIT
+    url: https://opensource.org/licenses/MIT`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocument(info)
+	assert.Len(t, err, 0)
+	assert.Equal(t, "a pie", d.Info.Value.Summary.Value)
+
+	// both are kept, validation reports they are mutually exclusive.
+	assert.Equal(t, "MIT", d.Info.Value.License.Value.Identifier.Value)
+	assert.Equal(t, "https://opensource.org/licenses/MIT", d.Info.Value.License.Value.URL.Value)
+}
+
 func TestCreateDocument_WebHooks(t *testing.T) {
 	initTest()
 	assert.Len(t, doc.Webhooks.Value, 1)
@@ -40,9 +40,27 @@ func (p *PathItem) FindExtension(ext string) *low.ValueReference[any] {
 	return low.FindItemInMap[any](ext, p.Extensions)
 }
 
-// Build extracts extensions, parameters, servers and each http method defined.
+// Build extracts extensions, parameters, servers and each http method defined. If the PathItem is a reference
+// (for example to a PathItem defined in components in 3.1), the reference is followed.
 // everything is extracted asynchronously for speed.
 func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
+	if ok, _, _ := utils.IsNodeRefValue(root); ok {
+		ref, err := low.LocateRefNode(root, idx)
+		if ref != nil {
+			root = ref
+			if err != nil {
+				if !idx.AllowCircularReferenceResolving() {
+					return fmt.Errorf("path item build failed: %s", err.Error())
+				}
+			}
+			if err = low.BuildModel(root, p); err != nil {
+				return err
+			}
+		} else {
+			return fmt.Errorf("path item build failed: cannot find reference: %s at line %d, col %d",
+				root.Content[1].Value, root.Content[1].Line, root.Content[1].Column)
+		}
+	}
 	p.Extensions = low.ExtractExtensions(root)
 	skip := false
 	var currentNode *yaml.Node
@@ -52,20 +70,24 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 
 	var ops []low.NodeReference[*Operation]
 
-	// extract parameters
-	params, ln, vn, pErr := low.ExtractArray[*Parameter](ParametersLabel, root, idx)
-	if pErr != nil {
-		return pErr
-	}
-	if params != nil {
-		p.Parameters = low.NodeReference[[]low.ValueReference[*Parameter]]{
-			Value:     params,
-			KeyNode:   ln,
-			ValueNode: vn,
+	// extract parameters, only parameters defined directly on the path item are extracted, operation
+	// parameters belong to the operation.
+	var ln, vn *yaml.Node
+	if _, pl, _ := utils.FindKeyNodeFullTop(ParametersLabel, root.Content); pl != nil {
+		params, pln, pvn, pErr := low.ExtractArray[*Parameter](ParametersLabel, root, idx)
+		if pErr != nil {
+			return pErr
+		}
+		if params != nil {
+			p.Parameters = low.NodeReference[[]low.ValueReference[*Parameter]]{
+				Value:     params,
+				KeyNode:   pln,
+				ValueNode: pvn,
+			}
 		}
 	}
 
-	_, ln, vn = utils.FindKeyNodeFull(ServersLabel, root.Content)
+	_, ln, vn = utils.FindKeyNodeFullTop(ServersLabel, root.Content)
 	if vn != nil {
 		if utils.IsNodeArray(vn) {
 			var servers []low.ValueReference[*Server]
@@ -252,6 +252,24 @@ func TestPathItem_Build_GoodRef(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPathItem_Build_OperationServers(t *testing.T) {
+
+	// servers defined by an operation do not belong to the path item.
+	yml := `get:
+  servers:
+    - url: https://pb33f.io`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n PathItem
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, n.Build(idxNode.Content[0], idx))
+	assert.Empty(t, n.Servers.Value)
+	assert.Len(t, n.Get.Value.Servers.Value, 1)
+}
+
 func TestPathItem_Build_BadRef(t *testing.T) {
 
 	// this is kinda nuts, it's also not illegal, however the mechanics still need to work.
@@ -428,3 +446,61 @@ func TestPaths_Build_BrokenOp(t *testing.T) {
 	err = n.Build(idxNode.Content[0], idx)
 	assert.Error(t, err)
 }
+
+func TestPathItem_Build_ComponentRef(t *testing.T) {
+
+	yml := `components:
+ pathItems:
+   Pets:
+     description: all the pets
+     get:
+       description: get the pets`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	yml = `$ref: '#/components/pathItems/Pets'`
+
+	var rootNode yaml.Node
+	mErr = yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	var n PathItem
+	err := low.BuildModel(rootNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(rootNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, "all the pets", n.Description.Value)
+	assert.Equal(t, "get the pets", n.Get.Value.Description.Value)
+}
+
+func TestPathItem_Build_ComponentRef_NotFound(t *testing.T) {
+
+	yml := `components:
+ pathItems:
+   Pets:
+     description: all the pets`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	yml = `$ref: '#/components/pathItems/Cats'`
+
+	var rootNode yaml.Node
+	mErr = yaml.Unmarshal([]byte(yml), &rootNode)
+	assert.NoError(t, mErr)
+
+	var n PathItem
+	err := low.BuildModel(rootNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(rootNode.Content[0], idx)
+	assert.Error(t, err)
+	assert.Equal(t, "path item build failed: cannot find reference: "+
+		"#/components/pathItems/Cats at line 1, col 7", err.Error())
+}
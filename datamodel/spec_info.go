@@ -87,18 +87,6 @@ func ExtractSpecInfo(spec []byte) (*SpecInfo, error) {
 		// no point in worrying about errors here, extract JSON friendly format.
 		// run in a separate thread, don't block.
 
-		if spec.SpecType == utils.OpenApi3 {
-			switch spec.Version {
-			case "3.1.0", "3.1":
-				spec.APISchema = OpenAPI31SchemaData
-			default:
-				spec.APISchema = OpenAPI3SchemaData
-			}
-		}
-		if spec.SpecType == utils.OpenApi2 {
-			spec.APISchema = OpenAPI2SchemaData
-		}
-
 		if utils.IsYAML(string(bytes)) {
 			_ = yaml.Unmarshal(bytes, &jsonSpec)
 			jsonData, _ := json.Marshal(jsonSpec)
@@ -130,6 +118,10 @@ func ExtractSpecInfo(spec []byte) (*SpecInfo, error) {
 		}
 		specVersion.Version = version
 		specVersion.SpecFormat = OAS3
+		specVersion.APISchema = OpenAPI3SchemaData
+		if strings.HasPrefix(version, "3.1") {
+			specVersion.APISchema = OpenAPI31SchemaData
+		}
 	}
 	if openAPI2 != nil {
 		specVersion.SpecType = utils.OpenApi2
@@ -148,6 +140,7 @@ func ExtractSpecInfo(spec []byte) (*SpecInfo, error) {
 		}
 		specVersion.Version = version
 		specVersion.SpecFormat = OAS2
+		specVersion.APISchema = OpenAPI2SchemaData
 	}
 	if asyncAPI != nil {
 		specVersion.SpecType = utils.AsyncApi
@@ -153,6 +153,18 @@ func TestExtractSpecInfo_OpenAPI3(t *testing.T) {
 
 }
 
+func TestExtractSpecInfo_APISchema(t *testing.T) {
+
+	r, _ := ExtractSpecInfo([]byte(OpenApi3Spec))
+	assert.Equal(t, OpenAPI3SchemaData, r.APISchema)
+
+	r, _ = ExtractSpecInfo([]byte("openapi: 3.1.1"))
+	assert.Equal(t, OpenAPI31SchemaData, r.APISchema)
+
+	r, _ = ExtractSpecInfo([]byte(OpenApi2Spec))
+	assert.Equal(t, OpenAPI2SchemaData, r.APISchema)
+}
+
 func TestExtractSpecInfo_OpenAPIWat(t *testing.T) {
 
 	r, e := ExtractSpecInfo([]byte(OpenApiWat))
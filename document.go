@@ -21,6 +21,8 @@ import (
 	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/libopenapi/validation"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
 	"gopkg.in/yaml.v3"
 )
 
@@ -46,8 +48,18 @@ type Document interface {
 	// any other types.
 	BuildV3Model() (*DocumentModel[v3high.Document], []error)
 
-	// Serialize will re-render a Document back into a []byte slice. If any modifications have been made to the
-	// underlying data model using low level APIs, then those changes will be reflected in the serialized output.
+	// Validate will structurally validate the specification against the official meta-schema for its version
+	// (Swagger 2.0, OpenAPI 3.0 or OpenAPI 3.1). If the specification is valid, no validation errors are returned.
+	// Every validation error carries the line and column of the offending key in the specification. An error is
+	// returned if the specification cannot be validated at all.
+	Validate() ([]*validation.ValidationError, error)
+
+	// Serialize will re-render a Document back into a []byte slice. If a model has been built using BuildV2Model()
+	// or BuildV3Model(), then any modifications made to the high level model will be rendered into the serialized
+	// output. Anything left untouched (including comments and the order of keys) is kept as it was in the original
+	// specification. Modifications made to the underlying low level model (for example using Mutate()) are
+	// reflected in the serialized output as well, if a value has been modified using both, the low level
+	// modification is kept.
 	//
 	// It's important to know that this should not be used if the resolver has been used on a specification to
 	// for anything other than checking for circular references. If the resolver is used to resolve the spec, then this
@@ -58,6 +70,8 @@ type Document interface {
 type document struct {
 	version string
 	info    *datamodel.SpecInfo
+	v2Model *DocumentModel[v2high.Swagger]
+	v3Model *DocumentModel[v3high.Document]
 }
 
 // DocumentModel represents either a Swagger document (version 2) or an OpenAPI document (version 3) that is
@@ -95,10 +109,16 @@ func (d *document) Serialize() ([]byte, error) {
 	if d.info == nil {
 		return nil, fmt.Errorf("unable to serialize, document has not yet been initialized")
 	}
+	root := d.info.RootNode
+	if d.v3Model != nil {
+		root = d.v3Model.Model.RenderDocument()
+	} else if d.v2Model != nil {
+		root = d.v2Model.Model.RenderDocument()
+	}
 	if d.info.SpecFileType == datamodel.YAMLFileType {
-		return yaml.Marshal(d.info.RootNode)
+		return yaml.Marshal(root)
 	} else {
-		yamlData, _ := yaml.Marshal(d.info.RootNode)
+		yamlData, _ := yaml.Marshal(root)
 		return utils.ConvertYAMLtoJSON(yamlData)
 	}
 }
@@ -119,9 +139,10 @@ func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
 		return nil, err
 	}
 	highDoc := v2high.NewSwaggerDocument(lowDoc)
-	return &DocumentModel[v2high.Swagger]{
+	d.v2Model = &DocumentModel[v2high.Swagger]{
 		Model: *highDoc,
-	}, nil
+	}
+	return d.v2Model, nil
 }
 
 func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
@@ -140,7 +161,65 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
 		return nil, err
 	}
 	highDoc := v3high.NewDocument(lowDoc)
-	return &DocumentModel[v3high.Document]{
+	d.v3Model = &DocumentModel[v3high.Document]{
 		Model: *highDoc,
-	}, nil
+	}
+	return d.v3Model, nil
+}
+
+func (d *document) Validate() ([]*validation.ValidationError, error) {
+	return validation.ValidateDocument(d.info)
+}
+
+// CompareDocuments will compare an original and an updated Document for changes. Both documents must be the same
+// type of specification (OpenAPI 3+ or Swagger). Each document will be built into a low-level model and then
+// every part of the specification is compared.
+//
+// A summary of everything that changed is returned as a pointer to a WhatChanged object. If either document
+// cannot be built, then no summary is returned, instead a slice of errors will explain everything that failed.
+func CompareDocuments(original, updated Document) (*what_changed.WhatChanged, []error) {
+	return CompareDocumentsWithOptions(original, updated, nil)
+}
+
+// CompareDocumentsWithOptions will compare an original and an updated Document for changes, the same as
+// CompareDocuments. The options configure the comparison, for example BreakingRules decide which changes are
+// breaking. Nil options use the built-in behavior.
+func CompareDocumentsWithOptions(original, updated Document,
+	options *what_changed.CompareOptions) (*what_changed.WhatChanged, []error) {
+	var errors []error
+	if original == nil || updated == nil || original.GetSpecInfo() == nil || updated.GetSpecInfo() == nil {
+		errors = append(errors, fmt.Errorf("unable to compare documents, both documents must be loaded"))
+		return nil, errors
+	}
+	oInfo := original.GetSpecInfo()
+	uInfo := updated.GetSpecInfo()
+	if oInfo.SpecFormat != uInfo.SpecFormat {
+		errors = append(errors, fmt.Errorf("unable to compare documents, original document is '%s' and "+
+			"updated document is '%s', both documents must be the same format", oInfo.SpecFormat, uInfo.SpecFormat))
+		return nil, errors
+	}
+	switch oInfo.SpecFormat {
+	case datamodel.OAS3:
+		oDoc, oErrs := v3low.CreateDocument(oInfo)
+		uDoc, uErrs := v3low.CreateDocument(uInfo)
+		errors = append(errors, oErrs...)
+		errors = append(errors, uErrs...)
+		if len(errors) > 0 {
+			return nil, errors
+		}
+		return what_changed.CompareOpenAPIDocumentsWithOptions(oDoc, uDoc, options), nil
+	case datamodel.OAS2:
+		oDoc, oErrs := v2low.CreateDocument(oInfo)
+		uDoc, uErrs := v2low.CreateDocument(uInfo)
+		errors = append(errors, oErrs...)
+		errors = append(errors, uErrs...)
+		if len(errors) > 0 {
+			return nil, errors
+		}
+		return what_changed.CompareSwaggerDocumentsWithOptions(oDoc, uDoc, options), nil
+	default:
+		errors = append(errors, fmt.Errorf("unable to compare documents, "+
+			"comparing '%s' specifications is not supported", oInfo.SpecFormat))
+		return nil, errors
+	}
 }
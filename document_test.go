@@ -5,9 +5,12 @@ package libopenapi
 
 import (
 	"fmt"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
 	"github.com/pb33f/libopenapi/utils"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
+	"strings"
 	"testing"
 )
 
@@ -149,6 +152,51 @@ info:
 	assert.Equal(t, ymlModified, string(serial))
 }
 
+func TestDocument_Serialize_HighModified(t *testing.T) {
+
+	yml := `openapi: 3.0
+info:
+    title: The magic API # so magic
+paths:
+    /magic:
+        get:
+            description: magic
+`
+	ymlModified := `openapi: 3.0
+info:
+    title: The magic API - but now, altered! # so magic
+paths:
+    /magic:
+        get:
+            description: magic
+    /more-magic:
+        description: even more magic
+`
+	doc, _ := NewDocument([]byte(yml))
+	v3Doc, _ := doc.BuildV3Model()
+
+	v3Doc.Model.Info.Title = "The magic API - but now, altered!"
+	v3Doc.Model.Paths.PathItems["/more-magic"] = &v3high.PathItem{Description: "even more magic"}
+
+	serial, err := doc.Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, ymlModified, string(serial))
+}
+
+func TestDocument_Serialize_V2Modified(t *testing.T) {
+
+	yml := `swagger: 2.0
+host: magic.pb33f.io
+`
+	doc, _ := NewDocument([]byte(yml))
+	v2Doc, _ := doc.BuildV2Model()
+	v2Doc.Model.Host = "more-magic.pb33f.io"
+
+	serial, err := doc.Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, "swagger: 2.0\nhost: more-magic.pb33f.io\n", string(serial))
+}
+
 func TestDocument_Serialize_JSON_Modified(t *testing.T) {
 
 	json := `{ 'openapi': '3.0',
@@ -174,6 +222,26 @@ func TestDocument_Serialize_JSON_Modified(t *testing.T) {
 	assert.Equal(t, jsonModified, string(serial))
 }
 
+func TestDocument_Serialize_JSON_HighModified(t *testing.T) {
+
+	json := `{ 'openapi': '3.0',
+ 'info': {
+   'title': 'The magic API'
+ }
+}
+`
+	jsonModified := `{"info":{"title":"The magic API - but now, altered!"},"openapi":"3.0"}`
+	doc, _ := NewDocument([]byte(json))
+
+	v3Doc, _ := doc.BuildV3Model()
+
+	v3Doc.Model.Info.Title = "The magic API - but now, altered!"
+
+	serial, err := doc.Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, jsonModified, string(serial))
+}
+
 func ExampleNewDocument_fromOpenAPI3Document() {
 
 	// How to read in an OpenAPI 3 Specification, into a Document.
@@ -360,3 +428,112 @@ info:
 	//     license:
 	//         url: https://pb33f.io/license
 }
+
+func TestCompareDocuments_OpenAPI(t *testing.T) {
+
+	original, _ := ioutil.ReadFile("test_specs/burgershop.openapi.yaml")
+	updated := strings.Replace(string(original), "The best burger API at princess beef", "The best burger API ever", 1)
+
+	originalDoc, _ := NewDocument(original)
+	updatedDoc, _ := NewDocument([]byte(updated))
+
+	changes, errs := CompareDocuments(originalDoc, updatedDoc)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, 1, changes.TotalChanges)
+	assert.Equal(t, 1, changes.Modified)
+	assert.NotNil(t, changes.Changes.InfoChanges)
+}
+
+func TestCompareDocuments_Identical(t *testing.T) {
+
+	original, _ := ioutil.ReadFile("test_specs/burgershop.openapi.yaml")
+	originalDoc, _ := NewDocument(original)
+	updatedDoc, _ := NewDocument(original)
+
+	changes, errs := CompareDocuments(originalDoc, updatedDoc)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, 0, changes.TotalChanges)
+	assert.Nil(t, changes.Changes)
+}
+
+func TestCompareDocuments_DifferentFormats(t *testing.T) {
+
+	originalDoc, _ := NewDocument([]byte(`openapi: 3.0.1`))
+	updatedDoc, _ := NewDocument([]byte(`swagger: 2.0`))
+
+	changes, errs := CompareDocuments(originalDoc, updatedDoc)
+	assert.Len(t, errs, 1)
+	assert.Nil(t, changes)
+}
+
+func TestCompareDocuments_NotLoaded(t *testing.T) {
+
+	changes, errs := CompareDocuments(nil, nil)
+	assert.Len(t, errs, 1)
+	assert.Nil(t, changes)
+}
+
+func TestCompareDocuments_Swagger(t *testing.T) {
+
+	original, _ := ioutil.ReadFile("test_specs/petstorev2-complete.yaml")
+	updated := strings.Replace(string(original), "host: petstore.swagger.io", "host: pets.pb33f.io", 1)
+
+	originalDoc, _ := NewDocument(original)
+	updatedDoc, _ := NewDocument([]byte(updated))
+
+	changes, errs := CompareDocuments(originalDoc, updatedDoc)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, 1, changes.TotalChanges)
+	assert.Equal(t, 1, changes.TotalBreakingChanges)
+	assert.Equal(t, 1, changes.Modified)
+	assert.NotNil(t, changes.SwaggerChanges)
+}
+
+func TestCompareDocumentsWithOptions_BreakingRules(t *testing.T) {
+
+	original, _ := ioutil.ReadFile("test_specs/petstorev2-complete.yaml")
+	updated := strings.Replace(string(original), "host: petstore.swagger.io", "host: pets.pb33f.io", 1)
+
+	originalDoc, _ := NewDocument(original)
+	updatedDoc, _ := NewDocument([]byte(updated))
+
+	rules, _ := what_changed.LoadBreakingRules([]byte(`document:
+  host:
+    modified: false`))
+
+	changes, errs := CompareDocumentsWithOptions(originalDoc, updatedDoc,
+		&what_changed.CompareOptions{BreakingRules: rules})
+	assert.Len(t, errs, 0)
+	assert.Equal(t, 1, changes.TotalChanges)
+	assert.Equal(t, 0, changes.TotalBreakingChanges)
+}
+
+func TestDocument_Validate(t *testing.T) {
+
+	spec, _ := ioutil.ReadFile("test_specs/petstorev3.json")
+	doc, _ := NewDocument(spec)
+
+	errs, err := doc.Validate()
+	assert.NoError(t, err)
+	assert.Len(t, errs, 0)
+}
+
+func TestDocument_Validate_Invalid(t *testing.T) {
+
+	doc, _ := NewDocument([]byte(`openapi: 3.0.1
+info:
+  title: burgers
+paths: {}`))
+
+	errs, err := doc.Validate()
+	assert.NoError(t, err)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/info: missing required property 'version', line 2, col 1", errs[0].Error())
+}
+
+func TestDocument_Validate_NoSpec(t *testing.T) {
+
+	doc := new(document) // not how this should be instantiated.
+	_, err := doc.Validate()
+	assert.Error(t, err)
+}
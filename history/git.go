@@ -0,0 +1,155 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gitLogFormat separates the hash, author, date and subject of a commit with NUL characters.
+const gitLogFormat = "--format=%H%x00%an%x00%aI%x00%s"
+
+// GitProvider is a ContentProvider that reads specifications from a local git repository, using the git
+// command line tool.
+type GitProvider struct {
+	// RepositoryPath is the path to the local git repository.
+	RepositoryPath string
+
+	// GitBinary is the git executable to run, defaults to 'git' (found using the PATH).
+	GitBinary string
+}
+
+// NewGitProvider creates a new GitProvider for the local git repository at repositoryPath.
+func NewGitProvider(repositoryPath string) *GitProvider {
+	return &GitProvider{RepositoryPath: repositoryPath}
+}
+
+// Revision resolves a commit hash, branch, tag or any other git revision into a Revision.
+func (g *GitProvider) Revision(revision string) (*Revision, error) {
+	if err := checkGitRevision(revision); err != nil {
+		return nil, err
+	}
+	out, err := g.run("log", "-1", gitLogFormat, revision, "--")
+	if err != nil {
+		return nil, err
+	}
+	revs, err := parseGitLog(out)
+	if err != nil {
+		return nil, err
+	}
+	if len(revs) == 0 {
+		return nil, fmt.Errorf("unable to resolve git revision '%s'", revision)
+	}
+	return revs[0].Revision, nil
+}
+
+// Revisions returns every commit after 'from', up to and including 'to', that changed the file at path. Commits
+// are returned oldest first.
+//
+// Only the first parent of a merge is followed, so each commit is compared to the commit before it on the same
+// line of history (commits on merged branches are seen as the merge commit). The file is followed across renames,
+// and commits that deleted the file are skipped.
+func (g *GitProvider) Revisions(path, from, to string) ([]*Revision, error) {
+	for _, r := range []string{from, to} {
+		if err := checkGitRevision(r); err != nil {
+			return nil, err
+		}
+	}
+	// git cannot follow renames in reverse, so the log is read newest first.
+	out, err := g.run("log", "--first-parent", "--follow", "--name-status", gitLogFormat,
+		fmt.Sprintf("%s..%s", from, to), "--", path)
+	if err != nil {
+		return nil, err
+	}
+	revs, err := parseGitLog(out)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []*Revision
+	for i := len(revs) - 1; i >= 0; i-- {
+		if revs[i].deleted {
+			continue
+		}
+		if revs[i].Path == path {
+			revs[i].Path = ""
+		}
+		revisions = append(revisions, revs[i].Revision)
+	}
+	return revisions, nil
+}
+
+// Content returns the content of the file at path, at the supplied revision.
+func (g *GitProvider) Content(path string, revision *Revision) ([]byte, error) {
+	return g.run("show", fmt.Sprintf("%s:%s", revision.Hash, path))
+}
+
+// checkGitRevision prevents a revision from being read by git as an option.
+func checkGitRevision(revision string) error {
+	if revision == "" || strings.HasPrefix(revision, "-") {
+		return fmt.Errorf("invalid git revision '%s'", revision)
+	}
+	return nil
+}
+
+// run executes git in the repository, returning standard output. If git fails, standard error is returned as
+// an error.
+func (g *GitProvider) run(args ...string) ([]byte, error) {
+	bin := g.GitBinary
+	if bin == "" {
+		bin = "git"
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(bin, append([]string{"-C", g.RepositoryPath}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s failed: %s", strings.Join(args, " "),
+			strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// gitRevision is a revision read from git log, with the file status when git log lists changed files.
+type gitRevision struct {
+	*Revision
+	deleted bool
+}
+
+// parseGitLog parses the output of git log, formatted using gitLogFormat, into revisions. The output of
+// --name-status sets the path of the file at each revision, and whether the revision deleted it.
+func parseGitLog(out []byte) ([]*gitRevision, error) {
+	var revs []*gitRevision
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line == "" {
+			continue
+		}
+		if status := strings.Split(line, "\t"); len(status) > 1 && !strings.Contains(line, "\x00") {
+			if len(revs) == 0 {
+				return nil, fmt.Errorf("unable to parse git log line '%s'", line)
+			}
+			rev := revs[len(revs)-1]
+			rev.Path = status[len(status)-1]
+			rev.deleted = status[0] == "D"
+			if strings.HasPrefix(status[0], "R") && len(status) == 3 {
+				rev.PreviousPath = status[1]
+			}
+			continue
+		}
+		parts := strings.SplitN(line, "\x00", 4)
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("unable to parse git log line '%s'", line)
+		}
+		date, err := time.Parse(time.RFC3339, parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse git commit date '%s': %w", parts[2], err)
+		}
+		revs = append(revs, &gitRevision{Revision: &Revision{Hash: parts[0], Author: parts[1], Date: date,
+			Message: parts[3]}})
+	}
+	return revs, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// createGitRepo creates a temporary git repository, with a commit for every version of a spec.
+func createGitRepo(t *testing.T, specs ...string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	dir := t.TempDir()
+	git := gitCommand(t, dir)
+	git("init", "-q")
+	for i, spec := range specs {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(spec), 0o644))
+		git("add", "openapi.yaml")
+		git("commit", "-q", "-m", "spec "+string(rune('a'+i)))
+		git("tag", "v"+string(rune('0'+i)))
+	}
+	// a commit that does not touch the spec.
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("pizza"), 0o644))
+	git("add", "README.md")
+	git("commit", "-q", "-m", "readme")
+	git("tag", "latest")
+	return dir
+}
+
+// gitCommand returns a function that runs git in dir, as a test user.
+func gitCommand(t *testing.T, dir string) func(args ...string) string {
+	return func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=pb33f",
+			"-c", "user.email=test@pb33f.io", "-c", "commit.gpgsign=false"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+}
+
+func TestGitProvider_CompareHistory(t *testing.T) {
+	dir := createGitRepo(t,
+		pizzaSpec("1.0.0", "getPizza"),
+		pizzaSpec("1.0.1", "getPizza"),
+		pizzaSpec("1.0.1", "fetchPizza"))
+
+	provider := NewGitProvider(dir)
+	comparisons, err := CompareHistory(provider, "openapi.yaml", "v0", "latest")
+	assert.NoError(t, err)
+	assert.Len(t, comparisons, 2)
+	assert.Equal(t, "spec b", comparisons[0].To.Message)
+	assert.Equal(t, "pb33f", comparisons[0].To.Author)
+	assert.Equal(t, 0, comparisons[0].Changes.TotalBreakingChanges)
+	assert.Equal(t, "spec c", comparisons[1].To.Message)
+	assert.Equal(t, 1, comparisons[1].Changes.TotalBreakingChanges)
+}
+
+func TestGitProvider_CompareRevisions(t *testing.T) {
+	dir := createGitRepo(t,
+		pizzaSpec("1.0.0", "getPizza"),
+		pizzaSpec("1.0.1", "fetchPizza"))
+
+	comparison, err := CompareRevisions(NewGitProvider(dir), "openapi.yaml", "v0", "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, comparison.Changes.TotalChanges)
+	assert.Len(t, comparison.From.Hash, 40)
+}
+
+func TestGitProvider_Errors(t *testing.T) {
+	dir := createGitRepo(t, pizzaSpec("1.0.0", "getPizza"))
+	provider := NewGitProvider(dir)
+
+	_, err := provider.Revision("no-such-revision")
+	assert.Error(t, err)
+
+	_, err = provider.Revision("--all")
+	assert.Error(t, err)
+
+	rev, _ := provider.Revision("v0")
+	_, err = provider.Content("missing.yaml", rev)
+	assert.Error(t, err)
+}
+
+func TestGitProvider_CompareHistory_RenamedDeletedMerged(t *testing.T) {
+	dir := createGitRepo(t, pizzaSpec("1.0.0", "getPizza"))
+	git := gitCommand(t, dir)
+	write := func(name, spec string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(spec), 0o644))
+		git("add", name)
+	}
+	git("checkout", "-q", "-b", "trunk")
+
+	// renamed, then changed under the new name.
+	git("mv", "openapi.yaml", "pizza.yaml")
+	git("commit", "-q", "-m", "rename")
+	write("pizza.yaml", pizzaSpec("1.0.1", "getPizza"))
+	git("commit", "-q", "-m", "bump")
+
+	// deleted, then added again.
+	git("rm", "-q", "pizza.yaml")
+	git("commit", "-q", "-m", "delete")
+	write("pizza.yaml", pizzaSpec("1.0.2", "getPizza"))
+	git("commit", "-q", "-m", "restore")
+
+	// changed twice on a branch, then merged.
+	git("checkout", "-q", "-b", "feature")
+	write("pizza.yaml", pizzaSpec("1.0.3", "getPizza"))
+	git("commit", "-q", "-m", "feature a")
+	write("pizza.yaml", pizzaSpec("1.0.3", "fetchPizza"))
+	git("commit", "-q", "-m", "feature b")
+	git("checkout", "-q", "trunk")
+	git("merge", "-q", "--no-ff", "-m", "merge", "feature")
+
+	comparisons, err := CompareHistory(NewGitProvider(dir), "pizza.yaml", "v0", "trunk")
+	assert.NoError(t, err)
+
+	var messages []string
+	for _, c := range comparisons {
+		messages = append(messages, c.To.Message)
+	}
+	assert.Equal(t, []string{"rename", "bump", "restore", "merge"}, messages)
+	assert.Equal(t, "openapi.yaml", comparisons[0].From.Path)
+	assert.Equal(t, 0, comparisons[0].Changes.TotalChanges)
+	assert.Equal(t, 1, comparisons[2].Changes.TotalChanges)
+	assert.Equal(t, 2, comparisons[3].Changes.TotalChanges)
+}
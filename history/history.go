@@ -0,0 +1,147 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package history compares the history of an OpenAPI or Swagger specification. A ContentProvider supplies every
+// revision of a specification file, each revision is built into a Document and compared to the revision before it,
+// producing a sequence of what-changed results.
+//
+// GitProvider reads specifications from a local git repository, any other source of history (or an in-memory
+// repository used by tests) can be supported by implementing ContentProvider.
+package history
+
+import (
+	"fmt"
+	"github.com/pb33f/libopenapi"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"time"
+)
+
+// Revision represents a single revision of a specification file.
+type Revision struct {
+	Hash    string
+	Author  string
+	Message string
+	Date    time.Time
+
+	// Path is the path of the specification file at this revision, if it is not the path being compared (the file
+	// was renamed). An empty Path uses the path being compared.
+	Path string
+
+	// PreviousPath is the path of the specification file before this revision, set when this revision renamed it.
+	PreviousPath string
+}
+
+// ContentProvider supplies the revisions of a specification file, and the content of the file at each revision.
+type ContentProvider interface {
+
+	// Revision resolves a single revision (for example a commit hash, branch or tag).
+	Revision(revision string) (*Revision, error)
+
+	// Revisions returns every revision after 'from' up to and including 'to' that changed the file at path,
+	// ordered from oldest to newest. The 'from' revision itself is not included. Revisions that deleted the file
+	// are not included, and revisions before the file was renamed to path have their Path set.
+	Revisions(path, from, to string) ([]*Revision, error)
+
+	// Content returns the content of the file at path, at the supplied revision.
+	Content(path string, revision *Revision) ([]byte, error)
+}
+
+// Comparison holds the result of comparing a specification at two revisions.
+type Comparison struct {
+	From    *Revision
+	To      *Revision
+	Changes *what_changed.WhatChanged
+}
+
+// CompareRevisions compares the specification at path between the revisions 'from' and 'to', ignoring every
+// revision in between. If the specification cannot be read or built at either revision, an error is returned.
+// The specification must be at path in both revisions.
+func CompareRevisions(provider ContentProvider, path, from, to string) (*Comparison, error) {
+	fromRev, err := provider.Revision(from)
+	if err != nil {
+		return nil, err
+	}
+	toRev, err := provider.Revision(to)
+	if err != nil {
+		return nil, err
+	}
+	fromDoc, err := buildDocument(provider, path, fromRev)
+	if err != nil {
+		return nil, err
+	}
+	toDoc, err := buildDocument(provider, path, toRev)
+	if err != nil {
+		return nil, err
+	}
+	return compare(fromDoc, toDoc, fromRev, toRev)
+}
+
+// CompareHistory compares the specification at path across every revision between 'from' and 'to' that changed
+// it. Each revision is compared to the revision before it, the first comparison is between 'from' and the first
+// revision that changed the file. The comparisons are returned in order, from oldest to newest.
+//
+// If the file was renamed, its history is followed using the path it had at each revision. If the file was
+// deleted and later added again, the revision that added it is compared to the last revision that had it.
+func CompareHistory(provider ContentProvider, path, from, to string) ([]*Comparison, error) {
+	fromRev, err := provider.Revision(from)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := provider.Revisions(path, from, to)
+	if err != nil {
+		return nil, err
+	}
+	// the file may have had a different path at 'from', if it was renamed after it.
+	if len(revisions) > 0 && (revisions[0].Path != "" || revisions[0].PreviousPath != "") {
+		rev := *fromRev
+		rev.Path = revisions[0].Path
+		if revisions[0].PreviousPath != "" {
+			rev.Path = revisions[0].PreviousPath
+		}
+		fromRev = &rev
+	}
+	previousDoc, err := buildDocument(provider, path, fromRev)
+	if err != nil {
+		return nil, err
+	}
+	previousRev := fromRev
+	var comparisons []*Comparison
+	for _, rev := range revisions {
+		doc, dErr := buildDocument(provider, path, rev)
+		if dErr != nil {
+			return comparisons, dErr
+		}
+		c, cErr := compare(previousDoc, doc, previousRev, rev)
+		if cErr != nil {
+			return comparisons, cErr
+		}
+		comparisons = append(comparisons, c)
+		previousDoc, previousRev = doc, rev
+	}
+	return comparisons, nil
+}
+
+// buildDocument reads the specification at a revision and creates a new Document from it.
+func buildDocument(provider ContentProvider, path string, rev *Revision) (libopenapi.Document, error) {
+	if rev.Path != "" {
+		path = rev.Path
+	}
+	content, err := provider.Content(path, rev)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := libopenapi.NewDocument(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build '%s' at revision '%s': %w", path, rev.Hash, err)
+	}
+	return doc, nil
+}
+
+// compare compares two documents, and wraps any errors with the revisions being compared.
+func compare(l, r libopenapi.Document, lRev, rRev *Revision) (*Comparison, error) {
+	changes, errs := libopenapi.CompareDocuments(l, r)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("unable to compare revision '%s' to '%s': %w", lRev.Hash, rRev.Hash, errs[0])
+	}
+	return &Comparison{From: lRev, To: rRev, Changes: changes}, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package history
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// memoryProvider is an in-memory repository, holding a linear history of a single file.
+type memoryProvider struct {
+	revisions []*Revision
+	content   map[string]string
+}
+
+func (m *memoryProvider) commit(content string) {
+	if m.content == nil {
+		m.content = make(map[string]string)
+	}
+	rev := &Revision{Hash: fmt.Sprintf("rev%d", len(m.revisions)), Message: "update"}
+	m.revisions = append(m.revisions, rev)
+	m.content[rev.Hash] = content
+}
+
+func (m *memoryProvider) index(revision string) int {
+	for i := range m.revisions {
+		if m.revisions[i].Hash == revision {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *memoryProvider) Revision(revision string) (*Revision, error) {
+	if i := m.index(revision); i >= 0 {
+		return m.revisions[i], nil
+	}
+	return nil, fmt.Errorf("unknown revision '%s'", revision)
+}
+
+func (m *memoryProvider) Revisions(_, from, to string) ([]*Revision, error) {
+	return m.revisions[m.index(from)+1 : m.index(to)+1], nil
+}
+
+func (m *memoryProvider) Content(_ string, revision *Revision) ([]byte, error) {
+	return []byte(m.content[revision.Hash]), nil
+}
+
+func pizzaSpec(version, operationId string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(`openapi: 3.0.1
+info:
+  title: pizza shop
+  version: VERSION
+paths:
+  /pizza:
+    get:
+      operationId: OPID
+      responses:
+        "200":
+          description: a pizza`, "VERSION", version), "OPID", operationId)
+}
+
+func TestCompareHistory(t *testing.T) {
+	repo := new(memoryProvider)
+	repo.commit(pizzaSpec("1.0.0", "getPizza"))
+	repo.commit(pizzaSpec("1.0.1", "getPizza"))
+	repo.commit(pizzaSpec("1.0.1", "fetchPizza"))
+
+	comparisons, err := CompareHistory(repo, "openapi.yaml", "rev0", "rev2")
+	assert.NoError(t, err)
+	assert.Len(t, comparisons, 2)
+
+	assert.Equal(t, "rev0", comparisons[0].From.Hash)
+	assert.Equal(t, "rev1", comparisons[0].To.Hash)
+	assert.Equal(t, 1, comparisons[0].Changes.TotalChanges)
+	assert.Equal(t, 0, comparisons[0].Changes.TotalBreakingChanges)
+
+	assert.Equal(t, "rev1", comparisons[1].From.Hash)
+	assert.Equal(t, "rev2", comparisons[1].To.Hash)
+	assert.Equal(t, 1, comparisons[1].Changes.TotalChanges)
+	assert.Equal(t, 1, comparisons[1].Changes.TotalBreakingChanges)
+}
+
+func TestCompareRevisions(t *testing.T) {
+	repo := new(memoryProvider)
+	repo.commit(pizzaSpec("1.0.0", "getPizza"))
+	repo.commit(pizzaSpec("1.0.1", "getPizza"))
+	repo.commit(pizzaSpec("1.0.1", "fetchPizza"))
+
+	comparison, err := CompareRevisions(repo, "openapi.yaml", "rev0", "rev2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, comparison.Changes.TotalChanges)
+	assert.Equal(t, 1, comparison.Changes.TotalBreakingChanges)
+}
+
+func TestCompareRevisions_BadRevision(t *testing.T) {
+	repo := new(memoryProvider)
+	repo.commit(pizzaSpec("1.0.0", "getPizza"))
+
+	_, err := CompareRevisions(repo, "openapi.yaml", "rev0", "nope")
+	assert.Error(t, err)
+}
+
+func TestCompareHistory_InvalidSpec(t *testing.T) {
+	repo := new(memoryProvider)
+	repo.commit(pizzaSpec("1.0.0", "getPizza"))
+	repo.commit(pizzaSpec("1.0.1", "getPizza"))
+	repo.commit("I am not a spec")
+
+	comparisons, err := CompareHistory(repo, "openapi.yaml", "rev0", "rev2")
+	assert.Error(t, err)
+	assert.Len(t, comparisons, 1)
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SpecIndexConfig configures how a SpecIndex looks up references to other files and to remote documents.
+//
+// BasePath and BaseURL locate the root document, references in a document that was looked up are always relative to
+// the location of that document. The zero value does not allow any file or remote lookups, use
+// CreateOpenAPIIndexConfig for a configuration that behaves like NewSpecIndex.
+type SpecIndexConfig struct {
+	// BasePath is the directory that file references are relative to. If empty, file references are relative to the
+	// working directory of the process (or the root of FS, if set).
+	BasePath string
+
+	// BaseURL is the location the specification was loaded from. If set, file references are relative to BaseURL
+	// and are fetched as remote documents, so AllowRemoteLookup is required to follow them.
+	BaseURL *url.URL
+
+	// FS is the file system file references are read from. If nil, the file system of the operating system is used.
+	FS fs.FS
+
+	// RemoteFetcher fetches remote documents. If nil, remote documents are fetched using HTTPClient.
+	RemoteFetcher RemoteFetcher
+
+	// HTTPClient is the client used to fetch remote documents when there is no RemoteFetcher. If nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// RemoteTimeout is how long fetching a remote document with HTTPClient can take. Zero means no timeout,
+	// other than any timeout of the client itself. It does not apply to a RemoteFetcher.
+	RemoteTimeout time.Duration
+
+	// AllowRemoteLookup determines if references to remote documents (http and https) are looked up.
+	AllowRemoteLookup bool
+
+	// AllowFileLookup determines if references to other files are looked up.
+	AllowFileLookup bool
+}
+
+// RemoteFetcher fetches the content of a remote document, it can be used to add authentication, caching, or to
+// serve documents from memory.
+type RemoteFetcher interface {
+	Fetch(url string) ([]byte, error)
+}
+
+// RemoteFetcherFunc is a function that can be used as a RemoteFetcher.
+type RemoteFetcherFunc func(url string) ([]byte, error)
+
+// Fetch calls the function.
+func (f RemoteFetcherFunc) Fetch(url string) ([]byte, error) {
+	return f(url)
+}
+
+// CreateOpenAPIIndexConfig creates a configuration that allows file and remote lookups. File references are relative
+// to the working directory, and remote documents are fetched using http.DefaultClient. This is the configuration
+// used by NewSpecIndex.
+func CreateOpenAPIIndexConfig() *SpecIndexConfig {
+	return &SpecIndexConfig{
+		AllowRemoteLookup: true,
+		AllowFileLookup:   true,
+	}
+}
+
+// CreateClosedAPIIndexConfig creates a configuration that does not allow any file or remote lookups, only references
+// local to the specification are looked up.
+func CreateClosedAPIIndexConfig() *SpecIndexConfig {
+	return &SpecIndexConfig{}
+}
+
+// fetchRemote fetches the content of a remote document, using the RemoteFetcher or the HTTPClient.
+func (c *SpecIndexConfig) fetchRemote(uri string) ([]byte, error) {
+	if !c.AllowRemoteLookup {
+		return nil, fmt.Errorf("unable to fetch remote document '%s', remote lookups are not allowed", uri)
+	}
+	if c.RemoteFetcher != nil {
+		return c.RemoteFetcher.Fetch(uri)
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ctx := context.Background()
+	if c.RemoteTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.RemoteTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unable to fetch remote document '%s', status code %d", uri, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// readFile reads the content of a file, from FS or the file system of the operating system. The file has already
+// been resolved against the location of the document that references it.
+func (c *SpecIndexConfig) readFile(file string) ([]byte, error) {
+	if !c.AllowFileLookup {
+		return nil, fmt.Errorf("unable to read file '%s', file lookups are not allowed", file)
+	}
+	if c.FS != nil {
+		// paths in an fs.FS are always slash separated and unrooted.
+		name := strings.TrimPrefix(path.Clean(filepath.ToSlash(file)), "/")
+		return fs.ReadFile(c.FS, name)
+	}
+	return ioutil.ReadFile(file)
+}
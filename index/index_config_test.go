@@ -0,0 +1,128 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var petRefSpec = `openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      $ref: '%s'`
+
+var petFile = `Pet:
+  type: object
+  properties:
+    name:
+      type: string`
+
+func newConfigTestIndex(t *testing.T, ref string, config *SpecIndexConfig) *SpecIndex {
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(petRefSpec, ref)), &rootNode))
+	return NewSpecIndexWithConfig(&rootNode, config)
+}
+
+func TestSpecIndexConfig_FS(t *testing.T) {
+	config := CreateOpenAPIIndexConfig()
+	config.FS = fstest.MapFS{"specs/schemas/pet.yaml": &fstest.MapFile{Data: []byte(petFile)}}
+	config.BasePath = "specs"
+
+	idx := newConfigTestIndex(t, "schemas/pet.yaml#/Pet", config)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.NotNil(t, idx.GetMappedReferences()["schemas/pet.yaml#/Pet"])
+	assert.Len(t, idx.GetAllExternalIndexes(), 1)
+	assert.Equal(t, config, idx.GetAllExternalIndexes()["schemas/pet.yaml"].GetConfig())
+}
+
+func TestSpecIndexConfig_BasePath(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte(petFile), 0664))
+
+	// relative to the working directory, the file cannot be found.
+	idx := newConfigTestIndex(t, "pet.yaml#/Pet", nil)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+
+	config := CreateOpenAPIIndexConfig()
+	config.BasePath = dir
+	idx = newConfigTestIndex(t, "pet.yaml#/Pet", config)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.NotNil(t, idx.GetMappedReferences()["pet.yaml#/Pet"])
+}
+
+func TestSpecIndexConfig_BaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/specs/schemas/pet.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(petFile))
+	}))
+	defer server.Close()
+
+	config := CreateOpenAPIIndexConfig()
+	config.BaseURL, _ = url.Parse(server.URL + "/specs/openapi.yaml")
+	config.HTTPClient = server.Client()
+	idx := newConfigTestIndex(t, "schemas/pet.yaml#/Pet", config)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.NotNil(t, idx.GetMappedReferences()["schemas/pet.yaml#/Pet"])
+
+	// remote references are fetched with the client.
+	idx = newConfigTestIndex(t, server.URL+"/specs/schemas/pet.yaml#/Pet", config)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+
+	idx = newConfigTestIndex(t, server.URL+"/specs/missing.yaml#/Pet", config)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+	assert.Contains(t, idx.GetReferenceIndexErrors()[0].Error.Error(), "status code 404")
+}
+
+func TestSpecIndexConfig_RemoteTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(petFile))
+	}))
+	defer server.Close()
+
+	config := CreateOpenAPIIndexConfig()
+	config.RemoteTimeout = 10 * time.Millisecond
+	idx := newConfigTestIndex(t, server.URL+"/pet.yaml#/Pet", config)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+}
+
+func TestSpecIndexConfig_RemoteFetcher(t *testing.T) {
+	var fetched []string
+	config := CreateOpenAPIIndexConfig()
+	config.RemoteFetcher = RemoteFetcherFunc(func(url string) ([]byte, error) {
+		fetched = append(fetched, url)
+		return []byte(petFile), nil
+	})
+	config.BaseURL, _ = url.Parse("https://pb33f.io/specs/")
+	idx := newConfigTestIndex(t, "../common/pet.yaml#/Pet", config)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Equal(t, []string{"https://pb33f.io/common/pet.yaml"}, fetched)
+}
+
+func TestSpecIndexConfig_Closed(t *testing.T) {
+	config := CreateClosedAPIIndexConfig()
+	config.FS = fstest.MapFS{"pet.yaml": &fstest.MapFile{Data: []byte(petFile)}}
+
+	idx := newConfigTestIndex(t, "pet.yaml#/Pet", config)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+	assert.Contains(t, idx.GetReferenceIndexErrors()[0].Error.Error(), "file lookups are not allowed")
+
+	idx = newConfigTestIndex(t, "https://pb33f.io/pet.yaml#/Pet", config)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+	assert.Contains(t, idx.GetReferenceIndexErrors()[0].Error.Error(), "remote lookups are not allowed")
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"gopkg.in/yaml.v3"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// documentIndexes holds the index of every external document by location. It is shared by an index and every index
+// it creates, so each document is only indexed once.
+type documentIndexes struct {
+	lock    sync.Mutex
+	indexes map[string]*SpecIndex
+}
+
+func newDocumentIndexes() *documentIndexes {
+	return &documentIndexes{indexes: make(map[string]*SpecIndex)}
+}
+
+// rootLocation returns the location of the root document, relative references in the root document are resolved
+// against it. A BasePath is a directory, so it is given a trailing slash.
+func (c *SpecIndexConfig) rootLocation() string {
+	if c.BaseURL != nil {
+		return c.BaseURL.String()
+	}
+	if c.BasePath != "" {
+		return strings.TrimSuffix(filepath.ToSlash(c.BasePath), "/") + "/"
+	}
+	return ""
+}
+
+// isRemoteLocation returns true if a location is an http or https URL.
+func isRemoteLocation(location string) bool {
+	lower := strings.ToLower(location)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// resolveLocation resolves the location of a referenced document (the part of the reference before the '#')
+// against the location of the document that contains the reference, following RFC 3986. An empty reference is the
+// same document. Locations that are not URLs are slash separated file paths, and are resolved the same way, with
+// any dot segments removed.
+func resolveLocation(base, ref string) string {
+	ref = strings.TrimPrefix(ref, "file:")
+	switch {
+	case ref == "":
+		return base
+	case isRemoteLocation(ref):
+		return ref
+	case isRemoteLocation(base):
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return ref
+		}
+		refURL, err := url.Parse(filepath.ToSlash(ref))
+		if err != nil {
+			return ref
+		}
+		return baseURL.ResolveReference(refURL).String()
+	}
+	ref = filepath.ToSlash(ref)
+	if path.IsAbs(ref) || filepath.IsAbs(ref) {
+		return path.Clean(ref)
+	}
+	return path.Join(path.Dir(base), ref)
+}
+
+// documentContent returns the content of a document node, or the node itself if it is not a document.
+func documentContent(root *yaml.Node) *yaml.Node {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+	return root
+}
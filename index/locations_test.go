@@ -0,0 +1,139 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"testing/fstest"
+)
+
+func TestResolveLocation(t *testing.T) {
+	for _, tc := range []struct {
+		base, ref, expected string
+	}{
+		{"", "pet.yaml", "pet.yaml"},
+		{"", "./schemas/../pet.yaml", "pet.yaml"},
+		{"specs/", "schemas/pet.yaml", "specs/schemas/pet.yaml"},
+		{"specs/schemas/pet.yaml", "../common.yaml", "specs/common.yaml"},
+		{"specs/schemas/pet.yaml", "", "specs/schemas/pet.yaml"},
+		{"specs/pet.yaml", "file:owner.yaml", "specs/owner.yaml"},
+		{"../specs/pet.yaml", "../../common.yaml", "../../common.yaml"},
+		{"/specs/schemas/pet.yaml", "./owner.yaml", "/specs/schemas/owner.yaml"},
+		{"specs/pet.yaml", "/common.yaml", "/common.yaml"},
+		{"specs/pet.yaml", "https://pb33f.io/common.yaml", "https://pb33f.io/common.yaml"},
+		{"https://pb33f.io/specs/schemas/pet.yaml", "../common.yaml", "https://pb33f.io/specs/common.yaml"},
+		{"https://pb33f.io/specs/schemas/pet.yaml", "/common.yaml", "https://pb33f.io/common.yaml"},
+		{"https://pb33f.io/specs/", "pet.yaml", "https://pb33f.io/specs/pet.yaml"},
+	} {
+		assert.Equal(t, tc.expected, resolveLocation(tc.base, tc.ref), "%s + %s", tc.base, tc.ref)
+	}
+}
+
+var nestedPetFile = `Pet:
+  type: object
+  properties:
+    error:
+      $ref: '../common.yaml#/Error'
+    owner:
+      $ref: 'owner.yaml'`
+
+var nestedOwnerFile = `type: object
+properties:
+  pets:
+    type: array
+    items:
+      $ref: 'pet.yaml#/Pet'`
+
+var commonFile = `Error:
+  type: string`
+
+func TestSpecIndex_NestedRelativeReferences(t *testing.T) {
+	config := CreateOpenAPIIndexConfig()
+	config.FS = fstest.MapFS{
+		"specs/schemas/pet.yaml":   &fstest.MapFile{Data: []byte(nestedPetFile)},
+		"specs/schemas/owner.yaml": &fstest.MapFile{Data: []byte(nestedOwnerFile)},
+		"specs/common.yaml":        &fstest.MapFile{Data: []byte(commonFile)},
+	}
+	config.BasePath = "specs"
+
+	idx := newConfigTestIndex(t, "schemas/pet.yaml#/Pet", config)
+	assert.Equal(t, "specs/", idx.GetLocation())
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+
+	pet := idx.GetAllExternalIndexes()["schemas/pet.yaml"]
+	assert.Equal(t, "specs/schemas/pet.yaml", pet.GetLocation())
+	assert.Empty(t, pet.GetReferenceIndexErrors())
+
+	// the nested reference is relative to the document it is in.
+	errorRef := pet.GetMappedReferences()["../common.yaml#/Error"]
+	assert.NotNil(t, errorRef)
+	assert.Equal(t, "specs/common.yaml#/Error", errorRef.RemoteLocation)
+	assert.Equal(t, "Error", errorRef.Name)
+
+	// a reference without a fragment is the whole document.
+	ownerRef := pet.GetMappedReferences()["owner.yaml"]
+	assert.NotNil(t, ownerRef)
+	assert.Equal(t, yaml.MappingNode, ownerRef.Node.Kind)
+	assert.Equal(t, "owner.yaml", ownerRef.Name)
+
+	// documents that reference each other are only indexed once.
+	owner := pet.GetAllExternalIndexes()["owner.yaml"]
+	assert.Empty(t, owner.GetReferenceIndexErrors())
+	assert.Same(t, pet, owner.GetAllExternalIndexes()["pet.yaml"])
+
+	// a reference node is found in the document that contains it, however deeply nested.
+	for _, doc := range []*SpecIndex{idx, pet, owner} {
+		for _, ref := range doc.GetAllSequencedReferences() {
+			assert.Same(t, doc, idx.FindReferenceIndex(ref.Node))
+		}
+	}
+	assert.Nil(t, idx.FindReferenceIndex(&yaml.Node{}))
+
+	// components in indexed documents are found relative to the document, without looking anything up.
+	found, foundIndex := pet.FindIndexedComponent("../common.yaml#/Error")
+	assert.NotNil(t, found)
+	assert.Equal(t, "specs/common.yaml", foundIndex.GetLocation())
+	found, foundIndex = owner.FindIndexedComponent("pet.yaml")
+	assert.Equal(t, yaml.MappingNode, found.Node.Kind)
+	assert.Same(t, pet, foundIndex)
+	found, _ = idx.FindIndexedComponent("../common.yaml#/Error")
+	assert.Nil(t, found)
+}
+
+func TestSpecIndex_NestedRemoteReferences(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch r.URL.Path {
+		case "/specs/schemas/pet.yaml":
+			_, _ = w.Write([]byte(nestedPetFile))
+		case "/specs/schemas/owner.yaml":
+			_, _ = w.Write([]byte(nestedOwnerFile))
+		case "/specs/common.yaml":
+			_, _ = w.Write([]byte(commonFile))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := CreateOpenAPIIndexConfig()
+	config.BaseURL, _ = url.Parse(server.URL + "/specs/openapi.yaml")
+	idx := newConfigTestIndex(t, "schemas/pet.yaml#/Pet", config)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+
+	pet := idx.GetAllExternalIndexes()["schemas/pet.yaml"]
+	assert.Empty(t, pet.GetReferenceIndexErrors())
+	assert.Equal(t, server.URL+"/specs/common.yaml#/Error",
+		pet.GetMappedReferences()["../common.yaml#/Error"].RemoteLocation)
+
+	// every document is only fetched once.
+	assert.ElementsMatch(t, []string{"/specs/schemas/pet.yaml", "/specs/common.yaml", "/specs/schemas/owner.yaml"},
+		requested)
+}
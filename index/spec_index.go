@@ -18,8 +18,7 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
 	"gopkg.in/yaml.v3"
-	"io/ioutil"
-	"net/http"
+	"path"
 	"strings"
 	"sync"
 )
@@ -135,6 +134,8 @@ type SpecIndex struct {
 	allLinks                            map[string]*Reference                       // all links
 	callbacksNode                       *yaml.Node                                  // components/callbacks node
 	allCallbacks                        map[string]*Reference                       // all components examples
+	pathItemsNode                       *yaml.Node                                  // components/pathItems node (3.1)
+	allComponentPathItems               map[string]*Reference                       // all components path items (3.1)
 	externalDocumentsNode               *yaml.Node                                  // external documents node
 	allExternalDocuments                map[string]*Reference                       // all external documents
 	externalSpecIndex                   map[string]*SpecIndex                       // create a primary index of all external specs and componentIds
@@ -150,6 +151,9 @@ type SpecIndex struct {
 	remoteLock                          sync.Mutex
 	circularReferences                  []*CircularReferenceResult // only available when the resolver has been used.
 	allowCircularReferences             bool                       // decide if you want to error out, or allow circular references, default is false.
+	config                              *SpecIndexConfig           // how file and remote references are looked up.
+	location                            string                     // where the document lives, references are relative to it.
+	documents                           *documentIndexes           // every external document indexed, shared by all indexes.
 }
 
 // ExternalLookupFunction is for lookup functions that take a JSONSchema reference and tries to find that node in the
@@ -191,9 +195,32 @@ func runIndexFunction(funcs []func() int, wg *sync.WaitGroup) {
 // NewSpecIndex will create a new index of an OpenAPI or Swagger spec. It's not resolved or converted into anything
 // other than a raw index of every node for every content type in the specification. This process runs as fast as
 // possible so dependencies looking through the tree, don't need to walk the entire thing over, and over.
+//
+// File references are looked up relative to the working directory, and remote references are fetched using
+// http.DefaultClient. Use NewSpecIndexWithConfig to control how (and if) references are looked up.
 func NewSpecIndex(rootNode *yaml.Node) *SpecIndex {
+	return NewSpecIndexWithConfig(rootNode, CreateOpenAPIIndexConfig())
+}
 
+// NewSpecIndexWithConfig will create a new index of an OpenAPI or Swagger spec, see NewSpecIndex. The config
+// determines where file references are read from, how remote references are fetched, and if either are looked up at
+// all. The same config is used by the indexes created for every file and remote document that is looked up. A nil
+// config is the same as CreateOpenAPIIndexConfig.
+func NewSpecIndexWithConfig(rootNode *yaml.Node, config *SpecIndexConfig) *SpecIndex {
+	if config == nil {
+		config = CreateOpenAPIIndexConfig()
+	}
+	index := newSpecIndex(rootNode, config, config.rootLocation(), newDocumentIndexes())
+	index.build()
+	return index
+}
+
+// newSpecIndex creates a new (empty) index of the document found at a location, build will index it.
+func newSpecIndex(rootNode *yaml.Node, config *SpecIndexConfig, location string, documents *documentIndexes) *SpecIndex {
 	index := new(SpecIndex)
+	index.config = config
+	index.location = location
+	index.documents = documents
 	index.root = rootNode
 	index.allRefs = make(map[string]*Reference)
 	index.allMappedRefs = make(map[string]*Reference)
@@ -226,16 +253,22 @@ func NewSpecIndex(rootNode *yaml.Node) *SpecIndex {
 	index.allExamples = make(map[string]*Reference)
 	index.allLinks = make(map[string]*Reference)
 	index.allCallbacks = make(map[string]*Reference)
+	index.allComponentPathItems = make(map[string]*Reference)
 	index.allExternalDocuments = make(map[string]*Reference)
 	index.securityRequirementRefs = make(map[string]map[string][]*Reference)
 	index.polymorphicRefs = make(map[string]*Reference)
 	index.refsWithSiblings = make(map[string]Reference)
 	index.seenRemoteSources = make(map[string]*yaml.Node)
 	index.opServersRefs = make(map[string]map[string][]*Reference)
+	return index
+}
+
+// build indexes the document, every external document that is referenced is looked up and indexed as well.
+func (index *SpecIndex) build() {
 
 	// there is no node! return an empty index.
-	if rootNode == nil {
-		return index
+	if index.root == nil {
+		return
 	}
 
 	// boot index.
@@ -275,8 +308,27 @@ func NewSpecIndex(rootNode *yaml.Node) *SpecIndex {
 	index.GetInlineDuplicateParamCount()
 	index.GetAllDescriptionsCount()
 	index.GetTotalTagsCount()
+}
 
-	return index
+// GetConfig returns the configuration used to look up file and remote references.
+func (index *SpecIndex) GetConfig() *SpecIndexConfig {
+	return index.getConfig()
+}
+
+// getConfig returns the config of the index, an index that was not created by NewSpecIndexWithConfig behaves as if
+// it was created by NewSpecIndex.
+func (index *SpecIndex) getConfig() *SpecIndexConfig {
+	if index.config == nil {
+		return CreateOpenAPIIndexConfig()
+	}
+	return index.config
+}
+
+// GetLocation returns the location of the document that was indexed, either a URL or a (slash separated) file path.
+// Relative references in the document are resolved against it. The location of a root document is the BaseURL or
+// BasePath of the config, and is empty if neither are set.
+func (index *SpecIndex) GetLocation() string {
+	return index.location
 }
 
 // GetRootNode returns document root node.
@@ -436,6 +488,11 @@ func (index *SpecIndex) GetAllCallbacks() map[string]*Reference {
 	return index.allCallbacks
 }
 
+// GetAllComponentPathItems will return all path items found in the document (under components, 3.1)
+func (index *SpecIndex) GetAllComponentPathItems() map[string]*Reference {
+	return index.allComponentPathItems
+}
+
 // GetInlineOperationDuplicateParameters will return a map of duplicates located in operation parameters.
 func (index *SpecIndex) GetInlineOperationDuplicateParameters() map[string][]*Reference {
 	return index.paramInlineDuplicates
@@ -526,6 +583,75 @@ func (index *SpecIndex) GetAllExternalIndexes() map[string]*SpecIndex {
 	return index.externalSpecIndex
 }
 
+// FindReferenceIndex returns the index of the document that contains a reference node (the map that holds the $ref),
+// references in a document are relative to that document. This index is searched, and then the index of every
+// external document, however deeply nested. Returns nil if the node is not in any indexed document.
+func (index *SpecIndex) FindReferenceIndex(refNode *yaml.Node) *SpecIndex {
+	return index.findReferenceIndex(refNode, make(map[*SpecIndex]bool))
+}
+
+// FindIndexedComponent returns the component a reference in this document points to, and the index of the document
+// the component is in. The reference is relative to this document. Unlike FindComponent, nothing is looked up and
+// the index is not changed, so a reference to another document is only found if that document is already indexed.
+// Returns nil if the component cannot be found.
+func (index *SpecIndex) FindIndexedComponent(componentId string) (*Reference, *SpecIndex) {
+	file, fragment := componentId, ""
+	if i := strings.Index(componentId, "#"); i >= 0 {
+		file, fragment = componentId[:i], componentId[i+1:]
+	}
+	target := index
+	if file != "" {
+		target = nil
+		if index.documents != nil {
+			index.documents.lock.Lock()
+			target = index.documents.indexes[resolveLocation(index.location, file)]
+			index.documents.lock.Unlock()
+		}
+		if target == nil {
+			target = index.externalSpecIndex[file]
+		}
+	}
+	if target == nil || target.root == nil {
+		return nil, nil
+	}
+	if strings.Trim(fragment, "/") == "" {
+		if file == "" {
+			return nil, nil
+		}
+		return &Reference{
+			Definition: componentId,
+			Name:       path.Base(target.location),
+			Node:       documentContent(target.root),
+			IsRemote:   true,
+		}, target
+	}
+	found := target.FindComponentInRoot("#" + fragment)
+	if found == nil {
+		return nil, nil
+	}
+	found.Definition = componentId
+	found.IsRemote = file != ""
+	return found, target
+}
+
+func (index *SpecIndex) findReferenceIndex(refNode *yaml.Node, seen map[*SpecIndex]bool) *SpecIndex {
+	if seen[index] {
+		return nil
+	}
+	seen[index] = true
+	for _, ref := range index.rawSequencedRefs {
+		if ref.Node == refNode {
+			return index
+		}
+	}
+	for _, extIndex := range index.externalSpecIndex {
+		if found := extIndex.findReferenceIndex(refNode, seen); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 // SetAllowCircularReferenceResolving will flip a bit that can be used by any consumers to determine if they want
 // to allow or disallow circular references to be resolved or visited
 func (index *SpecIndex) SetAllowCircularReferenceResolving(allow bool) {
@@ -1121,6 +1247,7 @@ func (index *SpecIndex) GetComponentSchemaCount() int {
 				_, examplesNode := utils.FindKeyNode("examples", index.root.Content[0].Content[i+1].Content)
 				_, linksNode := utils.FindKeyNode("links", index.root.Content[0].Content[i+1].Content)
 				_, callbacksNode := utils.FindKeyNode("callbacks", index.root.Content[0].Content[i+1].Content)
+				_, pathItemsNode := utils.FindKeyNode("pathItems", index.root.Content[0].Content[i+1].Content)
 
 				// extract schemas
 				if schemasNode != nil {
@@ -1177,6 +1304,12 @@ func (index *SpecIndex) GetComponentSchemaCount() int {
 					index.callbacksNode = callbacksNode
 				}
 
+				// extract path items (3.1)
+				if pathItemsNode != nil {
+					index.extractComponentPathItems(pathItemsNode, "#/components/pathItems/")
+					index.pathItemsNode = pathItemsNode
+				}
+
 			}
 
 			// swagger
@@ -1279,7 +1412,7 @@ func (index *SpecIndex) GetOperationCount() int {
 	for x, p := range index.pathsNode.Content {
 		if x%2 == 0 {
 
-			method := index.pathsNode.Content[x+1]
+			method := index.resolvePathItem(index.pathsNode.Content[x+1])
 
 			// extract methods for later use.
 			for y, m := range method.Content {
@@ -1339,7 +1472,7 @@ func (index *SpecIndex) GetOperationsParameterCount() int {
 	for x, pathItemNode := range index.pathsNode.Content {
 		if x%2 == 0 {
 
-			pathPropertyNode := index.pathsNode.Content[x+1]
+			pathPropertyNode := index.resolvePathItem(index.pathsNode.Content[x+1])
 
 			// extract methods for later use.
 			for y, prop := range pathPropertyNode.Content {
@@ -1557,19 +1690,20 @@ func (index *SpecIndex) FindComponent(componentId string, parent *yaml.Node) *Re
 		return index.lookupFileReference(id)
 	}
 
+	// a reference without a fragment is a reference to an entire document.
 	switch DetermineReferenceResolveType(componentId) {
 	case LocalResolve: // ideally, every single ref in every single spec is local. however, this is not the case.
 		return index.FindComponentInRoot(componentId)
 
 	case HttpResolve:
 		uri := strings.Split(componentId, "#")
-		if len(uri) == 2 {
+		if len(uri) <= 2 {
 			return index.performExternalLookup(uri, componentId, remoteLookup, parent)
 		}
 
 	case FileResolve:
 		uri := strings.Split(componentId, "#")
-		if len(uri) == 2 {
+		if len(uri) <= 2 {
 			return index.performExternalLookup(uri, componentId, fileLookup, parent)
 		}
 	}
@@ -1705,6 +1839,23 @@ func (index *SpecIndex) extractComponentCallbacks(callbacksNode *yaml.Node, path
 	}
 }
 
+func (index *SpecIndex) extractComponentPathItems(pathItemsNode *yaml.Node, pathPrefix string) {
+	var name string
+	for i, pathItem := range pathItemsNode.Content {
+		if i%2 == 0 {
+			name = pathItem.Value
+			continue
+		}
+		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		ref := &Reference{
+			Definition: def,
+			Name:       name,
+			Node:       pathItem,
+		}
+		index.allComponentPathItems[def] = ref
+	}
+}
+
 func (index *SpecIndex) extractComponentLinks(linksNode *yaml.Node, pathPrefix string) {
 	var name string
 	for i, link := range linksNode.Content {
@@ -1756,11 +1907,27 @@ func (index *SpecIndex) extractComponentSecuritySchemes(securitySchemesNode *yam
 	}
 }
 
+// performExternalLookup looks up a reference to another document. The document is located relative to the location
+// of this document (see RFC 3986), and indexed once, so any references it has are relative to its own location.
 func (index *SpecIndex) performExternalLookup(uri []string, componentId string,
 	lookupFunction ExternalLookupFunction, parent *yaml.Node) *Reference {
 
 	if len(uri) > 0 {
+		fragment := ""
+		if len(uri) > 1 {
+			fragment = uri[1]
+		}
+		location := resolveLocation(index.location, uri[0])
 		externalSpecIndex := index.externalSpecIndex[uri[0]]
+		if externalSpecIndex == nil && index.documents != nil {
+			// the document may already have been indexed, by another document that references it.
+			index.documents.lock.Lock()
+			externalSpecIndex = index.documents.indexes[location]
+			index.documents.lock.Unlock()
+			if externalSpecIndex != nil {
+				index.externalSpecIndex[uri[0]] = externalSpecIndex
+			}
+		}
 		var foundNode *yaml.Node
 		if externalSpecIndex == nil {
 
@@ -1782,25 +1949,34 @@ func (index *SpecIndex) performExternalLookup(uri []string, componentId string,
 
 			// cool, cool, lets index this spec also. This is a recursive action and will keep going
 			// until all remote references have been found.
-			newIndex := NewSpecIndex(newRoot)
-			index.externalSpecIndex[uri[0]] = newIndex
+			index.externalSpecIndex[uri[0]] = index.indexDocument(newRoot, location)
 
+		} else if fragment == "" {
+			foundNode = documentContent(externalSpecIndex.root)
 		} else {
 
-			foundRef := externalSpecIndex.FindComponentInRoot(uri[1])
+			foundRef := externalSpecIndex.FindComponentInRoot(fragment)
 			if foundRef != nil {
 				foundNode = foundRef.Node
 			}
 		}
 
 		if foundNode != nil {
-			nameSegs := strings.Split(uri[1], "/")
+			name := path.Base(location)
+			if fragment != "" {
+				nameSegs := strings.Split(fragment, "/")
+				name = nameSegs[len(nameSegs)-1]
+			}
+			remoteLocation := location
+			if len(uri) > 1 {
+				remoteLocation = location + "#" + fragment
+			}
 			ref := &Reference{
 				Definition:     componentId,
-				Name:           nameSegs[len(nameSegs)-1],
+				Name:           name,
 				Node:           foundNode,
 				IsRemote:       true,
-				RemoteLocation: componentId,
+				RemoteLocation: remoteLocation,
 			}
 			return ref
 		}
@@ -1808,6 +1984,25 @@ func (index *SpecIndex) performExternalLookup(uri []string, componentId string,
 	return nil
 }
 
+// indexDocument returns the index of an external document found at a location. Each document is only indexed once,
+// which also stops documents that reference each other from being indexed forever.
+func (index *SpecIndex) indexDocument(root *yaml.Node, location string) *SpecIndex {
+	documents := index.documents
+	if documents == nil {
+		documents = newDocumentIndexes()
+	}
+	documents.lock.Lock()
+	if existing := documents.indexes[location]; existing != nil {
+		documents.lock.Unlock()
+		return existing
+	}
+	newIndex := newSpecIndex(root, index.getConfig(), location, documents)
+	documents.indexes[location] = newIndex
+	documents.lock.Unlock()
+	newIndex.build()
+	return newIndex
+}
+
 func (index *SpecIndex) FindComponentInRoot(componentId string) *Reference {
 	if index.root != nil {
 		name, friendlySearch := utils.ConvertComponentIdIntoFriendlyPathSearch(componentId)
@@ -1828,6 +2023,17 @@ func (index *SpecIndex) FindComponentInRoot(componentId string) *Reference {
 	return nil
 }
 
+// resolvePathItem returns the path item node a reference points to, a path item can be a reference to a path
+// item defined in components (3.1). If the node is not a reference, or it cannot be found, the node is returned.
+func (index *SpecIndex) resolvePathItem(pathItem *yaml.Node) *yaml.Node {
+	if ok, _, ref := utils.IsNodeRefValue(pathItem); ok {
+		if mapped := index.allMappedRefs[ref]; mapped != nil && mapped.Node != nil {
+			return mapped.Node
+		}
+	}
+	return pathItem
+}
+
 func (index *SpecIndex) countUniqueInlineDuplicates() int {
 	if index.componentsInlineParamUniqueCount > 0 {
 		return index.componentsInlineParamUniqueCount
@@ -1940,70 +2146,43 @@ func isHttpMethod(val string) bool {
 	return false
 }
 
+// lookupRemoteReference looks up a reference to a remote document, nothing is returned if the reference cannot be
+// found in the document.
 func (index *SpecIndex) lookupRemoteReference(ref string) (*yaml.Node, *yaml.Node, error) {
-
-	// split string to remove file reference
-	uri := strings.Split(ref, "#")
-
-	var parsedRemoteDocument *yaml.Node
-	if index.seenRemoteSources[uri[0]] != nil {
-		parsedRemoteDocument = index.seenRemoteSources[uri[0]]
-	} else {
-		resp, err := http.Get(uri[0])
-		if err != nil {
-			return nil, nil, err
-		}
-		body, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		var remoteDoc yaml.Node
-		err = yaml.Unmarshal(body, &remoteDoc)
-		if err != nil {
-			return nil, nil, err
-		}
-		parsedRemoteDocument = &remoteDoc
-		index.remoteLock.Lock()
-		index.seenRemoteSources[uri[0]] = &remoteDoc
-		index.remoteLock.Unlock()
-	}
-
-	// lookup item from reference by using a path query.
-	query := fmt.Sprintf("$%s", strings.ReplaceAll(uri[1], "/", "."))
-
-	// remove any URL encoding
-	query = strings.Replace(query, "~1", "./", 1)
-	query = strings.ReplaceAll(query, "~1", "/")
-
-	path, err := yamlpath.NewPath(query)
-	if err != nil {
+	node, doc, err := index.lookupExternalReference(ref)
+	if node == nil {
 		return nil, nil, err
 	}
-	result, _ := path.Find(parsedRemoteDocument)
-	if len(result) == 1 {
-		return result[0], parsedRemoteDocument, nil
-	}
-	return nil, nil, nil
+	return node, doc, nil
 }
 
+// lookupFileReference looks up a reference to a file, the document is returned even if the reference cannot be
+// found in it. Files referenced from a remote document are remote themselves, and are fetched.
 func (index *SpecIndex) lookupFileReference(ref string) (*yaml.Node, *yaml.Node, error) {
+	return index.lookupExternalReference(ref)
+}
 
-	// split string to remove file reference
-	uri := strings.Split(ref, "#")
-
-	if len(uri) != 2 {
-		return nil, nil, fmt.Errorf("unable to determine filename for file reference: '%s'", ref)
-	}
-
-	file := strings.ReplaceAll(uri[0], "file:", "")
-
-	var parsedRemoteDocument *yaml.Node
-	if index.seenRemoteSources[file] != nil {
-		parsedRemoteDocument = index.seenRemoteSources[file]
-	} else {
+// lookupExternalReference looks up a reference to another document, which is either fetched remotely or read from
+// a file (depending on where it is located, relative to this document). Each document is only fetched or read once.
+// If the reference has no fragment, the entire document is returned.
+func (index *SpecIndex) lookupExternalReference(ref string) (*yaml.Node, *yaml.Node, error) {
 
-		body, err := ioutil.ReadFile(file)
+	// split string to remove file reference
+	file, fragment, _ := strings.Cut(ref, "#")
+	location := resolveLocation(index.location, file)
+
+	index.remoteLock.Lock()
+	parsedRemoteDocument := index.seenRemoteSources[location]
+	index.remoteLock.Unlock()
+
+	if parsedRemoteDocument == nil {
+		var body []byte
+		var err error
+		if isRemoteLocation(location) {
+			body, err = index.getConfig().fetchRemote(location)
+		} else {
+			body, err = index.getConfig().readFile(location)
+		}
 		if err != nil {
 			return nil, nil, err
 		}
@@ -2014,11 +2193,21 @@ func (index *SpecIndex) lookupFileReference(ref string) (*yaml.Node, *yaml.Node,
 			return nil, nil, err
 		}
 		parsedRemoteDocument = &remoteDoc
-		index.seenRemoteSources[file] = &remoteDoc
+		index.remoteLock.Lock()
+		if index.seenRemoteSources == nil {
+			index.seenRemoteSources = make(map[string]*yaml.Node)
+		}
+		index.seenRemoteSources[location] = &remoteDoc
+		index.remoteLock.Unlock()
+	}
+
+	// a reference to an entire document.
+	if fragment == "" {
+		return documentContent(parsedRemoteDocument), parsedRemoteDocument, nil
 	}
 
 	// lookup item from reference by using a path query.
-	query := fmt.Sprintf("$%s", strings.ReplaceAll(uri[1], "/", "."))
+	query := fmt.Sprintf("$%s", strings.ReplaceAll(fragment, "/", "."))
 
 	// remove any URL encoding
 	query = strings.Replace(query, "~1", "./", 1)
@@ -2032,6 +2221,5 @@ func (index *SpecIndex) lookupFileReference(ref string) (*yaml.Node, *yaml.Node,
 	if len(result) == 1 {
 		return result[0], parsedRemoteDocument, nil
 	}
-
 	return nil, parsedRemoteDocument, nil
 }
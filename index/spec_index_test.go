@@ -438,6 +438,36 @@ paths:
 	assert.Equal(t, 4, index.GetGlobalCallbacksCount())
 }
 
+func TestSpecIndex_ComponentPathItems(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  pathItems:
+    Pets:
+      get:
+        description: list pets
+        parameters:
+          - name: limit
+            in: query
+      post:
+        description: create pet
+paths:
+  /pets:
+    $ref: '#/components/pathItems/Pets'
+  /cats:
+    get:
+      description: list cats`
+
+	var rootNode yaml.Node
+	yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndex(&rootNode)
+	assert.Len(t, index.GetAllComponentPathItems(), 1)
+	assert.NotNil(t, index.GetAllComponentPathItems()["#/components/pathItems/Pets"])
+	assert.Equal(t, 3, index.GetOperationCount())
+	assert.Equal(t, 1, index.GetOperationsParameterCount())
+}
+
 func TestSpecIndex_ExtractComponentsFromRefs(t *testing.T) {
 	yml := `components:
   schemas:
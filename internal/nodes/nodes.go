@@ -0,0 +1,69 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package nodes holds helpers for reading, creating and copying yaml.Node trees, that are shared by the bundler
+// and the converter.
+package nodes
+
+import (
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// MapValue returns the value of a key in a mapping node, or nil if the key does not exist.
+func MapValue(node *yaml.Node, key string) *yaml.Node {
+	node = Resolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	_, value := utils.FindKeyNodeTop(key, node.Content)
+	return Resolve(value)
+}
+
+// Resolve unwraps document and alias nodes.
+func Resolve(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) > 0:
+			node = node.Content[0]
+		case node.Kind == yaml.AliasNode && node.Alias != nil:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// NewString creates a string scalar node.
+func NewString(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// Clone creates a deep copy of a node, aliases are replaced with copies of the nodes they point to (a document node
+// is copied as a document). Flow and quoted styles are dropped (JSON specifications would otherwise render as JSON
+// inside YAML), strings that need quotes are still quoted when the node is encoded. Every $ref found is rewritten
+// using rewrite (which may be nil).
+func Clone(node *yaml.Node, rewrite func(ref string) string) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	if node == nil {
+		return nil
+	}
+	c := *node
+	c.Anchor = ""
+	c.Style &^= yaml.FlowStyle | yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i, n := range node.Content {
+		c.Content[i] = Clone(n, rewrite)
+	}
+	if rewrite != nil && c.Kind == yaml.MappingNode {
+		for i := 0; i < len(c.Content)-1; i += 2 {
+			if c.Content[i].Value == "$ref" && c.Content[i+1].Kind == yaml.ScalarNode {
+				c.Content[i+1].Value = rewrite(c.Content[i+1].Value)
+			}
+		}
+	}
+	return &c
+}
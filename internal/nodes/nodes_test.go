@@ -0,0 +1,46 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package nodes
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestMapValue(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(`burger: &burger
+  name: title
+  cheese: true
+alias: *burger`), &root))
+
+	// documents and aliases are unwrapped, only the keys of the mapping itself are looked up.
+	assert.Equal(t, "true", MapValue(MapValue(&root, "alias"), "cheese").Value)
+	assert.Nil(t, MapValue(&root, "title"))
+	assert.Nil(t, MapValue(&root, "name"))
+	assert.Nil(t, MapValue(MapValue(MapValue(&root, "burger"), "name"), "name"))
+	assert.Nil(t, MapValue(nil, "burger"))
+}
+
+func TestClone(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(`{"pet": &pet {"$ref": "pet.yaml"}, "alias": *pet}`), &root))
+
+	cloned := Clone(&root, func(ref string) string {
+		return "#/components/schemas/Pet"
+	})
+	assert.Equal(t, yaml.DocumentNode, cloned.Kind)
+	out, _ := yaml.Marshal(cloned)
+	assert.Equal(t, `pet:
+    $ref: '#/components/schemas/Pet'
+alias:
+    $ref: '#/components/schemas/Pet'
+`, string(out))
+
+	// the original is not changed.
+	assert.Equal(t, "pet.yaml", MapValue(MapValue(&root, "pet"), "$ref").Value)
+	assert.Nil(t, Clone(nil, nil))
+	assert.Equal(t, "!!str", NewString("pet").Tag)
+}
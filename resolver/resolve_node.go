@@ -0,0 +1,100 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"fmt"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ResolveNode creates a standalone, resolved copy of a node from the specification, such as a single operation or
+// schema. Every reference beneath the node is resolved (local, file and remote, using the index), the node is not
+// changed. References are relative to the root document of the index, to resolve a node from another document, use
+// a resolver for the index of that document (from GetAllExternalIndexes). Polymorphic references are not looked up
+// when the index is built, any that point to a document that has not been indexed are looked up, which adds it to
+// the index.
+//
+// Circular references are handled as configured by config (see ResolveCopy), every circular reference found is
+// returned, and resolving errors include them as well. When config is CircularReferencesError, no copy is returned
+// if there are any. Nothing is recorded with the resolver, so nodes can be resolved any number of times.
+func (resolver *Resolver) ResolveNode(node *yaml.Node,
+	config *CopyConfig) (*yaml.Node, []*index.CircularReferenceResult, []*ResolvingError) {
+	c := newCopier(resolver, config)
+	resolved := c.copy(node, resolver.specIndex)
+	errs := c.finish()
+	if c.failed {
+		return nil, c.results, errs
+	}
+	return resolved, c.results, errs
+}
+
+// ResolvePointer creates a standalone, resolved copy of the node a JSON pointer points to in the root document of the
+// specification, such as '/paths/~1pets/get' or '#/components/schemas/Pet'. It works the same way as ResolveNode.
+func (resolver *Resolver) ResolvePointer(pointer string,
+	config *CopyConfig) (*yaml.Node, []*index.CircularReferenceResult, []*ResolvingError) {
+	node := findPointer(resolver.specIndex.GetRootNode(), pointer)
+	if node == nil {
+		_, path := utils.ConvertComponentIdIntoFriendlyPathSearch("#" + strings.TrimPrefix(pointer, "#"))
+		return nil, nil, []*ResolvingError{{
+			Error: fmt.Errorf("cannot resolve pointer `%s`, it's missing", pointer),
+			Path:  path,
+		}}
+	}
+	return resolver.ResolveNode(node, config)
+}
+
+// findPointer returns the node a JSON pointer points to, or nil if there is no such node. A pointer that starts with
+// a '#' is a URI fragment, and is unescaped first.
+func findPointer(root *yaml.Node, pointer string) *yaml.Node {
+	if strings.HasPrefix(pointer, "#") {
+		pointer = pointer[1:]
+		if unescaped, err := url.PathUnescape(pointer); err == nil {
+			pointer = unescaped
+		}
+	}
+	node := root
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if pointer == "" {
+		return node
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil
+	}
+	for _, segment := range strings.Split(pointer[1:], "/") {
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		for node != nil && node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		if node == nil {
+			return nil
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			var found *yaml.Node
+			for i := 0; i < len(node.Content)-1; i += 2 {
+				if node.Content[i].Value == segment {
+					found = node.Content[i+1]
+					break
+				}
+			}
+			node = found
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[i]
+		default:
+			return nil
+		}
+	}
+	return node
+}
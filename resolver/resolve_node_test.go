@@ -0,0 +1,89 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestResolver_ResolvePointer(t *testing.T) {
+	resolver, original := newCircularTestResolver(t)
+
+	resolved, circular, errs := resolver.ResolvePointer("/paths/~1burgers/post", nil)
+	assert.Empty(t, circular)
+	assert.Empty(t, errs)
+	assert.Equal(t, "done.",
+		findCopiedNode(t, resolved, "$.requestBody.content.application/json.schema.description").Value)
+
+	// a fragment works as well, and circular references are reported along the way.
+	resolved, circular, errs = resolver.ResolvePointer("#/components/schemas/One", nil)
+	assert.Len(t, errs, 1)
+	assert.Len(t, circular, 1)
+	assert.Equal(t, "Two -> One -> Two", circular[0].GenerateJourneyPath())
+	assert.Equal(t, "Two", circular[0].Start.Name)
+	assert.False(t, circular[0].IsPolymorphicResult)
+	assert.Equal(t, "#/components/schemas/Two",
+		findCopiedNode(t, resolved, "$.properties.things.properties.testThing.properties.things.$ref").Value)
+
+	after, _ := yaml.Marshal(resolver.specIndex.GetRootNode())
+	assert.Equal(t, string(original), string(after))
+
+	// resolving again finds the same results, nothing is recorded with the resolver.
+	_, circular, errs = resolver.ResolvePointer("#/components/schemas/One", nil)
+	assert.Len(t, circular, 1)
+	assert.Len(t, errs, 1)
+	assert.Empty(t, resolver.GetCircularErrors())
+	assert.Empty(t, resolver.GetResolvingErrors())
+}
+
+func TestResolver_ResolvePointer_Missing(t *testing.T) {
+	resolver, _ := newCircularTestResolver(t)
+
+	resolved, circular, errs := resolver.ResolvePointer("#/components/schemas/Nope", nil)
+	assert.Nil(t, resolved)
+	assert.Nil(t, circular)
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0].Error, "cannot resolve pointer `#/components/schemas/Nope`, it's missing")
+
+	resolved, _, _ = resolver.ResolvePointer("components", nil)
+	assert.Nil(t, resolved)
+}
+
+func TestResolver_ResolveNode(t *testing.T) {
+	resolver, _ := newCircularTestResolver(t)
+	three := findCopiedNode(t, resolver.specIndex.GetRootNode(), "$.components.schemas.Three")
+
+	resolved, circular, errs := resolver.ResolveNode(three, &CopyConfig{CircularReferences: CircularReferencesError})
+	assert.Nil(t, resolved)
+	assert.Len(t, circular, 1)
+	assert.Equal(t, "Seven -> Three -> Seven", circular[0].GenerateJourneyPath())
+	assert.Len(t, errs, 1)
+
+	// the same node without circular references resolves.
+	four := findCopiedNode(t, resolver.specIndex.GetRootNode(), "$.components.schemas.Four")
+	resolved, circular, errs = resolver.ResolveNode(four, &CopyConfig{CircularReferences: CircularReferencesError})
+	assert.Empty(t, circular)
+	assert.Empty(t, errs)
+	assert.Equal(t, "done.", findCopiedNode(t, resolved, "$.properties.lemons.description").Value)
+	assert.NotSame(t, four, resolved)
+}
+
+func TestFindPointer(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(`paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+  a~b: tilde`), &root))
+
+	assert.Equal(t, "id", findPointer(&root, "/paths/~1pets~1{id}/get/parameters/0/name").Value)
+	assert.Equal(t, "id", findPointer(&root, "#/paths/~1pets~1%7Bid%7D/get/parameters/0/name").Value)
+	assert.Equal(t, "tilde", findPointer(&root, "/paths/a~0b").Value)
+	assert.Equal(t, yaml.MappingNode, findPointer(&root, "").Kind)
+	assert.Nil(t, findPointer(&root, "/paths/~1pets~1{id}/get/parameters/1"))
+	assert.Nil(t, findPointer(&root, "/paths/nope"))
+}
@@ -0,0 +1,285 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"fmt"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+	"sort"
+	"strings"
+)
+
+// CircularReferenceMode determines what happens to a circular reference when creating a resolved copy.
+type CircularReferenceMode int
+
+const (
+	// CircularReferencesAsReference leaves circular references as a $ref, this is the default.
+	CircularReferencesAsReference CircularReferenceMode = iota
+
+	// CircularReferencesInline inlines circular references CircularDepth times, before leaving them as a $ref.
+	CircularReferencesInline
+
+	// CircularReferencesError fails to create a resolved copy when there are circular references.
+	CircularReferencesError
+)
+
+// CopyConfig configures how a resolved copy is created.
+type CopyConfig struct {
+	// CircularReferences determines what happens to circular references.
+	CircularReferences CircularReferenceMode
+
+	// CircularDepth is how many times a circular reference is inlined when using CircularReferencesInline.
+	CircularDepth int
+}
+
+// ResolveCopy is a non-destructive alternative to Resolve. It creates a deep copy of the specification with every
+// reference resolved (local, file and remote), the node tree held by the index is not changed. Nothing is
+// shared between the copy and the original, so the copy can be changed and serialized safely. Like Resolve, the
+// places a reference is used share the same resolved node in the copy.
+//
+// Circular references are handled as configured by config, a nil config leaves them as a $ref. Inlining circular
+// references can make the copy very large, each loop is inlined CircularDepth times wherever it is used. Circular
+// references are returned as resolving errors and are available from GetCircularErrors. When config is
+// CircularReferencesError, no copy is returned if there are any.
+//
+// Creating a copy again does not record the same circular references or errors twice.
+func (resolver *Resolver) ResolveCopy(config *CopyConfig) (*yaml.Node, []*ResolvingError) {
+	resolved, results, errs := resolver.ResolveNode(resolver.specIndex.GetRootNode(), config)
+	resolver.record(results, errs)
+	return resolved, errs
+}
+
+// record keeps circular references and errors with the resolver, anything already recorded is skipped.
+func (resolver *Resolver) record(results []*index.CircularReferenceResult, errs []*ResolvingError) {
+	seen := make(map[string]bool)
+	for _, circRef := range resolver.circularReferences {
+		seen[circRef.GenerateJourneyPath()] = true
+	}
+	for _, circRef := range results {
+		if !seen[circRef.GenerateJourneyPath()] {
+			seen[circRef.GenerateJourneyPath()] = true
+			resolver.circularReferences = append(resolver.circularReferences, circRef)
+		}
+	}
+	seen = make(map[string]bool)
+	for _, err := range resolver.resolvingErrors {
+		seen[err.Path+" "+err.Error.Error()] = true
+	}
+	for _, err := range errs {
+		if !seen[err.Path+" "+err.Error.Error()] {
+			seen[err.Path+" "+err.Error.Error()] = true
+			resolver.resolvingErrors = append(resolver.resolvingErrors, err)
+		}
+	}
+}
+
+// copier creates a resolved copy of a node tree, the references being resolved are kept on the journey so circular
+// references can be found.
+type copier struct {
+	resolver *Resolver
+	config   CopyConfig
+	failed   bool
+	errors   []*ResolvingError
+	journey  []*copyFrame
+
+	// polymorphicDepth is the number of polymorphic (allOf, oneOf and anyOf) keys being copied.
+	polymorphicDepth int
+
+	// resolved holds the resolved copy of every reference that resolves the same way wherever it is, by location.
+	resolved map[string]*yaml.Node
+
+	// circular holds the locations of every loop found, so each is only reported once.
+	circular map[string]bool
+	results  []*index.CircularReferenceResult
+}
+
+// copyFrame is a reference on the journey.
+type copyFrame struct {
+	ref         *index.Reference
+	location    string
+	polymorphic int
+
+	// circular is true if there are any circular references beneath the reference.
+	circular bool
+}
+
+func newCopier(resolver *Resolver, config *CopyConfig) *copier {
+	c := &copier{resolver: resolver, resolved: make(map[string]*yaml.Node), circular: make(map[string]bool)}
+	if config != nil {
+		c.config = *config
+	}
+	return c
+}
+
+// finish returns every error, including the circular references found.
+func (c *copier) finish() []*ResolvingError {
+	for _, circRef := range c.results {
+		c.errors = append(c.errors, &ResolvingError{
+			Error: fmt.Errorf("Circular reference detected: %s", circRef.Start.Name),
+			Node:  circRef.LoopPoint.Node,
+			Path:  circRef.GenerateJourneyPath(),
+		})
+	}
+	return c.errors
+}
+
+// copy creates a resolved copy of a node from the document indexed by doc, references are relative to it.
+func (c *copier) copy(node *yaml.Node, doc *index.SpecIndex) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return c.copy(node.Alias, doc)
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			if node.Content[i].Value == "$ref" && utils.IsNodeStringValue(node.Content[i+1]) {
+				return c.resolveReference(node, node.Content[i+1].Value, doc)
+			}
+		}
+	}
+	copied := *node
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		polymorphic := node.Kind == yaml.MappingNode && i%2 == 1 && isPolymorphicKey(node.Content[i-1].Value)
+		if polymorphic {
+			c.polymorphicDepth++
+		}
+		copied.Content[i] = c.copy(child, doc)
+		if polymorphic {
+			c.polymorphicDepth--
+		}
+	}
+	return &copied
+}
+
+// resolveReference returns a resolved copy of the component a reference in the document indexed by doc points to.
+// References that cannot be resolved, and circular references that are not inlined, are copied as they are.
+func (c *copier) resolveReference(node *yaml.Node, value string, doc *index.SpecIndex) *yaml.Node {
+	ref := doc.GetMappedReferences()[value]
+	var target *index.SpecIndex
+	if ref == nil {
+		// polymorphic references are not looked up when a document is indexed, find them in the indexed documents.
+		ref, target = doc.FindIndexedComponent(value)
+	}
+	if ref == nil {
+		// the document has not been indexed, so look it up now.
+		ref = doc.FindComponent(value, node)
+	}
+	file, fragment := value, ""
+	if i := strings.Index(value, "#"); i >= 0 {
+		file, fragment = value[:i], value[i+1:]
+	}
+	if target == nil {
+		target = doc
+		if file != "" {
+			target = doc.GetAllExternalIndexes()[file]
+		}
+	}
+	if ref == nil || ref.Node == nil || target == nil {
+		_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(value)
+		c.errors = append(c.errors, &ResolvingError{
+			Error: fmt.Errorf("cannot resolve reference `%s`, it's missing", value),
+			Node:  node,
+			Path:  path,
+		})
+		return c.copyReference(node)
+	}
+
+	location := target.GetLocation() + "#" + fragment
+	if resolved, ok := c.resolved[location]; ok {
+		return resolved
+	}
+	loopIndex, seen := -1, 0
+	for i := range c.journey {
+		if c.journey[i].location == location {
+			if loopIndex < 0 {
+				loopIndex = i
+			}
+			seen++
+		}
+	}
+	if loopIndex >= 0 {
+		c.recordCircular(ref, loopIndex)
+		switch c.config.CircularReferences {
+		case CircularReferencesError:
+			c.failed = true
+			return c.copyReference(node)
+		case CircularReferencesInline:
+			if seen > c.config.CircularDepth {
+				return c.copyReference(node)
+			}
+		default:
+			return c.copyReference(node)
+		}
+	}
+	if c.failed {
+		return c.copyReference(node)
+	}
+
+	position := len(c.journey)
+	current := &copyFrame{ref: ref, location: location, polymorphic: c.polymorphicDepth}
+	c.journey = append(c.journey, current)
+	resolved := c.copy(ref.Node, target)
+	c.journey = c.journey[:position]
+
+	// every reference left as a $ref in a resolved copy is circular, wherever the copy is used. Inlining circular
+	// references depends on how many times they have been inlined already, so those copies are never reused.
+	// Reusing copies keeps specifications with lots of shared references from growing exponentially.
+	if !current.circular || c.config.CircularReferences != CircularReferencesInline {
+		c.resolved[location] = resolved
+	}
+	if position > 0 {
+		parent := c.journey[position-1]
+		parent.circular = parent.circular || current.circular
+	}
+	return resolved
+}
+
+// recordCircular records a circular reference, the loop starts at loopIndex on the journey. A loop is only recorded
+// once, however many times it is inlined, or wherever it is entered.
+func (c *copier) recordCircular(ref *index.Reference, loopIndex int) {
+	c.journey[len(c.journey)-1].circular = true
+	var loop []string
+	inLoop := make(map[string]bool)
+	for _, frame := range c.journey[loopIndex:] {
+		if !inLoop[frame.location] {
+			inLoop[frame.location] = true
+			loop = append(loop, frame.location)
+		}
+	}
+	sort.Strings(loop)
+	key := strings.Join(loop, " ")
+	if c.circular[key] {
+		return
+	}
+	c.circular[key] = true
+	journey := make([]*index.Reference, len(c.journey), len(c.journey)+1)
+	for i := range c.journey {
+		journey[i] = c.journey[i].ref
+	}
+	c.results = append(c.results, &index.CircularReferenceResult{
+		Journey:             append(journey, ref),
+		Start:               c.journey[loopIndex].ref,
+		LoopIndex:           loopIndex,
+		LoopPoint:           ref,
+		IsPolymorphicResult: c.polymorphicDepth > c.journey[loopIndex].polymorphic,
+	})
+}
+
+// copyReference deep copies a reference node that is not resolved.
+func (c *copier) copyReference(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = c.copyReference(child)
+	}
+	return &copied
+}
+
+func isPolymorphicKey(key string) bool {
+	return key == "allOf" || key == "oneOf" || key == "anyOf"
+}
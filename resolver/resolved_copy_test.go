@@ -0,0 +1,128 @@
+// Copyright 2022 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"testing"
+	"testing/fstest"
+)
+
+func newCircularTestResolver(t *testing.T) (*Resolver, []byte) {
+	circular, _ := ioutil.ReadFile("../test_specs/circular-tests.yaml")
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal(circular, &rootNode))
+	original, _ := yaml.Marshal(&rootNode)
+	return NewResolver(index.NewSpecIndex(&rootNode)), original
+}
+
+func findCopiedNode(t *testing.T, root *yaml.Node, path string) *yaml.Node {
+	found, err := utils.FindNodesWithoutDeserializing(root, path)
+	assert.NoError(t, err)
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
+
+func TestResolver_ResolveCopy(t *testing.T) {
+	resolver, original := newCircularTestResolver(t)
+
+	resolved, errs := resolver.ResolveCopy(nil)
+	assert.NotNil(t, resolved)
+	assert.Len(t, errs, 3)
+	assert.Len(t, resolver.GetCircularErrors(), 3)
+	assert.Equal(t, "Ten -> Ten", resolver.GetCircularErrors()[2].GenerateJourneyPath())
+
+	// copying again records nothing new.
+	_, errs = resolver.ResolveCopy(nil)
+	assert.Len(t, errs, 3)
+	assert.Len(t, resolver.GetCircularErrors(), 3)
+	assert.Len(t, resolver.GetResolvingErrors(), 3)
+
+	// references are resolved, circular references are left as a $ref.
+	assert.Equal(t, "done.", findCopiedNode(t, resolved,
+		"$.paths./burgers.post.requestBody.content.application/json.schema.description").Value)
+	assert.Equal(t, "test two",
+		findCopiedNode(t, resolved, "$.components.schemas.One.properties.things.description").Value)
+	assert.Equal(t, "#/components/schemas/Ten",
+		findCopiedNode(t, resolved, "$.components.schemas.Ten.properties.yeah.properties.yeah.$ref").Value)
+
+	// the copy can be serialized, and the original is not changed.
+	_, err := yaml.Marshal(resolved)
+	assert.NoError(t, err)
+	after, _ := yaml.Marshal(resolver.specIndex.GetRootNode())
+	assert.Equal(t, string(original), string(after))
+}
+
+func TestResolver_ResolveCopy_InlineCircularReferences(t *testing.T) {
+	resolver, _ := newCircularTestResolver(t)
+
+	resolved, errs := resolver.ResolveCopy(&CopyConfig{CircularReferences: CircularReferencesInline, CircularDepth: 2})
+	assert.Len(t, errs, 3)
+
+	// the first reference to Ten is not circular, it is then inlined twice.
+	yeah := "$.components.schemas.Ten.properties.yeah"
+	assert.Nil(t, findCopiedNode(t, resolved, yeah+".properties.yeah.properties.yeah.$ref"))
+	assert.Equal(t, "#/components/schemas/Ten",
+		findCopiedNode(t, resolved, yeah+".properties.yeah.properties.yeah.properties.yeah.$ref").Value)
+}
+
+func TestResolver_ResolveCopy_ErrorOnCircularReferences(t *testing.T) {
+	resolver, _ := newCircularTestResolver(t)
+
+	resolved, errs := resolver.ResolveCopy(&CopyConfig{CircularReferences: CircularReferencesError})
+	assert.Nil(t, resolved)
+	assert.NotEmpty(t, errs)
+	assert.EqualError(t, errs[0].Error, "Circular reference detected: Two")
+}
+
+func TestResolver_ResolveCopy_Files(t *testing.T) {
+	config := index.CreateClosedAPIIndexConfig()
+	config.AllowFileLookup = true
+	config.FS = fstest.MapFS{
+		"schemas/pet.yaml": &fstest.MapFile{Data: []byte(`Pet:
+  type: object
+  properties:
+    owner:
+      $ref: '../common.yaml#/Owner'`)},
+		"common.yaml": &fstest.MapFile{Data: []byte(`Owner:
+  type: object
+  properties:
+    pets:
+      type: array
+      items:
+        $ref: 'schemas/pet.yaml#/Pet'
+Cat:
+  description: cat`)},
+	}
+
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'schemas/pet.yaml#/Pet'
+    Animal:
+      oneOf:
+        - $ref: 'common.yaml#/Cat'
+    Missing:
+      $ref: '#/components/schemas/Nope'`), &rootNode))
+	resolver := NewResolver(index.NewSpecIndexWithConfig(&rootNode, config))
+
+	resolved, errs := resolver.ResolveCopy(nil)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "cannot resolve reference `#/components/schemas/Nope`, it's missing", errs[0].Error.Error())
+	assert.Equal(t, "#/components/schemas/Nope", findCopiedNode(t, resolved, "$.components.schemas.Missing.$ref").Value)
+
+	// references in other files are relative to the file they are in.
+	pets := findCopiedNode(t, resolved, "$.components.schemas.Pet.properties.owner.properties.pets")
+	assert.Equal(t, "array", findCopiedNode(t, pets, "$.type").Value)
+	assert.Equal(t, "schemas/pet.yaml#/Pet", findCopiedNode(t, pets, "$.items.$ref").Value)
+	assert.Equal(t, "cat", findCopiedNode(t, resolved, "$.components.schemas.Animal.oneOf[0].description").Value)
+}
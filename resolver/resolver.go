@@ -72,7 +72,7 @@ func (resolver *Resolver) GetNonPolymorphicCircularErrors() []*index.CircularRef
 // Resolve will resolve the specification, everything that is not polymorphic and not circular, will be resolved.
 // this data can get big, it results in a massive duplication of data. This is a destructive method and will permanently
 // re-organize the node tree. Make sure you have copied your original tree before running this (if you want to preserve
-// original data)
+// original data). ResolveCopy resolves a copy of the tree instead.
 func (resolver *Resolver) Resolve() []*ResolvingError {
 
 	mapped := resolver.specIndex.GetMappedReferencesSequenced()
@@ -145,6 +145,13 @@ func (resolver *Resolver) CheckForCircularReferences() []*ResolvingError {
 	return resolver.resolvingErrors
 }
 
+// relative is a reference found while visiting another reference, along with the mapped reference it points to
+// (from the index of the document that contains it).
+type relative struct {
+	*index.Reference
+	target *index.Reference
+}
+
 // VisitReference will visit a reference as part of a journey and will return resolved nodes.
 func (resolver *Resolver) VisitReference(ref *index.Reference, seen map[string]bool, journey []*index.Reference, resolve bool) []*yaml.Node {
 
@@ -163,9 +170,9 @@ func (resolver *Resolver) VisitReference(ref *index.Reference, seen map[string]b
 		// check if we have seen this on the journey before, if so! it's circular
 		skip := false
 		for i, j := range journey {
-			if j.Definition == r.Definition {
+			if j.Node == r.target.Node {
 
-				foundDup := resolver.specIndex.GetMappedReferences()[r.Definition]
+				foundDup := r.target
 
 				var circRef *index.CircularReferenceResult
 				if !foundDup.Circular {
@@ -188,8 +195,7 @@ func (resolver *Resolver) VisitReference(ref *index.Reference, seen map[string]b
 			}
 		}
 		if !skip {
-			original := resolver.specIndex.GetMappedReferences()[r.Definition]
-			resolved := resolver.VisitReference(original, seen, journey, resolve)
+			resolved := resolver.VisitReference(r.target, seen, journey, resolve)
 			if resolve {
 				r.Node.Content = resolved // this is where we perform the actual resolving.
 			}
@@ -203,15 +209,24 @@ func (resolver *Resolver) VisitReference(ref *index.Reference, seen map[string]b
 	return ref.Node.Content
 }
 
+// referenceIndex returns the index of the document that contains a reference node (the map that holds the $ref),
+// references are relative to the document they are in.
+func (resolver *Resolver) referenceIndex(refNode *yaml.Node) *index.SpecIndex {
+	if doc := resolver.specIndex.FindReferenceIndex(refNode); doc != nil {
+		return doc
+	}
+	return resolver.specIndex
+}
+
 func (resolver *Resolver) extractRelatives(node *yaml.Node,
 	foundRelatives map[string]bool,
-	journey []*index.Reference, resolve bool) []*index.Reference {
+	journey []*index.Reference, resolve bool) []*relative {
 
 	if len(journey) > 100 {
 		return nil
 	}
 
-	var found []*index.Reference
+	var found []*relative
 	if len(node.Content) > 0 {
 		for i, n := range node.Content {
 			if utils.IsNodeMap(n) || utils.IsNodeArray(n) {
@@ -225,7 +240,7 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 				}
 
 				value := node.Content[i+1].Value
-				ref := resolver.specIndex.GetMappedReferences()[value]
+				ref := resolver.referenceIndex(node).GetMappedReferences()[value]
 
 				if ref == nil {
 					// TODO handle error, missing ref, can't resolve.
@@ -239,10 +254,13 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 					continue
 				}
 
-				r := &index.Reference{
-					Definition: value,
-					Name:       value,
-					Node:       node,
+				r := &relative{
+					Reference: &index.Reference{
+						Definition: value,
+						Name:       value,
+						Node:       node,
+					},
+					target: ref,
 				}
 
 				found = append(found, r)
@@ -262,11 +280,11 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 						if _, v := utils.FindKeyNodeTop("items", node.Content[i+1].Content); v != nil {
 							if utils.IsNodeMap(v) {
 								if d, _, l := utils.IsNodeRefValue(v); d {
-									ref := resolver.specIndex.GetMappedReferences()[l]
+									ref := resolver.referenceIndex(v).GetMappedReferences()[l]
 									if ref != nil && !ref.Circular {
 										circ := false
 										for f := range journey {
-											if journey[f].Definition == ref.Definition {
+											if journey[f].Node == ref.Node {
 												circ = true
 												break
 											}
@@ -299,11 +317,11 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 							v := node.Content[i+1].Content[q]
 							if utils.IsNodeMap(v) {
 								if d, _, l := utils.IsNodeRefValue(v); d {
-									ref := resolver.specIndex.GetMappedReferences()[l]
+									ref := resolver.referenceIndex(v).GetMappedReferences()[l]
 									if ref != nil && !ref.Circular {
 										circ := false
 										for f := range journey {
-											if journey[f].Definition == ref.Definition {
+											if journey[f].Node == ref.Node {
 												circ = true
 												break
 											}
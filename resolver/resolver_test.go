@@ -7,6 +7,7 @@ import (
 	"gopkg.in/yaml.v3"
 	"io/ioutil"
 	"testing"
+	"testing/fstest"
 )
 
 func TestNewResolver(t *testing.T) {
@@ -204,4 +205,45 @@ func ExampleNewResolver() {
 		len(circularErrors), len(resolver.GetPolymorphicCircularErrors()), len(resolver.GetNonPolymorphicCircularErrors()))
 	// Output: There are 21 circular reference errors, 19 of them are polymorphic errors, 2 are not
 
-}
\ No newline at end of file
+}
+func TestResolver_Resolve_NestedRelativeReferences(t *testing.T) {
+	config := index.CreateClosedAPIIndexConfig()
+	config.AllowFileLookup = true
+	config.FS = fstest.MapFS{
+		"schemas/pet.yaml": &fstest.MapFile{Data: []byte(`Pet:
+  type: object
+  properties:
+    error:
+      $ref: '../common.yaml#/Error'
+    sibling:
+      $ref: 'sibling.yaml#/Sib'`)},
+		"schemas/sibling.yaml": &fstest.MapFile{Data: []byte(`Sib:
+  type: string
+  description: sibling`)},
+		"common.yaml": &fstest.MapFile{Data: []byte(`Error:
+  type: object
+  description: error`)},
+	}
+
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: './schemas/pet.yaml#/Pet'`
+
+	// references in other files are relative to the file they are in.
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &rootNode))
+	resolver := NewResolver(index.NewSpecIndexWithConfig(&rootNode, config))
+	assert.Empty(t, resolver.CheckForCircularReferences())
+
+	rootNode = yaml.Node{}
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &rootNode))
+	resolver = NewResolver(index.NewSpecIndexWithConfig(&rootNode, config))
+	assert.Empty(t, resolver.Resolve())
+
+	pet := findCopiedNode(t, &rootNode, "$.components.schemas.Pet")
+	assert.Equal(t, "object", findCopiedNode(t, pet, "$.type").Value)
+	assert.Equal(t, "error", findCopiedNode(t, pet, "$.properties.error.description").Value)
+	assert.Equal(t, "sibling", findCopiedNode(t, pet, "$.properties.sibling.description").Value)
+}
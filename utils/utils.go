@@ -205,9 +205,9 @@ type KeyNodeSearch struct {
 // Returns the key and value
 func FindKeyNodeTop(key string, nodes []*yaml.Node) (keyNode *yaml.Node, valueNode *yaml.Node) {
 
-	for i, v := range nodes {
-		if key == v.Value {
-			return v, nodes[i+1] // next node is what we need.
+	for i := 0; i < len(nodes)-1; i += 2 {
+		if key == nodes[i].Value {
+			return nodes[i], nodes[i+1] // next node is what we need.
 		}
 	}
 	return nil, nil
@@ -275,6 +275,19 @@ func TestFindKeyNodeTop_NotFound(t *testing.T) {
 	assert.Nil(t, v)
 }
 
+func TestFindKeyNodeTop_Values(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`description: title
+nested:
+  title: pizza
+summary: title`), &root)
+
+	// only keys are matched, never values or the keys of child nodes.
+	k, v := FindKeyNodeTop("title", root.Content[0].Content)
+	assert.Nil(t, k)
+	assert.Nil(t, v)
+}
+
 func TestFindKeyNode(t *testing.T) {
 	nodes, _ := FindNodes(getPetstore(), "$")
 	k, v := FindKeyNode("/pet", nodes[0].Content)
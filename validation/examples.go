@@ -0,0 +1,271 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"gopkg.in/yaml.v3"
+	"sort"
+)
+
+// ExampleError is an example in a specification that is not valid against the schema it documents.
+type ExampleError struct {
+	Message      string             // Message is a human-readable description of the failure.
+	Name         string             // Name is the name of the example, empty if the example is not named.
+	Path         string             // Path is a JSON pointer to the example value in the specification.
+	SchemaPath   string             // SchemaPath is a JSON pointer to the schema the example documents.
+	Line         int                // Line is the line number of the example value.
+	Column       int                // Column is the column number of the example value.
+	SchemaErrors []*ValidationError // SchemaErrors contains every failure of the example against the schema.
+}
+
+// Error returns a string representation of the invalid example, including the line and column.
+func (e *ExampleError) Error() string {
+	return fmt.Sprintf("%s: %s, line %d, col %d", e.Path, e.Message, e.Line, e.Column)
+}
+
+// exampleValidator walks a Document, validating every example it finds against the schema it documents.
+type exampleValidator struct {
+	schemas       *SchemaValidator
+	checked       map[[2]*yaml.Node]bool
+	walkedSchemas map[*yaml.Node]bool
+	errors        []*ExampleError
+}
+
+// ValidateExamples will validate every example in an OpenAPI 3+ Document against the schema it documents. This
+// covers the 'example' and 'examples' of media types, parameters and headers (including examples referenced from
+// components), and the 'example' and 'examples' (OpenAPI 3.1) of every schema. Examples of request bodies and
+// parameters are validated as requests, and examples of responses are validated as responses (see Direction).
+// External examples ('externalValue') are not validated.
+//
+// Every invalid example is returned in the order it appears in the specification, or nil if all examples are valid.
+// An error is returned if the Document cannot be indexed.
+func ValidateExamples(document *v3high.Document) ([]*ExampleError, error) {
+	document, err := indexedDocument(document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate examples, %s", err.Error())
+	}
+	e := &exampleValidator{
+		schemas:       NewSchemaValidator(document.Index),
+		checked:       make(map[[2]*yaml.Node]bool),
+		walkedSchemas: make(map[*yaml.Node]bool),
+	}
+	if document.Paths != nil {
+		for _, pathItem := range document.Paths.PathItems {
+			e.pathItem(pathItem)
+		}
+	}
+	for _, pathItem := range document.Webhooks {
+		e.pathItem(pathItem)
+	}
+	if c := document.Components; c != nil {
+		for _, schema := range c.Schemas {
+			e.schemaProxy(schema)
+		}
+		for _, p := range c.Parameters {
+			e.parameter(p)
+		}
+		for _, h := range c.Headers {
+			e.header(h, Response)
+		}
+		for _, rb := range c.RequestBodies {
+			e.content(rb.Content, Request)
+		}
+		for _, r := range c.Responses {
+			e.response(r)
+		}
+		for _, cb := range c.Callbacks {
+			e.callback(cb)
+		}
+		for _, pathItem := range c.PathItems {
+			e.pathItem(pathItem)
+		}
+	}
+	sort.SliceStable(e.errors, func(i, j int) bool {
+		if e.errors[i].Line != e.errors[j].Line {
+			return e.errors[i].Line < e.errors[j].Line
+		}
+		return e.errors[i].Column < e.errors[j].Column
+	})
+	return e.errors, nil
+}
+
+func (e *exampleValidator) pathItem(pathItem *v3high.PathItem) {
+	if pathItem == nil {
+		return
+	}
+	for _, p := range pathItem.Parameters {
+		e.parameter(p)
+	}
+	for _, op := range operations(pathItem) {
+		for _, p := range op.Parameters {
+			e.parameter(p)
+		}
+		if op.RequestBody != nil {
+			e.content(op.RequestBody.Content, Request)
+		}
+		if op.Responses != nil {
+			for _, r := range op.Responses.Codes {
+				e.response(r)
+			}
+			e.response(op.Responses.Default)
+		}
+		for _, cb := range op.Callbacks {
+			e.callback(cb)
+		}
+	}
+}
+
+func (e *exampleValidator) callback(callback *v3high.Callback) {
+	if callback == nil {
+		return
+	}
+	for _, pathItem := range callback.Expression {
+		e.pathItem(pathItem)
+	}
+}
+
+func (e *exampleValidator) response(response *v3high.Response) {
+	if response == nil {
+		return
+	}
+	for _, h := range response.Headers {
+		e.header(h, Response)
+	}
+	e.content(response.Content, Response)
+}
+
+func (e *exampleValidator) parameter(p *v3high.Parameter) {
+	if p == nil || p.GoLow() == nil {
+		return
+	}
+	e.content(p.Content, Request)
+	schema := e.schemaProxy(p.Schema)
+	e.examples(schema, p.GoLow().Example, p.GoLow().Examples.Value, Request)
+}
+
+func (e *exampleValidator) header(h *v3high.Header, direction Direction) {
+	if h == nil || h.GoLow() == nil {
+		return
+	}
+	e.content(h.Content, direction)
+	schema := e.schemaProxy(h.Schema)
+	e.examples(schema, h.GoLow().Example, h.GoLow().Examples.Value, direction)
+}
+
+func (e *exampleValidator) content(content map[string]*v3high.MediaType, direction Direction) {
+	for _, mt := range content {
+		if mt.GoLow() == nil {
+			continue
+		}
+		for _, enc := range mt.Encoding {
+			for _, h := range enc.Headers {
+				e.header(h, direction)
+			}
+		}
+		schema := e.schemaProxy(mt.Schema)
+		e.examples(schema, mt.GoLow().Example, mt.GoLow().Examples.Value, direction)
+	}
+}
+
+// examples validates the single example and the named examples of a media type, parameter or header.
+func (e *exampleValidator) examples(schema *yaml.Node, example low.NodeReference[any],
+	examples map[low.KeyReference[string]]low.ValueReference[*lowbase.Example], direction Direction) {
+	if schema == nil {
+		return
+	}
+	e.check(schema, example.ValueNode, "", direction)
+	for k, v := range examples {
+		if v.Value != nil {
+			e.check(schema, v.Value.Value.ValueNode, k.Value, direction)
+		}
+	}
+}
+
+// schemaProxy walks the schema of a SchemaProxy, and returns the schema node.
+func (e *exampleValidator) schemaProxy(proxy *base.SchemaProxy) *yaml.Node {
+	if proxy == nil || proxy.GoLow() == nil {
+		return nil
+	}
+	schema := proxy.GoLow().GetValueNode()
+	e.schema(schema)
+	return schema
+}
+
+// schemaKeywords are the keywords of a schema that contain a single schema, or a sequence of schemas.
+var schemaKeywords = []string{"items", "additionalItems", "additionalProperties", "not", "if", "then", "else",
+	"contains", "propertyNames", "unevaluatedItems", "unevaluatedProperties", "allOf", "anyOf", "oneOf", "prefixItems"}
+
+// schemaMapKeywords are the keywords of a schema that contain a map of schemas.
+var schemaMapKeywords = []string{"properties", "patternProperties", "dependentSchemas", "$defs", "definitions"}
+
+// schema validates the 'example' and 'examples' of a schema node and every schema inside it. References are not
+// followed, the schemas they point to are walked when the components of the document are walked.
+func (e *exampleValidator) schema(schema *yaml.Node) {
+	schema = resolveNode(schema)
+	if schema == nil || schema.Kind != yaml.MappingNode || e.walkedSchemas[schema] {
+		return
+	}
+	e.walkedSchemas[schema] = true
+	s := e.schemas.schema
+	e.check(schema, s.keyword(schema, "example"), "", Any)
+	if examples := s.keyword(schema, "examples"); examples != nil && examples.Kind == yaml.SequenceNode {
+		for _, example := range examples.Content {
+			e.check(schema, example, "", Any)
+		}
+	}
+	for _, k := range schemaKeywords {
+		sub := s.keyword(schema, k)
+		if sub == nil {
+			continue
+		}
+		if sub.Kind == yaml.SequenceNode {
+			for _, item := range sub.Content {
+				e.schema(item)
+			}
+			continue
+		}
+		e.schema(sub)
+	}
+	for _, k := range schemaMapKeywords {
+		if sub := s.keyword(schema, k); sub != nil && sub.Kind == yaml.MappingNode {
+			for i := 1; i < len(sub.Content); i += 2 {
+				e.schema(sub.Content[i])
+			}
+		}
+	}
+}
+
+// check validates a single example against a schema, each example is only validated once against each schema.
+func (e *exampleValidator) check(schema, example *yaml.Node, name string, direction Direction) {
+	if example == nil {
+		return
+	}
+	key := [2]*yaml.Node{schema, example}
+	if e.checked[key] {
+		return
+	}
+	e.checked[key] = true
+	errs := e.schemas.schema.ValidateNodeAgainst(schema, e.schemas.pointer(schema), example, direction)
+	if len(errs) == 0 {
+		return
+	}
+	message := fmt.Sprintf("example is not valid: %s", errs[0].Message)
+	if name != "" {
+		message = fmt.Sprintf("example '%s' is not valid: %s", name, errs[0].Message)
+	}
+	e.errors = append(e.errors, &ExampleError{
+		Message:      message,
+		Name:         name,
+		Path:         e.schemas.pointer(example),
+		SchemaPath:   e.schemas.pointer(schema),
+		Line:         example.Line,
+		Column:       example.Column,
+		SchemaErrors: errs,
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+var exampleSpec = `openapi: 3.1.0
+info:
+  title: examples
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+          example: ten
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+              examples:
+                good:
+                  value: [{name: chuffy}]
+                bad:
+                  $ref: '#/components/examples/BadPets'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+            example:
+              id: 1
+              name: chuffy
+components:
+  examples:
+    BadPets:
+      value: [{name: 12}]
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+          example: chuffy
+        born:
+          type: string
+          format: date
+          examples: ["2022-11-04", "yesterday"]
+      example:
+        born: "2022-11-04"`
+
+func newTestDocument(t *testing.T, spec string) *v3high.Document {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	assert.NoError(t, err)
+	lowDoc, errs := v3low.CreateDocument(info)
+	assert.Empty(t, errs)
+	return v3high.NewDocument(lowDoc)
+}
+
+func TestValidateExamples(t *testing.T) {
+	errs, err := ValidateExamples(newTestDocument(t, exampleSpec))
+	assert.NoError(t, err)
+	assert.Len(t, errs, 5)
+
+	assert.Equal(t, "example is not valid: expected integer, found string", errs[0].Message)
+	assert.Equal(t, "#/paths/~1pets/get/parameters/0/example", errs[0].Path)
+	assert.Equal(t, 13, errs[0].Line)
+	assert.Equal(t, 20, errs[0].Column)
+	assert.Equal(t, "#/paths/~1pets/get/parameters/0/schema", errs[0].SchemaPath)
+
+	// the request body example sends a readOnly property.
+	assert.Equal(t, "example is not valid: property 'id' is readOnly and must not be sent", errs[1].Message)
+	assert.Equal(t, "#/paths/~1pets/post/requestBody/content/application~1json/example", errs[1].Path)
+	assert.Equal(t, 35, errs[1].Line)
+
+	// examples referenced from components are reported where they are defined.
+	assert.Equal(t, "bad", errs[2].Name)
+	assert.Equal(t, "#/components/examples/BadPets/value", errs[2].Path)
+	assert.Equal(t, 40, errs[2].Line)
+	assert.Equal(t, "#/0/name", errs[2].SchemaErrors[0].Path)
+	assert.Equal(t, "example 'bad' is not valid: expected string, found integer", errs[2].Message)
+
+	assert.Equal(t, "#/components/schemas/Pet/properties/born/examples/1", errs[3].Path)
+	assert.Equal(t, "example is not valid: 'yesterday' is not a valid 'date'", errs[3].Message)
+	assert.Equal(t, "#/components/schemas/Pet/properties/born", errs[3].SchemaPath)
+
+	assert.Equal(t, "#/components/schemas/Pet/example", errs[4].Path)
+	assert.Equal(t, "example is not valid: missing required property 'name'", errs[4].Message)
+	assert.Equal(t, "#/components/examples/BadPets/value: example 'bad' is not valid: "+
+		"expected string, found integer, line 40, col 14", errs[2].Error())
+}
+
+func TestValidateExamples_Valid(t *testing.T) {
+	spec, _ := ioutil.ReadFile("../test_specs/petstorev3.json")
+	errs, err := ValidateExamples(newTestDocument(t, string(spec)))
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+
+	_, err = ValidateExamples(nil)
+	assert.Error(t, err)
+}
+
+func TestValidateExamples_UnresolvedReference(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: examples
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Limit:
+      allOf:
+        - $ref: '#/components/schemas/Nope'
+      example: 10`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+	lowDoc, _ := v3low.CreateDocument(info)
+	errs, err := ValidateExamples(v3high.NewDocument(lowDoc))
+	assert.NoError(t, err)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "example is not valid: unable to resolve reference '#/components/schemas/Nope'", errs[0].Message)
+	assert.Equal(t, "#/components/schemas/Limit/allOf/0/$ref", errs[0].SchemaErrors[0].SchemaPath)
+}
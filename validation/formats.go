@@ -0,0 +1,96 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"encoding/base64"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+var hostnamePattern = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// stringFormats checks the string formats defined by JSON Schema and the OpenAPI Specification. Unknown formats
+// are not asserted.
+var stringFormats = map[string]func(value string) bool{
+	"date-time": func(value string) bool {
+		_, err := time.Parse(time.RFC3339Nano, value)
+		return err == nil
+	},
+	"date": func(value string) bool {
+		_, err := time.Parse("2006-01-02", value)
+		return err == nil
+	},
+	"time": func(value string) bool {
+		_, err := time.Parse("15:04:05Z07:00", value)
+		if err != nil {
+			_, err = time.Parse("15:04:05.999999999Z07:00", value)
+		}
+		return err == nil
+	},
+	"email": func(value string) bool {
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	},
+	"uuid": uuidPattern.MatchString,
+	"ipv4": func(value string) bool {
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && !strings.Contains(value, ":")
+	},
+	"ipv6": func(value string) bool {
+		return net.ParseIP(value) != nil && strings.Contains(value, ":")
+	},
+	"hostname": func(value string) bool {
+		return len(value) <= 253 && hostnamePattern.MatchString(value)
+	},
+	"uri": func(value string) bool {
+		u, err := url.Parse(value)
+		return err == nil && u.Scheme != ""
+	},
+	"uri-reference": func(value string) bool {
+		_, err := url.Parse(value)
+		return err == nil
+	},
+	"byte": func(value string) bool {
+		_, err := base64.StdEncoding.DecodeString(value)
+		return err == nil
+	},
+	"regex": func(value string) bool {
+		_, err := regexp.Compile(value)
+		return err == nil
+	},
+}
+
+// validateFormat asserts the 'format' of a string, or the range of an 'int32' or 'int64' integer.
+func (s *JSONSchema) validateFormat(schema *yaml.Node, schemaPath string, loc *location) []*ValidationError {
+	format := s.keyword(schema, "format")
+	if format == nil {
+		return nil
+	}
+	node := loc.value
+	switch nodeType(node) {
+	case "string":
+		if check, ok := stringFormats[format.Value]; ok && !check(node.Value) {
+			return []*ValidationError{s.newError(loc, schema, schemaPath, "format",
+				fmt.Sprintf("'%s' is not a valid '%s'", node.Value, format.Value))}
+		}
+	case "integer":
+		n, _ := toFloat(node)
+		if (format.Value == "int32" && (n < math.MinInt32 || n > math.MaxInt32)) ||
+			(format.Value == "int64" && (n < math.MinInt64 || n > math.MaxInt64)) {
+			return []*ValidationError{s.newError(loc, schema, schemaPath, "format",
+				fmt.Sprintf("%s is out of range for '%s'", strconv.FormatFloat(n, 'f', -1, 64), format.Value))}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,561 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"gopkg.in/yaml.v3"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HTTPValidationError represents a single failure found when validating an HTTP request or response against
+// an OpenAPI 3+ document. Every error points back to the part of the specification that was violated.
+type HTTPValidationError struct {
+	Message      string             // Message is a human-readable description of the failure.
+	Location     string             // Location is where the failure was found, for example 'query' or 'requestBody'.
+	Name         string             // Name is the failing parameter, header or media type.
+	SpecPath     string             // SpecPath is a JSON pointer to the object in the specification that was violated.
+	SpecLine     int                // SpecLine is the line number of the object in the specification that was violated.
+	SpecColumn   int                // SpecColumn is the column number of the object in the specification that was violated.
+	SchemaErrors []*ValidationError // SchemaErrors contains the schema failures of a parameter, header or body value.
+}
+
+// Error returns a string representation of the validation failure, including the line and column in the
+// specification.
+func (e *HTTPValidationError) Error() string {
+	return fmt.Sprintf("%s: %s, line %d, col %d", e.SpecPath, e.Message, e.SpecLine, e.SpecColumn)
+}
+
+// HTTPValidator validates HTTP requests and responses against the operations defined by an OpenAPI 3+ Document.
+//
+// Requests are matched to an Operation using the path templates of the document (with the base path of any
+// servers removed) and the request method. Path, query, header and cookie parameters are then parsed according to
+// their 'style' and 'explode' settings and validated against their schemas, as are request and response bodies
+// with a JSON or form media type.
+type HTTPValidator struct {
+	document *v3high.Document
+	schemas  *SchemaValidator
+	routes   []*route
+}
+
+// route is a compiled path template, with the base path of a server.
+type route struct {
+	path     string
+	pathItem *v3high.PathItem
+	regex    *regexp.Regexp
+	params   []string
+	node     *yaml.Node
+}
+
+// NewHTTPValidator will create a new HTTPValidator for a Document. A Document that has been created from scratch
+// (that has no index) is rendered and parsed, so that every error can point back to a line in the specification.
+func NewHTTPValidator(document *v3high.Document) (*HTTPValidator, error) {
+	document, err := indexedDocument(document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create http validator, %s", err.Error())
+	}
+	v := &HTTPValidator{
+		document: document,
+		schemas:  NewSchemaValidator(document.Index),
+	}
+	v.compileRoutes()
+	return v, nil
+}
+
+// indexedDocument returns a Document that is backed by a low-level model and an index. A Document that has been
+// created from scratch is rendered and parsed.
+func indexedDocument(document *v3high.Document) (*v3high.Document, error) {
+	if document == nil {
+		return nil, fmt.Errorf("no document has been supplied")
+	}
+	if document.Index != nil && document.GoLow() != nil {
+		return document, nil
+	}
+	rendered, err := document.Render()
+	if err != nil {
+		return nil, fmt.Errorf("the document cannot be rendered: %s", err.Error())
+	}
+	info, err := datamodel.ExtractSpecInfo(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("the document cannot be parsed: %s", err.Error())
+	}
+	lowDoc, errs := v3low.CreateDocument(info)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("the document cannot be built: %s", errs[0].Error())
+	}
+	return v3high.NewDocument(lowDoc), nil
+}
+
+// Validate will validate an HTTP request, and the response to that request if it is not nil. If the request
+// and response are both valid, nil is returned.
+func (v *HTTPValidator) Validate(request *http.Request, response *http.Response) []*HTTPValidationError {
+	errs := v.ValidateRequest(request)
+	if response != nil {
+		errs = append(errs, v.validateResponse(request, response, len(errs) == 0)...)
+	}
+	return errs
+}
+
+// ValidateRequest will validate the parameters and body of an HTTP request against the matching Operation. If
+// no Operation can be found for the request, that is also a failure. If the request is valid, nil is returned.
+func (v *HTTPValidator) ValidateRequest(request *http.Request) []*HTTPValidationError {
+	r, op, pathValues, err := v.findOperation(request)
+	if err != nil {
+		return []*HTTPValidationError{err}
+	}
+	var errs []*HTTPValidationError
+	for _, p := range v.mergeParameters(r.pathItem.Parameters, op.Parameters) {
+		errs = append(errs, v.validateParameter(p, request, pathValues)...)
+	}
+	if op.RequestBody != nil {
+		errs = append(errs, v.validateRequestBody(op, request)...)
+	}
+	return errs
+}
+
+// ValidateResponse will validate the headers and body of an HTTP response against the Operation matching the
+// request that was made. If the response is valid, nil is returned.
+func (v *HTTPValidator) ValidateResponse(request *http.Request, response *http.Response) []*HTTPValidationError {
+	return v.validateResponse(request, response, true)
+}
+
+// validateResponse validates a response, reportRoute determines if a request that cannot be matched to an
+// operation is reported (it already has been when validating a request and response together).
+func (v *HTTPValidator) validateResponse(request *http.Request, response *http.Response,
+	reportRoute bool) []*HTTPValidationError {
+	_, op, _, err := v.findOperation(request)
+	if err != nil {
+		if reportRoute {
+			return []*HTTPValidationError{err}
+		}
+		return nil
+	}
+	resp, keyNode := v.findResponse(op, response.StatusCode)
+	if resp == nil {
+		return []*HTTPValidationError{v.newError("response", strconv.Itoa(response.StatusCode),
+			fmt.Sprintf("response code '%d' is not defined for operation '%s'", response.StatusCode,
+				operationName(op, request)), operationNode(op), nil)}
+	}
+	var errs []*HTTPValidationError
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// the content type of a response is described by its content, and is ignored as a header.
+		if strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		var headerKey *yaml.Node
+		if resp.GoLow() != nil {
+			headerKey = findKeyNode(name, resp.GoLow().Headers.Value)
+		}
+		errs = append(errs, v.validateHeader(newHeaderParameter(name, resp.Headers[name], headerKey),
+			response.Header)...)
+	}
+	if len(resp.Content) > 0 {
+		var contentKey *yaml.Node
+		if resp.GoLow() != nil {
+			contentKey = resp.GoLow().Content.KeyNode
+		}
+		if contentKey == nil {
+			contentKey = keyNode
+		}
+		body, readErr := readBody(&response.Body)
+		if readErr != nil {
+			return append(errs, v.newError("responseBody", "", fmt.Sprintf("unable to read response body: %s",
+				readErr.Error()), contentKey, nil))
+		}
+		if len(body) > 0 {
+			errs = append(errs, v.validateBody("responseBody", "response body", resp.Content, contentKey,
+				response.Header.Get("Content-Type"), body, Response)...)
+		}
+	}
+	return errs
+}
+
+// findResponse locates the response for a status code, first by the exact code, then by a range of codes
+// (like '2XX') and finally the default response.
+func (v *HTTPValidator) findResponse(op *v3high.Operation, code int) (*v3high.Response, *yaml.Node) {
+	if op.Responses == nil {
+		return nil, nil
+	}
+	var lowResponses *v3low.Responses
+	if op.Responses.GoLow() != nil {
+		lowResponses = op.Responses.GoLow()
+	}
+	for _, c := range []string{strconv.Itoa(code), fmt.Sprintf("%dXX", code/100), fmt.Sprintf("%dxx", code/100)} {
+		if r, ok := op.Responses.Codes[c]; ok {
+			var keyNode *yaml.Node
+			if lowResponses != nil {
+				keyNode = findKeyNode(c, lowResponses.Codes)
+			}
+			return r, keyNode
+		}
+	}
+	if op.Responses.Default != nil {
+		var keyNode *yaml.Node
+		if lowResponses != nil {
+			keyNode = lowResponses.Default.KeyNode
+		}
+		return op.Responses.Default, keyNode
+	}
+	return nil, nil
+}
+
+// validateRequestBody checks a request body is present if required, and is valid against the schema of its
+// media type.
+func (v *HTTPValidator) validateRequestBody(op *v3high.Operation, request *http.Request) []*HTTPValidationError {
+	rb := op.RequestBody
+	var keyNode *yaml.Node
+	if op.GoLow() != nil {
+		keyNode = op.GoLow().RequestBody.KeyNode
+	}
+	body, err := readBody(&request.Body)
+	if err != nil {
+		return []*HTTPValidationError{v.newError("requestBody", "",
+			fmt.Sprintf("unable to read request body: %s", err.Error()), keyNode, nil)}
+	}
+	if len(body) == 0 {
+		if rb.Required {
+			return []*HTTPValidationError{v.newError("requestBody", "",
+				fmt.Sprintf("request body is required by operation '%s', but is missing",
+					operationName(op, request)), keyNode, nil)}
+		}
+		return nil
+	}
+	return v.validateBody("requestBody", "request body", rb.Content, keyNode,
+		request.Header.Get("Content-Type"), body, Request)
+}
+
+// validateBody validates a request or response body against the schema of the media type matching the
+// content type of the body.
+func (v *HTTPValidator) validateBody(location, description string, content map[string]*v3high.MediaType,
+	keyNode *yaml.Node, contentType string, body []byte, direction Direction) []*HTTPValidationError {
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return []*HTTPValidationError{v.newError(location, contentType,
+			fmt.Sprintf("%s content type '%s' cannot be parsed", description, contentType), keyNode, nil)}
+	}
+	mt, name := findMediaType(content, mediaType)
+	if mt == nil {
+		var supported []string
+		for k := range content {
+			supported = append(supported, k)
+		}
+		sort.Strings(supported)
+		return []*HTTPValidationError{v.newError(location, mediaType,
+			fmt.Sprintf("%s content type '%s' is not supported, expected one of %s", description, mediaType,
+				strings.Join(supported, ", ")), keyNode, nil)}
+	}
+	if mt.Schema == nil || mt.Schema.GoLow() == nil {
+		return nil
+	}
+	schema := mt.Schema.GoLow().GetValueNode()
+	if mt.GoLow() != nil && mt.GoLow().Schema.KeyNode != nil {
+		keyNode = mt.GoLow().Schema.KeyNode
+	}
+
+	var instance *yaml.Node
+	switch {
+	case isJSONMediaType(mediaType):
+		if !json.Valid(body) {
+			return []*HTTPValidationError{v.newError(location, name,
+				fmt.Sprintf("%s is not valid JSON", description), keyNode, nil)}
+		}
+		var n yaml.Node
+		if err = yaml.Unmarshal(body, &n); err != nil {
+			return []*HTTPValidationError{v.newError(location, name,
+				fmt.Sprintf("%s cannot be decoded: %s", description, err.Error()), keyNode, nil)}
+		}
+		instance = &n
+	case mediaType == "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return []*HTTPValidationError{v.newError(location, name,
+				fmt.Sprintf("%s cannot be decoded: %s", description, err.Error()), keyNode, nil)}
+		}
+		instance = v.formNode(schema, form)
+	default:
+		// only JSON and form bodies can be validated against a schema.
+		return nil
+	}
+	errs := v.schemas.schema.ValidateNodeAgainst(schema, v.schemas.pointer(schema), instance, direction)
+	if len(errs) > 0 {
+		return []*HTTPValidationError{v.newError(location, name,
+			fmt.Sprintf("%s is not valid: %s", description, errs[0].Message), keyNode, errs)}
+	}
+	return nil
+}
+
+// findOperation locates the route and Operation for a request, along with the raw values of any path parameters.
+func (v *HTTPValidator) findOperation(request *http.Request) (*route, *v3high.Operation, map[string]string,
+	*HTTPValidationError) {
+
+	path := request.URL.EscapedPath()
+	var matched *route
+	for _, r := range v.routes {
+		m := r.regex.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		if matched == nil {
+			matched = r
+		}
+		op := operationForMethod(r.pathItem, request.Method)
+		if op == nil {
+			continue
+		}
+		values := make(map[string]string, len(r.params))
+		for i, name := range r.params {
+			values[name] = m[i+1]
+		}
+		return r, op, values, nil
+	}
+	if matched != nil {
+		e := v.newError("request", request.Method,
+			fmt.Sprintf("method '%s' is not defined for path '%s'", request.Method, matched.path), matched.node, nil)
+		if e.SpecPath != "" {
+			// the node is the key of the path item, which points to the paths, so point to the path item instead.
+			e.SpecPath += "/" + escapePointer(matched.path)
+		}
+		return nil, nil, nil, e
+	}
+	var pathsNode *yaml.Node
+	if v.document.GoLow() != nil {
+		pathsNode = v.document.GoLow().Paths.KeyNode
+	}
+	return nil, nil, nil, v.newError("request", request.URL.Path,
+		fmt.Sprintf("path '%s' was not found in the specification", request.URL.Path), pathsNode, nil)
+}
+
+var pathParameterPattern = regexp.MustCompile(`{([^{}]+)}`)
+
+// compileRoutes creates a route for every combination of path template and server base path. Routes are sorted so
+// that literal paths are matched before templated paths (for example '/pets/mine' before '/pets/{id}').
+func (v *HTTPValidator) compileRoutes() {
+	if v.document.Paths == nil {
+		return
+	}
+	var lowPaths *v3low.Paths
+	if v.document.Paths.GoLow() != nil {
+		lowPaths = v.document.Paths.GoLow()
+	}
+	for path, pathItem := range v.document.Paths.PathItems {
+		servers := append([]*v3high.Server{}, v.document.Servers...)
+		servers = append(servers, pathItem.Servers...)
+		for _, op := range operations(pathItem) {
+			servers = append(servers, op.Servers...)
+		}
+		bases := map[string]bool{}
+		if len(v.document.Servers) == 0 {
+			bases[""] = true // the default server is '/'.
+		}
+		for _, s := range servers {
+			bases[serverBasePattern(s)] = true
+		}
+		var keyNode *yaml.Node
+		if lowPaths != nil {
+			keyNode = findKeyNode(path, lowPaths.PathItems)
+		}
+		for base := range bases {
+			var params []string
+			pattern := strings.Builder{}
+			pattern.WriteString("^" + base)
+			last := 0
+			for _, m := range pathParameterPattern.FindAllStringSubmatchIndex(path, -1) {
+				pattern.WriteString(regexp.QuoteMeta(path[last:m[0]]))
+				pattern.WriteString("([^/]+)")
+				params = append(params, path[m[2]:m[3]])
+				last = m[1]
+			}
+			pattern.WriteString(regexp.QuoteMeta(path[last:]) + "$")
+			regex, err := regexp.Compile(pattern.String())
+			if err != nil {
+				continue
+			}
+			v.routes = append(v.routes, &route{path: path, pathItem: pathItem, regex: regex, params: params,
+				node: keyNode})
+		}
+	}
+	sort.SliceStable(v.routes, func(i, j int) bool {
+		a, b := v.routes[i], v.routes[j]
+		if len(a.params) != len(b.params) {
+			return len(a.params) < len(b.params)
+		}
+		if len(a.regex.String()) != len(b.regex.String()) {
+			return len(a.regex.String()) > len(b.regex.String())
+		}
+		return a.regex.String() < b.regex.String()
+	})
+}
+
+// serverBasePattern returns a regular expression matching the base path of a server URL. Variables are matched
+// using their enum values (and default), or any single path segment if there is no enum.
+func serverBasePattern(server *v3high.Server) string {
+	u := server.URL
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+		if j := strings.Index(u, "/"); j >= 0 {
+			u = u[j:]
+		} else {
+			u = ""
+		}
+	}
+	u = strings.TrimSuffix(u, "/")
+	pattern := strings.Builder{}
+	last := 0
+	for _, m := range pathParameterPattern.FindAllStringSubmatchIndex(u, -1) {
+		pattern.WriteString(regexp.QuoteMeta(u[last:m[0]]))
+		variable := server.Variables[u[m[2]:m[3]]]
+		var values []string
+		if variable != nil {
+			for _, e := range variable.Enum {
+				values = append(values, regexp.QuoteMeta(e))
+			}
+			if len(values) == 0 && variable.Default != "" {
+				values = append(values, regexp.QuoteMeta(variable.Default))
+			}
+		}
+		if len(values) > 0 {
+			pattern.WriteString("(?:" + strings.Join(values, "|") + ")")
+		} else {
+			pattern.WriteString("[^/]+")
+		}
+		last = m[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(u[last:]))
+	return pattern.String()
+}
+
+func operations(pathItem *v3high.PathItem) []*v3high.Operation {
+	var ops []*v3high.Operation
+	for _, op := range []*v3high.Operation{pathItem.Get, pathItem.Put, pathItem.Post, pathItem.Delete,
+		pathItem.Options, pathItem.Head, pathItem.Patch, pathItem.Trace} {
+		if op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func operationForMethod(pathItem *v3high.PathItem, method string) *v3high.Operation {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return pathItem.Get
+	case http.MethodPut:
+		return pathItem.Put
+	case http.MethodPost:
+		return pathItem.Post
+	case http.MethodDelete:
+		return pathItem.Delete
+	case http.MethodOptions:
+		return pathItem.Options
+	case http.MethodHead:
+		return pathItem.Head
+	case http.MethodPatch:
+		return pathItem.Patch
+	case http.MethodTrace:
+		return pathItem.Trace
+	}
+	return nil
+}
+
+// operationName returns the operationId of an Operation, or the method and path of the request if there is none.
+func operationName(op *v3high.Operation, request *http.Request) string {
+	if op.OperationId != "" {
+		return op.OperationId
+	}
+	return request.Method + " " + request.URL.Path
+}
+
+// operationNode returns the node used to locate an Operation in the specification.
+func operationNode(op *v3high.Operation) *yaml.Node {
+	if op.GoLow() == nil {
+		return nil
+	}
+	if op.GoLow().Responses.KeyNode != nil {
+		return op.GoLow().Responses.KeyNode
+	}
+	return op.GoLow().OperationId.KeyNode
+}
+
+// findMediaType locates a media type by an exact match, then by a wildcard subtype (like 'image/*') and finally
+// the '*/*' wildcard.
+func findMediaType(content map[string]*v3high.MediaType, mediaType string) (*v3high.MediaType, string) {
+	if mt, ok := content[mediaType]; ok {
+		return mt, mediaType
+	}
+	for k, mt := range content {
+		if parsed, _, err := mime.ParseMediaType(k); err == nil && parsed == mediaType {
+			return mt, k
+		}
+	}
+	if i := strings.Index(mediaType, "/"); i > 0 {
+		if mt, ok := content[mediaType[:i]+"/*"]; ok {
+			return mt, mediaType[:i] + "/*"
+		}
+	}
+	if mt, ok := content["*/*"]; ok {
+		return mt, "*/*"
+	}
+	return nil, ""
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// readBody reads a body in full and replaces it, so it can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// findKeyNode locates the key node of a named item in a low-level map.
+func findKeyNode[T any](name string, collection map[low.KeyReference[string]]low.ValueReference[T]) *yaml.Node {
+	for k := range collection {
+		if k.Value == name {
+			return k.KeyNode
+		}
+	}
+	return nil
+}
+
+// newError creates an HTTPValidationError that points to a node in the specification.
+func (v *HTTPValidator) newError(location, name, message string, node *yaml.Node,
+	schemaErrors []*ValidationError) *HTTPValidationError {
+	e := &HTTPValidationError{
+		Message:      message,
+		Location:     location,
+		Name:         name,
+		SchemaErrors: schemaErrors,
+	}
+	if node != nil {
+		e.SpecPath = v.schemas.pointer(node)
+		e.SpecLine = node.Line
+		e.SpecColumn = node.Column
+	}
+	return e
+}
@@ -0,0 +1,450 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var petSpec = `openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/{version}
+    variables:
+      version:
+        default: v1
+        enum: [v1, v2]
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              age:
+                type: integer
+              name:
+                type: string
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: string
+            minLength: 5
+        - name: session
+          in: cookie
+          schema:
+            type: string
+      responses:
+        "200":
+          description: pets
+          headers:
+            Rate-Limit:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        default:
+          description: error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+          application/x-www-form-urlencoded:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "2XX":
+          description: created
+  /pets/mine:
+    get:
+      operationId: myPets
+      responses:
+        "200":
+          description: mine
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          description: pet
+  /colors/{colors}:
+    get:
+      parameters:
+        - name: colors
+          in: path
+          required: true
+          style: label
+          explode: true
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [red, green, blue]
+      responses:
+        "200":
+          description: colors
+  /points/{point}:
+    get:
+      parameters:
+        - name: point
+          in: path
+          required: true
+          style: matrix
+          explode: true
+          schema:
+            type: object
+            properties:
+              x:
+                type: number
+              y:
+                type: number
+      responses:
+        "200":
+          description: point
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+        age:
+          type: integer
+          minimum: 0
+    Error:
+      type: object
+      required: [message]
+      properties:
+        message:
+          type: string`
+
+func newTestHTTPValidator(t *testing.T, spec string) *HTTPValidator {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	assert.NoError(t, err)
+	lowDoc, errs := v3low.CreateDocument(info)
+	assert.Empty(t, errs)
+	v, err := NewHTTPValidator(v3high.NewDocument(lowDoc))
+	assert.NoError(t, err)
+	return v
+}
+
+func newTestRequest(method, target, contentType, body string) *http.Request {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req
+}
+
+func newTestResponse(code int, contentType, body string) *http.Response {
+	resp := &http.Response{StatusCode: code, Header: http.Header{},
+		Body: ioutil.NopCloser(strings.NewReader(body))}
+	if contentType != "" {
+		resp.Header.Set("Content-Type", contentType)
+	}
+	return resp
+}
+
+func TestNewHTTPValidator_NoDocument(t *testing.T) {
+	v, err := NewHTTPValidator(nil)
+	assert.Nil(t, v)
+	assert.Error(t, err)
+}
+
+func TestHTTPValidator_ValidateRequest_Valid(t *testing.T) {
+	v := newTestHTTPValidator(t, petSpec)
+	req := newTestRequest(http.MethodGet,
+		"https://api.example.com/v1/pets?limit=10&tags=a&tags=b&filter[age]=3&filter[name]=fluffy", "", "")
+	req.Header.Set("X-Request-Id", "abcdef")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "123"})
+	assert.Empty(t, v.ValidateRequest(req))
+}
+
+func TestHTTPValidator_ValidateRequest_Routing(t *testing.T) {
+	v := newTestHTTPValidator(t, petSpec)
+
+	errs := v.ValidateRequest(newTestRequest(http.MethodGet, "/v1/unknown", "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "request", errs[0].Location)
+	assert.Equal(t, "path '/v1/unknown' was not found in the specification", errs[0].Message)
+	assert.Equal(t, 11, errs[0].SpecLine)
+
+	// the server variable must be one of the enum values.
+	errs = v.ValidateRequest(newTestRequest(http.MethodGet, "/v3/pets/mine", "", ""))
+	assert.Len(t, errs, 1)
+
+	errs = v.ValidateRequest(newTestRequest(http.MethodDelete, "/v2/pets/1", "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "method 'DELETE' is not defined for path '/pets/{petId}'", errs[0].Message)
+	assert.Equal(t, "#/paths/~1pets~1{petId}", errs[0].SpecPath)
+	assert.Equal(t, 87, errs[0].SpecLine)
+
+	// a literal path is preferred over a templated one.
+	r, op, _, e := v.findOperation(newTestRequest(http.MethodGet, "/v2/pets/mine", "", ""))
+	assert.Nil(t, e)
+	assert.Equal(t, "/pets/mine", r.path)
+	assert.Equal(t, "myPets", op.OperationId)
+
+	r, op, values, e := v.findOperation(newTestRequest(http.MethodGet, "/v1/pets/12", "", ""))
+	assert.Nil(t, e)
+	assert.Equal(t, "/pets/{petId}", r.path)
+	assert.Equal(t, "getPet", op.OperationId)
+	assert.Equal(t, "12", values["petId"])
+}
+
+func TestHTTPValidator_ValidateRequest_Parameters(t *testing.T) {
+	v := newTestHTTPValidator(t, petSpec)
+	req := newTestRequest(http.MethodGet, "/v1/pets?limit=500&filter[age]=old", "", "")
+	errs := v.ValidateRequest(req)
+	assert.Len(t, errs, 3)
+
+	assert.Equal(t, "query", errs[0].Location)
+	assert.Equal(t, "limit", errs[0].Name)
+	assert.Equal(t, "query parameter 'limit' is not valid: must be less than or equal to 100", errs[0].Message)
+	assert.Equal(t, "#/paths/~1pets/get/parameters/0", errs[0].SpecPath)
+	assert.Equal(t, 16, errs[0].SpecLine)
+	assert.Len(t, errs[0].SchemaErrors, 1)
+	assert.Equal(t, "#/paths/~1pets/get/parameters/0/schema/maximum", errs[0].SchemaErrors[0].SchemaPath)
+	assert.Equal(t, 20, errs[0].SchemaErrors[0].SchemaLine)
+
+	assert.Equal(t, "query parameter 'filter' is not valid: expected integer, found string", errs[1].Message)
+	assert.Equal(t, "#/age", errs[1].SchemaErrors[0].Path)
+
+	assert.Equal(t, "header", errs[2].Location)
+	assert.Equal(t, "header parameter 'X-Request-Id' is required, but is missing", errs[2].Message)
+	assert.Equal(t, 37, errs[2].SpecLine)
+
+	req = newTestRequest(http.MethodGet, "/v1/pets/abc", "", "")
+	errs = v.ValidateRequest(req)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "path parameter 'petId' is not valid: expected integer, found string", errs[0].Message)
+	assert.Equal(t, "#/paths/~1pets~1{petId}/parameters/0", errs[0].SpecPath)
+}
+
+func TestHTTPValidator_ValidateRequest_Styles(t *testing.T) {
+	v := newTestHTTPValidator(t, petSpec)
+	assert.Empty(t, v.ValidateRequest(newTestRequest(http.MethodGet, "/v1/colors/.red.green", "", "")))
+	assert.Empty(t, v.ValidateRequest(newTestRequest(http.MethodGet, "/v1/points/;x=1.5;y=2", "", "")))
+
+	errs := v.ValidateRequest(newTestRequest(http.MethodGet, "/v1/colors/.red.pink", "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/1", errs[0].SchemaErrors[0].Path)
+
+	errs = v.ValidateRequest(newTestRequest(http.MethodGet, "/v1/colors/red", "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "path parameter 'colors' cannot be parsed: "+
+		"value 'red' is not serialized using the 'label' style", errs[0].Message)
+
+	errs = v.ValidateRequest(newTestRequest(http.MethodGet, "/v1/points/;x=up;y=2", "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/x", errs[0].SchemaErrors[0].Path)
+}
+
+func TestHTTPValidator_ParseStyles(t *testing.T) {
+	v := newTestHTTPValidator(t, petSpec)
+	array := v.schemas.schema.keyword(v.schemas.schema.keyword(v.schemas.schema.root, "paths"), "/colors/{colors}")
+	array = v.schemas.schema.keyword(v.schemas.schema.keyword(array, "get"), "parameters").Content[0]
+	array = v.schemas.schema.keyword(array, "schema")
+
+	tests := []struct {
+		style   string
+		explode bool
+		raw     string
+	}{
+		{"simple", false, "red,green"},
+		{"label", false, ".red,green"},
+		{"matrix", false, ";colors=red,green"},
+		{"matrix", true, ";colors=red;colors=green"},
+		{"form", false, "red,green"},
+	}
+	for _, tt := range tests {
+		node, err := v.parseStyled(&parameter{name: "colors", style: tt.style, explode: tt.explode,
+			schema: array}, tt.raw)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"red", "green"}, nodeValue(node), tt.style)
+	}
+
+	query := map[string][]string{"colors": {"red|green"}, "space": {"red green"}}
+	node, present, err := v.parseQuery(&parameter{name: "colors", style: "pipeDelimited", schema: array}, query)
+	assert.True(t, present)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"red", "green"}, nodeValue(node))
+
+	node, _, _ = v.parseQuery(&parameter{name: "space", style: "spaceDelimited", schema: array}, query)
+	assert.Equal(t, []any{"red", "green"}, nodeValue(node))
+
+	_, present, _ = v.parseQuery(&parameter{name: "missing", style: "form", schema: array}, query)
+	assert.False(t, present)
+}
+
+func TestHTTPValidator_ValidateRequest_Body(t *testing.T) {
+	v := newTestHTTPValidator(t, petSpec)
+	assert.Empty(t, v.ValidateRequest(newTestRequest(http.MethodPost, "/v1/pets", "application/json",
+		`{"name": "fluffy", "age": 3}`)))
+	assert.Empty(t, v.ValidateRequest(newTestRequest(http.MethodPost, "/v1/pets",
+		"application/x-www-form-urlencoded", `name=fluffy&age=3`)))
+
+	errs := v.ValidateRequest(newTestRequest(http.MethodPost, "/v1/pets", "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "requestBody", errs[0].Location)
+	assert.Equal(t, "request body is required by operation 'createPet', but is missing", errs[0].Message)
+	assert.Equal(t, 69, errs[0].SpecLine)
+
+	errs = v.ValidateRequest(newTestRequest(http.MethodPost, "/v1/pets", "application/json; charset=utf-8",
+		`{"id": 1, "age": -1}`))
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaErrors, 3)
+	assert.Equal(t, "missing required property 'name'", errs[0].SchemaErrors[0].Message)
+	assert.Equal(t, "property 'id' is readOnly and must not be sent", errs[0].SchemaErrors[1].Message)
+	assert.Equal(t, "#/components/schemas/Pet/properties/id/readOnly", errs[0].SchemaErrors[1].SchemaPath)
+	assert.Equal(t, 1, errs[0].SchemaErrors[1].Line)
+	assert.Equal(t, "#/age", errs[0].SchemaErrors[2].Path)
+
+	errs = v.ValidateRequest(newTestRequest(http.MethodPost, "/v1/pets", "application/json", `{"name": `))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "request body is not valid JSON", errs[0].Message)
+
+	errs = v.ValidateRequest(newTestRequest(http.MethodPost, "/v1/pets", "text/plain", `fluffy`))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "request body content type 'text/plain' is not supported, expected one of "+
+		"application/json, application/x-www-form-urlencoded", errs[0].Message)
+}
+
+func TestHTTPValidator_ValidateResponse(t *testing.T) {
+	v := newTestHTTPValidator(t, petSpec)
+	req := newTestRequest(http.MethodGet, "/v1/pets", "", "")
+	req.Header.Set("X-Request-Id", "abcdef")
+
+	resp := newTestResponse(200, "application/json", `[{"id": 1, "name": "fluffy"}]`)
+	resp.Header.Set("Rate-Limit", "10")
+	assert.Empty(t, v.Validate(req, resp))
+
+	// the response body can still be read after validating.
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `[{"id": 1, "name": "fluffy"}]`, string(body))
+
+	resp = newTestResponse(200, "application/json", `[{"name": "fluffy"}]`)
+	errs := v.ValidateResponse(req, resp)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "response header 'Rate-Limit' is required, but is missing", errs[0].Message)
+	assert.Equal(t, "header", errs[0].Location)
+	assert.Equal(t, "responseBody", errs[1].Location)
+	assert.Equal(t, "response body is not valid: missing required property 'id'", errs[1].Message)
+	assert.Equal(t, "#/0", errs[1].SchemaErrors[0].Path)
+
+	// the default response is used for undefined codes.
+	errs = v.ValidateResponse(req, newTestResponse(500, "application/json", `{}`))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/components/schemas/Error/required", errs[0].SchemaErrors[0].SchemaPath)
+
+	// response code ranges.
+	post := newTestRequest(http.MethodPost, "/v1/pets", "application/json", `{"name": "fluffy"}`)
+	assert.Empty(t, v.ValidateResponse(post, newTestResponse(201, "", "")))
+	errs = v.ValidateResponse(post, newTestResponse(404, "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "response code '404' is not defined for operation 'createPet'", errs[0].Message)
+	assert.Equal(t, "response", errs[0].Location)
+}
+
+func TestHTTPValidator_Validate_UnknownPath(t *testing.T) {
+	v := newTestHTTPValidator(t, petSpec)
+	errs := v.Validate(newTestRequest(http.MethodGet, "/nope", "", ""), newTestResponse(200, "", ""))
+	assert.Len(t, errs, 1)
+}
+
+func TestHTTPValidator_Petstore(t *testing.T) {
+	spec, _ := ioutil.ReadFile("../test_specs/petstorev3.json")
+	v := newTestHTTPValidator(t, string(spec))
+
+	assert.Empty(t, v.ValidateRequest(newTestRequest(http.MethodGet, "/api/v3/pet/findByStatus?status=sold",
+		"", "")))
+	errs := v.ValidateRequest(newTestRequest(http.MethodGet, "/api/v3/pet/findByStatus?status=lost", "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "query parameter 'status' is not valid: value must be one of "+
+		"['available', 'pending', 'sold']", errs[0].Message)
+
+	errs = v.ValidateRequest(newTestRequest(http.MethodPost, "/api/v3/pet", "application/json",
+		`{"name": "doggie", "photoUrls": "nope"}`))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/photoUrls", errs[0].SchemaErrors[0].Path)
+	assert.Equal(t, "#/components/schemas/Pet/properties/photoUrls/type", errs[0].SchemaErrors[0].SchemaPath)
+}
+
+func TestHTTPValidator_DocumentFromScratch(t *testing.T) {
+	doc := &v3high.Document{
+		Version: "3.1.0",
+		Info:    &base.Info{Title: "scratch", Version: "1.0"},
+		Paths: &v3high.Paths{PathItems: map[string]*v3high.PathItem{
+			"/things/{id}": {Get: &v3high.Operation{
+				Parameters: []*v3high.Parameter{{Name: "id", In: "path", Required: true,
+					Schema: base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}})}},
+				Responses: &v3high.Responses{Codes: map[string]*v3high.Response{
+					"200": {Description: "ok"}}},
+			}},
+		}},
+	}
+	v, err := NewHTTPValidator(doc)
+	assert.NoError(t, err)
+	assert.Empty(t, v.ValidateRequest(newTestRequest(http.MethodGet, "/things/1", "", "")))
+	errs := v.ValidateRequest(newTestRequest(http.MethodGet, "/things/one", "", ""))
+	assert.Len(t, errs, 1)
+	assert.NotZero(t, errs[0].SpecLine)
+	assert.Equal(t, "#/paths/~1things~1{id}/get/parameters/0: path parameter 'id' is not valid: "+
+		"expected integer, found string, line 9, col 19", errs[0].Error())
+}
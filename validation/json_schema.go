@@ -0,0 +1,1095 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//go:embed schemas/json-schema-draft-04.json
+var jsonSchemaDraft4Data string
+
+// knownSchemas are schemas that can be referenced from another schema by their URI.
+var knownSchemas = map[string]string{
+	"http://json-schema.org/draft-04/schema": jsonSchemaDraft4Data,
+}
+
+var compiledKnownSchemas = make(map[string]*JSONSchema)
+var compiledKnownSchemaLock sync.Mutex
+
+// Direction determines how the readOnly and writeOnly keywords are treated when validating.
+type Direction int
+
+const (
+	// Any ignores readOnly and writeOnly.
+	Any Direction = iota
+
+	// Request is used when validating a request, readOnly properties are not required and must not be sent.
+	Request
+
+	// Response is used when validating a response, writeOnly properties are not required and must not be sent.
+	Response
+)
+
+// JSONSchema is a compiled JSON Schema that is able to validate a yaml.Node tree. Because the tree is validated
+// in its node form, every ValidationError returned carries the line and column of the offending key or value. The
+// schema is also kept in its node form, so every ValidationError knows the line and column of the failing keyword.
+//
+// The keywords understood are the validation and applicator keywords of drafts 4 through to 2020-12, which covers
+// everything used by the OpenAPI and Swagger meta-schemas. OpenAPI 'discriminator' is understood, as is 'nullable'
+// (unless the root is an OpenAPI 3.1 document, which uses type arrays instead), and 'readOnly' and 'writeOnly' when
+// validating in the Request or Response Direction. References are resolved locally (JSON pointers and anchors),
+// and references to the JSON Schema draft 4 meta-schema are resolved against an embedded copy. When the schema is
+// used by a SchemaValidator or HTTPValidator, references to other documents are resolved using the index of the
+// document that contains the reference. A reference that cannot be resolved is a failure. The 'format' keyword is
+// treated as an annotation and is not asserted, unless the schema is used by a SchemaValidator or HTTPValidator.
+type JSONSchema struct {
+	base      string // URI of a known schema or an indexed document, empty for everything else.
+	root      *yaml.Node
+	index     *index.SpecIndex // index of the document, nil if references to other documents cannot be resolved.
+	nullable  bool             // true if the OpenAPI 3.0 'nullable' keyword is understood.
+	formats   bool             // true if the 'format' keyword is asserted.
+	anchors   map[string]*yaml.Node
+	patterns  map[string]*regexp.Regexp
+	keywords  sync.Map // *yaml.Node -> map[string]int (index of each key in the mapping)
+	documents *indexedSchemas
+	lock      sync.RWMutex
+}
+
+// indexedSchemas holds the JSONSchema of every indexed document that has been referenced, and the JSONSchema of
+// the document that contains each reference node that has been followed.
+type indexedSchemas struct {
+	schemas   map[*index.SpecIndex]*JSONSchema
+	referrers map[*yaml.Node]*JSONSchema
+	lock      sync.Mutex
+}
+
+// location describes where in the instance tree the validator currently is.
+type location struct {
+	key   *yaml.Node // key node of the value, nil for array items and the root.
+	value *yaml.Node
+	path  string
+}
+
+// failedBranch holds the result of a failed 'anyOf' or 'oneOf' branch.
+type failedBranch struct {
+	errors    []*ValidationError
+	evaluated int
+}
+
+// visit is used to guard against schemas that reference themselves without consuming any of the instance.
+type visit struct {
+	schema *yaml.Node
+	node   *yaml.Node
+}
+
+// validationContext holds the state of a single validation run.
+type validationContext struct {
+	direction Direction
+	seen      map[visit]bool
+}
+
+// CompileJSONSchema will parse a JSON or YAML encoded JSON Schema, ready to validate yaml.Node trees. An error is
+// returned if the schema cannot be parsed.
+func CompileJSONSchema(schema []byte) (*JSONSchema, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("unable to compile schema: %s", err.Error())
+	}
+	return NewJSONSchema(&root), nil
+}
+
+// NewJSONSchema will create a JSONSchema from an already parsed root node. The root node can be an entire
+// document (like an OpenAPI specification) that contains schemas, which can then be validated against using
+// ValidateNodeAgainst.
+func NewJSONSchema(root *yaml.Node) *JSONSchema {
+	s := &JSONSchema{
+		root:     resolveNode(root),
+		anchors:  make(map[string]*yaml.Node),
+		patterns: make(map[string]*regexp.Regexp),
+		documents: &indexedSchemas{
+			schemas:   make(map[*index.SpecIndex]*JSONSchema),
+			referrers: make(map[*yaml.Node]*JSONSchema),
+		},
+	}
+	s.nullable = true
+	if v := s.keyword(s.root, "openapi"); v != nil && strings.HasPrefix(v.Value, "3.1") {
+		s.nullable = false
+	}
+	s.collectAnchors(s.root, make(map[*yaml.Node]bool))
+	return s
+}
+
+// ValidateNode will validate a yaml.Node tree against the schema. If the node is valid, nil is returned, otherwise
+// a slice of ValidationError pointers explains every failure.
+func (s *JSONSchema) ValidateNode(node *yaml.Node) []*ValidationError {
+	return s.ValidateNodeAgainst(s.root, "#", node, Any)
+}
+
+// ValidateNodeAgainst will validate a yaml.Node tree against a schema node that belongs to this JSONSchema (for
+// example a schema inside an OpenAPI specification), any references are resolved against the root. The schema path
+// is used as the base of the SchemaPath for every error returned. The direction determines how readOnly and
+// writeOnly properties are treated.
+func (s *JSONSchema) ValidateNodeAgainst(schema *yaml.Node, schemaPath string, node *yaml.Node,
+	direction Direction) []*ValidationError {
+	if node == nil || schema == nil {
+		return nil
+	}
+	ctx := &validationContext{direction: direction, seen: make(map[visit]bool)}
+	errs, _ := s.validate(resolveNode(schema), schemaPath, &location{value: resolveNode(node), path: "#"}, ctx)
+	return errs
+}
+
+func (s *JSONSchema) collectAnchors(schema *yaml.Node, seen map[*yaml.Node]bool) {
+	schema = resolveNode(schema)
+	if schema == nil || seen[schema] {
+		return
+	}
+	seen[schema] = true
+	switch schema.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(schema.Content)-1; i += 2 {
+			k, v := schema.Content[i].Value, schema.Content[i+1]
+			switch k {
+			case "$anchor", "$dynamicAnchor":
+				s.anchors[v.Value] = schema
+			case "enum", "const", "default", "examples", "example":
+				continue
+			}
+			s.collectAnchors(v, seen)
+		}
+	case yaml.SequenceNode:
+		for _, v := range schema.Content {
+			s.collectAnchors(v, seen)
+		}
+	}
+}
+
+// resolve locates the target of a reference, returning the schema, the JSON pointer to it and the JSONSchema that
+// owns it. Local references and references to known schemas (see knownSchemas) can always be resolved, references
+// to other documents are resolved if they have been indexed.
+func (s *JSONSchema) resolve(ref string) (*yaml.Node, string, *JSONSchema) {
+	if !strings.HasPrefix(ref, "#") {
+		base, fragment, _ := strings.Cut(ref, "#")
+		owner := compileKnownSchema(base)
+		if owner == nil && s.index != nil {
+			if _, doc := s.index.FindIndexedComponent(base); doc != nil {
+				owner = s.indexedSchema(doc)
+			}
+		}
+		if owner == nil {
+			return nil, "", nil
+		}
+		return owner.resolve("#" + fragment)
+	}
+	fragment := ref[1:]
+	if s.root == nil {
+		return nil, "", nil
+	}
+	if fragment == "" {
+		return s.root, s.base + "#", s
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		if a, ok := s.anchors[fragment]; ok {
+			return a, s.base + ref, s
+		}
+		return nil, "", nil
+	}
+	current := s.root
+	for _, seg := range strings.Split(fragment[1:], "/") {
+		if unescaped, err := url.PathUnescape(seg); err == nil {
+			seg = unescaped
+		}
+		seg = strings.ReplaceAll(strings.ReplaceAll(seg, "~1", "/"), "~0", "~")
+		switch current.Kind {
+		case yaml.MappingNode:
+			current = s.keyword(current, seg)
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(current.Content) {
+				return nil, "", nil
+			}
+			current = resolveNode(current.Content[i])
+		default:
+			return nil, "", nil
+		}
+		if current == nil {
+			return nil, "", nil
+		}
+	}
+	return current, s.base + ref, s
+}
+
+// indexedSchema returns the JSONSchema of an indexed document, which is created the first time it is needed. The
+// document inherits the settings of this JSONSchema.
+func (s *JSONSchema) indexedSchema(doc *index.SpecIndex) *JSONSchema {
+	if doc == s.index {
+		return s
+	}
+	s.documents.lock.Lock()
+	defer s.documents.lock.Unlock()
+	if owner, ok := s.documents.schemas[doc]; ok {
+		return owner
+	}
+	owner := NewJSONSchema(doc.GetRootNode())
+	owner.base = doc.GetLocation()
+	owner.index = doc
+	owner.nullable = s.nullable
+	owner.formats = s.formats
+	owner.documents = s.documents
+	s.documents.schemas[doc] = owner
+	return owner
+}
+
+// referrer returns the JSONSchema of the document that contains a reference node, because a reference is relative
+// to the document it is in. A schema from another document can be validated by this JSONSchema (a SchemaProxy
+// that references another document is rendered using the node it references).
+func (s *JSONSchema) referrer(refNode *yaml.Node) *JSONSchema {
+	if s.index == nil {
+		return s
+	}
+	s.documents.lock.Lock()
+	owner, ok := s.documents.referrers[refNode]
+	s.documents.lock.Unlock()
+	if ok {
+		return owner
+	}
+	owner = s
+	if doc := s.index.FindReferenceIndex(refNode); doc != nil {
+		owner = s.indexedSchema(doc)
+	}
+	s.documents.lock.Lock()
+	s.documents.referrers[refNode] = owner
+	s.documents.lock.Unlock()
+	return owner
+}
+
+// compileKnownSchema compiles one of the knownSchemas once, and then caches it. If the URI is not known, then
+// nil is returned.
+func compileKnownSchema(uri string) *JSONSchema {
+	compiledKnownSchemaLock.Lock()
+	defer compiledKnownSchemaLock.Unlock()
+	if s, ok := compiledKnownSchemas[uri]; ok {
+		return s
+	}
+	data, known := knownSchemas[uri]
+	if !known {
+		return nil
+	}
+	s, err := CompileJSONSchema([]byte(data))
+	if err != nil {
+		return nil
+	}
+	s.base = uri
+	compiledKnownSchemas[uri] = s
+	return s
+}
+
+func (s *JSONSchema) regex(pattern string) *regexp.Regexp {
+	s.lock.RLock()
+	r, ok := s.patterns[pattern]
+	s.lock.RUnlock()
+	if ok {
+		return r
+	}
+	r, _ = regexp.Compile(pattern)
+	s.lock.Lock()
+	s.patterns[pattern] = r
+	s.lock.Unlock()
+	return r
+}
+
+// keywordIndex returns the position of every key in a mapping node, the result is cached.
+func (s *JSONSchema) keywordIndex(schema *yaml.Node) map[string]int {
+	if cached, ok := s.keywords.Load(schema); ok {
+		return cached.(map[string]int)
+	}
+	index := make(map[string]int, len(schema.Content)/2)
+	for i := 0; i < len(schema.Content)-1; i += 2 {
+		if _, exists := index[schema.Content[i].Value]; !exists {
+			index[schema.Content[i].Value] = i
+		}
+	}
+	s.keywords.Store(schema, index)
+	return index
+}
+
+// keyword returns the value of a keyword in a schema, or nil if the keyword is not present.
+func (s *JSONSchema) keyword(schema *yaml.Node, name string) *yaml.Node {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return nil
+	}
+	if i, ok := s.keywordIndex(schema)[name]; ok {
+		return resolveNode(schema.Content[i+1])
+	}
+	return nil
+}
+
+// validate checks a single instance location against a schema, returning any errors and the set of property
+// names that were evaluated by the schema (used by 'unevaluatedProperties').
+func (s *JSONSchema) validate(schema *yaml.Node, schemaPath string, loc *location,
+	ctx *validationContext) ([]*ValidationError, map[string]bool) {
+
+	schema = resolveNode(schema)
+	if schema == nil {
+		return nil, nil
+	}
+	if schema.Kind == yaml.ScalarNode && schema.Tag == "!!bool" {
+		if schema.Value == "false" {
+			return []*ValidationError{s.newError(loc, schema, schemaPath, "", "value is not allowed")}, nil
+		}
+		return nil, nil
+	}
+	if schema.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	v := visit{schema: schema, node: loc.value}
+	if ctx.seen[v] {
+		return nil, nil
+	}
+	ctx.seen[v] = true
+	defer delete(ctx.seen, v)
+
+	var errs []*ValidationError
+	evaluated := make(map[string]bool)
+	node := loc.value
+
+	// references are applied alongside any sibling keywords.
+	for _, k := range []string{"$ref", "$dynamicRef"} {
+		if ref := s.keyword(schema, k); ref != nil && ref.Kind == yaml.ScalarNode {
+			target, targetPath, owner := s.referrer(schema).resolve(ref.Value)
+			if target == nil {
+				errs = append(errs, s.newError(loc, schema, schemaPath, k,
+					fmt.Sprintf("unable to resolve reference '%s'", ref.Value)))
+				continue
+			}
+			e, ev := owner.validate(target, targetPath, loc, ctx)
+			errs = append(errs, e...)
+			mergeEvaluated(evaluated, ev)
+		}
+	}
+
+	// OpenAPI 3.0 allows null for any type, when a schema is nullable.
+	nullable := s.nullable && isTrue(s.keyword(schema, "nullable")) && nodeType(node) == "null"
+
+	if t := s.keyword(schema, "type"); t != nil && !nullable {
+		if !matchesType(t, node) {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "type",
+				fmt.Sprintf("expected %s, found %s", describeType(t), nodeType(node))))
+			return errs, evaluated
+		}
+	}
+	if e := s.keyword(schema, "enum"); e != nil && e.Kind == yaml.SequenceNode && !nullable {
+		found := false
+		val := nodeValue(node)
+		for _, candidate := range e.Content {
+			if valuesEqual(val, nodeValue(candidate)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "enum",
+				fmt.Sprintf("value must be one of %s", describeValues(e))))
+		}
+	}
+	if c := s.keyword(schema, "const"); c != nil {
+		if !valuesEqual(nodeValue(node), nodeValue(c)) {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "const",
+				fmt.Sprintf("value must be '%v'", c.Value)))
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		e, ev := s.validateObject(schema, schemaPath, loc, ctx)
+		errs = append(errs, e...)
+		mergeEvaluated(evaluated, ev)
+	case yaml.SequenceNode:
+		errs = append(errs, s.validateArray(schema, schemaPath, loc, ctx)...)
+	case yaml.ScalarNode:
+		errs = append(errs, s.validateScalar(schema, schemaPath, loc)...)
+		if s.formats {
+			errs = append(errs, s.validateFormat(schema, schemaPath, loc)...)
+		}
+	}
+
+	// a discriminator selects a single branch of 'oneOf' or 'anyOf' to validate against.
+	discriminated := false
+	if d := s.keyword(schema, "discriminator"); d != nil && node.Kind == yaml.MappingNode {
+		var e []*ValidationError
+		var ev map[string]bool
+		discriminated, e, ev = s.validateDiscriminator(schema, d, schemaPath, loc, ctx)
+		errs = append(errs, e...)
+		mergeEvaluated(evaluated, ev)
+	}
+
+	// in-place applicators
+	if all := s.keyword(schema, "allOf"); all != nil && all.Kind == yaml.SequenceNode {
+		for i, sub := range all.Content {
+			e, ev := s.validate(sub, fmt.Sprintf("%s/allOf/%d", schemaPath, i), loc, ctx)
+			errs = append(errs, e...)
+			mergeEvaluated(evaluated, ev)
+		}
+	}
+	if anyOf := s.keyword(schema, "anyOf"); !discriminated && anyOf != nil && anyOf.Kind == yaml.SequenceNode &&
+		len(anyOf.Content) > 0 {
+		var failed []failedBranch
+		for i, sub := range anyOf.Content {
+			e, ev := s.validate(sub, fmt.Sprintf("%s/anyOf/%d", schemaPath, i), loc, ctx)
+			if len(e) == 0 {
+				mergeEvaluated(evaluated, ev)
+			} else {
+				failed = append(failed, failedBranch{errors: e, evaluated: len(ev)})
+			}
+		}
+		if len(failed) == len(anyOf.Content) {
+			errs = append(errs, s.bestMatch(failed, loc, schema, schemaPath, "anyOf")...)
+		}
+	}
+	if oneOf := s.keyword(schema, "oneOf"); !discriminated && oneOf != nil && oneOf.Kind == yaml.SequenceNode {
+		var failed []failedBranch
+		var valid []int
+		for i, sub := range oneOf.Content {
+			e, ev := s.validate(sub, fmt.Sprintf("%s/oneOf/%d", schemaPath, i), loc, ctx)
+			if len(e) == 0 {
+				valid = append(valid, i)
+				mergeEvaluated(evaluated, ev)
+			} else {
+				failed = append(failed, failedBranch{errors: e, evaluated: len(ev)})
+			}
+		}
+		if len(valid) == 0 {
+			errs = append(errs, s.bestMatch(failed, loc, schema, schemaPath, "oneOf")...)
+		}
+		if len(valid) > 1 {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "oneOf",
+				fmt.Sprintf("value matches more than one schema (%s), but must match exactly one",
+					joinInts(valid))))
+		}
+	}
+	if not := s.keyword(schema, "not"); not != nil {
+		if e, _ := s.validate(not, schemaPath+"/not", loc, ctx); len(e) == 0 {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "not",
+				"value must not be valid against the schema defined in 'not'"))
+		}
+	}
+	if cond := s.keyword(schema, "if"); cond != nil {
+		e, ev := s.validate(cond, schemaPath+"/if", loc, ctx)
+		if len(e) == 0 {
+			mergeEvaluated(evaluated, ev)
+			if then := s.keyword(schema, "then"); then != nil {
+				e, ev = s.validate(then, schemaPath+"/then", loc, ctx)
+				errs = append(errs, e...)
+				mergeEvaluated(evaluated, ev)
+			}
+		} else if els := s.keyword(schema, "else"); els != nil {
+			e, ev = s.validate(els, schemaPath+"/else", loc, ctx)
+			errs = append(errs, e...)
+			mergeEvaluated(evaluated, ev)
+		}
+	}
+
+	// unevaluated properties can only be checked once everything else has had a look.
+	if unevaluated := s.keyword(schema, "unevaluatedProperties"); unevaluated != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			name := node.Content[i].Value
+			if evaluated[name] {
+				continue
+			}
+			child := childLocation(loc, node.Content[i], node.Content[i+1])
+			if isFalse(unevaluated) {
+				errs = append(errs, s.newError(child, schema, schemaPath, "unevaluatedProperties",
+					fmt.Sprintf("property '%s' is not allowed", name)))
+				continue
+			}
+			e, _ := s.validate(unevaluated, schemaPath+"/unevaluatedProperties", child, ctx)
+			errs = append(errs, e...)
+			evaluated[name] = true
+		}
+	}
+	return errs, evaluated
+}
+
+func (s *JSONSchema) validateObject(schema *yaml.Node, schemaPath string, loc *location,
+	ctx *validationContext) ([]*ValidationError, map[string]bool) {
+
+	var errs []*ValidationError
+	evaluated := make(map[string]bool)
+	node := loc.value
+	count := len(node.Content) / 2
+
+	present := make(map[string]bool, count)
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		present[node.Content[i].Value] = true
+	}
+
+	props := s.keyword(schema, "properties")
+	if req := s.keyword(schema, "required"); req != nil && req.Kind == yaml.SequenceNode {
+		for _, r := range req.Content {
+			if !present[r.Value] && !s.skipProperty(s.keyword(props, r.Value), ctx.direction) {
+				errs = append(errs, s.newError(loc, schema, schemaPath, "required",
+					fmt.Sprintf("missing required property '%s'", r.Value)))
+			}
+		}
+	}
+	if min, ok := toFloat(s.keyword(schema, "minProperties")); ok && float64(count) < min {
+		errs = append(errs, s.newError(loc, schema, schemaPath, "minProperties",
+			fmt.Sprintf("must have at least %v properties, found %d", min, count)))
+	}
+	if max, ok := toFloat(s.keyword(schema, "maxProperties")); ok && float64(count) > max {
+		errs = append(errs, s.newError(loc, schema, schemaPath, "maxProperties",
+			fmt.Sprintf("must have no more than %v properties, found %d", max, count)))
+	}
+
+	patternProps := s.keyword(schema, "patternProperties")
+	additional := s.keyword(schema, "additionalProperties")
+	names := s.keyword(schema, "propertyNames")
+
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		name := keyNode.Value
+		child := childLocation(loc, keyNode, valueNode)
+
+		if names != nil {
+			nameNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name,
+				Line: keyNode.Line, Column: keyNode.Column}
+			e, _ := s.validate(names, schemaPath+"/propertyNames",
+				&location{value: nameNode, path: child.path}, ctx)
+			errs = append(errs, e...)
+		}
+
+		matched := false
+		if sub := s.keyword(props, name); sub != nil {
+			matched = true
+			propertyPath := schemaPath + "/properties/" + escapePointer(name)
+			if s.skipProperty(sub, ctx.direction) {
+				keyword := directionKeyword(ctx.direction)
+				errs = append(errs, s.newError(child, sub, propertyPath, keyword,
+					fmt.Sprintf("property '%s' is %s and must not be sent", name, keyword)))
+			}
+			e, _ := s.validate(sub, propertyPath, child, ctx)
+			errs = append(errs, e...)
+		}
+		if patternProps != nil && patternProps.Kind == yaml.MappingNode {
+			for p := 0; p < len(patternProps.Content)-1; p += 2 {
+				pattern := patternProps.Content[p].Value
+				if r := s.regex(pattern); r != nil && r.MatchString(name) {
+					matched = true
+					e, _ := s.validate(patternProps.Content[p+1],
+						schemaPath+"/patternProperties/"+escapePointer(pattern), child, ctx)
+					errs = append(errs, e...)
+				}
+			}
+		}
+		if !matched && additional != nil {
+			matched = true
+			if isFalse(additional) {
+				errs = append(errs, s.newError(child, schema, schemaPath, "additionalProperties",
+					fmt.Sprintf("property '%s' is not allowed", name)))
+			} else {
+				e, _ := s.validate(additional, schemaPath+"/additionalProperties", child, ctx)
+				errs = append(errs, e...)
+			}
+		}
+		if matched {
+			evaluated[name] = true
+		}
+	}
+
+	if deps := s.keyword(schema, "dependentSchemas"); deps != nil && deps.Kind == yaml.MappingNode {
+		for d := 0; d < len(deps.Content)-1; d += 2 {
+			name := deps.Content[d].Value
+			if present[name] {
+				e, ev := s.validate(deps.Content[d+1], schemaPath+"/dependentSchemas/"+escapePointer(name), loc, ctx)
+				errs = append(errs, e...)
+				mergeEvaluated(evaluated, ev)
+			}
+		}
+	}
+	if deps := s.keyword(schema, "dependencies"); deps != nil && deps.Kind == yaml.MappingNode {
+		for d := 0; d < len(deps.Content)-1; d += 2 {
+			name, dep := deps.Content[d].Value, resolveNode(deps.Content[d+1])
+			if !present[name] {
+				continue
+			}
+			if dep.Kind == yaml.SequenceNode {
+				for _, r := range dep.Content {
+					if !present[r.Value] {
+						errs = append(errs, s.newError(loc, schema, schemaPath, "dependencies",
+							fmt.Sprintf("property '%s' requires property '%s'", name, r.Value)))
+					}
+				}
+				continue
+			}
+			e, _ := s.validate(dep, schemaPath+"/dependencies/"+escapePointer(name), loc, ctx)
+			errs = append(errs, e...)
+		}
+	}
+	return errs, evaluated
+}
+
+// validateDiscriminator validates an object against the 'oneOf' or 'anyOf' branch selected by the value of its
+// discriminator property. The value is looked up in the discriminator mapping, or is used as the name of a schema in
+// the components of the document. If there are no 'oneOf' or 'anyOf' branches, then nothing is selected.
+func (s *JSONSchema) validateDiscriminator(schema, discriminator *yaml.Node, schemaPath string, loc *location,
+	ctx *validationContext) (bool, []*ValidationError, map[string]bool) {
+
+	keyword := "oneOf"
+	branches := s.keyword(schema, keyword)
+	if branches == nil {
+		keyword = "anyOf"
+		branches = s.keyword(schema, keyword)
+	}
+	propertyName := s.keyword(discriminator, "propertyName")
+	if branches == nil || branches.Kind != yaml.SequenceNode || propertyName == nil {
+		return false, nil, nil
+	}
+	var child *location
+	for i := 0; i < len(loc.value.Content)-1; i += 2 {
+		if loc.value.Content[i].Value == propertyName.Value {
+			child = childLocation(loc, loc.value.Content[i], loc.value.Content[i+1])
+		}
+	}
+	if child == nil {
+		return true, []*ValidationError{s.newError(loc, schema, schemaPath, "discriminator",
+			fmt.Sprintf("missing discriminator property '%s'", propertyName.Value))}, nil
+	}
+	value := child.value.Value
+	ref := "#/components/schemas/" + escapePointer(value)
+	if mapped := s.keyword(s.keyword(discriminator, "mapping"), value); mapped != nil {
+		ref = mapped.Value
+		if !strings.Contains(ref, "/") {
+			ref = "#/components/schemas/" + escapePointer(ref)
+		}
+	}
+	target, _, _ := s.resolve(ref)
+	for i, branch := range branches.Content {
+		if s.isSchema(resolveNode(branch), ref, target) {
+			e, ev := s.validate(branch, fmt.Sprintf("%s/%s/%d", schemaPath, keyword, i), loc, ctx)
+			return true, e, ev
+		}
+	}
+	return true, []*ValidationError{s.newError(child, schema, schemaPath, "discriminator",
+		fmt.Sprintf("discriminator value '%s' does not match any of the schemas defined in '%s'",
+			value, keyword))}, nil
+}
+
+// isSchema returns true if a schema is the target of a reference, or is a reference to it.
+func (s *JSONSchema) isSchema(schema *yaml.Node, ref string, target *yaml.Node) bool {
+	if target != nil && schema == target {
+		return true
+	}
+	r := s.keyword(schema, "$ref")
+	if r == nil {
+		return false
+	}
+	if r.Value == ref {
+		return true
+	}
+	resolved, _, _ := s.resolve(r.Value)
+	return resolved != nil && resolved == target
+}
+
+// skipProperty returns true if a property schema is readOnly when validating a request, or writeOnly when
+// validating a response.
+func (s *JSONSchema) skipProperty(property *yaml.Node, direction Direction) bool {
+	if property == nil || direction == Any {
+		return false
+	}
+	return isTrue(s.keyword(property, directionKeyword(direction)))
+}
+
+func directionKeyword(direction Direction) string {
+	if direction == Request {
+		return "readOnly"
+	}
+	return "writeOnly"
+}
+
+func (s *JSONSchema) validateArray(schema *yaml.Node, schemaPath string, loc *location,
+	ctx *validationContext) []*ValidationError {
+
+	var errs []*ValidationError
+	node := loc.value
+	count := len(node.Content)
+
+	if min, ok := toFloat(s.keyword(schema, "minItems")); ok && float64(count) < min {
+		errs = append(errs, s.newError(loc, schema, schemaPath, "minItems",
+			fmt.Sprintf("must have at least %v items, found %d", min, count)))
+	}
+	if max, ok := toFloat(s.keyword(schema, "maxItems")); ok && float64(count) > max {
+		errs = append(errs, s.newError(loc, schema, schemaPath, "maxItems",
+			fmt.Sprintf("must have no more than %v items, found %d", max, count)))
+	}
+	if isTrue(s.keyword(schema, "uniqueItems")) {
+		values := make([]any, count)
+		for i := range node.Content {
+			values[i] = nodeValue(node.Content[i])
+		}
+	uniqueCheck:
+		for i := 0; i < count; i++ {
+			for j := i + 1; j < count; j++ {
+				if valuesEqual(values[i], values[j]) {
+					errs = append(errs, s.newError(itemLocation(loc, j, node.Content[j]), schema, schemaPath,
+						"uniqueItems", fmt.Sprintf("items must be unique, item %d is a duplicate of item %d", j, i)))
+					break uniqueCheck
+				}
+			}
+		}
+	}
+
+	// tuples are defined by 'prefixItems' (2020-12) or by an array of 'items' (draft 4).
+	var prefix, rest *yaml.Node
+	prefixKeyword, restKeyword := "prefixItems", "items"
+	if p := s.keyword(schema, "prefixItems"); p != nil {
+		prefix, rest = p, s.keyword(schema, "items")
+	} else if p = s.keyword(schema, "items"); p != nil && p.Kind == yaml.SequenceNode {
+		prefix, rest = p, s.keyword(schema, "additionalItems")
+		prefixKeyword, restKeyword = "items", "additionalItems"
+	} else {
+		rest = s.keyword(schema, "items")
+	}
+	var prefixSchemas []*yaml.Node
+	if prefix != nil && prefix.Kind == yaml.SequenceNode {
+		prefixSchemas = prefix.Content
+	}
+	for i, item := range node.Content {
+		child := itemLocation(loc, i, item)
+		if i < len(prefixSchemas) {
+			e, _ := s.validate(prefixSchemas[i], fmt.Sprintf("%s/%s/%d", schemaPath, prefixKeyword, i), child, ctx)
+			errs = append(errs, e...)
+			continue
+		}
+		if rest == nil {
+			continue
+		}
+		if isFalse(rest) {
+			errs = append(errs, s.newError(child, schema, schemaPath, restKeyword,
+				fmt.Sprintf("item %d is not allowed", i)))
+			continue
+		}
+		e, _ := s.validate(rest, schemaPath+"/"+restKeyword, child, ctx)
+		errs = append(errs, e...)
+	}
+	if contains := s.keyword(schema, "contains"); contains != nil {
+		found := false
+		for i, item := range node.Content {
+			if e, _ := s.validate(contains, schemaPath+"/contains", itemLocation(loc, i, item), ctx); len(e) == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "contains",
+				"no items match the schema defined in 'contains'"))
+		}
+	}
+	return errs
+}
+
+func (s *JSONSchema) validateScalar(schema *yaml.Node, schemaPath string, loc *location) []*ValidationError {
+	var errs []*ValidationError
+	node := loc.value
+	switch nodeType(node) {
+	case "string":
+		length := float64(utf8.RuneCountInString(node.Value))
+		if min, ok := toFloat(s.keyword(schema, "minLength")); ok && length < min {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "minLength",
+				fmt.Sprintf("must be at least %v characters long", min)))
+		}
+		if max, ok := toFloat(s.keyword(schema, "maxLength")); ok && length > max {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "maxLength",
+				fmt.Sprintf("must be no more than %v characters long", max)))
+		}
+		if pattern := s.keyword(schema, "pattern"); pattern != nil {
+			if r := s.regex(pattern.Value); r != nil && !r.MatchString(node.Value) {
+				errs = append(errs, s.newError(loc, schema, schemaPath, "pattern",
+					fmt.Sprintf("'%s' does not match pattern '%s'", node.Value, pattern.Value)))
+			}
+		}
+	case "integer", "number":
+		n, ok := toFloat(node)
+		if !ok {
+			return errs
+		}
+		exclusiveMin, exclusiveMax := s.keyword(schema, "exclusiveMinimum"), s.keyword(schema, "exclusiveMaximum")
+		if min, ok := toFloat(s.keyword(schema, "minimum")); ok {
+			if isTrue(exclusiveMin) && n <= min {
+				errs = append(errs, s.newError(loc, schema, schemaPath, "exclusiveMinimum",
+					fmt.Sprintf("must be greater than %v", min)))
+			} else if n < min {
+				errs = append(errs, s.newError(loc, schema, schemaPath, "minimum",
+					fmt.Sprintf("must be greater than or equal to %v", min)))
+			}
+		}
+		if max, ok := toFloat(s.keyword(schema, "maximum")); ok {
+			if isTrue(exclusiveMax) && n >= max {
+				errs = append(errs, s.newError(loc, schema, schemaPath, "exclusiveMaximum",
+					fmt.Sprintf("must be less than %v", max)))
+			} else if n > max {
+				errs = append(errs, s.newError(loc, schema, schemaPath, "maximum",
+					fmt.Sprintf("must be less than or equal to %v", max)))
+			}
+		}
+		if min, ok := toFloat(exclusiveMin); ok && n <= min {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "exclusiveMinimum",
+				fmt.Sprintf("must be greater than %v", min)))
+		}
+		if max, ok := toFloat(exclusiveMax); ok && n >= max {
+			errs = append(errs, s.newError(loc, schema, schemaPath, "exclusiveMaximum",
+				fmt.Sprintf("must be less than %v", max)))
+		}
+		if multiple, ok := toFloat(s.keyword(schema, "multipleOf")); ok && multiple > 0 {
+			if q := n / multiple; math.Abs(q-math.Round(q)) > 1e-9 {
+				errs = append(errs, s.newError(loc, schema, schemaPath, "multipleOf",
+					fmt.Sprintf("must be a multiple of %v", multiple)))
+			}
+		}
+	}
+	return errs
+}
+
+// bestMatch picks the errors from the failed branches of 'anyOf' or 'oneOf' that are the most relevant. The branch
+// that recognized the most properties of the instance is most likely the one that was intended, after that errors
+// found deeper in the instance are more specific, with any remaining ties going to the branch with the fewest errors.
+func (s *JSONSchema) bestMatch(failed []failedBranch, loc *location, schema *yaml.Node,
+	schemaPath, keyword string) []*ValidationError {
+	if len(failed) == 0 {
+		return []*ValidationError{s.newError(loc, schema, schemaPath, keyword,
+			fmt.Sprintf("value does not match any of the schemas defined in '%s'", keyword))}
+	}
+	best := failed[0]
+	for _, f := range failed[1:] {
+		if f.evaluated != best.evaluated {
+			if f.evaluated > best.evaluated {
+				best = f
+			}
+			continue
+		}
+		if d, bd := errorDepth(f.errors), errorDepth(best.errors); d > bd || (d == bd && len(f.errors) < len(best.errors)) {
+			best = f
+		}
+	}
+	return best.errors
+}
+
+func errorDepth(errs []*ValidationError) int {
+	depth := 0
+	for _, e := range errs {
+		if d := strings.Count(e.Path, "/"); d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+// newError creates a ValidationError for a keyword of a schema that failed. If there is no keyword, then the
+// schema itself failed.
+func (s *JSONSchema) newError(loc *location, schema *yaml.Node, schemaPath, keyword, message string) *ValidationError {
+	node := loc.key
+	if node == nil {
+		node = loc.value
+	}
+	schemaNode := schema
+	if keyword != "" {
+		schemaPath = schemaPath + "/" + keyword
+		if i, ok := s.keywordIndex(schema)[keyword]; ok {
+			schemaNode = schema.Content[i]
+		}
+	} else {
+		keyword = schema.Value
+	}
+	return &ValidationError{
+		Message:      message,
+		Keyword:      keyword,
+		Path:         loc.path,
+		SchemaPath:   schemaPath,
+		Line:         node.Line,
+		Column:       node.Column,
+		Node:         node,
+		SchemaLine:   schemaNode.Line,
+		SchemaColumn: schemaNode.Column,
+	}
+}
+
+func childLocation(parent *location, key, value *yaml.Node) *location {
+	return &location{key: key, value: resolveNode(value), path: parent.path + "/" + escapePointer(key.Value)}
+}
+
+func itemLocation(parent *location, index int, value *yaml.Node) *location {
+	return &location{value: resolveNode(value), path: fmt.Sprintf("%s/%d", parent.path, index)}
+}
+
+// resolveNode unwraps document and alias nodes.
+func resolveNode(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) > 0:
+			node = node.Content[0]
+		case node.Kind == yaml.AliasNode && node.Alias != nil:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+	return node
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func isTrue(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && node.Tag == "!!bool" && node.Value == "true"
+}
+
+func isFalse(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && node.Tag == "!!bool" && node.Value == "false"
+}
+
+// nodeType returns the JSON type of a node.
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.Tag {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		if f, ok := toFloat(node); ok && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "string"
+}
+
+func matchesType(t *yaml.Node, node *yaml.Node) bool {
+	actual := nodeType(node)
+	check := func(expected string) bool {
+		return expected == actual || (expected == "number" && actual == "integer")
+	}
+	switch t.Kind {
+	case yaml.ScalarNode:
+		return check(t.Value)
+	case yaml.SequenceNode:
+		for _, e := range t.Content {
+			if check(e.Value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func describeType(t *yaml.Node) string {
+	if t.Kind == yaml.SequenceNode {
+		var types []string
+		for _, e := range t.Content {
+			types = append(types, e.Value)
+		}
+		return strings.Join(types, " or ")
+	}
+	return t.Value
+}
+
+func describeValues(values *yaml.Node) string {
+	var out []string
+	for _, v := range values.Content {
+		out = append(out, fmt.Sprintf("'%v'", v.Value))
+	}
+	return "[" + strings.Join(out, ", ") + "]"
+}
+
+func joinInts(ints []int) string {
+	var out []string
+	for _, i := range ints {
+		out = append(out, strconv.Itoa(i))
+	}
+	return strings.Join(out, ", ")
+}
+
+// nodeValue converts a node into a plain JSON compatible value, all numbers are float64.
+func nodeValue(node *yaml.Node) any {
+	node = resolveNode(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		m := make(map[string]any, len(node.Content)/2)
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			m[node.Content[i].Value] = nodeValue(node.Content[i+1])
+		}
+		return m
+	case yaml.SequenceNode:
+		s := make([]any, len(node.Content))
+		for i := range node.Content {
+			s[i] = nodeValue(node.Content[i])
+		}
+		return s
+	}
+	switch nodeType(node) {
+	case "null":
+		return nil
+	case "boolean":
+		b, _ := strconv.ParseBool(node.Value)
+		return b
+	case "integer", "number":
+		if f, ok := toFloat(node); ok {
+			return f
+		}
+	}
+	return node.Value
+}
+
+func valuesEqual(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// toFloat returns the numeric value of a scalar node.
+func toFloat(node *yaml.Node) (float64, bool) {
+	if node == nil || node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+		return 0, false
+	}
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return 0, false
+	}
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func mergeEvaluated(into, from map[string]bool) {
+	for k := range from {
+		into[k] = true
+	}
+}
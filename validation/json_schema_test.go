@@ -0,0 +1,550 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func validateYAML(t *testing.T, schema, instance string) []*ValidationError {
+	s, err := CompileJSONSchema([]byte(schema))
+	assert.NoError(t, err)
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(instance), &node))
+	return s.ValidateNode(&node)
+}
+
+func TestCompileJSONSchema_Bad(t *testing.T) {
+	_, err := CompileJSONSchema([]byte("{{ nope"))
+	assert.Error(t, err)
+}
+
+func TestJSONSchema_ValidateNode_Nil(t *testing.T) {
+	s, _ := CompileJSONSchema([]byte(`type: object`))
+	assert.Nil(t, s.ValidateNode(nil))
+}
+
+func TestJSONSchema_Valid(t *testing.T) {
+	schema := `type: object
+required: [name]
+properties:
+  name:
+    type: string
+    minLength: 1
+  tags:
+    type: array
+    items:
+      type: string
+    uniqueItems: true
+  age:
+    type: integer
+    minimum: 0`
+
+	errs := validateYAML(t, schema, `name: pizza
+tags: [hot, cheesy]
+age: 2`)
+	assert.Nil(t, errs)
+}
+
+func TestJSONSchema_Required_LineCol(t *testing.T) {
+	schema := `type: object
+properties:
+  burger:
+    type: object
+    required: [name]`
+
+	errs := validateYAML(t, schema, `burger:
+  size: large`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "required", errs[0].Keyword)
+	assert.Equal(t, "#/burger", errs[0].Path)
+	assert.Equal(t, "#/properties/burger/required", errs[0].SchemaPath)
+	assert.Equal(t, 1, errs[0].Line)
+	assert.Equal(t, 1, errs[0].Column)
+	assert.Equal(t, "#/burger: missing required property 'name', line 1, col 1", errs[0].Error())
+}
+
+func TestJSONSchema_AdditionalProperties(t *testing.T) {
+	schema := `type: object
+properties:
+  name:
+    type: string
+patternProperties:
+  '^x-': {}
+additionalProperties: false`
+
+	errs := validateYAML(t, schema, `name: pizza
+x-cheese: true
+toppings: pepperoni`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "additionalProperties", errs[0].Keyword)
+	assert.Equal(t, "property 'toppings' is not allowed", errs[0].Message)
+	assert.Equal(t, 3, errs[0].Line)
+	assert.Equal(t, 1, errs[0].Column)
+}
+
+func TestJSONSchema_Type(t *testing.T) {
+	schema := `type: object
+properties:
+  name:
+    type: string
+  count:
+    type: [integer, "null"]
+  price:
+    type: number`
+
+	errs := validateYAML(t, schema, `name: 12
+count: 1.5
+price: 2`)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "expected string, found integer", errs[0].Message)
+	assert.Equal(t, "expected integer or null, found number", errs[1].Message)
+
+	assert.Nil(t, validateYAML(t, schema, `count: ~`))
+}
+
+func TestJSONSchema_EnumConst(t *testing.T) {
+	schema := `type: object
+properties:
+  size:
+    enum: [small, large, 1]
+  kind:
+    const: burger`
+
+	assert.Nil(t, validateYAML(t, schema, `size: 1.0`))
+	errs := validateYAML(t, schema, `size: medium
+kind: pizza`)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "value must be one of ['small', 'large', '1']", errs[0].Message)
+	assert.Equal(t, "value must be 'burger'", errs[1].Message)
+}
+
+func TestJSONSchema_Numbers(t *testing.T) {
+	schema := `type: object
+properties:
+  draft4:
+    minimum: 1
+    exclusiveMinimum: true
+    maximum: 10
+    exclusiveMaximum: true
+  modern:
+    exclusiveMinimum: 1
+    exclusiveMaximum: 10
+    multipleOf: 0.5
+  plain:
+    minimum: 1
+    maximum: 10`
+
+	assert.Nil(t, validateYAML(t, schema, `draft4: 5
+modern: 5.5
+plain: 10`))
+	errs := validateYAML(t, schema, `draft4: 1
+modern: 10
+plain: 11`)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "exclusiveMinimum", errs[0].Keyword)
+	assert.Equal(t, "exclusiveMaximum", errs[1].Keyword)
+	assert.Equal(t, "maximum", errs[2].Keyword)
+
+	errs = validateYAML(t, schema, `draft4: 10
+modern: 2.2
+plain: 0`)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "exclusiveMaximum", errs[0].Keyword)
+	assert.Equal(t, "multipleOf", errs[1].Keyword)
+	assert.Equal(t, "minimum", errs[2].Keyword)
+}
+
+func TestJSONSchema_Strings(t *testing.T) {
+	schema := `type: object
+properties:
+  code:
+    pattern: '^[0-9]{3}$'
+    minLength: 3
+    maxLength: 3`
+
+	errs := validateYAML(t, schema, `code: "1a"`)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "minLength", errs[0].Keyword)
+	assert.Equal(t, "'1a' does not match pattern '^[0-9]{3}$'", errs[1].Message)
+
+	errs = validateYAML(t, schema, `code: "1234"`)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "maxLength", errs[0].Keyword)
+}
+
+func TestJSONSchema_Arrays(t *testing.T) {
+	schema := `type: object
+properties:
+  list:
+    minItems: 2
+    maxItems: 3
+    uniqueItems: true
+    contains:
+      const: cheese
+  draft4Tuple:
+    items:
+      - type: string
+    additionalItems: false
+  modernTuple:
+    prefixItems:
+      - type: string
+    items:
+      type: integer`
+
+	assert.Nil(t, validateYAML(t, schema, `list: [cheese, ham]
+draft4Tuple: [one]
+modernTuple: [one, 2, 3]`))
+
+	errs := validateYAML(t, schema, `list: [ham, ham, ham, ham]
+draft4Tuple: [one, two]
+modernTuple: [1, two]`)
+	assert.Len(t, errs, 6)
+	assert.Equal(t, "maxItems", errs[0].Keyword)
+	assert.Equal(t, "uniqueItems", errs[1].Keyword)
+	assert.Equal(t, "#/list/1", errs[1].Path)
+	assert.Equal(t, "contains", errs[2].Keyword)
+	assert.Equal(t, "additionalItems", errs[3].Keyword)
+	assert.Equal(t, "#/draft4Tuple/1", errs[3].Path)
+	assert.Equal(t, "#/properties/modernTuple/prefixItems/0/type", errs[4].SchemaPath)
+	assert.Equal(t, "#/properties/modernTuple/items/type", errs[5].SchemaPath)
+
+	errs = validateYAML(t, schema, `list: [cheese]`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "minItems", errs[0].Keyword)
+}
+
+func TestJSONSchema_Objects(t *testing.T) {
+	schema := `type: object
+minProperties: 1
+maxProperties: 2
+propertyNames:
+  pattern: '^[a-z]+$'
+dependencies:
+  credit: [billing]`
+
+	errs := validateYAML(t, schema, `{}`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "minProperties", errs[0].Keyword)
+
+	errs = validateYAML(t, schema, `credit: 1
+Cheese: 2
+ham: 3`)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "maxProperties", errs[0].Keyword)
+	assert.Equal(t, "pattern", errs[1].Keyword)
+	assert.Equal(t, "#/Cheese", errs[1].Path)
+	assert.Equal(t, 2, errs[1].Line)
+	assert.Equal(t, "property 'credit' requires property 'billing'", errs[2].Message)
+}
+
+func TestJSONSchema_Refs(t *testing.T) {
+	schema := `$defs:
+  burger:
+    $anchor: burger
+    type: object
+    required: [name]
+  name:
+    type: string
+type: object
+properties:
+  pointer:
+    $ref: '#/$defs/burger'
+  anchor:
+    $ref: '#burger'
+  escaped:
+    $ref: '#/$defs/na%6De'
+  missing:
+    $ref: '#/$defs/nope'
+  external:
+    $ref: 'https://pb33f.io/nope.json'`
+
+	assert.Nil(t, validateYAML(t, schema, `escaped: hello`))
+
+	// references that cannot be resolved are failures.
+	errs := validateYAML(t, schema, `missing: 1
+external: 1`)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "$ref", errs[0].Keyword)
+	assert.Equal(t, "unable to resolve reference '#/$defs/nope'", errs[0].Message)
+	assert.Equal(t, "#/missing", errs[0].Path)
+	assert.Equal(t, "#/properties/missing/$ref", errs[0].SchemaPath)
+	assert.Equal(t, "unable to resolve reference 'https://pb33f.io/nope.json'", errs[1].Message)
+
+	errs = validateYAML(t, schema, `pointer: {}
+anchor: {}
+escaped: 1`)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "#/$defs/burger/required", errs[0].SchemaPath)
+	assert.Equal(t, "#burger/required", errs[1].SchemaPath)
+	assert.Equal(t, "#/$defs/na%6De/type", errs[2].SchemaPath)
+}
+
+func TestJSONSchema_Refs_Draft4(t *testing.T) {
+	schema := `type: object
+properties:
+  type:
+    $ref: 'http://json-schema.org/draft-04/schema#/properties/type'`
+
+	assert.Nil(t, validateYAML(t, schema, `type: string`))
+	errs := validateYAML(t, schema, `type: burger`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "enum", errs[0].Keyword)
+	assert.Equal(t, "http://json-schema.org/draft-04/schema#/definitions/simpleTypes/enum", errs[0].SchemaPath)
+}
+
+func TestJSONSchema_Recursive(t *testing.T) {
+	schema := `$ref: '#/$defs/node'
+$defs:
+  node:
+    type: object
+    properties:
+      child:
+        $ref: '#/$defs/node'
+      loop:
+        allOf:
+          - $ref: '#/$defs/node/properties/loop'`
+
+	errs := validateYAML(t, schema, `child:
+  child:
+    child: 1
+loop: {}`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/child/child/child", errs[0].Path)
+}
+
+func TestJSONSchema_Combinators(t *testing.T) {
+	schema := `type: object
+properties:
+  any:
+    anyOf:
+      - type: string
+      - type: integer
+  one:
+    oneOf:
+      - type: integer
+      - minimum: 5
+  all:
+    allOf:
+      - type: integer
+      - minimum: 5
+  not:
+    not:
+      type: string
+  never: false`
+
+	assert.Nil(t, validateYAML(t, schema, `any: 1
+one: 1
+all: 6
+not: 1`))
+
+	errs := validateYAML(t, schema, `any: true
+one: 6
+all: 1
+not: nope
+never: 1`)
+	assert.Len(t, errs, 5)
+	assert.Equal(t, "type", errs[0].Keyword)
+	assert.Equal(t, "value matches more than one schema (0, 1), but must match exactly one", errs[1].Message)
+	assert.Equal(t, "minimum", errs[2].Keyword)
+	assert.Equal(t, "not", errs[3].Keyword)
+	assert.Equal(t, "value is not allowed", errs[4].Message)
+}
+
+func TestJSONSchema_OneOf_BestMatch(t *testing.T) {
+	schema := `type: object
+additionalProperties:
+  oneOf:
+    - $ref: '#/$defs/burger'
+    - $ref: '#/$defs/reference'
+$defs:
+  reference:
+    type: object
+    required: [$ref]
+  burger:
+    type: object
+    properties:
+      name:
+        type: string
+      size:
+        enum: [small, large]
+    additionalProperties: false`
+
+	errs := validateYAML(t, schema, `bigMac:
+  name: big mac
+  size: massive
+whopper:
+  $ref: '#/whopper'`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/bigMac/size", errs[0].Path)
+	assert.Equal(t, "enum", errs[0].Keyword)
+	assert.Equal(t, 3, errs[0].Line)
+}
+
+func TestJSONSchema_IfThenElse_Unevaluated(t *testing.T) {
+	schema := `type: object
+properties:
+  kind:
+    type: string
+if:
+  properties:
+    kind:
+      const: burger
+then:
+  properties:
+    bun:
+      type: string
+  required: [bun]
+else:
+  properties:
+    crust:
+      type: string
+dependentSchemas:
+  sauce:
+    properties:
+      sauce:
+        type: string
+$ref: '#/$defs/extensions'
+unevaluatedProperties: false
+$defs:
+  extensions:
+    patternProperties:
+      '^x-': true`
+
+	assert.Nil(t, validateYAML(t, schema, `kind: burger
+bun: sesame
+sauce: ketchup
+x-fries: true`))
+	assert.Nil(t, validateYAML(t, schema, `kind: pizza
+crust: thin`))
+
+	errs := validateYAML(t, schema, `kind: burger
+crust: thin`)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "missing required property 'bun'", errs[0].Message)
+	assert.Equal(t, "property 'crust' is not allowed", errs[1].Message)
+	assert.Equal(t, 2, errs[1].Line)
+}
+
+func TestJSONSchema_UnevaluatedProperties_Schema(t *testing.T) {
+	schema := `type: object
+properties:
+  name:
+    type: string
+unevaluatedProperties:
+  type: integer`
+
+	errs := validateYAML(t, schema, `name: pizza
+slices: eight`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/slices", errs[0].Path)
+	assert.Equal(t, "#/unevaluatedProperties/type", errs[0].SchemaPath)
+}
+
+func TestJSONSchema_Aliases(t *testing.T) {
+	schema := `type: object
+additionalProperties:
+  type: object
+  required: [name]`
+
+	errs := validateYAML(t, schema, `one: &burger
+  size: large
+two: *burger`)
+	assert.Len(t, errs, 2)
+}
+
+func TestJSONSchema_SchemaLocation(t *testing.T) {
+	schema := `type: object
+properties:
+  name:
+    type: string
+    maxLength: 3`
+	errs := validateYAML(t, schema, `name: fluffy`)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/properties/name/maxLength", errs[0].SchemaPath)
+	assert.Equal(t, 5, errs[0].SchemaLine)
+	assert.Equal(t, 5, errs[0].SchemaColumn)
+}
+
+func TestJSONSchema_Nullable(t *testing.T) {
+	schema := `type: string
+enum: [a, b]
+nullable: true`
+	assert.Empty(t, validateYAML(t, schema, `~`))
+	assert.Empty(t, validateYAML(t, schema, `a`))
+	assert.Len(t, validateYAML(t, schema, `c`), 1)
+	assert.Len(t, validateYAML(t, `type: string`, `~`), 1)
+}
+
+func TestJSONSchema_ReadOnlyWriteOnly(t *testing.T) {
+	s, err := CompileJSONSchema([]byte(`type: object
+required: [id, password]
+properties:
+  id:
+    type: integer
+    readOnly: true
+  password:
+    type: string
+    writeOnly: true`))
+	assert.NoError(t, err)
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`password: secret`), &node)
+	assert.Len(t, s.ValidateNodeAgainst(s.root, "#", &node, Any), 1)
+	assert.Empty(t, s.ValidateNodeAgainst(s.root, "#", &node, Request))
+
+	errs := s.ValidateNodeAgainst(s.root, "#", &node, Response)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "missing required property 'id'", errs[0].Message)
+	assert.Equal(t, "property 'password' is writeOnly and must not be sent", errs[1].Message)
+	assert.Equal(t, "writeOnly", errs[1].Keyword)
+	assert.Equal(t, "#/properties/password/writeOnly", errs[1].SchemaPath)
+	assert.Equal(t, 1, errs[1].Line)
+}
+
+func TestJSONSchema_Formats(t *testing.T) {
+	s, err := CompileJSONSchema([]byte(`type: object
+properties:
+  when:
+    format: date-time
+  at:
+    format: time
+  id:
+    format: uuid
+  v4:
+    format: ipv4
+  v6:
+    format: ipv6
+  link:
+    format: uri
+  data:
+    format: byte
+  pattern:
+    format: regex
+  count:
+    format: int64
+  custom:
+    format: not-a-format`))
+	assert.NoError(t, err)
+
+	var valid, invalid yaml.Node
+	_ = yaml.Unmarshal([]byte(`{when: "2022-11-04T10:00:00Z", at: "10:00:00+01:00",
+id: "fe2a2c0e-3f4f-4d5b-9a61-2e4c7d7b2f10", v4: "10.0.0.1", v6: "::1", link: "https://pb33f.io",
+data: "aGVsbG8=", pattern: "^[a-z]+$", count: 12, custom: "anything"}`), &valid)
+	_ = yaml.Unmarshal([]byte(`{when: "yesterday", at: "10am", id: "nope", v4: "::1", v6: "10.0.0.1",
+link: "pb33f", data: "!!", pattern: "[", count: 1e20, custom: "anything"}`), &invalid)
+
+	// formats are only asserted when asked for.
+	assert.Empty(t, s.ValidateNode(&invalid))
+
+	s.formats = true
+	assert.Empty(t, s.ValidateNode(&valid))
+	errs := s.ValidateNode(&invalid)
+	assert.Len(t, errs, 9)
+	for _, e := range errs {
+		assert.Equal(t, "format", e.Keyword)
+	}
+}
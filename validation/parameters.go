@@ -0,0 +1,441 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"gopkg.in/yaml.v3"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parameter holds everything needed to parse and validate a parameter or response header, regardless of where
+// it was defined.
+type parameter struct {
+	name     string
+	in       string
+	style    string
+	explode  bool
+	required bool
+	schema   *yaml.Node // schema node in the specification, nil if there is no schema.
+	content  bool       // true if the value is serialized using a media type (JSON), rather than a style.
+	node     *yaml.Node // node used to locate the parameter in the specification.
+}
+
+// ignoredHeaders are header parameters that are described elsewhere in the specification, and are ignored.
+var ignoredHeaders = map[string]bool{"Accept": true, "Content-Type": true, "Authorization": true}
+
+func newParameter(p *v3high.Parameter) *parameter {
+	param := &parameter{name: p.Name, in: p.In, style: p.Style, explode: p.Explode,
+		required: p.Required || p.In == "path"}
+	explodeSet := false
+	if p.GoLow() != nil {
+		param.node = p.GoLow().Name.KeyNode
+		explodeSet = p.GoLow().Explode.ValueNode != nil
+	}
+	param.schema, param.content = parameterSchema(p.Schema, p.Content)
+	param.applyDefaults(explodeSet)
+	return param
+}
+
+func newHeaderParameter(name string, h *v3high.Header, keyNode *yaml.Node) *parameter {
+	param := &parameter{name: name, in: "responseHeader", style: h.Style, explode: h.Explode,
+		required: h.Required, node: keyNode}
+	explodeSet := h.GoLow() != nil && h.GoLow().Explode.ValueNode != nil
+	param.schema, param.content = parameterSchema(h.Schema, h.Content)
+	param.applyDefaults(explodeSet)
+	return param
+}
+
+// applyDefaults sets the default style for the location of a parameter, 'form' style parameters explode by default.
+func (p *parameter) applyDefaults(explodeSet bool) {
+	if p.style == "" {
+		switch p.in {
+		case "query", "cookie":
+			p.style = "form"
+		default:
+			p.style = "simple"
+		}
+	}
+	if !explodeSet {
+		p.explode = p.style == "form"
+	}
+}
+
+// describe returns a description of the parameter, used in error messages.
+func (p *parameter) describe() string {
+	if p.in == "responseHeader" {
+		return fmt.Sprintf("response header '%s'", p.name)
+	}
+	return fmt.Sprintf("%s parameter '%s'", p.in, p.name)
+}
+
+// parameterSchema returns the schema node of a parameter or header, either from its schema or from the schema
+// of the media type in its content.
+func parameterSchema(schema *base.SchemaProxy, content map[string]*v3high.MediaType) (*yaml.Node, bool) {
+	if schema != nil && schema.GoLow() != nil {
+		return schema.GoLow().GetValueNode(), false
+	}
+	for _, mt := range content {
+		if mt.Schema != nil && mt.Schema.GoLow() != nil {
+			return mt.Schema.GoLow().GetValueNode(), true
+		}
+	}
+	return nil, len(content) > 0
+}
+
+// mergeParameters combines the parameters of a PathItem and an Operation. An Operation parameter overrides a
+// PathItem parameter with the same name and location.
+func (v *HTTPValidator) mergeParameters(pathParams, opParams []*v3high.Parameter) []*parameter {
+	var merged []*parameter
+	overridden := make(map[string]bool)
+	for _, p := range opParams {
+		overridden[p.In+":"+p.Name] = true
+	}
+	for _, p := range pathParams {
+		if !overridden[p.In+":"+p.Name] {
+			merged = append(merged, newParameter(p))
+		}
+	}
+	for _, p := range opParams {
+		merged = append(merged, newParameter(p))
+	}
+	return merged
+}
+
+// validateParameter extracts the value of a request parameter and validates it.
+func (v *HTTPValidator) validateParameter(p *parameter, request *http.Request,
+	pathValues map[string]string) []*HTTPValidationError {
+
+	switch p.in {
+	case "path":
+		raw, ok := pathValues[p.name]
+		if !ok {
+			return v.checkValue(p, nil, false, nil)
+		}
+		if unescaped, err := url.PathUnescape(raw); err == nil {
+			raw = unescaped
+		}
+		node, err := v.parseStyled(p, raw)
+		return v.checkValue(p, node, true, err)
+	case "query":
+		node, present, err := v.parseQuery(p, request.URL.Query())
+		return v.checkValue(p, node, present, err)
+	case "header":
+		if ignoredHeaders[http.CanonicalHeaderKey(p.name)] {
+			return nil
+		}
+		return v.validateHeader(p, request.Header)
+	case "cookie":
+		cookie, err := request.Cookie(p.name)
+		if err != nil {
+			return v.checkValue(p, nil, false, nil)
+		}
+		raw := cookie.Value
+		if unescaped, err := url.QueryUnescape(raw); err == nil {
+			raw = unescaped
+		}
+		node, err := v.parseStyled(p, raw)
+		return v.checkValue(p, node, true, err)
+	}
+	return nil
+}
+
+// validateHeader extracts the value of a request or response header and validates it.
+func (v *HTTPValidator) validateHeader(p *parameter, header http.Header) []*HTTPValidationError {
+	values := header.Values(p.name)
+	if len(values) == 0 {
+		return v.checkValue(p, nil, false, nil)
+	}
+	node, err := v.parseStyled(p, strings.Join(values, ","))
+	return v.checkValue(p, node, true, err)
+}
+
+// checkValue reports a missing required value, a value that cannot be parsed, or a value that fails to validate
+// against the schema of the parameter.
+func (v *HTTPValidator) checkValue(p *parameter, node *yaml.Node, present bool, err error) []*HTTPValidationError {
+	location := p.in
+	if location == "responseHeader" {
+		location = "header"
+	}
+	if !present {
+		if p.required {
+			return []*HTTPValidationError{v.newError(location, p.name,
+				fmt.Sprintf("%s is required, but is missing", p.describe()), p.node, nil)}
+		}
+		return nil
+	}
+	if err != nil {
+		return []*HTTPValidationError{v.newError(location, p.name,
+			fmt.Sprintf("%s cannot be parsed: %s", p.describe(), err.Error()), p.node, nil)}
+	}
+	if p.schema == nil || node == nil {
+		return nil
+	}
+	errs := v.schemas.schema.ValidateNodeAgainst(p.schema, v.schemas.pointer(p.schema), node, Any)
+	if len(errs) > 0 {
+		return []*HTTPValidationError{v.newError(location, p.name,
+			fmt.Sprintf("%s is not valid: %s", p.describe(), errs[0].Message), p.node, errs)}
+	}
+	return nil
+}
+
+// parseStyled parses a path, header or cookie value, serialized using the 'simple', 'label', 'matrix' or 'form'
+// style, into a node that matches the type of the parameter schema.
+func (v *HTTPValidator) parseStyled(p *parameter, raw string) (*yaml.Node, error) {
+	if p.content {
+		return parseContent(raw)
+	}
+	schemaType := v.schemaType(p.schema)
+	separator := ","
+	switch p.style {
+	case "label":
+		if !strings.HasPrefix(raw, ".") {
+			return nil, fmt.Errorf("value '%s' is not serialized using the 'label' style", raw)
+		}
+		raw = raw[1:]
+		if p.explode {
+			separator = "."
+		}
+	case "matrix":
+		prefix := ";" + p.name
+		if schemaType == "object" && p.explode {
+			if !strings.HasPrefix(raw, ";") {
+				return nil, fmt.Errorf("value '%s' is not serialized using the 'matrix' style", raw)
+			}
+			return v.objectNode(p.schema, splitPairs(strings.Split(raw[1:], ";")))
+		}
+		if !strings.HasPrefix(raw, prefix) {
+			return nil, fmt.Errorf("value '%s' is not serialized using the 'matrix' style", raw)
+		}
+		if schemaType == "array" && p.explode {
+			var values []string
+			for _, part := range strings.Split(raw[1:], ";") {
+				values = append(values, strings.TrimPrefix(strings.TrimPrefix(part, p.name), "="))
+			}
+			return v.arrayNode(p.schema, values), nil
+		}
+		raw = strings.TrimPrefix(strings.TrimPrefix(raw, prefix), "=")
+	}
+
+	switch schemaType {
+	case "array":
+		return v.arrayNode(p.schema, strings.Split(raw, separator)), nil
+	case "object":
+		parts := strings.Split(raw, separator)
+		if p.explode {
+			return v.objectNode(p.schema, splitPairs(parts))
+		}
+		return v.objectNode(p.schema, parts)
+	}
+	return scalarNode(raw, schemaType), nil
+}
+
+// parseQuery parses a query parameter serialized using the 'form', 'spaceDelimited', 'pipeDelimited' or
+// 'deepObject' style, into a node that matches the type of the parameter schema.
+func (v *HTTPValidator) parseQuery(p *parameter, query url.Values) (*yaml.Node, bool, error) {
+	schemaType := v.schemaType(p.schema)
+	if p.style == "deepObject" {
+		var pairs []string
+		keys := make([]string, 0, len(query))
+		for k := range query {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if strings.HasPrefix(k, p.name+"[") && strings.HasSuffix(k, "]") {
+				pairs = append(pairs, k[len(p.name)+1:len(k)-1], query[k][0])
+			}
+		}
+		if len(pairs) == 0 {
+			return nil, false, nil
+		}
+		node, err := v.objectNode(p.schema, pairs)
+		return node, true, err
+	}
+	if schemaType == "object" && p.explode && !p.content {
+		// exploded objects are serialized as individual query parameters, one per property.
+		var pairs []string
+		if props := v.schemas.schema.keyword(v.resolveSchema(p.schema), "properties"); props != nil {
+			for i := 0; i < len(props.Content)-1; i += 2 {
+				if values, ok := query[props.Content[i].Value]; ok {
+					pairs = append(pairs, props.Content[i].Value, values[0])
+				}
+			}
+		}
+		if len(pairs) == 0 {
+			return nil, false, nil
+		}
+		node, err := v.objectNode(p.schema, pairs)
+		return node, true, err
+	}
+	values, ok := query[p.name]
+	if !ok {
+		return nil, false, nil
+	}
+	if p.content {
+		node, err := parseContent(values[0])
+		return node, true, err
+	}
+	separator := ","
+	switch p.style {
+	case "spaceDelimited":
+		separator = " "
+	case "pipeDelimited":
+		separator = "|"
+	}
+	switch schemaType {
+	case "array":
+		if p.explode && p.style == "form" {
+			return v.arrayNode(p.schema, values), true, nil
+		}
+		return v.arrayNode(p.schema, strings.Split(values[0], separator)), true, nil
+	case "object":
+		node, err := v.objectNode(p.schema, strings.Split(values[0], separator))
+		return node, true, err
+	}
+	return scalarNode(values[0], schemaType), true, nil
+}
+
+// formNode converts a form encoded body into an object node, using the schema to determine the type of each
+// property.
+func (v *HTTPValidator) formNode(schema *yaml.Node, form url.Values) *yaml.Node {
+	keys := make([]string, 0, len(form))
+	for k := range form {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	props := v.schemas.schema.keyword(v.resolveSchema(schema), "properties")
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, k := range keys {
+		property := v.schemas.schema.keyword(props, k)
+		var value *yaml.Node
+		if v.schemaType(property) == "array" {
+			value = v.arrayNode(property, form[k])
+		} else {
+			value = scalarNode(form[k][0], v.schemaType(property))
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, value)
+	}
+	return node
+}
+
+// arrayNode creates a sequence node, using the 'items' schema to determine the type of each item.
+func (v *HTTPValidator) arrayNode(schema *yaml.Node, values []string) *yaml.Node {
+	itemType := v.schemaType(v.schemas.schema.keyword(v.resolveSchema(schema), "items"))
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, value := range values {
+		node.Content = append(node.Content, scalarNode(value, itemType))
+	}
+	return node
+}
+
+// objectNode creates a mapping node from a list of alternating property names and values, using the 'properties'
+// of the schema to determine the type of each value.
+func (v *HTTPValidator) objectNode(schema *yaml.Node, pairs []string) (*yaml.Node, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("value '%s' is not a valid object, properties and values must be paired",
+			strings.Join(pairs, ","))
+	}
+	props := v.schemas.schema.keyword(v.resolveSchema(schema), "properties")
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i < len(pairs); i += 2 {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: pairs[i]},
+			scalarNode(pairs[i+1], v.schemaType(v.schemas.schema.keyword(props, pairs[i]))))
+	}
+	return node, nil
+}
+
+// resolveSchema follows any references of a schema, to the schema they point to.
+func (v *HTTPValidator) resolveSchema(schema *yaml.Node) *yaml.Node {
+	schema = resolveNode(schema)
+	for i := 0; i < 10 && schema != nil; i++ {
+		ref := v.schemas.schema.keyword(schema, "$ref")
+		if ref == nil {
+			return schema
+		}
+		target, _, _ := v.schemas.schema.resolve(ref.Value)
+		schema = target
+	}
+	return schema
+}
+
+// schemaType returns the type of value a schema expects, the first type that is not 'null' is used if there is
+// more than one.
+func (v *HTTPValidator) schemaType(schema *yaml.Node) string {
+	schema = v.resolveSchema(schema)
+	if schema == nil {
+		return ""
+	}
+	if t := v.schemas.schema.keyword(schema, "type"); t != nil {
+		if t.Kind == yaml.SequenceNode {
+			for _, e := range t.Content {
+				if e.Value != "null" {
+					return e.Value
+				}
+			}
+			return ""
+		}
+		return t.Value
+	}
+	if v.schemas.schema.keyword(schema, "properties") != nil {
+		return "object"
+	}
+	if v.schemas.schema.keyword(schema, "items") != nil {
+		return "array"
+	}
+	return ""
+}
+
+// splitPairs splits a list of 'name=value' strings into alternating property names and values.
+func splitPairs(parts []string) []string {
+	var pairs []string
+	for _, part := range parts {
+		name, value, _ := strings.Cut(part, "=")
+		pairs = append(pairs, name, value)
+	}
+	return pairs
+}
+
+// parseContent parses a parameter value serialized as JSON.
+func parseContent(raw string) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &node); err != nil {
+		return nil, fmt.Errorf("value '%s' is not valid JSON", raw)
+	}
+	if len(node.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}, nil
+	}
+	return &node, nil
+}
+
+// scalarNode creates a scalar node from a serialized value. The value is typed using the schema type, if the value
+// cannot be converted to that type, it remains a string (and will fail validation).
+func scalarNode(value, schemaType string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	switch schemaType {
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+			node.Tag = "!!float"
+			if _, err = strconv.ParseInt(value, 10, 64); err == nil {
+				node.Tag = "!!int"
+			}
+		}
+	case "boolean":
+		if value == "true" || value == "false" {
+			node.Tag = "!!bool"
+		}
+	case "null":
+		if value == "" || value == "null" {
+			node.Tag = "!!null"
+		}
+	}
+	return node
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+	"sync"
+)
+
+// SchemaValidator validates values against the high-level Schema and SchemaProxy models of a specification.
+//
+// Schemas are validated in their rendered form, so any changes made to the high-level models are validated. A
+// schema that has not changed is validated using the original specification, so every error knows the line and
+// column of the failing keyword, and its SchemaPath points into the specification. References are resolved using
+// the index of the specification, including references to other documents that have been indexed (relative to the
+// document that contains them). A reference that cannot be resolved is a failure. The 'format' keyword is asserted.
+type SchemaValidator struct {
+	root        *yaml.Node
+	schema      *JSONSchema
+	renderer    *high.Renderer
+	pointers    map[*yaml.Node]string
+	pointerOnce sync.Once
+}
+
+// NewSchemaValidator will create a new SchemaValidator for the specification that has been indexed. The index may
+// be nil, in which case references cannot be resolved.
+func NewSchemaValidator(idx *index.SpecIndex) *SchemaValidator {
+	var root *yaml.Node
+	if idx != nil {
+		root = idx.GetRootNode()
+	}
+	v := &SchemaValidator{root: root, schema: NewJSONSchema(root), renderer: high.NewRenderer(root)}
+	v.schema.index = idx
+	v.schema.formats = true
+	return v
+}
+
+// ValidateValue will validate a value against the Schema of a SchemaProxy. The value can be anything that can be
+// encoded as YAML (like the result of decoding JSON into an 'any'), or a *yaml.Node. The direction determines how
+// readOnly and writeOnly properties are treated. If the value is valid, nil is returned.
+//
+// An error is returned if the value cannot be encoded.
+func (v *SchemaValidator) ValidateValue(schema *base.SchemaProxy, value any,
+	direction Direction) ([]*ValidationError, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("unable to validate value, no schema has been supplied")
+	}
+	var original *yaml.Node
+	if schema.GoLow() != nil {
+		original = schema.GoLow().GetValueNode()
+	}
+	return v.validate(schema.RenderNode(v.renderer, original), value, direction)
+}
+
+// ValidateSchemaValue will validate a value against a Schema, see ValidateValue. A Schema is not able to keep track
+// of its original node in the specification, so the errors returned do not carry the location of the failing
+// keyword. Use ValidateValue with the SchemaProxy of the Schema if that is required.
+func (v *SchemaValidator) ValidateSchemaValue(schema *base.Schema, value any,
+	direction Direction) ([]*ValidationError, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("unable to validate value, no schema has been supplied")
+	}
+	return v.validate(v.renderer.Render(schema, nil), value, direction)
+}
+
+func (v *SchemaValidator) validate(schema *yaml.Node, value any, direction Direction) ([]*ValidationError, error) {
+	node, err := encodeValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate value, it cannot be encoded: %s", err.Error())
+	}
+	if schema == nil {
+		// an empty schema allows anything.
+		return nil, nil
+	}
+	return v.schema.ValidateNodeAgainst(schema, v.pointer(schema), node, direction), nil
+}
+
+// encodeValue encodes a value into a yaml.Node, unless it already is one. The YAML encoder panics when it is given
+// something that cannot be encoded (like a func), which is recovered as an error.
+func encodeValue(value any) (node *yaml.Node, err error) {
+	if n, ok := value.(*yaml.Node); ok {
+		return n, nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			node, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+	node = new(yaml.Node)
+	err = node.Encode(value)
+	return node, err
+}
+
+// pointer returns a JSON pointer to a node in the specification. Key nodes point to the object that contains them.
+// The pointers are only generated the first time they are needed, a node that is not part of the specification
+// (because it has been rendered from a changed model) is given the pointer '#'.
+func (v *SchemaValidator) pointer(node *yaml.Node) string {
+	v.pointerOnce.Do(func() {
+		v.pointers = make(map[*yaml.Node]string)
+		if v.root == nil {
+			return
+		}
+		var walk func(n *yaml.Node, pointer string)
+		walk = func(n *yaml.Node, pointer string) {
+			if _, seen := v.pointers[n]; seen {
+				return
+			}
+			v.pointers[n] = pointer
+			switch n.Kind {
+			case yaml.MappingNode:
+				for i := 0; i < len(n.Content)-1; i += 2 {
+					v.pointers[n.Content[i]] = pointer
+					walk(n.Content[i+1], pointer+"/"+escapePointer(n.Content[i].Value))
+				}
+			case yaml.SequenceNode:
+				for i, item := range n.Content {
+					walk(item, fmt.Sprintf("%s/%d", pointer, i))
+				}
+			}
+		}
+		walk(resolveNode(v.root), "#")
+	})
+	if p, ok := v.pointers[node]; ok {
+		return p
+	}
+	return "#"
+}
@@ -0,0 +1,309 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"encoding/json"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+	"testing/fstest"
+)
+
+var animalSpec = `openapi: 3.0.3
+info:
+  title: animals
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: kind
+        mapping:
+          kitty: '#/components/schemas/Cat'
+    Cat:
+      type: object
+      required: [kind, lives]
+      properties:
+        kind:
+          type: string
+        lives:
+          type: integer
+          format: int32
+    Dog:
+      type: object
+      required: [kind, bark]
+      properties:
+        kind:
+          type: string
+        bark:
+          type: string
+    Owner:
+      type: object
+      properties:
+        id:
+          type: string
+          format: uuid
+          readOnly: true
+        email:
+          type: string
+          format: email
+        born:
+          type: string
+          format: date
+        nickname:
+          type: string
+          nullable: true
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Animal'
+        friend:
+          $ref: '#/components/schemas/Owner'`
+
+func newTestSchemaValidator(t *testing.T, spec string) (*SchemaValidator, *v3high.Document) {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	assert.NoError(t, err)
+	lowDoc, errs := v3low.CreateDocument(info)
+	assert.Empty(t, errs)
+	doc := v3high.NewDocument(lowDoc)
+	return NewSchemaValidator(doc.Index), doc
+}
+
+func decodeJSON(t *testing.T, data string) any {
+	var value any
+	assert.NoError(t, json.Unmarshal([]byte(data), &value))
+	return value
+}
+
+func TestSchemaValidator_ValidateValue(t *testing.T) {
+	v, doc := newTestSchemaValidator(t, animalSpec)
+	owner := doc.Components.Schemas["Owner"]
+
+	errs, err := v.ValidateValue(owner, decodeJSON(t, `{"email": "dave@quobix.com", "born": "2022-11-04",
+		"nickname": null, "pets": [{"kind": "Dog", "bark": "woof"}, {"kind": "kitty", "lives": 9}]}`), Any)
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, _ = v.ValidateValue(owner, map[string]any{"email": "nope", "born": "04/11/2022"}, Any)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "'04/11/2022' is not a valid 'date'", errs[0].Message)
+	assert.Equal(t, "#/born", errs[0].Path)
+	assert.Equal(t, "#/components/schemas/Owner/properties/born/format", errs[0].SchemaPath)
+	assert.Equal(t, 45, errs[0].SchemaLine)
+	assert.Equal(t, "'nope' is not a valid 'email'", errs[1].Message)
+}
+
+func TestSchemaValidator_Discriminator(t *testing.T) {
+	v, doc := newTestSchemaValidator(t, animalSpec)
+	animal := doc.Components.Schemas["Animal"]
+
+	errs, _ := v.ValidateValue(animal, decodeJSON(t, `{"kind": "Cat", "lives": 9}`), Any)
+	assert.Empty(t, errs)
+
+	// only the branch selected by the discriminator is validated.
+	errs, _ = v.ValidateValue(animal, decodeJSON(t, `{"kind": "Dog", "lives": 9}`), Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "missing required property 'bark'", errs[0].Message)
+	assert.Equal(t, "#/components/schemas/Dog/required", errs[0].SchemaPath)
+
+	errs, _ = v.ValidateValue(animal, decodeJSON(t, `{"kind": "kitty", "lives": 99999999999}`), Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "99999999999 is out of range for 'int32'", errs[0].Message)
+	assert.Equal(t, "#/lives", errs[0].Path)
+
+	errs, _ = v.ValidateValue(animal, decodeJSON(t, `{"kind": "Fish"}`), Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "discriminator value 'Fish' does not match any of the schemas defined in 'oneOf'",
+		errs[0].Message)
+	assert.Equal(t, "#/kind", errs[0].Path)
+	assert.Equal(t, "#/components/schemas/Animal/discriminator", errs[0].SchemaPath)
+
+	errs, _ = v.ValidateValue(animal, decodeJSON(t, `{"lives": 9}`), Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "missing discriminator property 'kind'", errs[0].Message)
+}
+
+func TestSchemaValidator_Circular(t *testing.T) {
+	v, doc := newTestSchemaValidator(t, animalSpec)
+	owner := doc.Components.Schemas["Owner"]
+
+	errs, _ := v.ValidateValue(owner, decodeJSON(t,
+		`{"friend": {"friend": {"friend": {"pets": [{"kind": "Dog"}]}}}}`), Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/friend/friend/friend/pets/0", errs[0].Path)
+	assert.Equal(t, "#/components/schemas/Dog/required", errs[0].SchemaPath)
+
+	spec, _ := ioutil.ReadFile("../test_specs/circular-tests.yaml")
+	v, doc = newTestSchemaValidator(t, "openapi: 3.0.1\n"+string(spec))
+	errs, err := v.ValidateValue(doc.Components.Schemas["One"], decodeJSON(t,
+		`{"things": {"testThing": {"things": {}}}}`), Any)
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+}
+
+func TestSchemaValidator_ReadOnly(t *testing.T) {
+	v, doc := newTestSchemaValidator(t, animalSpec)
+	owner := doc.Components.Schemas["Owner"]
+	value := map[string]any{"id": "fe2a2c0e-3f4f-4d5b-9a61-2e4c7d7b2f10"}
+
+	errs, _ := v.ValidateValue(owner, value, Response)
+	assert.Empty(t, errs)
+	errs, _ = v.ValidateValue(owner, value, Request)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "property 'id' is readOnly and must not be sent", errs[0].Message)
+}
+
+func TestSchemaValidator_NullableTypeArrays(t *testing.T) {
+	v, doc := newTestSchemaValidator(t, animalSpec)
+	errs, _ := v.ValidateValue(doc.Components.Schemas["Owner"], map[string]any{"nickname": nil}, Any)
+	assert.Empty(t, errs)
+
+	// OpenAPI 3.1 uses type arrays, nullable is no longer understood.
+	spec31 := `openapi: 3.1.0
+info:
+  title: nulls
+  version: 1.0.0
+components:
+  schemas:
+    Old:
+      type: string
+      nullable: true
+    New:
+      type: [string, "null"]`
+	v, doc = newTestSchemaValidator(t, spec31)
+	errs, _ = v.ValidateValue(doc.Components.Schemas["Old"], nil, Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "expected string, found null", errs[0].Message)
+	errs, _ = v.ValidateValue(doc.Components.Schemas["New"], nil, Any)
+	assert.Empty(t, errs)
+}
+
+func TestSchemaValidator_ChangedModel(t *testing.T) {
+	v, doc := newTestSchemaValidator(t, animalSpec)
+	dog := doc.Components.Schemas["Dog"]
+	dog.Schema().Required = []string{"kind"}
+
+	errs, _ := v.ValidateValue(dog, map[string]any{"kind": "Dog"}, Any)
+	assert.Empty(t, errs)
+	errs, _ = v.ValidateValue(dog, map[string]any{"kind": 1}, Any)
+	assert.Len(t, errs, 1)
+
+	// the changed schema is no longer part of the specification, but unchanged keywords keep their location.
+	assert.Equal(t, "#/properties/kind/type", errs[0].SchemaPath)
+	assert.Equal(t, 30, errs[0].SchemaLine)
+}
+
+func TestSchemaValidator_Schema(t *testing.T) {
+	v := NewSchemaValidator(nil)
+	schema := &base.Schema{Type: []string{"object"}, Required: []string{"name"},
+		Properties: map[string]*base.SchemaProxy{
+			"name": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}, Format: "hostname"}),
+		}}
+	errs, err := v.ValidateSchemaValue(schema, map[string]any{"name": "pb33f.io"}, Any)
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, _ = v.ValidateSchemaValue(schema, map[string]any{"name": "not a host"}, Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/properties/name/format", errs[0].SchemaPath)
+
+	_, err = v.ValidateSchemaValue(nil, nil, Any)
+	assert.Error(t, err)
+	_, err = v.ValidateValue(nil, nil, Any)
+	assert.Error(t, err)
+	_, err = v.ValidateSchemaValue(schema, func() {}, Any)
+	assert.Error(t, err)
+}
+
+var externalPetFile = `Pet:
+  type: object
+  required: [name]
+  properties:
+    name:
+      type: string
+    error:
+      type: object
+      properties:
+        detail:
+          $ref: '../common.yaml#/Error'`
+
+var externalCommonFile = `Error:
+  type: object
+  required: [code]`
+
+func TestSchemaValidator_ExternalReferences(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: external
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Ext:
+      $ref: './schemas/pet.yaml#/Pet'
+    Pets:
+      type: array
+      items:
+        $ref: './schemas/pet.yaml#/Pet'
+    Dangling:
+      $ref: '#/components/schemas/Nope'`
+
+	config := index.CreateOpenAPIIndexConfig()
+	config.FS = fstest.MapFS{
+		"specs/schemas/pet.yaml": &fstest.MapFile{Data: []byte(externalPetFile)},
+		"specs/common.yaml":      &fstest.MapFile{Data: []byte(externalCommonFile)},
+	}
+	config.BasePath = "specs"
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	assert.NoError(t, err)
+	lowDoc, _ := v3low.CreateDocumentFromConfig(info, config)
+	doc := v3high.NewDocument(lowDoc)
+	v := NewSchemaValidator(doc.Index)
+
+	// references to other documents are resolved, and references in those documents are relative to them.
+	errs, err := v.ValidateValue(doc.Components.Schemas["Ext"], 42, Any)
+	assert.NoError(t, err)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "expected object, found integer", errs[0].Message)
+
+	errs, _ = v.ValidateValue(doc.Components.Schemas["Pets"], decodeJSON(t, `[{"name": "chicken"}, {"error": {"detail": {}}}]`),
+		Any)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "#/1", errs[0].Path)
+	assert.Equal(t, "missing required property 'name'", errs[0].Message)
+	assert.Equal(t, "specs/schemas/pet.yaml#/Pet/required", errs[0].SchemaPath)
+	assert.Equal(t, "#/1/error/detail", errs[1].Path)
+	assert.Equal(t, "missing required property 'code'", errs[1].Message)
+	assert.Equal(t, "specs/common.yaml#/Error/required", errs[1].SchemaPath)
+
+	// a schema from another document keeps resolving references relative to that document.
+	petError := doc.Components.Schemas["Ext"].Schema().Properties["error"]
+	errs, _ = v.ValidateValue(petError, decodeJSON(t, `{"detail": {}}`), Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/detail", errs[0].Path)
+	assert.Equal(t, "specs/common.yaml#/Error/required", errs[0].SchemaPath)
+
+	// a reference that cannot be resolved is a failure.
+	errs, _ = v.ValidateValue(doc.Components.Schemas["Dangling"], 42, Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "$ref", errs[0].Keyword)
+	assert.Equal(t, "unable to resolve reference '#/components/schemas/Nope'", errs[0].Message)
+
+	// a document that has not been indexed cannot be resolved.
+	lowDoc, _ = v3low.CreateDocument(info)
+	doc = v3high.NewDocument(lowDoc)
+	errs, _ = NewSchemaValidator(doc.Index).ValidateValue(doc.Components.Schemas["Ext"], 42, Any)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "unable to resolve reference './schemas/pet.yaml#/Pet'", errs[0].Message)
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package validation contains tools for validating OpenAPI and Swagger specifications.
+//
+// Documents are validated structurally against the official meta-schema for their version, which are embedded
+// in the datamodel package. Validation operates directly on the yaml.Node tree of the specification, which means
+// every error knows exactly where in the document it came from.
+package validation
+
+import (
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel"
+	"gopkg.in/yaml.v3"
+	"sync"
+)
+
+// ValidationError represents a single failure found when validating a node tree against a JSON Schema.
+type ValidationError struct {
+	Message      string     // Message is a human-readable description of the failure.
+	Keyword      string     // Keyword is the schema keyword that failed, for example 'required'.
+	Path         string     // Path is a JSON pointer to the failing location in the document, for example '#/info'.
+	SchemaPath   string     // SchemaPath is a JSON pointer to the failing keyword in the schema.
+	Line         int        // Line is the line number of the offending key (or value if there is no key).
+	Column       int        // Column is the column number of the offending key (or value if there is no key).
+	Node         *yaml.Node // Node is the offending key (or value if there is no key).
+	SchemaLine   int        // SchemaLine is the line number of the failing keyword in the schema.
+	SchemaColumn int        // SchemaColumn is the column number of the failing keyword in the schema.
+}
+
+// Error returns a string representation of the validation failure, including the line and column.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s, line %d, col %d", e.Path, e.Message, e.Line, e.Column)
+}
+
+var metaSchemas = make(map[string]*JSONSchema)
+var metaSchemaLock sync.Mutex
+
+// ValidateDocument will validate a specification against the meta-schema matching its version (Swagger 2.0,
+// OpenAPI 3.0 or OpenAPI 3.1), which is set as the APISchema of the SpecInfo. If the document is valid, nil is returned. If the document is invalid then every
+// failure is returned, each one carrying the line and column of the offending key.
+//
+// An error is returned if the specification has not been parsed, or is of a type that cannot be validated.
+func ValidateDocument(info *datamodel.SpecInfo) ([]*ValidationError, error) {
+	if info == nil || info.RootNode == nil {
+		return nil, fmt.Errorf("unable to validate document, no specification has been loaded")
+	}
+	if info.APISchema == "" {
+		return nil, fmt.Errorf("unable to validate document, "+
+			"validating '%s' specifications is not supported", info.SpecType)
+	}
+	schema, err := compileMetaSchema(info.APISchema)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ValidateNode(info.RootNode), nil
+}
+
+// compileMetaSchema compiles an embedded meta-schema once, and then caches it.
+func compileMetaSchema(schemaData string) (*JSONSchema, error) {
+	metaSchemaLock.Lock()
+	defer metaSchemaLock.Unlock()
+	if s, ok := metaSchemas[schemaData]; ok {
+		return s, nil
+	}
+	s, err := CompileJSONSchema([]byte(schemaData))
+	if err != nil {
+		return nil, err
+	}
+	metaSchemas[schemaData] = s
+	return s, nil
+}
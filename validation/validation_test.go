@@ -0,0 +1,174 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func validateSpec(t *testing.T, spec []byte) []*ValidationError {
+	info, err := datamodel.ExtractSpecInfo(spec)
+	assert.NoError(t, err)
+	errs, err := ValidateDocument(info)
+	assert.NoError(t, err)
+	return errs
+}
+
+func TestValidateDocument_NoSpec(t *testing.T) {
+	_, err := ValidateDocument(nil)
+	assert.Error(t, err)
+	_, err = ValidateDocument(&datamodel.SpecInfo{})
+	assert.Error(t, err)
+}
+
+func TestValidateDocument_Unsupported(t *testing.T) {
+	info, _ := datamodel.ExtractSpecInfo([]byte(`asyncapi: 2.0.0`))
+	errs, err := ValidateDocument(info)
+	assert.Nil(t, errs)
+	assert.Equal(t, "unable to validate document, validating 'asyncapi' specifications is not supported",
+		err.Error())
+}
+
+func TestValidateDocument_OpenAPI3_Valid(t *testing.T) {
+	for _, spec := range []string{"petstorev3.json", "asana.yaml"} {
+		data, _ := ioutil.ReadFile("../test_specs/" + spec)
+		assert.Nil(t, validateSpec(t, data), spec)
+	}
+}
+
+func TestValidateDocument_Swagger_Valid(t *testing.T) {
+	for _, spec := range []string{"petstorev2.json", "xsoar.json"} {
+		data, _ := ioutil.ReadFile("../test_specs/" + spec)
+		assert.Nil(t, validateSpec(t, data), spec)
+	}
+}
+
+func TestValidateDocument_Swagger_Invalid(t *testing.T) {
+	data, _ := ioutil.ReadFile("../test_specs/petstorev2-complete.yaml")
+	errs := validateSpec(t, data)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "#/paths/~1user/borked: property 'borked' is not allowed, line 604, col 5", errs[0].Error())
+	assert.Equal(t, "#/externalPaths: property 'externalPaths' is not allowed, line 862, col 1", errs[1].Error())
+}
+
+func TestValidateDocument_OpenAPI3_Invalid(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: burgers
+paths:
+  /burgers:
+    get:
+      responses:
+        "200":
+          description: ok
+      parameters:
+        - name: limit
+          in: body
+          schema:
+            type: integer
+components:
+  schemas:
+    Burger:
+      type: thing`
+
+	errs := validateSpec(t, []byte(spec))
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "#/info", errs[0].Path)
+	assert.Equal(t, "missing required property 'version'", errs[0].Message)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Equal(t, 1, errs[0].Column)
+
+	assert.Equal(t, "#/paths/~1burgers/get/parameters/0/in", errs[1].Path)
+	assert.Equal(t, 12, errs[1].Line)
+	assert.Equal(t, 11, errs[1].Column)
+
+	assert.Equal(t, "#/components/schemas/Burger/type", errs[2].Path)
+	assert.Equal(t, "enum", errs[2].Keyword)
+	assert.Equal(t, 18, errs[2].Line)
+	assert.Equal(t, 7, errs[2].Column)
+}
+
+func TestValidateDocument_OpenAPI31(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: burgers
+  version: 1.0.0
+  license:
+    name: MIT
+    identifier: MIT
+webhooks:
+  newBurger:
+    post:
+      responses:
+        "200":
+          description: ok
+components:
+  pathItems:
+    burgers:
+      get:
+        description: burgers
+  schemas:
+    Burger:
+      type: [object, "null"]
+      prefixItems: []`
+
+	assert.Nil(t, validateSpec(t, []byte(spec)))
+}
+
+func TestValidateDocument_OpenAPI31_LicenseIdentifierAndURL(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: burgers
+  version: 1.0.0
+  license:
+    name: MIT
+    identifier: MIT
+    url: https://opensource.org/licenses/MIT
+paths: {}`
+
+	errs := validateSpec(t, []byte(spec))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/info/license", errs[0].Path)
+	assert.Equal(t, "oneOf", errs[0].Keyword)
+}
+
+func TestValidateDocument_OpenAPI31_Invalid(t *testing.T) {
+	data, _ := ioutil.ReadFile("../test_specs/burgershop.openapi.yaml")
+	errs := validateSpec(t, data)
+	assert.Len(t, errs, 5)
+	assert.Equal(t, "#/components/links/AnotherLocateBurger/server", errs[1].Path)
+	assert.Equal(t, "property 'server' is not allowed", errs[1].Message)
+	assert.Equal(t, 314, errs[1].Line)
+	assert.Equal(t, 7, errs[1].Column)
+	assert.Equal(t, "#/components/parameters/BurgerHeader", errs[4].Path)
+	assert.Equal(t, "oneOf", errs[4].Keyword)
+}
+
+func TestValidateDocument_OpenAPI31_Extensions(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: burgers
+  version: 1.0.0
+  x-burger: true
+  burger: false
+components: {}`
+
+	errs := validateSpec(t, []byte(spec))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/info/burger", errs[0].Path)
+	assert.Equal(t, "unevaluatedProperties", errs[0].Keyword)
+	assert.Equal(t, 6, errs[0].Line)
+	assert.Equal(t, 3, errs[0].Column)
+}
+
+func BenchmarkValidateDocument_Stripe(b *testing.B) {
+	data, _ := ioutil.ReadFile("../test_specs/stripe.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	for i := 0; i < b.N; i++ {
+		_, _ = ValidateDocument(info)
+	}
+}
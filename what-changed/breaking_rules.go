@@ -0,0 +1,250 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"unicode"
+)
+
+// WildcardRuleObject can be used in place of an object name to define a rule for a property of every object.
+const WildcardRuleObject = "*"
+
+// DocumentRuleObject is the object name used for properties held directly by an OpenAPI or Swagger document.
+const DocumentRuleObject = "document"
+
+// BreakingChangeRule determines if adding, modifying, removing or moving a property is a breaking change. A nil
+// value means the rule has no opinion, and the built-in behavior of the comparison is used.
+//
+// The Request and Response rules refine the rule for changes to requests (request bodies and parameters) and to
+// responses (including their headers). Where they have no opinion, the rule itself is used.
+type BreakingChangeRule struct {
+	Added    *bool               `json:"added,omitempty" yaml:"added,omitempty"`
+	Modified *bool               `json:"modified,omitempty" yaml:"modified,omitempty"`
+	Removed  *bool               `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Moved    *bool               `json:"moved,omitempty" yaml:"moved,omitempty"`
+	Request  *BreakingChangeRule `json:"request,omitempty" yaml:"request,omitempty"`
+	Response *BreakingChangeRule `json:"response,omitempty" yaml:"response,omitempty"`
+}
+
+// BreakingRules maps an object name and a property name to a BreakingChangeRule. Object names are the lower camel
+// case names of the low-level models, for example 'schema', 'pathItem', 'securityScheme' or 'oauthFlows'. Swagger
+// and OpenAPI 3+ objects that share a name share rules. Document level properties use the 'document' object and
+// the '*' object defines rules for a property of every object.
+//
+// Properties are the labels used by Change.Property, except for entries of a map (such as paths, response codes or
+// components), which use the object name of the entry, so removing any path is the 'pathItem' property of 'paths',
+// and removing any component schema is the 'schema' property of 'components'. Extensions belong to the object that
+// holds them, and use the name of the extension as the property. Every change a rule flags as breaking is counted
+// as a breaking change, including changes to extensions and external docs, which are never breaking by default.
+//
+// A rules configuration looks like this in YAML:
+//
+//	schema:
+//	  enum:
+//	    added: false
+//	    response:
+//	      added: true
+//	paths:
+//	  pathItem:
+//	    removed: true
+//	'*':
+//	  description:
+//	    modified: false
+//
+// Rules are used by CompareOptions, or applied to the results of any Compare function using Apply.
+type BreakingRules map[string]map[string]*BreakingChangeRule
+
+// CompareOptions configures the comparison of two documents.
+type CompareOptions struct {
+
+	// BreakingRules determine which changes are breaking, instead of the built-in behavior. Nil uses the built-in
+	// behavior for every change.
+	BreakingRules BreakingRules
+}
+
+// LoadBreakingRules will parse a YAML or JSON rules configuration into BreakingRules. Unknown change types are
+// reported as an error.
+func LoadBreakingRules(config []byte) (BreakingRules, error) {
+	var rules BreakingRules
+	dec := yaml.NewDecoder(bytes.NewReader(config))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("unable to load breaking rules: %w", err)
+	}
+	return rules, nil
+}
+
+// IsBreaking looks up the rule for an object, property, direction and change type. The rule for the object is
+// checked first, then the wildcard object. If no rule exists, found is false.
+func (b BreakingRules) IsBreaking(object, property string, direction SchemaDirection,
+	changeType int) (breaking bool, found bool) {
+	for _, o := range []string{object, WildcardRuleObject} {
+		if rule := b[o][property]; rule != nil {
+			if v := rule.forDirection(direction).forChangeType(changeType); v != nil {
+				return *v, true
+			}
+			if v := rule.forChangeType(changeType); v != nil {
+				return *v, true
+			}
+		}
+	}
+	return false, false
+}
+
+// Apply will set the breaking state of every change in a tree of changes, such as a *WhatChanged or the results of
+// any Compare function, using the rules. Changes without a rule get their built-in breaking state, so a tree of
+// changes can have rules applied more than once. The breaking total of a WhatChanged is updated.
+func (b BreakingRules) Apply(changes any) {
+	b.apply(reflect.ValueOf(changes), DocumentRuleObject, SchemaDirectionUnknown)
+	if wc, ok := changes.(*WhatChanged); ok && wc != nil {
+		if wc.Changes != nil {
+			wc.TotalBreakingChanges = wc.Changes.TotalBreakingChanges()
+		}
+		if wc.SwaggerChanges != nil {
+			wc.TotalBreakingChanges = wc.SwaggerChanges.TotalBreakingChanges()
+		}
+	}
+}
+
+// apply walks a tree of changes, every change is owned by the closest changes object (a change to an extension is
+// owned by the object that holds the extension). Changes beneath requests and responses have a direction.
+func (b BreakingRules) apply(v reflect.Value, object string, direction SchemaDirection) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if d, ok := changeDirections[v.Type()]; ok {
+			direction = d
+		}
+		if c, ok := v.Interface().(ruleChange); ok {
+			c.applyRules(b, object, direction)
+			return
+		}
+		b.apply(v.Elem(), object, direction)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			b.apply(v.Index(i), object, direction)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			b.apply(iter.Value(), object, direction)
+		}
+	case reflect.Struct:
+		if o, ok := v.Interface().(ruleOwner); ok && o.ruleObject() != DocumentRuleObject {
+			object = o.ruleObject()
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				b.apply(v.Field(i), object, direction)
+			}
+		}
+	}
+}
+
+// changeDirections holds the changes objects for requests and responses, everything beneath them has a direction.
+var changeDirections = map[reflect.Type]SchemaDirection{
+	reflect.TypeOf((*RequestBodyChanges)(nil)): SchemaDirectionRequest,
+	reflect.TypeOf((*ParameterChanges)(nil)):   SchemaDirectionRequest,
+	reflect.TypeOf((*ParameterChangesV2)(nil)): SchemaDirectionRequest,
+	reflect.TypeOf((*ResponseChanges)(nil)):    SchemaDirectionResponse,
+	reflect.TypeOf((*ResponsesChanges)(nil)):   SchemaDirectionResponse,
+	reflect.TypeOf((*ResponseChangesV2)(nil)):  SchemaDirectionResponse,
+	reflect.TypeOf((*ResponsesChangesV2)(nil)): SchemaDirectionResponse,
+}
+
+// ruleChange is implemented by every Change, so rules can be applied without knowing the type of the change.
+type ruleChange interface {
+	applyRules(rules BreakingRules, object string, direction SchemaDirection)
+}
+
+// ruleOwner is implemented by every changes object (using PropertyChanges), and returns the object name that owns
+// the changes.
+type ruleOwner interface {
+	ruleObject() string
+}
+
+func (r *BreakingChangeRule) forDirection(direction SchemaDirection) *BreakingChangeRule {
+	switch direction {
+	case SchemaDirectionRequest:
+		return r.Request
+	case SchemaDirectionResponse:
+		return r.Response
+	}
+	return nil
+}
+
+func (r *BreakingChangeRule) forChangeType(changeType int) *bool {
+	if r == nil {
+		return nil
+	}
+	switch changeType {
+	case PropertyAdded, ObjectAdded:
+		return r.Added
+	case Modified:
+		return r.Modified
+	case PropertyRemoved, ObjectRemoved:
+		return r.Removed
+	case Moved, ModifiedAndMoved:
+		return r.Moved
+	}
+	return nil
+}
+
+// ruleObjectNameOverrides holds rule object names that cannot be derived from a model name.
+var ruleObjectNameOverrides = map[string]string{
+	"OAuthFlows":  "oauthFlows",
+	"OAuthFlow":   "oauthFlow",
+	"SchemaProxy": "schema",
+}
+
+// ruleObjectName converts the name of T into a rule object name, 'PathItem' becomes 'pathItem' and 'XML' becomes
+// 'xml'. Changes owned by any type are document changes.
+func ruleObjectName[T any]() string {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface || t.Name() == "" {
+		return DocumentRuleObject
+	}
+	if n, ok := ruleObjectNameOverrides[t.Name()]; ok {
+		return n
+	}
+	return lowerCamel(t.Name())
+}
+
+// ruleEntryName returns the rule property for an entry of a map of T, which is the rule object name of T. Entries
+// that are not objects have no name, and use the key of the entry as the property.
+func ruleEntryName[T any]() string {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+	return ruleObjectName[T]()
+}
+
+// lowerCamel converts an exported Go name into lower camel case, a leading acronym is lowered as a whole.
+func lowerCamel(n string) string {
+	name := []rune(n)
+	for i := range name {
+		if !unicode.IsUpper(name[i]) {
+			break
+		}
+		// keep the last capital of a leading acronym, when it starts the next word.
+		if i > 0 && i+1 < len(name) && unicode.IsLower(name[i+1]) {
+			break
+		}
+		name[i] = unicode.ToLower(name[i])
+	}
+	return string(name)
+}
@@ -0,0 +1,315 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestLoadBreakingRules_YAML(t *testing.T) {
+
+	config := `schema:
+  enum:
+    added: true
+    removed: false
+'*':
+  description:
+    modified: false`
+
+	rules, err := LoadBreakingRules([]byte(config))
+	assert.NoError(t, err)
+
+	b, found := rules.IsBreaking("schema", v3.EnumLabel, SchemaDirectionUnknown, PropertyAdded)
+	assert.True(t, found)
+	assert.True(t, b)
+
+	b, found = rules.IsBreaking("schema", v3.EnumLabel, SchemaDirectionUnknown, PropertyRemoved)
+	assert.True(t, found)
+	assert.False(t, b)
+
+	_, found = rules.IsBreaking("schema", v3.EnumLabel, SchemaDirectionUnknown, Modified)
+	assert.False(t, found)
+
+	b, found = rules.IsBreaking("parameter", v3.DescriptionLabel, SchemaDirectionUnknown, Modified)
+	assert.True(t, found)
+	assert.False(t, b)
+}
+
+func TestLoadBreakingRules_JSON(t *testing.T) {
+
+	config := `{"parameter": {"required": {"modified": false}}}`
+
+	rules, err := LoadBreakingRules([]byte(config))
+	assert.NoError(t, err)
+
+	b, found := rules.IsBreaking("parameter", v3.RequiredLabel, SchemaDirectionUnknown, Modified)
+	assert.True(t, found)
+	assert.False(t, b)
+}
+
+func TestLoadBreakingRules_UnknownChangeType(t *testing.T) {
+
+	config := `schema:
+  enum:
+    changed: true`
+
+	_, err := LoadBreakingRules([]byte(config))
+	assert.Error(t, err)
+}
+
+func TestRuleObjectName(t *testing.T) {
+	assert.Equal(t, "schema", ruleObjectName[*base.Schema]())
+	assert.Equal(t, "pathItem", ruleObjectName[*v3.PathItem]())
+	assert.Equal(t, "pathItem", ruleObjectName[*v2.PathItem]())
+	assert.Equal(t, "xml", ruleObjectName[*base.XML]())
+	assert.Equal(t, "oauthFlows", ruleObjectName[*v3.OAuthFlows]())
+	assert.Equal(t, DocumentRuleObject, ruleObjectName[any]())
+}
+
+func TestCompareSchemas_BreakingRules(t *testing.T) {
+	left := `components:
+  schemas:
+    OK:
+      description: pizza
+      enum: [cheese, ham]`
+
+	right := `components:
+  schemas:
+    OK:
+      description: tasty pizza
+      enum: [cheese, ham, pineapple]`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	// built-in behavior, nothing is breaking.
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+
+	rules, _ := LoadBreakingRules([]byte(`schema:
+  enum:
+    added: true
+'*':
+  description:
+    modified: true`))
+
+	rules.Apply(changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+
+	// applying no rules restores the built-in behavior.
+	BreakingRules{}.Apply(changes)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+}
+
+func TestCompareTags_BreakingRules(t *testing.T) {
+
+	left := `name: pizza
+description: all about pizza`
+
+	right := `name: pizza
+description: everything about pizza`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc base.Tag
+	var rDoc base.Tag
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	changes := CompareTags([]low.ValueReference[*base.Tag]{{Value: &lDoc}},
+		[]low.ValueReference[*base.Tag]{{Value: &rDoc}})
+
+	// an object rule beats a wildcard rule.
+	BreakingRules{
+		"tag": {v3.DescriptionLabel: {Modified: boolPtr(true)}},
+		"*":   {v3.DescriptionLabel: {Modified: boolPtr(false)}},
+	}.Apply(changes)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
+
+func TestLoadBreakingRules_Direction(t *testing.T) {
+
+	config := `schema:
+  enum:
+    added: false
+    response:
+      added: true`
+
+	rules, err := LoadBreakingRules([]byte(config))
+	assert.NoError(t, err)
+
+	b, _ := rules.IsBreaking("schema", v3.EnumLabel, SchemaDirectionUnknown, PropertyAdded)
+	assert.False(t, b)
+	b, _ = rules.IsBreaking("schema", v3.EnumLabel, SchemaDirectionRequest, PropertyAdded)
+	assert.False(t, b)
+	b, _ = rules.IsBreaking("schema", v3.EnumLabel, SchemaDirectionResponse, PropertyAdded)
+	assert.True(t, b)
+
+	// the direction has no opinion on removing, the rule itself has none either.
+	_, found := rules.IsBreaking("schema", v3.EnumLabel, SchemaDirectionResponse, PropertyRemoved)
+	assert.False(t, found)
+}
+
+func TestCompareOpenAPIDocumentsWithOptions_Direction(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              enum: [cheese]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                enum: [cheese]
+components:
+  schemas:
+    Pizza:
+      enum: [cheese]`
+
+	right := `openapi: 3.1.0
+paths:
+  /pizza:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              enum: [cheese, ham]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                enum: [cheese, ham]
+components:
+  schemas:
+    Pizza:
+      enum: [cheese, ham]`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	rules, _ := LoadBreakingRules([]byte(`schema:
+  enum:
+    added: false
+    response:
+      added: true`))
+
+	wc := CompareOpenAPIDocumentsWithOptions(leftDoc, rightDoc, &CompareOptions{BreakingRules: rules})
+	assert.Equal(t, 3, wc.TotalChanges)
+	assert.Equal(t, 1, wc.TotalBreakingChanges)
+
+	op := wc.Changes.PathsChanges.PathItemsChanges["/pizza"].PostChanges
+	assert.False(t, op.RequestBodyChanges.ContentChanges["application/json"].SchemaChanges.Changes[0].Breaking)
+	assert.True(t, op.ResponsesChanges.ResponseChanges["200"].ContentChanges["application/json"].
+		SchemaChanges.Changes[0].Breaking)
+	assert.False(t, wc.Changes.ComponentsChanges.SchemaChanges["Pizza"].Changes[0].Breaking)
+}
+
+func TestCompareOpenAPIDocumentsWithOptions_MapEntries(t *testing.T) {
+	left := `openapi: 3.1.0
+x-pizza: cheese
+paths:
+  /pizza:
+    get:
+      description: pizza
+  /burger:
+    get:
+      description: burger`
+
+	right := `openapi: 3.1.0
+x-pizza: ham
+paths:
+  /pizza:
+    get:
+      description: pizza`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	// built-in behavior, removing a path is breaking and changing an extension is not.
+	wc := CompareOpenAPIDocuments(leftDoc, rightDoc)
+	assert.Equal(t, 2, wc.TotalChanges)
+	assert.Equal(t, 1, wc.TotalBreakingChanges)
+
+	// any path is a pathItem entry of paths, and the extension belongs to the document.
+	rules, _ := LoadBreakingRules([]byte(`paths:
+  pathItem:
+    removed: false
+document:
+  x-pizza:
+    modified: true`))
+
+	wc = CompareOpenAPIDocumentsWithOptions(leftDoc, rightDoc, &CompareOptions{BreakingRules: rules})
+	assert.Equal(t, 2, wc.TotalChanges)
+	assert.Equal(t, 1, wc.TotalBreakingChanges)
+	assert.Equal(t, "/burger", wc.Changes.PathsChanges.Changes[0].Property)
+	assert.False(t, wc.Changes.PathsChanges.Changes[0].Breaking)
+	assert.True(t, wc.Changes.ExtensionChanges.Changes[0].Breaking)
+	assert.Equal(t, 1, wc.Changes.ExtensionChanges.TotalBreakingChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithOptions_ExternalDocs(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      externalDocs:
+        url: https://pb33f.io/pizza
+      x-oven: hot`
+
+	right := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      externalDocs:
+        url: https://pb33f.io/hot-pizza
+      x-oven: cold`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	// built-in behavior, neither external docs nor extensions are breaking.
+	wc := CompareOpenAPIDocuments(leftDoc, rightDoc)
+	assert.Equal(t, 2, wc.TotalChanges)
+	assert.Equal(t, 0, wc.TotalBreakingChanges)
+
+	// changes flagged as breaking by rules are counted, wherever they are.
+	rules, _ := LoadBreakingRules([]byte(`externalDoc:
+  url:
+    modified: true
+operation:
+  x-oven:
+    modified: true`))
+
+	wc = CompareOpenAPIDocumentsWithOptions(leftDoc, rightDoc, &CompareOptions{BreakingRules: rules})
+	assert.Equal(t, 2, wc.TotalChanges)
+	assert.Equal(t, 2, wc.TotalBreakingChanges)
+	op := wc.Changes.PathsChanges.PathItemsChanges["/pizza"].GetChanges
+	assert.Equal(t, 1, op.ExternalDocChanges.TotalBreakingChanges())
+	assert.Equal(t, 1, op.ExtensionChanges.TotalBreakingChanges())
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
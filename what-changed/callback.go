@@ -0,0 +1,58 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// CallbackChanges represents all changes made between two Callback OpenAPI objects.
+type CallbackChanges struct {
+	PropertyChanges[*v3.Callback]
+	ExpressionChanges map[string]*PathItemChanges
+	ExtensionChanges  *ExtensionChanges
+}
+
+// TotalChanges returns a total count of all changes made between Callback objects
+func (c *CallbackChanges) TotalChanges() int {
+	d := c.PropertyChanges.TotalChanges()
+	for k := range c.ExpressionChanges {
+		d += c.ExpressionChanges[k].TotalChanges()
+	}
+	if c.ExtensionChanges != nil {
+		d += c.ExtensionChanges.TotalChanges()
+	}
+	return d
+}
+
+// TotalBreakingChanges returns a total count of all changes made between Callback objects
+func (c *CallbackChanges) TotalBreakingChanges() int {
+	d := c.PropertyChanges.TotalBreakingChanges()
+	for k := range c.ExpressionChanges {
+		d += c.ExpressionChanges[k].TotalBreakingChanges()
+	}
+	if c.ExtensionChanges != nil {
+		d += c.ExtensionChanges.TotalBreakingChanges()
+	}
+	return d
+}
+
+// CompareCallback will compare two Callback objects and return a pointer to CallbackChanges with all the things
+// that have changed between them. Returns nil if nothing changed.
+func CompareCallback(l, r *v3.Callback) *CallbackChanges {
+	var changes []*Change[*v3.Callback]
+
+	cc := new(CallbackChanges)
+
+	// expressions, removing an expression is a breaking change.
+	cc.ExpressionChanges = CheckMapForChanges(l.Expression.Value, r.Expression.Value, &changes,
+		false, true, ComparePathItems)
+
+	cc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	cc.Changes = changes
+	if cc.TotalChanges() <= 0 {
+		return nil
+	}
+	return cc
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareCallback_Modified(t *testing.T) {
+
+	left := `'{$request.query.queryUrl}':
+  post:
+    operationId: pizzaReady
+'{$request.query.otherUrl}':
+  post:
+    operationId: pizzaBurned`
+
+	right := `'{$request.query.queryUrl}':
+  post:
+    operationId: pizzaIsReady
+'{$request.query.newUrl}':
+  post:
+    operationId: pizzaDelivered`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Callback
+	var rDoc v3.Callback
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareCallback(&lDoc, &rDoc)
+	assert.Equal(t, 3, extChanges.TotalChanges())
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.ExpressionChanges["{$request.query.queryUrl}"])
+}
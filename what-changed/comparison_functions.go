@@ -6,6 +6,7 @@ package what_changed
 import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"gopkg.in/yaml.v3"
+	"sort"
 )
 
 // CreateChange is a generic function that will create a Change of type T, populate all properties if set, and then
@@ -20,6 +21,7 @@ func CreateChange[T any](changes *[]*Change[T], changeType int, property string,
 		ChangeType: changeType,
 		Property:   property,
 		Breaking:   breaking,
+		builtIn:    breaking,
 	}
 	// if the left is not nil, we have an original value
 	if leftValueNode != nil && leftValueNode.Value != "" {
@@ -114,8 +116,9 @@ func CheckSpecificObjectAdded[T any](l, r map[string]*T, label string) bool {
 
 // CheckProperties will iterate through a slice of PropertyCheck pointers of type T. The method is a convenience method
 // for running checks on the following methods in order:
-//   CheckPropertyAdditionOrRemoval
-//   CheckForModification
+//
+//	CheckPropertyAdditionOrRemoval
+//	CheckForModification
 func CheckProperties[T any](properties []*PropertyCheck[T]) {
 	for _, n := range properties {
 		CheckPropertyAdditionOrRemoval(n.LeftNode, n.RightNode, n.Label, n.Changes, n.Breaking, n.Original, n.New)
@@ -133,7 +136,7 @@ func CheckPropertyAdditionOrRemoval[T any](l, r *yaml.Node,
 // CheckForRemoval will check left and right yaml.Node instances for changes. Anything that is found missing on the
 // right, but present on the left, is considered a removal. A new Change[T] will be created with the type
 //
-//  PropertyRemoved
+//	PropertyRemoved
 //
 // The Change is then added to the slice of []Change[T] instances provided as a pointer.
 func CheckForRemoval[T any](l, r *yaml.Node, label string, changes *[]*Change[T], breaking bool, orig, new T) {
@@ -145,7 +148,7 @@ func CheckForRemoval[T any](l, r *yaml.Node, label string, changes *[]*Change[T]
 // CheckForAddition will check left and right yaml.Node instances for changes. Anything that is found missing on the
 // left, but present on the left, is considered an addition. A new Change[T] will be created with the type
 //
-//  PropertyAdded
+//	PropertyAdded
 //
 // The Change is then added to the slice of []Change[T] instances provided as a pointer.
 func CheckForAddition[T any](l, r *yaml.Node, label string, changes *[]*Change[T], breaking bool, orig, new T) {
@@ -182,3 +185,149 @@ func CheckExtensions[T low.HasExtensions[T]](l, r T) *ExtensionChanges {
 	}
 	return CompareExtensions(lExt, rExt)
 }
+
+// CheckMapForChanges checks a left (original) and right (new) low-level map of objects for additions, removals and
+// modifications. Keys missing on the right are recorded as ObjectRemoved, keys missing on the left are recorded as
+// ObjectAdded and the changes are added to the supplied slice of changes.
+//
+// Keys found on both sides are passed to compareFunc, which should be the Compare function for the type being
+// checked. Any results that contain changes are returned in a map, keyed by the map key that was compared.
+func CheckMapForChanges[T any, N any, R interface {
+	*N
+	TotalChanges() int
+}, C any](l, r map[low.KeyReference[string]]low.ValueReference[T], changes *[]*Change[C],
+	breakingAdd, breakingRemove bool, compareFunc func(l, r T) R) map[string]R {
+	return checkMapForChanges(l, r, changes, breakingAdd, breakingRemove, noMoves, compareFunc)
+}
+
+// checkMapForChanges works the same way as CheckMapForChanges, and also pairs removed keys with added keys as
+// selected by moves. Keys are paired if they are path templates that only differ by the names of parameters
+// (which is recorded as Moved or ModifiedAndMoved and is never breaking), or if the values of both keys have
+// identical hashes (which is recorded as Moved, and is as breaking as a removal). The result of a ModifiedAndMoved
+// value is keyed by its new key.
+func checkMapForChanges[T any, N any, R interface {
+	*N
+	TotalChanges() int
+}, C any](l, r map[low.KeyReference[string]]low.ValueReference[T], changes *[]*Change[C],
+	breakingAdd, breakingRemove bool, moves moveDetection, compareFunc func(l, r T) R) map[string]R {
+
+	lValues := make(map[string]low.ValueReference[T])
+	rValues := make(map[string]low.ValueReference[T])
+	lKeys := make(map[string]*yaml.Node)
+	rKeys := make(map[string]*yaml.Node)
+	var keys []string
+	for k := range l {
+		lValues[k.Value] = l[k]
+		lKeys[k.Value] = k.KeyNode
+		keys = append(keys, k.Value)
+	}
+	for k := range r {
+		rValues[k.Value] = r[k]
+		rKeys[k.Value] = k.KeyNode
+		if _, ok := lValues[k.Value]; !ok {
+			keys = append(keys, k.Value)
+		}
+	}
+
+	// sort keys so results are always returned in the same order.
+	sort.Strings(keys)
+
+	var removed, added []string
+	for _, k := range keys {
+		_, lok := lValues[k]
+		_, rok := rValues[k]
+		if lok && !rok {
+			removed = append(removed, k)
+		}
+		if !lok && rok {
+			added = append(added, k)
+		}
+	}
+	moved, removed, added := detectMoves(removed, added, lValues, rValues, moves)
+	movedTo := make(map[string]movedKey)
+	for _, m := range moved {
+		movedTo[m.to] = m
+	}
+	removedKeys := make(map[string]bool)
+	for _, k := range removed {
+		removedKeys[k] = true
+	}
+	addedKeys := make(map[string]bool)
+	for _, k := range added {
+		addedKeys[k] = true
+	}
+
+	// map entries are added, removed and moved as a whole, so rules use the entry object name as the property.
+	entry := ruleEntryName[T]()
+	createChange := func(changeType int, k string, l, r *yaml.Node, breaking bool, o, n any) {
+		CreateChange[C](changes, changeType, k, l, r, breaking, o, n)
+		(*changes)[len(*changes)-1].ruleProperty = entry
+	}
+
+	results := make(map[string]R)
+	for _, k := range keys {
+		lv, lok := lValues[k]
+		rv, rok := rValues[k]
+		if m, ok := movedTo[k]; ok {
+			lv = lValues[m.from]
+			changeType, breaking := Moved, breakingRemove
+			if m.template {
+				breaking = false
+			}
+			if m.modified {
+				changeType = ModifiedAndMoved
+				if res := compareFunc(lv.GetValue(), rv.GetValue()); res != nil && res.TotalChanges() > 0 {
+					results[k] = res
+				}
+			}
+			createChange(changeType, k, lKeys[m.from], rKeys[k], breaking, lv.GetValue(), rv.GetValue())
+			continue
+		}
+		if removedKeys[k] {
+			createChange(ObjectRemoved, k, lKeys[k], nil, breakingRemove, lv.GetValue(), nil)
+			continue
+		}
+		if addedKeys[k] {
+			createChange(ObjectAdded, k, nil, rKeys[k], breakingAdd, nil, rv.GetValue())
+			continue
+		}
+		if !lok || !rok {
+			// moved away, the move is recorded against the new key.
+			continue
+		}
+		if res := compareFunc(lv.GetValue(), rv.GetValue()); res != nil && res.TotalChanges() > 0 {
+			results[k] = res
+		}
+	}
+	if len(results) <= 0 {
+		return nil
+	}
+	return results
+}
+
+// CheckStringSliceForChanges will check a left (original) and right (new) slice of string values for any
+// values that were added or removed. The order of the values is not considered a change.
+func CheckStringSliceForChanges[C any](l, r []low.ValueReference[string], label string, changes *[]*Change[C],
+	breakingAdd, breakingRemove bool, original, new any) {
+
+	lValues := make(map[string]low.ValueReference[string])
+	rValues := make(map[string]low.ValueReference[string])
+	for i := range l {
+		lValues[l[i].Value] = l[i]
+	}
+	for i := range r {
+		rValues[r[i].Value] = r[i]
+	}
+	for i := range l {
+		if _, ok := rValues[l[i].Value]; !ok {
+			CreateChange[C](changes, PropertyRemoved, label, l[i].ValueNode, nil,
+				breakingRemove, original, new)
+		}
+	}
+	for i := range r {
+		if _, ok := lValues[r[i].Value]; !ok {
+			CreateChange[C](changes, PropertyAdded, label, nil, r[i].ValueNode,
+				breakingAdd, original, new)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// ComponentsChanges represents changes made between two OpenAPI 3+ Components objects.
+type ComponentsChanges struct {
+	PropertyChanges[*v3.Components]
+	SchemaChanges         map[string]*SchemaChanges
+	ResponsesChanges      map[string]*ResponseChanges
+	ParameterChanges      map[string]*ParameterChanges
+	ExamplesChanges       map[string]*ExampleChanges
+	RequestBodyChanges    map[string]*RequestBodyChanges
+	HeaderChanges         map[string]*HeaderChanges
+	SecuritySchemeChanges map[string]*SecuritySchemeChanges
+	LinkChanges           map[string]*LinkChanges
+	CallbackChanges       map[string]*CallbackChanges
+	PathItemsChanges      map[string]*PathItemChanges
+	ExtensionChanges      *ExtensionChanges
+}
+
+// TotalChanges returns total changes for all Components
+func (c *ComponentsChanges) TotalChanges() int {
+	v := c.PropertyChanges.TotalChanges()
+	for k := range c.SchemaChanges {
+		v += c.SchemaChanges[k].TotalChanges()
+	}
+	for k := range c.ResponsesChanges {
+		v += c.ResponsesChanges[k].TotalChanges()
+	}
+	for k := range c.ParameterChanges {
+		v += c.ParameterChanges[k].TotalChanges()
+	}
+	for k := range c.ExamplesChanges {
+		v += c.ExamplesChanges[k].TotalChanges()
+	}
+	for k := range c.RequestBodyChanges {
+		v += c.RequestBodyChanges[k].TotalChanges()
+	}
+	for k := range c.HeaderChanges {
+		v += c.HeaderChanges[k].TotalChanges()
+	}
+	for k := range c.SecuritySchemeChanges {
+		v += c.SecuritySchemeChanges[k].TotalChanges()
+	}
+	for k := range c.LinkChanges {
+		v += c.LinkChanges[k].TotalChanges()
+	}
+	for k := range c.CallbackChanges {
+		v += c.CallbackChanges[k].TotalChanges()
+	}
+	for k := range c.PathItemsChanges {
+		v += c.PathItemsChanges[k].TotalChanges()
+	}
+	if c.ExtensionChanges != nil {
+		v += c.ExtensionChanges.TotalChanges()
+	}
+	return v
+}
+
+// TotalBreakingChanges returns all breaking changes found for all Components
+func (c *ComponentsChanges) TotalBreakingChanges() int {
+	v := c.PropertyChanges.TotalBreakingChanges()
+	for k := range c.SchemaChanges {
+		v += c.SchemaChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.ResponsesChanges {
+		v += c.ResponsesChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.ParameterChanges {
+		v += c.ParameterChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.ExamplesChanges {
+		v += c.ExamplesChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.RequestBodyChanges {
+		v += c.RequestBodyChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.HeaderChanges {
+		v += c.HeaderChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.SecuritySchemeChanges {
+		v += c.SecuritySchemeChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.LinkChanges {
+		v += c.LinkChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.CallbackChanges {
+		v += c.CallbackChanges[k].TotalBreakingChanges()
+	}
+	for k := range c.PathItemsChanges {
+		v += c.PathItemsChanges[k].TotalBreakingChanges()
+	}
+	if c.ExtensionChanges != nil {
+		v += c.ExtensionChanges.TotalBreakingChanges()
+	}
+	return v
+}
+
+// CompareComponents will compare a left (original) and right (new) Components object for changes. Removing a
+// component is considered a breaking change, as anything that references it will no longer resolve. Adding a
+// component is not a breaking change. If changes are found, a pointer to ComponentsChanges is returned, otherwise
+// nil is returned.
+func CompareComponents(l, r *v3.Components) *ComponentsChanges {
+	var changes []*Change[*v3.Components]
+
+	cc := new(ComponentsChanges)
+
+	cc.SchemaChanges = checkMapForChanges(l.Schemas.Value, r.Schemas.Value, &changes,
+		false, true, identicalMoves, CompareSchemas)
+	cc.ResponsesChanges = checkMapForChanges(l.Responses.Value, r.Responses.Value, &changes,
+		false, true, identicalMoves, CompareResponse)
+	cc.ParameterChanges = checkMapForChanges(l.Parameters.Value, r.Parameters.Value, &changes,
+		false, true, identicalMoves, CompareParameters)
+	cc.ExamplesChanges = checkMapForChanges(l.Examples.Value, r.Examples.Value, &changes,
+		false, false, identicalMoves, CompareExamples)
+	cc.RequestBodyChanges = checkMapForChanges(l.RequestBodies.Value, r.RequestBodies.Value, &changes,
+		false, true, identicalMoves, CompareRequestBodies)
+	cc.HeaderChanges = checkMapForChanges(l.Headers.Value, r.Headers.Value, &changes,
+		false, true, identicalMoves, CompareHeaders)
+	cc.SecuritySchemeChanges = checkMapForChanges(l.SecuritySchemes.Value, r.SecuritySchemes.Value, &changes,
+		false, true, identicalMoves, CompareSecuritySchemes)
+	cc.LinkChanges = checkMapForChanges(l.Links.Value, r.Links.Value, &changes,
+		false, true, identicalMoves, CompareLinks)
+	cc.CallbackChanges = checkMapForChanges(l.Callbacks.Value, r.Callbacks.Value, &changes,
+		false, true, identicalMoves, CompareCallback)
+	cc.PathItemsChanges = checkMapForChanges(l.PathItems.Value, r.PathItems.Value, &changes,
+		false, true, identicalMoves, ComparePathItems)
+
+	cc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	cc.Changes = changes
+	if cc.TotalChanges() <= 0 {
+		return nil
+	}
+	return cc
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompareComponents_Identical(t *testing.T) {
+
+	left := `openapi: 3.0.1
+components:
+  schemas:
+    Pizza:
+      type: object
+  parameters:
+    Size:
+      name: size
+      in: query`
+
+	right := left
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := CompareComponents(lDoc.Components.Value, rDoc.Components.Value)
+	assert.Nil(t, changes)
+}
+
+func TestCompareComponents_Modified(t *testing.T) {
+
+	left := `openapi: 3.0.1
+components:
+  schemas:
+    Pizza:
+      type: object
+    Burger:
+      type: object
+  parameters:
+    Size:
+      name: size
+      in: query
+  securitySchemes:
+    ApiKey:
+      type: apiKey
+      name: X-API-KEY
+      in: header`
+
+	right := `openapi: 3.0.1
+components:
+  schemas:
+    Pizza:
+      type: string
+  parameters:
+    Size:
+      name: size
+      in: query
+    Crust:
+      name: crust
+      in: query
+  securitySchemes:
+    ApiKey:
+      type: apiKey
+      name: X-PIZZA-KEY
+      in: header`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := CompareComponents(lDoc.Components.Value, rDoc.Components.Value)
+	assert.Equal(t, 4, changes.TotalChanges())
+	assert.Equal(t, 3, changes.TotalBreakingChanges())
+	assert.NotNil(t, changes.SchemaChanges["Pizza"])
+	assert.NotNil(t, changes.SecuritySchemeChanges["ApiKey"])
+	assert.Len(t, changes.Changes, 2)
+}
+
+func TestCompareComponents_PathItems(t *testing.T) {
+
+	left := `openapi: 3.1.0
+components:
+  pathItems:
+    Pets:
+      description: pets
+      get:
+        description: list pets
+    Dogs:
+      description: dogs
+      get:
+        description: list dogs`
+
+	right := `openapi: 3.1.0
+components:
+  pathItems:
+    Pets:
+      description: pets
+      get:
+        description: list all the pets
+    Cats:
+      description: cats
+      get:
+        description: list cats`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := CompareComponents(lDoc.Components.Value, rDoc.Components.Value)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.NotNil(t, changes.PathItemsChanges["Pets"])
+	assert.Len(t, changes.Changes, 2)
+}
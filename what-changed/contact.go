@@ -18,9 +18,10 @@ func (c *ContactChanges) TotalChanges() int {
 	return c.PropertyChanges.TotalChanges()
 }
 
-// TotalBreakingChanges always returns 0 for Contact objects, they are non-binding.
+// TotalBreakingChanges returns the number of breaking changes made to Contact objects. They are non-binding, so
+// only changes marked as breaking by BreakingRules are counted.
 func (c *ContactChanges) TotalBreakingChanges() int {
-	return 0
+	return c.PropertyChanges.TotalBreakingChanges()
 }
 
 // CompareContact will check a left (original) and right (new) Contact object for any changes. If there
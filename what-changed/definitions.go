@@ -0,0 +1,174 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+)
+
+// DefinitionsChanges represents changes found between two Swagger Definitions objects.
+type DefinitionsChanges struct {
+	PropertyChanges[*v2.Definitions]
+	SchemaChanges map[string]*SchemaChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger Definitions objects.
+func (d *DefinitionsChanges) TotalChanges() int {
+	c := d.PropertyChanges.TotalChanges()
+	for k := range d.SchemaChanges {
+		c += d.SchemaChanges[k].TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger Definitions objects.
+func (d *DefinitionsChanges) TotalBreakingChanges() int {
+	c := d.PropertyChanges.TotalBreakingChanges()
+	for k := range d.SchemaChanges {
+		c += d.SchemaChanges[k].TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareDefinitions compares a left (original) and right (new) Swagger Definitions object for changes. Removing
+// a definition is a breaking change, adding one is not. If changes are found, a pointer to DefinitionsChanges is
+// returned, otherwise nil is returned.
+func CompareDefinitions(l, r *v2.Definitions) *DefinitionsChanges {
+	var changes []*Change[*v2.Definitions]
+
+	dc := new(DefinitionsChanges)
+	dc.SchemaChanges = checkMapForChanges(l.Schemas, r.Schemas, &changes, false, true, identicalMoves, CompareSchemas)
+
+	dc.Changes = changes
+	if dc.TotalChanges() <= 0 {
+		return nil
+	}
+	return dc
+}
+
+// ParameterDefinitionsChanges represents changes found between two Swagger ParameterDefinitions objects.
+type ParameterDefinitionsChanges struct {
+	PropertyChanges[*v2.ParameterDefinitions]
+	ParameterChanges map[string]*ParameterChangesV2
+}
+
+// TotalChanges returns the total number of changes found between two Swagger ParameterDefinitions objects.
+func (d *ParameterDefinitionsChanges) TotalChanges() int {
+	c := d.PropertyChanges.TotalChanges()
+	for k := range d.ParameterChanges {
+		c += d.ParameterChanges[k].TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger
+// ParameterDefinitions objects.
+func (d *ParameterDefinitionsChanges) TotalBreakingChanges() int {
+	c := d.PropertyChanges.TotalBreakingChanges()
+	for k := range d.ParameterChanges {
+		c += d.ParameterChanges[k].TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareParameterDefinitions compares a left (original) and right (new) Swagger ParameterDefinitions object for
+// changes. Removing a parameter definition is a breaking change, adding one is not. If changes are found, a pointer
+// to ParameterDefinitionsChanges is returned, otherwise nil is returned.
+func CompareParameterDefinitions(l, r *v2.ParameterDefinitions) *ParameterDefinitionsChanges {
+	var changes []*Change[*v2.ParameterDefinitions]
+
+	dc := new(ParameterDefinitionsChanges)
+	dc.ParameterChanges = checkMapForChanges(l.Definitions, r.Definitions, &changes,
+		false, true, identicalMoves, CompareParametersV2)
+
+	dc.Changes = changes
+	if dc.TotalChanges() <= 0 {
+		return nil
+	}
+	return dc
+}
+
+// ResponsesDefinitionsChanges represents changes found between two Swagger ResponsesDefinitions objects.
+type ResponsesDefinitionsChanges struct {
+	PropertyChanges[*v2.ResponsesDefinitions]
+	ResponseChanges map[string]*ResponseChangesV2
+}
+
+// TotalChanges returns the total number of changes found between two Swagger ResponsesDefinitions objects.
+func (d *ResponsesDefinitionsChanges) TotalChanges() int {
+	c := d.PropertyChanges.TotalChanges()
+	for k := range d.ResponseChanges {
+		c += d.ResponseChanges[k].TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger
+// ResponsesDefinitions objects.
+func (d *ResponsesDefinitionsChanges) TotalBreakingChanges() int {
+	c := d.PropertyChanges.TotalBreakingChanges()
+	for k := range d.ResponseChanges {
+		c += d.ResponseChanges[k].TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareResponsesDefinitions compares a left (original) and right (new) Swagger ResponsesDefinitions object for
+// changes. Removing a response definition is a breaking change, adding one is not. If changes are found, a pointer
+// to ResponsesDefinitionsChanges is returned, otherwise nil is returned.
+func CompareResponsesDefinitions(l, r *v2.ResponsesDefinitions) *ResponsesDefinitionsChanges {
+	var changes []*Change[*v2.ResponsesDefinitions]
+
+	dc := new(ResponsesDefinitionsChanges)
+	dc.ResponseChanges = checkMapForChanges(l.Definitions, r.Definitions, &changes,
+		false, true, identicalMoves, CompareResponseV2)
+
+	dc.Changes = changes
+	if dc.TotalChanges() <= 0 {
+		return nil
+	}
+	return dc
+}
+
+// SecurityDefinitionsChanges represents changes found between two Swagger SecurityDefinitions objects.
+type SecurityDefinitionsChanges struct {
+	PropertyChanges[*v2.SecurityDefinitions]
+	SecuritySchemeChanges map[string]*SecuritySchemeChangesV2
+}
+
+// TotalChanges returns the total number of changes found between two Swagger SecurityDefinitions objects.
+func (d *SecurityDefinitionsChanges) TotalChanges() int {
+	c := d.PropertyChanges.TotalChanges()
+	for k := range d.SecuritySchemeChanges {
+		c += d.SecuritySchemeChanges[k].TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger
+// SecurityDefinitions objects.
+func (d *SecurityDefinitionsChanges) TotalBreakingChanges() int {
+	c := d.PropertyChanges.TotalBreakingChanges()
+	for k := range d.SecuritySchemeChanges {
+		c += d.SecuritySchemeChanges[k].TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareSecurityDefinitions compares a left (original) and right (new) Swagger SecurityDefinitions object for
+// changes. Removing a security definition is a breaking change, adding one is not. If changes are found, a pointer
+// to SecurityDefinitionsChanges is returned, otherwise nil is returned.
+func CompareSecurityDefinitions(l, r *v2.SecurityDefinitions) *SecurityDefinitionsChanges {
+	var changes []*Change[*v2.SecurityDefinitions]
+
+	dc := new(SecurityDefinitionsChanges)
+	dc.SecuritySchemeChanges = checkMapForChanges(l.Definitions, r.Definitions, &changes,
+		false, true, identicalMoves, CompareSecuritySchemesV2)
+
+	dc.Changes = changes
+	if dc.TotalChanges() <= 0 {
+		return nil
+	}
+	return dc
+}
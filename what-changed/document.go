@@ -0,0 +1,131 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CompareOpenAPIDocuments will compare a left (original) and right (new) OpenAPI 3+ document for changes. Every
+// top-level object is compared, and the results are summarized into a WhatChanged object. A WhatChanged object is
+// always returned, if nothing changed then the TotalChanges will be zero and Changes will be nil.
+func CompareOpenAPIDocuments(l, r *v3.Document) *WhatChanged {
+	return CompareOpenAPIDocumentsWithOptions(l, r, nil)
+}
+
+// CompareOpenAPIDocumentsWithOptions will compare a left (original) and right (new) OpenAPI 3+ document for changes,
+// the same as CompareOpenAPIDocuments. Any BreakingRules in the options decide which changes are breaking.
+func CompareOpenAPIDocumentsWithOptions(l, r *v3.Document, options *CompareOptions) *WhatChanged {
+	changes := compareOpenAPIDocuments(l, r)
+	if options != nil && options.BreakingRules != nil {
+		options.BreakingRules.Apply(changes)
+	}
+	return CreateWhatChanged(changes)
+}
+
+// compareOpenAPIDocuments will compare every top-level object of a left (original) and right (new) OpenAPI 3+
+// document. If changes are found, a pointer to Changes is returned, otherwise nil is returned.
+func compareOpenAPIDocuments(l, r *v3.Document) *Changes {
+	var changes []*Change[any]
+	var props []*PropertyCheck[any]
+
+	// OpenAPI version, the version is held against the root node, so the actual value node needs locating.
+	if l.Version.Value != r.Version.Value {
+		CreateChange[any](&changes, Modified, v3.OpenAPILabel,
+			findVersionNode(l.Version.ValueNode), findVersionNode(r.Version.ValueNode), true, l, r)
+	}
+
+	// JSON Schema Dialect
+	props = append(props, &PropertyCheck[any]{
+		LeftNode:  l.JsonSchemaDialect.ValueNode,
+		RightNode: r.JsonSchemaDialect.ValueNode,
+		Label:     v3.JSONSchemaDialectLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	dc := new(Changes)
+
+	// info
+	if l.Info.Value != nil && r.Info.Value != nil {
+		dc.InfoChanges = CompareInfo(l.Info.Value, r.Info.Value)
+	}
+	checkDocumentObject(l.Info, r.Info, base.InfoLabel, &changes, false, true)
+
+	// paths
+	if l.Paths.Value != nil && r.Paths.Value != nil {
+		dc.PathsChanges = ComparePaths(l.Paths.Value, r.Paths.Value)
+	}
+	checkDocumentObject(l.Paths, r.Paths, v3.PathsLabel, &changes, false, true)
+
+	// tags
+	dc.TagChanges = CompareTags(l.Tags.Value, r.Tags.Value)
+
+	// external docs
+	if l.ExternalDocs.Value != nil && r.ExternalDocs.Value != nil {
+		if !low.AreEqual(l.ExternalDocs.Value, r.ExternalDocs.Value) {
+			dc.ExternalDocChanges = CompareExternalDocs(l.ExternalDocs.Value, r.ExternalDocs.Value)
+		}
+	}
+	checkDocumentObject(l.ExternalDocs, r.ExternalDocs, v3.ExternalDocsLabel, &changes, false, false)
+
+	// servers
+	dc.ServerChanges = checkServers(l.Servers.Value, r.Servers.Value, &changes)
+
+	// security
+	if l.Security.Value != nil || r.Security.Value != nil {
+		dc.SecurityRequirementChanges = CompareSecurityRequirement(l.Security.Value, r.Security.Value)
+	}
+
+	// components
+	if l.Components.Value != nil && r.Components.Value != nil {
+		dc.ComponentsChanges = CompareComponents(l.Components.Value, r.Components.Value)
+	}
+	checkDocumentObject(l.Components, r.Components, v3.ComponentsLabel, &changes, false, true)
+
+	// webhooks
+	dc.WebhookChanges = CheckMapForChanges(l.Webhooks.Value, r.Webhooks.Value, &changes,
+		false, true, ComparePathItems)
+
+	dc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	dc.Changes = changes
+	if dc.TotalChanges() <= 0 {
+		return nil
+	}
+	return dc
+}
+
+// checkDocumentObject will check if a top-level document object has been added or removed, adding or removing it is
+// breaking if breakingAdd or breakingRemove are true.
+func checkDocumentObject[T any](l, r low.NodeReference[*T], label string, changes *[]*Change[any],
+	breakingAdd, breakingRemove bool) {
+	if l.Value != nil && r.Value == nil {
+		CreateChange[any](changes, ObjectRemoved, label, l.ValueNode, nil, breakingRemove, l.Value, nil)
+	}
+	if l.Value == nil && r.Value != nil {
+		CreateChange[any](changes, ObjectAdded, label, nil, r.ValueNode, breakingAdd, nil, r.Value)
+	}
+}
+
+// findVersionNode will locate the value node of the 'openapi' or 'swagger' version property of a root node.
+func findVersionNode(root *yaml.Node) *yaml.Node {
+	if root == nil {
+		return nil
+	}
+	if _, _, vn := utils.FindKeyNodeFull(v3.OpenAPILabel, root.Content); vn != nil {
+		return vn
+	}
+	_, _, vn := utils.FindKeyNodeFull(v2.SwaggerLabel, root.Content)
+	return vn
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func TestCompareOpenAPIDocuments_Identical(t *testing.T) {
+
+	spec, _ := ioutil.ReadFile("../test_specs/burgershop.openapi.yaml")
+
+	lInfo, _ := datamodel.ExtractSpecInfo(spec)
+	rInfo, _ := datamodel.ExtractSpecInfo(spec)
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := CompareOpenAPIDocuments(lDoc, rDoc)
+	assert.Equal(t, 0, changes.TotalChanges)
+	assert.Nil(t, changes.Changes)
+}
+
+func TestCompareOpenAPIDocuments_Modified(t *testing.T) {
+
+	left := `openapi: 3.0.1
+info:
+  title: pizza shop
+  version: 1.0.0
+tags:
+  - name: pizza
+servers:
+  - url: https://pb33f.io
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      parameters:
+        - name: size
+          in: query
+  /burgers:
+    get:
+      operationId: getBurgers
+components:
+  schemas:
+    Pizza:
+      type: object`
+
+	right := `openapi: 3.1.0
+info:
+  title: pizza and fries shop
+  version: 1.0.0
+tags:
+  - name: pizza
+servers:
+  - url: https://pb33f.io
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+  /fries:
+    get:
+      operationId: getFries
+components:
+  schemas:
+    Pizza:
+      type: object
+    Fries:
+      type: object
+x-shop: open`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := CompareOpenAPIDocuments(lDoc, rDoc)
+	assert.Equal(t, 7, changes.TotalChanges)
+	assert.Equal(t, 3, changes.TotalBreakingChanges)
+	assert.Equal(t, 2, changes.Modified)
+	assert.Equal(t, 3, changes.Added)
+	assert.Equal(t, 2, changes.Removed)
+	assert.Equal(t, changes.TotalChanges, changes.Added+changes.Removed+changes.Modified)
+	assert.NotNil(t, changes.Changes.InfoChanges)
+	assert.NotNil(t, changes.Changes.PathsChanges)
+	assert.NotNil(t, changes.Changes.ComponentsChanges)
+	assert.NotNil(t, changes.Changes.ExtensionChanges)
+	assert.Nil(t, changes.Changes.TagChanges)
+	assert.Equal(t, "3.0.1", changes.Changes.Changes[0].Original)
+	assert.Equal(t, "3.1.0", changes.Changes.Changes[0].New)
+}
+
+func TestCompareOpenAPIDocuments_ObjectsRemoved(t *testing.T) {
+
+	left := `openapi: 3.0.1
+info:
+  title: pizza shop
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+components:
+  schemas:
+    Pizza:
+      type: object`
+
+	right := `openapi: 3.0.1
+info:
+  title: pizza shop`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := CompareOpenAPIDocuments(lDoc, rDoc)
+	assert.Equal(t, 2, changes.TotalChanges)
+	assert.Equal(t, 2, changes.TotalBreakingChanges)
+	assert.Equal(t, 2, changes.Removed)
+
+	// and the reverse, adding objects is not breaking.
+	changes = CompareOpenAPIDocuments(rDoc, lDoc)
+	assert.Equal(t, 2, changes.TotalChanges)
+	assert.Equal(t, 0, changes.TotalBreakingChanges)
+	assert.Equal(t, 2, changes.Added)
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// EncodingChanges represent all the changes made to an Encoding object
+type EncodingChanges struct {
+	PropertyChanges[*v3.Encoding]
+	HeaderChanges map[string]*HeaderChanges
+}
+
+// TotalChanges returns the total number of changes made between two Encoding objects
+func (e *EncodingChanges) TotalChanges() int {
+	c := e.PropertyChanges.TotalChanges()
+	for k := range e.HeaderChanges {
+		c += e.HeaderChanges[k].TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the number of changes made between two Encoding objects that were breaking.
+func (e *EncodingChanges) TotalBreakingChanges() int {
+	c := e.PropertyChanges.TotalBreakingChanges()
+	for k := range e.HeaderChanges {
+		c += e.HeaderChanges[k].TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareEncoding compares a left (original) and right (new) Encoding object for changes. If anything is found,
+// a pointer to EncodingChanges is returned, otherwise nil is returned.
+func CompareEncoding(l, r *v3.Encoding) *EncodingChanges {
+	var changes []*Change[*v3.Encoding]
+	var props []*PropertyCheck[*v3.Encoding]
+
+	// ContentType
+	props = append(props, &PropertyCheck[*v3.Encoding]{
+		LeftNode:  l.ContentType.ValueNode,
+		RightNode: r.ContentType.ValueNode,
+		Label:     v3.ContentTypeLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Style
+	props = append(props, &PropertyCheck[*v3.Encoding]{
+		LeftNode:  l.Style.ValueNode,
+		RightNode: r.Style.ValueNode,
+		Label:     v3.StyleLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Explode
+	props = append(props, &PropertyCheck[*v3.Encoding]{
+		LeftNode:  l.Explode.ValueNode,
+		RightNode: r.Explode.ValueNode,
+		Label:     v3.ExplodeLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// AllowReserved
+	props = append(props, &PropertyCheck[*v3.Encoding]{
+		LeftNode:  l.AllowReserved.ValueNode,
+		RightNode: r.AllowReserved.ValueNode,
+		Label:     v3.AllowReservedLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	ec := new(EncodingChanges)
+
+	// check headers
+	ec.HeaderChanges = CheckMapForChanges(l.Headers.Value, r.Headers.Value, &changes, false, true, CompareHeaders)
+
+	ec.Changes = changes
+	if ec.TotalChanges() <= 0 {
+		return nil
+	}
+	return ec
+}
@@ -23,8 +23,18 @@ func (e *ExampleChanges) TotalChanges() int {
 	return l
 }
 
-// TotalChanges
+// TotalBreakingChanges returns the number of breaking changes made to Example objects. They are non-binding, so
+// only changes marked as breaking by BreakingRules are counted.
+func (e *ExampleChanges) TotalBreakingChanges() int {
+	l := e.PropertyChanges.TotalBreakingChanges()
+	if e.ExtensionChanges != nil {
+		l += e.ExtensionChanges.PropertyChanges.TotalBreakingChanges()
+	}
+	return l
+}
 
+// CompareExamples will compare a left (original) and right (new) Example object for changes. If changes are found,
+// a pointer to ExampleChanges is returned, otherwise nil is returned.
 func CompareExamples(l, r *base.Example) *ExampleChanges {
 
 	ec := new(ExampleChanges)
@@ -17,9 +17,10 @@ func (e *ExtensionChanges) TotalChanges() int {
 	return e.PropertyChanges.TotalChanges()
 }
 
-// TotalBreakingChanges always returns 0 for Extension objects, they are non-binding.
+// TotalBreakingChanges returns the number of breaking changes made to Extension objects. They are non-binding, so
+// only changes marked as breaking by BreakingRules are counted.
 func (e *ExtensionChanges) TotalBreakingChanges() int {
-	return 0
+	return e.PropertyChanges.TotalBreakingChanges()
 }
 
 // CompareExtensions will compare a left and right map of Key/ValueReference models for any changes to
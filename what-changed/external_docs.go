@@ -23,9 +23,14 @@ func (e *ExternalDocChanges) TotalChanges() int {
 	return c
 }
 
-// TotalBreakingChanges always returns 0 for ExternalDoc objects, they are non-binding.
+// TotalBreakingChanges returns the number of breaking changes made to ExternalDoc objects. They are non-binding, so
+// only changes marked as breaking by BreakingRules are counted.
 func (e *ExternalDocChanges) TotalBreakingChanges() int {
-	return 0
+	c := e.PropertyChanges.TotalBreakingChanges()
+	if e.ExtensionChanges != nil {
+		c += e.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
 }
 
 // CompareExternalDocs will compare a left (original) and a right (new) slice of ValueReference
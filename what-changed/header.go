@@ -0,0 +1,177 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// HeaderChanges represents changes made between two Header objects.
+type HeaderChanges struct {
+	PropertyChanges[*v3.Header]
+	SchemaChanges    *SchemaChanges
+	ExamplesChanges  map[string]*ExampleChanges
+	ContentChanges   map[string]*MediaTypeChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Header objects
+func (h *HeaderChanges) TotalChanges() int {
+	c := h.PropertyChanges.TotalChanges()
+	if h.SchemaChanges != nil {
+		c += h.SchemaChanges.TotalChanges()
+	}
+	for k := range h.ExamplesChanges {
+		c += h.ExamplesChanges[k].TotalChanges()
+	}
+	for k := range h.ContentChanges {
+		c += h.ContentChanges[k].TotalChanges()
+	}
+	if h.ExtensionChanges != nil {
+		c += h.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Header objects.
+func (h *HeaderChanges) TotalBreakingChanges() int {
+	c := h.PropertyChanges.TotalBreakingChanges()
+	if h.SchemaChanges != nil {
+		c += h.SchemaChanges.TotalBreakingChanges()
+	}
+	for k := range h.ExamplesChanges {
+		c += h.ExamplesChanges[k].TotalBreakingChanges()
+	}
+	for k := range h.ContentChanges {
+		c += h.ContentChanges[k].TotalBreakingChanges()
+	}
+	if h.ExtensionChanges != nil {
+		c += h.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareHeaders will compare a left (original) and a right (new) Header object for changes. If changes are found,
+// a pointer to HeaderChanges is returned, otherwise nil is returned.
+func CompareHeaders(l, r *v3.Header) *HeaderChanges {
+	return compareHeaders(l, r, SchemaDirectionUnknown)
+}
+
+// compareHeaders will compare a left (original) and a right (new) Header object for changes, schemas are compared
+// using the supplied direction.
+func compareHeaders(l, r *v3.Header, direction SchemaDirection) *HeaderChanges {
+	var changes []*Change[*v3.Header]
+	var props []*PropertyCheck[*v3.Header]
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.Header]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// Deprecated
+	props = append(props, &PropertyCheck[*v3.Header]{
+		LeftNode:  l.Deprecated.ValueNode,
+		RightNode: r.Deprecated.ValueNode,
+		Label:     v3.DeprecatedLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// AllowEmptyValue
+	props = append(props, &PropertyCheck[*v3.Header]{
+		LeftNode:  l.AllowEmptyValue.ValueNode,
+		RightNode: r.AllowEmptyValue.ValueNode,
+		Label:     v3.AllowEmptyValueLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Style
+	props = append(props, &PropertyCheck[*v3.Header]{
+		LeftNode:  l.Style.ValueNode,
+		RightNode: r.Style.ValueNode,
+		Label:     v3.StyleLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Explode
+	props = append(props, &PropertyCheck[*v3.Header]{
+		LeftNode:  l.Explode.ValueNode,
+		RightNode: r.Explode.ValueNode,
+		Label:     v3.ExplodeLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// AllowReserved
+	props = append(props, &PropertyCheck[*v3.Header]{
+		LeftNode:  l.AllowReserved.ValueNode,
+		RightNode: r.AllowReserved.ValueNode,
+		Label:     v3.AllowReservedLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Example
+	props = append(props, &PropertyCheck[*v3.Header]{
+		LeftNode:  l.Example.ValueNode,
+		RightNode: r.Example.ValueNode,
+		Label:     v3.ExampleLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// required
+	checkRequired(l.Required.ValueNode, r.Required.ValueNode, &changes, l, r, direction)
+
+	hc := new(HeaderChanges)
+
+	// schema
+	hc.SchemaChanges = checkSchema(l.Schema.Value, r.Schema.Value, l.Schema.ValueNode, r.Schema.ValueNode,
+		&changes, l, r, direction)
+
+	// examples
+	hc.ExamplesChanges = CheckMapForChanges(l.Examples.Value, r.Examples.Value, &changes,
+		false, false, CompareExamples)
+
+	// content
+	hc.ContentChanges = CheckMapForChanges(l.Content.Value, r.Content.Value, &changes,
+		false, true, mediaTypeComparison(direction))
+
+	hc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	hc.Changes = changes
+	if hc.TotalChanges() <= 0 {
+		return nil
+	}
+	return hc
+}
+
+// headerComparison returns a function that compares Header objects in the supplied direction.
+func headerComparison(direction SchemaDirection) func(l, r *v3.Header) *HeaderChanges {
+	return func(l, r *v3.Header) *HeaderChanges {
+		return compareHeaders(l, r, direction)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// HeaderChangesV2 represents changes found between two Swagger Header objects.
+type HeaderChangesV2 struct {
+	PropertyChanges[*v2.Header]
+	ItemsChanges     *ItemsChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger Header objects.
+func (h *HeaderChangesV2) TotalChanges() int {
+	c := h.PropertyChanges.TotalChanges()
+	if h.ItemsChanges != nil {
+		c += h.ItemsChanges.TotalChanges()
+	}
+	if h.ExtensionChanges != nil {
+		c += h.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger Header objects.
+func (h *HeaderChangesV2) TotalBreakingChanges() int {
+	c := h.PropertyChanges.TotalBreakingChanges()
+	if h.ItemsChanges != nil {
+		c += h.ItemsChanges.TotalBreakingChanges()
+	}
+	if h.ExtensionChanges != nil {
+		c += h.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareHeadersV2 compares a left (original) and right (new) Swagger Header object for changes. If changes are
+// found, a pointer to HeaderChangesV2 is returned, otherwise nil is returned.
+func CompareHeadersV2(l, r *v2.Header) *HeaderChangesV2 {
+	var changes []*Change[*v2.Header]
+	var props []*PropertyCheck[*v2.Header]
+
+	// Description
+	props = append(props, &PropertyCheck[*v2.Header]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// type, format, collectionFormat and validation properties
+	props = appendItemsChecks(props, itemsNodesFromHeader(l), itemsNodesFromHeader(r), &changes, l, r)
+
+	// check properties
+	CheckProperties(props)
+
+	// enum
+	CheckStringSliceForChanges(l.Enum.Value, r.Enum.Value, v3.EnumLabel, &changes, false, true, l, r)
+
+	hc := new(HeaderChangesV2)
+
+	// items
+	hc.ItemsChanges = checkItems(l.Items, r.Items, &changes)
+
+	hc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	hc.Changes = changes
+	if hc.TotalChanges() <= 0 {
+		return nil
+	}
+	return hc
+}
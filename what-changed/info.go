@@ -27,9 +27,17 @@ func (i *InfoChanges) TotalChanges() int {
 	return t
 }
 
-// TotalBreakingChanges always returns 0 for Info objects, they are non-binding.
+// TotalBreakingChanges returns the number of breaking changes made to Info objects. They are non-binding, so
+// only changes marked as breaking by BreakingRules are counted.
 func (i *InfoChanges) TotalBreakingChanges() int {
-	return 0
+	t := i.PropertyChanges.TotalBreakingChanges()
+	if i.ContactChanges != nil {
+		t += i.ContactChanges.TotalBreakingChanges()
+	}
+	if i.LicenseChanges != nil {
+		t += i.LicenseChanges.TotalBreakingChanges()
+	}
+	return t
 }
 
 // CompareInfo will compare a left (original) and a right (new) Info object. Any changes
@@ -51,6 +59,17 @@ func CompareInfo(l, r *base.Info) *InfoChanges {
 		New:       r,
 	})
 
+	// Summary (3.1)
+	props = append(props, &PropertyCheck[*base.Info]{
+		LeftNode:  l.Summary.ValueNode,
+		RightNode: r.Summary.ValueNode,
+		Label:     v3.SummaryLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
 	// Description
 	props = append(props, &PropertyCheck[*base.Info]{
 		LeftNode:  l.Description.ValueNode,
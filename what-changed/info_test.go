@@ -392,3 +392,39 @@ license:
 	extChanges := CompareInfo(&lDoc, &rDoc)
 	assert.Nil(t, extChanges)
 }
+
+func TestCompareInfo_SummaryModified(t *testing.T) {
+
+	left := `title: a nice spec
+summary: a summary
+license:
+  name: MIT
+  identifier: MIT`
+
+	right := `title: a nice spec
+summary: a new summary
+license:
+  name: Apache 2.0
+  identifier: Apache-2.0`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc base.Info
+	var rDoc base.Info
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareInfo(&lDoc, &rDoc)
+	assert.Equal(t, 3, extChanges.TotalChanges())
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.SummaryLabel, extChanges.Changes[0].Property)
+	assert.Len(t, extChanges.LicenseChanges.Changes, 2)
+	assert.Equal(t, v3.IdentifierLabel, extChanges.LicenseChanges.Changes[1].Property)
+	assert.Equal(t, "Apache-2.0", extChanges.LicenseChanges.Changes[1].New)
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ItemsChanges represent changes found between two Swagger Items objects.
+type ItemsChanges struct {
+	PropertyChanges[*v2.Items]
+	ItemsChanges *ItemsChanges
+}
+
+// TotalChanges returns the total number of changes found between two Items objects.
+func (i *ItemsChanges) TotalChanges() int {
+	c := i.PropertyChanges.TotalChanges()
+	if i.ItemsChanges != nil {
+		c += i.ItemsChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Items objects.
+func (i *ItemsChanges) TotalBreakingChanges() int {
+	c := i.PropertyChanges.TotalBreakingChanges()
+	if i.ItemsChanges != nil {
+		c += i.ItemsChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareItems compares a left (original) and right (new) Swagger Items object for changes. Every change to the
+// type, format, collection format or validation properties of Items is a breaking change. If changes are found,
+// a pointer to ItemsChanges is returned, otherwise nil is returned.
+func CompareItems(l, r *v2.Items) *ItemsChanges {
+	var changes []*Change[*v2.Items]
+	var props []*PropertyCheck[*v2.Items]
+
+	props = appendItemsChecks(props, itemsNodesFromItems(l), itemsNodesFromItems(r), &changes, l, r)
+
+	// check properties
+	CheckProperties(props)
+
+	// enum
+	CheckStringSliceForChanges(l.Enum.Value, r.Enum.Value, v3.EnumLabel, &changes, false, true, l, r)
+
+	ic := new(ItemsChanges)
+
+	// items
+	ic.ItemsChanges = checkItems(l.Items, r.Items, &changes)
+
+	ic.Changes = changes
+	if ic.TotalChanges() <= 0 {
+		return nil
+	}
+	return ic
+}
+
+// checkItems will check a left (original) and right (new) reference to an Items object for changes. Adding or
+// removing items is a breaking change.
+func checkItems[T any](l, r low.NodeReference[*v2.Items], changes *[]*Change[T]) *ItemsChanges {
+	if l.Value != nil && r.Value == nil {
+		CreateChange[T](changes, ObjectRemoved, v3.ItemsLabel,
+			l.ValueNode, nil, true, l.Value, nil)
+	}
+	if l.Value == nil && r.Value != nil {
+		CreateChange[T](changes, ObjectAdded, v3.ItemsLabel,
+			nil, r.ValueNode, true, nil, r.Value)
+	}
+	if l.Value != nil && r.Value != nil {
+		return CompareItems(l.Value, r.Value)
+	}
+	return nil
+}
+
+// itemsNodes holds the value nodes of the properties shared by Swagger Items, Header and Parameter objects.
+type itemsNodes struct {
+	Type             *yaml.Node
+	Format           *yaml.Node
+	CollectionFormat *yaml.Node
+	Default          *yaml.Node
+	Maximum          *yaml.Node
+	ExclusiveMaximum *yaml.Node
+	Minimum          *yaml.Node
+	ExclusiveMinimum *yaml.Node
+	MaxLength        *yaml.Node
+	MinLength        *yaml.Node
+	Pattern          *yaml.Node
+	MaxItems         *yaml.Node
+	MinItems         *yaml.Node
+	UniqueItems      *yaml.Node
+	MultipleOf       *yaml.Node
+}
+
+func itemsNodesFromItems(i *v2.Items) *itemsNodes {
+	return &itemsNodes{
+		Type:             i.Type.ValueNode,
+		Format:           i.Format.ValueNode,
+		CollectionFormat: i.CollectionFormat.ValueNode,
+		Default:          i.Default.ValueNode,
+		Maximum:          i.Maximum.ValueNode,
+		ExclusiveMaximum: i.ExclusiveMaximum.ValueNode,
+		Minimum:          i.Minimum.ValueNode,
+		ExclusiveMinimum: i.ExclusiveMinimum.ValueNode,
+		MaxLength:        i.MaxLength.ValueNode,
+		MinLength:        i.MinLength.ValueNode,
+		Pattern:          i.Pattern.ValueNode,
+		MaxItems:         i.MaxItems.ValueNode,
+		MinItems:         i.MinItems.ValueNode,
+		UniqueItems:      i.UniqueItems.ValueNode,
+		MultipleOf:       i.MultipleOf.ValueNode,
+	}
+}
+
+func itemsNodesFromHeader(h *v2.Header) *itemsNodes {
+	return &itemsNodes{
+		Type:             h.Type.ValueNode,
+		Format:           h.Format.ValueNode,
+		CollectionFormat: h.CollectionFormat.ValueNode,
+		Default:          h.Default.ValueNode,
+		Maximum:          h.Maximum.ValueNode,
+		ExclusiveMaximum: h.ExclusiveMaximum.ValueNode,
+		Minimum:          h.Minimum.ValueNode,
+		ExclusiveMinimum: h.ExclusiveMinimum.ValueNode,
+		MaxLength:        h.MaxLength.ValueNode,
+		MinLength:        h.MinLength.ValueNode,
+		Pattern:          h.Pattern.ValueNode,
+		MaxItems:         h.MaxItems.ValueNode,
+		MinItems:         h.MinItems.ValueNode,
+		UniqueItems:      h.UniqueItems.ValueNode,
+		MultipleOf:       h.MultipleOf.ValueNode,
+	}
+}
+
+func itemsNodesFromParameter(p *v2.Parameter) *itemsNodes {
+	return &itemsNodes{
+		Type:             p.Type.ValueNode,
+		Format:           p.Format.ValueNode,
+		CollectionFormat: p.CollectionFormat.ValueNode,
+		Default:          p.Default.ValueNode,
+		Maximum:          p.Maximum.ValueNode,
+		ExclusiveMaximum: p.ExclusiveMaximum.ValueNode,
+		Minimum:          p.Minimum.ValueNode,
+		ExclusiveMinimum: p.ExclusiveMinimum.ValueNode,
+		MaxLength:        p.MaxLength.ValueNode,
+		MinLength:        p.MinLength.ValueNode,
+		Pattern:          p.Pattern.ValueNode,
+		MaxItems:         p.MaxItems.ValueNode,
+		MinItems:         p.MinItems.ValueNode,
+		UniqueItems:      p.UniqueItems.ValueNode,
+		MultipleOf:       p.MultipleOf.ValueNode,
+	}
+}
+
+// appendItemsChecks will append a PropertyCheck for every property shared by Swagger Items, Header and
+// Parameter objects. All of these properties change how a value is serialized or validated, so every change
+// is considered breaking.
+func appendItemsChecks[T any](props []*PropertyCheck[T], l, r *itemsNodes, changes *[]*Change[T],
+	original, new T) []*PropertyCheck[T] {
+
+	checks := []struct {
+		label string
+		l, r  *yaml.Node
+	}{
+		{v3.TypeLabel, l.Type, r.Type},
+		{v3.FormatLabel, l.Format, r.Format},
+		{v2.CollectionFormatLabel, l.CollectionFormat, r.CollectionFormat},
+		{v3.DefaultLabel, l.Default, r.Default},
+		{v3.MaximumLabel, l.Maximum, r.Maximum},
+		{v3.ExclusiveMaximumLabel, l.ExclusiveMaximum, r.ExclusiveMaximum},
+		{v3.MinimumLabel, l.Minimum, r.Minimum},
+		{v3.ExclusiveMinimumLabel, l.ExclusiveMinimum, r.ExclusiveMinimum},
+		{v3.MaxLengthLabel, l.MaxLength, r.MaxLength},
+		{v3.MinLengthLabel, l.MinLength, r.MinLength},
+		{v3.PatternLabel, l.Pattern, r.Pattern},
+		{v3.MaxItemsLabel, l.MaxItems, r.MaxItems},
+		{v3.MinItemsLabel, l.MinItems, r.MinItems},
+		{v3.UniqueItemsLabel, l.UniqueItems, r.UniqueItems},
+		{v3.MultipleOfLabel, l.MultipleOf, r.MultipleOf},
+	}
+	for _, c := range checks {
+		props = append(props, &PropertyCheck[T]{
+			LeftNode:  c.l,
+			RightNode: c.r,
+			Label:     c.label,
+			Changes:   changes,
+			Breaking:  true,
+			Original:  original,
+			New:       new,
+		})
+	}
+	return props
+}
@@ -18,9 +18,10 @@ func (l *LicenseChanges) TotalChanges() int {
 	return l.PropertyChanges.TotalChanges()
 }
 
-// TotalBreakingChanges always returns 0 for License objects, they are non-binding.
+// TotalBreakingChanges returns the number of breaking changes made to License objects. They are non-binding, so
+// only changes marked as breaking by BreakingRules are counted.
 func (l *LicenseChanges) TotalBreakingChanges() int {
-	return 0
+	return l.PropertyChanges.TotalBreakingChanges()
 }
 
 // CompareLicense will check a left (original) and right (new) License object for any changes. If there
@@ -53,6 +54,17 @@ func CompareLicense(l, r *base.License) *LicenseChanges {
 		New:       r,
 	})
 
+	// check identifier (3.1)
+	props = append(props, &PropertyCheck[*base.License]{
+		LeftNode:  l.Identifier.ValueNode,
+		RightNode: r.Identifier.ValueNode,
+		Label:     v3.IdentifierLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
 	// check everything.
 	CheckProperties(props)
 
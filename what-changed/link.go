@@ -0,0 +1,136 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// LinkChanges represent changes made between two OpenAPI Link Objects.
+type LinkChanges struct {
+	PropertyChanges[*v3.Link]
+	ServerChanges    *ServerChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total changes made between OpenAPI Link objects
+func (l *LinkChanges) TotalChanges() int {
+	c := l.PropertyChanges.TotalChanges()
+	if l.ServerChanges != nil {
+		c += l.ServerChanges.TotalChanges()
+	}
+	if l.ExtensionChanges != nil {
+		c += l.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the number of breaking changes made between two OpenAPI Link Objects.
+func (l *LinkChanges) TotalBreakingChanges() int {
+	c := l.PropertyChanges.TotalBreakingChanges()
+	if l.ServerChanges != nil {
+		c += l.ServerChanges.TotalBreakingChanges()
+	}
+	if l.ExtensionChanges != nil {
+		c += l.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareLinks checks a left (original) and right (new) OpenAPI Link for any changes. If they are found, returns
+// a pointer to LinkChanges, otherwise returns nil.
+func CompareLinks(l, r *v3.Link) *LinkChanges {
+	var changes []*Change[*v3.Link]
+	var props []*PropertyCheck[*v3.Link]
+
+	// OperationRef
+	props = append(props, &PropertyCheck[*v3.Link]{
+		LeftNode:  l.OperationRef.ValueNode,
+		RightNode: r.OperationRef.ValueNode,
+		Label:     v3.OperationRefLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// OperationId
+	props = append(props, &PropertyCheck[*v3.Link]{
+		LeftNode:  l.OperationId.ValueNode,
+		RightNode: r.OperationId.ValueNode,
+		Label:     v3.OperationIdLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// RequestBody
+	props = append(props, &PropertyCheck[*v3.Link]{
+		LeftNode:  l.RequestBody.ValueNode,
+		RightNode: r.RequestBody.ValueNode,
+		Label:     v3.RequestBodyLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.Link]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// parameters, removing or changing a parameter expression is a breaking change.
+	lParams := FlattenLowLevelMap[string](l.Parameters.Value)
+	rParams := FlattenLowLevelMap[string](r.Parameters.Value)
+	for k := range lParams {
+		if rParams[k] == nil {
+			CreateChange[*v3.Link](&changes, ObjectRemoved, k,
+				lParams[k].GetValueNode(), nil, true, l, r)
+			continue
+		}
+		if lParams[k].Value != rParams[k].Value {
+			CreateChange[*v3.Link](&changes, Modified, k,
+				lParams[k].GetValueNode(), rParams[k].GetValueNode(), true, l, r)
+		}
+	}
+	for k := range rParams {
+		if lParams[k] == nil {
+			CreateChange[*v3.Link](&changes, ObjectAdded, k,
+				nil, rParams[k].GetValueNode(), false, l, r)
+		}
+	}
+
+	lc := new(LinkChanges)
+
+	// server
+	if l.Server.Value != nil && r.Server.Value != nil {
+		lc.ServerChanges = CompareServers(l.Server.Value, r.Server.Value)
+	}
+	if l.Server.Value != nil && r.Server.Value == nil {
+		CreateChange[*v3.Link](&changes, ObjectRemoved, v3.ServerLabel,
+			l.Server.ValueNode, nil, true, l.Server.Value, nil)
+	}
+	if l.Server.Value == nil && r.Server.Value != nil {
+		CreateChange[*v3.Link](&changes, ObjectAdded, v3.ServerLabel,
+			nil, r.Server.ValueNode, true, nil, r.Server.Value)
+	}
+
+	lc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	lc.Changes = changes
+	if lc.TotalChanges() <= 0 {
+		return nil
+	}
+	return lc
+}
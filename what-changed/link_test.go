@@ -0,0 +1,46 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareLinks_Modified(t *testing.T) {
+
+	left := `operationId: getOven
+description: the oven
+parameters:
+  ovenId: $response.body#/ovenId
+  temp: $response.body#/temp`
+
+	right := `operationId: getOven
+description: the hot oven
+parameters:
+  ovenId: $response.body#/id
+  size: $response.body#/size
+server:
+  url: https://pb33f.io`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Link
+	var rDoc v3.Link
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareLinks(&lDoc, &rDoc)
+	assert.Equal(t, 5, extChanges.TotalChanges())
+	assert.Equal(t, 3, extChanges.TotalBreakingChanges())
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// MediaTypeChanges represent changes made between two OpenAPI MediaType instances.
+type MediaTypeChanges struct {
+	PropertyChanges[*v3.MediaType]
+	SchemaChanges    *SchemaChanges
+	ExampleChanges   map[string]*ExampleChanges
+	EncodingChanges  map[string]*EncodingChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes between two MediaType instances.
+func (m *MediaTypeChanges) TotalChanges() int {
+	c := m.PropertyChanges.TotalChanges()
+	if m.SchemaChanges != nil {
+		c += m.SchemaChanges.TotalChanges()
+	}
+	for k := range m.ExampleChanges {
+		c += m.ExampleChanges[k].TotalChanges()
+	}
+	for k := range m.EncodingChanges {
+		c += m.EncodingChanges[k].TotalChanges()
+	}
+	if m.ExtensionChanges != nil {
+		c += m.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes made between two MediaType instances.
+func (m *MediaTypeChanges) TotalBreakingChanges() int {
+	c := m.PropertyChanges.TotalBreakingChanges()
+	if m.SchemaChanges != nil {
+		c += m.SchemaChanges.TotalBreakingChanges()
+	}
+	for k := range m.ExampleChanges {
+		c += m.ExampleChanges[k].TotalBreakingChanges()
+	}
+	for k := range m.EncodingChanges {
+		c += m.EncodingChanges[k].TotalBreakingChanges()
+	}
+	if m.ExtensionChanges != nil {
+		c += m.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareMediaTypes compares a left (original) and a right (new) MediaType object for changes. If changes
+// are found, a pointer to MediaTypeChanges is returned, otherwise nil is returned.
+func CompareMediaTypes(l, r *v3.MediaType) *MediaTypeChanges {
+	return CompareMediaTypesWithDirection(l, r, SchemaDirectionUnknown)
+}
+
+// CompareMediaTypesWithDirection compares a left (original) and a right (new) MediaType object for changes, the
+// schema is compared using the supplied direction. If changes are found, a pointer to MediaTypeChanges is returned,
+// otherwise nil is returned.
+func CompareMediaTypesWithDirection(l, r *v3.MediaType, direction SchemaDirection) *MediaTypeChanges {
+	var changes []*Change[*v3.MediaType]
+	var props []*PropertyCheck[*v3.MediaType]
+
+	// Example
+	props = append(props, &PropertyCheck[*v3.MediaType]{
+		LeftNode:  l.Example.ValueNode,
+		RightNode: r.Example.ValueNode,
+		Label:     v3.ExampleLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	mc := new(MediaTypeChanges)
+
+	// schema
+	mc.SchemaChanges = checkSchema(l.Schema.Value, r.Schema.Value, l.Schema.ValueNode, r.Schema.ValueNode,
+		&changes, l, r, direction)
+
+	// examples
+	mc.ExampleChanges = CheckMapForChanges(l.Examples.Value, r.Examples.Value, &changes,
+		false, false, CompareExamples)
+
+	// encoding
+	mc.EncodingChanges = CheckMapForChanges(l.Encoding.Value, r.Encoding.Value, &changes,
+		false, true, CompareEncoding)
+
+	mc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	mc.Changes = changes
+	if mc.TotalChanges() <= 0 {
+		return nil
+	}
+	return mc
+}
+
+// mediaTypeComparison returns a function that compares MediaType objects in the supplied direction.
+func mediaTypeComparison(direction SchemaDirection) func(l, r *v3.MediaType) *MediaTypeChanges {
+	return func(l, r *v3.MediaType) *MediaTypeChanges {
+		return CompareMediaTypesWithDirection(l, r, direction)
+	}
+}
@@ -24,17 +24,25 @@ const (
 
 	// PropertyRemoved means that a property of an object was removed
 	PropertyRemoved
+
+	// Moved means that an object was moved (renamed) without being modified
+	Moved
+
+	// ModifiedAndMoved means that an object was moved (renamed) and modified
+	ModifiedAndMoved
 )
 
 // WhatChanged is a summary object that contains a high level summary of everything changed.
 type WhatChanged struct {
-	Added            int
-	Removed          int
-	ModifiedAndMoved int
-	Modified         int
-	Moved            int
-	TotalChanges     int
-	Changes          *Changes
+	Added                int
+	Removed              int
+	ModifiedAndMoved     int
+	Modified             int
+	Moved                int
+	TotalChanges         int
+	TotalBreakingChanges int
+	Changes              *Changes
+	SwaggerChanges       *SwaggerChanges
 }
 
 // ChangeContext holds a reference to the line and column positions of original and new change.
@@ -81,6 +89,25 @@ type Change[T any] struct {
 
 	// NewObject represents the new object that has been modified.
 	NewObject any
+
+	// builtIn is the breaking state of the change without any BreakingRules.
+	builtIn bool
+
+	// ruleProperty is the property used to look up BreakingRules, if it is not Property.
+	ruleProperty string
+}
+
+// applyRules sets the breaking state of the change using the rule for the object that owns the change, or the
+// built-in breaking state if there is no rule.
+func (c *Change[T]) applyRules(rules BreakingRules, object string, direction SchemaDirection) {
+	property := c.Property
+	if c.ruleProperty != "" {
+		property = c.ruleProperty
+	}
+	c.Breaking = c.builtIn
+	if breaking, found := rules.IsBreaking(object, property, direction, c.ChangeType); found {
+		c.Breaking = breaking
+	}
 }
 
 // PropertyChanges holds a slice of Change[T] change pointers
@@ -89,6 +116,11 @@ type PropertyChanges[T any] struct {
 }
 
 // TotalChanges returns the total number of property changes made.
+// ruleObject returns the name of the object that owns the changes, used to look up BreakingRules.
+func (p PropertyChanges[T]) ruleObject() string {
+	return ruleObjectName[T]()
+}
+
 func (p PropertyChanges[T]) TotalChanges() int {
 	return len(p.Changes)
 }
@@ -131,6 +163,164 @@ type PropertyCheck[T any] struct {
 	Changes *[]*Change[T]
 }
 
+// Changes holds the changes found between every top-level object of two OpenAPI documents. Each property
+// will be nil if nothing changed.
 type Changes struct {
-	TagChanges *TagChanges
+	PropertyChanges[any]
+	InfoChanges                *InfoChanges
+	PathsChanges               *PathsChanges
+	TagChanges                 *TagChanges
+	ExternalDocChanges         *ExternalDocChanges
+	ServerChanges              []*ServerChanges
+	SecurityRequirementChanges *SecurityRequirementChanges
+	ComponentsChanges          *ComponentsChanges
+	WebhookChanges             map[string]*PathItemChanges
+	ExtensionChanges           *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two OpenAPI documents.
+func (c *Changes) TotalChanges() int {
+	t := c.PropertyChanges.TotalChanges()
+	if c.InfoChanges != nil {
+		t += c.InfoChanges.TotalChanges()
+	}
+	if c.PathsChanges != nil {
+		t += c.PathsChanges.TotalChanges()
+	}
+	if c.TagChanges != nil {
+		t += c.TagChanges.TotalChanges()
+	}
+	if c.ExternalDocChanges != nil {
+		t += c.ExternalDocChanges.TotalChanges()
+	}
+	for k := range c.ServerChanges {
+		t += c.ServerChanges[k].TotalChanges()
+	}
+	if c.SecurityRequirementChanges != nil {
+		t += c.SecurityRequirementChanges.TotalChanges()
+	}
+	if c.ComponentsChanges != nil {
+		t += c.ComponentsChanges.TotalChanges()
+	}
+	for k := range c.WebhookChanges {
+		t += c.WebhookChanges[k].TotalChanges()
+	}
+	if c.ExtensionChanges != nil {
+		t += c.ExtensionChanges.TotalChanges()
+	}
+	return t
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two OpenAPI documents.
+func (c *Changes) TotalBreakingChanges() int {
+	t := c.PropertyChanges.TotalBreakingChanges()
+	if c.InfoChanges != nil {
+		t += c.InfoChanges.TotalBreakingChanges()
+	}
+	if c.PathsChanges != nil {
+		t += c.PathsChanges.TotalBreakingChanges()
+	}
+	if c.TagChanges != nil {
+		t += c.TagChanges.TotalBreakingChanges()
+	}
+	if c.ExternalDocChanges != nil {
+		t += c.ExternalDocChanges.TotalBreakingChanges()
+	}
+	for k := range c.ServerChanges {
+		t += c.ServerChanges[k].TotalBreakingChanges()
+	}
+	if c.SecurityRequirementChanges != nil {
+		t += c.SecurityRequirementChanges.TotalBreakingChanges()
+	}
+	if c.ComponentsChanges != nil {
+		t += c.ComponentsChanges.TotalBreakingChanges()
+	}
+	for k := range c.WebhookChanges {
+		t += c.WebhookChanges[k].TotalBreakingChanges()
+	}
+	if c.ExtensionChanges != nil {
+		t += c.ExtensionChanges.TotalBreakingChanges()
+	}
+	return t
+}
+
+// SwaggerChanges holds the changes found between every top-level object of two Swagger documents. Each property
+// will be nil if nothing changed.
+type SwaggerChanges struct {
+	PropertyChanges[any]
+	InfoChanges                 *InfoChanges
+	PathsChanges                *PathsChangesV2
+	DefinitionsChanges          *DefinitionsChanges
+	ParameterDefinitionsChanges *ParameterDefinitionsChanges
+	ResponsesDefinitionsChanges *ResponsesDefinitionsChanges
+	SecurityDefinitionsChanges  *SecurityDefinitionsChanges
+	TagChanges                  *TagChanges
+	ExternalDocChanges          *ExternalDocChanges
+	ExtensionChanges            *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger documents.
+func (c *SwaggerChanges) TotalChanges() int {
+	t := c.PropertyChanges.TotalChanges()
+	if c.InfoChanges != nil {
+		t += c.InfoChanges.TotalChanges()
+	}
+	if c.PathsChanges != nil {
+		t += c.PathsChanges.TotalChanges()
+	}
+	if c.DefinitionsChanges != nil {
+		t += c.DefinitionsChanges.TotalChanges()
+	}
+	if c.ParameterDefinitionsChanges != nil {
+		t += c.ParameterDefinitionsChanges.TotalChanges()
+	}
+	if c.ResponsesDefinitionsChanges != nil {
+		t += c.ResponsesDefinitionsChanges.TotalChanges()
+	}
+	if c.SecurityDefinitionsChanges != nil {
+		t += c.SecurityDefinitionsChanges.TotalChanges()
+	}
+	if c.TagChanges != nil {
+		t += c.TagChanges.TotalChanges()
+	}
+	if c.ExternalDocChanges != nil {
+		t += c.ExternalDocChanges.TotalChanges()
+	}
+	if c.ExtensionChanges != nil {
+		t += c.ExtensionChanges.TotalChanges()
+	}
+	return t
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger documents.
+func (c *SwaggerChanges) TotalBreakingChanges() int {
+	t := c.PropertyChanges.TotalBreakingChanges()
+	if c.InfoChanges != nil {
+		t += c.InfoChanges.TotalBreakingChanges()
+	}
+	if c.PathsChanges != nil {
+		t += c.PathsChanges.TotalBreakingChanges()
+	}
+	if c.DefinitionsChanges != nil {
+		t += c.DefinitionsChanges.TotalBreakingChanges()
+	}
+	if c.ParameterDefinitionsChanges != nil {
+		t += c.ParameterDefinitionsChanges.TotalBreakingChanges()
+	}
+	if c.ResponsesDefinitionsChanges != nil {
+		t += c.ResponsesDefinitionsChanges.TotalBreakingChanges()
+	}
+	if c.SecurityDefinitionsChanges != nil {
+		t += c.SecurityDefinitionsChanges.TotalBreakingChanges()
+	}
+	if c.TagChanges != nil {
+		t += c.TagChanges.TotalBreakingChanges()
+	}
+	if c.ExternalDocChanges != nil {
+		t += c.ExternalDocChanges.TotalBreakingChanges()
+	}
+	if c.ExtensionChanges != nil {
+		t += c.ExtensionChanges.TotalBreakingChanges()
+	}
+	return t
 }
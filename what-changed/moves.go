@@ -0,0 +1,227 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"gopkg.in/yaml.v3"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// moveDetection selects how keys removed from a map are paired with keys added to it.
+type moveDetection int
+
+const (
+	// noMoves never pairs keys, every key is added or removed.
+	noMoves moveDetection = iota
+
+	// templateMoves pairs path templates that only differ by the names of parameters, used for paths.
+	templateMoves
+
+	// identicalMoves pairs keys with identical values, used for renamed components.
+	identicalMoves
+)
+
+// movedKey is a key that was removed from a map, paired with the key that replaced it.
+type movedKey struct {
+	from, to string
+	modified bool
+	template bool
+}
+
+// pathParameterLocation is the location (in) of a parameter that is part of a path template.
+const pathParameterLocation = "path"
+
+var pathTemplateParams = regexp.MustCompile(`{[^}]*}`)
+
+// normalizePathTemplate replaces every parameter of a path template with an empty parameter, so path templates that
+// only differ by the names of parameters are equal. Keys that are not paths are returned as is.
+func normalizePathTemplate(key string) string {
+	if !strings.HasPrefix(key, "/") {
+		return key
+	}
+	return pathTemplateParams.ReplaceAllString(key, "{}")
+}
+
+// detectMoves pairs keys removed from a map with keys added to it, as selected by moves. With templateMoves, path
+// templates that only differ by the names of parameters are paired, they are the same path and may have been
+// modified (for example renaming a path parameter). With identicalMoves, keys are paired if their values have
+// identical hashes. Paired keys are returned, along with the removed and added keys that could not be paired.
+func detectMoves[T any](removed, added []string, lValues, rValues map[string]low.ValueReference[T],
+	moves moveDetection) ([]movedKey, []string, []string) {
+	if moves == noMoves || len(removed) == 0 || len(added) == 0 {
+		return nil, removed, added
+	}
+
+	var moved []movedKey
+	pairedAdded := make(map[string]bool)
+	pairedRemoved := make(map[string]bool)
+
+	switch moves {
+	case templateMoves:
+		// path templates that only differ by parameter names.
+		for _, rk := range removed {
+			for _, ak := range added {
+				if pairedAdded[ak] || rk == normalizePathTemplate(rk) {
+					continue
+				}
+				if normalizePathTemplate(rk) == normalizePathTemplate(ak) {
+					moved = append(moved, movedKey{from: rk, to: ak, template: true,
+						modified: hashMapValue(lValues[rk]) != hashMapValue(rValues[ak])})
+					pairedAdded[ak], pairedRemoved[rk] = true, true
+					break
+				}
+			}
+		}
+	case identicalMoves:
+		// identical values.
+		addedHashes := make(map[string][]string)
+		for _, ak := range added {
+			h := hashMapValue(rValues[ak])
+			addedHashes[h] = append(addedHashes[h], ak)
+		}
+		for _, rk := range removed {
+			h := hashMapValue(lValues[rk])
+			if candidates := addedHashes[h]; len(candidates) > 0 {
+				moved = append(moved, movedKey{from: rk, to: candidates[0]})
+				addedHashes[h] = candidates[1:]
+				pairedAdded[candidates[0]], pairedRemoved[rk] = true, true
+			}
+		}
+	}
+
+	var remainingRemoved, remainingAdded []string
+	for _, rk := range removed {
+		if !pairedRemoved[rk] {
+			remainingRemoved = append(remainingRemoved, rk)
+		}
+	}
+	for _, ak := range added {
+		if !pairedAdded[ak] {
+			remainingAdded = append(remainingAdded, ak)
+		}
+	}
+	return moved, remainingRemoved, remainingAdded
+}
+
+// hashMapValue returns a hash of a value held by a map. Values that are Hashable use their own Hash() function,
+// a SchemaProxy uses the Hash() of the schema (or the reference), and anything else is hashed using the yaml.Node
+// that holds the value. Line and column numbers are never part of the hash, so moved values hash identically.
+func hashMapValue[T any](v low.ValueReference[T]) string {
+	switch h := any(v.Value).(type) {
+	case *base.SchemaProxy:
+		if h == nil {
+			break
+		}
+		if h.IsSchemaReference() {
+			return low.GenerateHashString(h.GetSchemaReference())
+		}
+		if s := h.Schema(); s != nil {
+			return fmt.Sprintf("%x", s.Hash())
+		}
+	case low.Hashable:
+		return fmt.Sprintf("%x", h.Hash())
+	}
+	sum := sha256.New()
+	hashNode(v.ValueNode, sum)
+	return fmt.Sprintf("%x", sum.Sum(nil))
+}
+
+// hashNode writes the kind, tag and value of a node and all of its children to a hash.
+func hashNode(n *yaml.Node, sum io.Writer) {
+	if n == nil {
+		return
+	}
+	_, _ = sum.Write([]byte(fmt.Sprintf("%d|%s|%s|%d[", n.Kind, n.Tag, n.Value, len(n.Content))))
+	for _, c := range n.Content {
+		hashNode(c, sum)
+	}
+	if n.Alias != nil {
+		hashNode(n.Alias, sum)
+	}
+	_, _ = sum.Write([]byte("]"))
+}
+
+// pathParameterRenames maps the parameter names of an original path template to the parameter names at the same
+// position of a renamed path template, for example '/pets/{id}' renamed to '/pets/{petId}' maps 'id' to 'petId'.
+// Nothing is returned if the path was not renamed, or if the templates are not the same path.
+func pathParameterRenames(lPath, rPath string) map[string]string {
+	if lPath == rPath || !strings.HasPrefix(lPath, "/") || normalizePathTemplate(lPath) != normalizePathTemplate(rPath) {
+		return nil
+	}
+	lNames := pathTemplateParams.FindAllString(lPath, -1)
+	rNames := pathTemplateParams.FindAllString(rPath, -1)
+	renames := make(map[string]string)
+	for i := range lNames {
+		if lNames[i] != rNames[i] {
+			renames[strings.Trim(lNames[i], "{}")] = strings.Trim(rNames[i], "{}")
+		}
+	}
+	return renames
+}
+
+// pathTemplates maps every value of a map of paths to its path template.
+func pathTemplates[T comparable](paths map[low.KeyReference[string]]low.ValueReference[T]) map[T]string {
+	templates := make(map[T]string)
+	for k, v := range paths {
+		templates[v.Value] = k.Value
+	}
+	return templates
+}
+
+// pairRenamedPathParameters pairs removed path parameters with added path parameters, using the renames of a path
+// template (from pathParameterRenames). Renaming a path parameter does not change a request, so a parameter named
+// after a placeholder of the original path, replaced by a parameter named after the placeholder in the same position
+// of the renamed path, is considered renamed. The parameters that could not be paired are returned.
+func pairRenamedPathParameters[P any](removed, added []low.ValueReference[P], renames map[string]string,
+	in, name func(P) string) ([][2]low.ValueReference[P], []low.ValueReference[P], []low.ValueReference[P]) {
+
+	if len(renames) == 0 {
+		return nil, removed, added
+	}
+	var pairs [][2]low.ValueReference[P]
+	var remainingRemoved []low.ValueReference[P]
+	paired := make(map[int]bool)
+	for i := range removed {
+		to, renamed := renames[name(removed[i].Value)]
+		found := -1
+		if renamed && in(removed[i].Value) == pathParameterLocation {
+			for j := range added {
+				if !paired[j] && in(added[j].Value) == pathParameterLocation && name(added[j].Value) == to {
+					found = j
+					break
+				}
+			}
+		}
+		if found < 0 {
+			remainingRemoved = append(remainingRemoved, removed[i])
+			continue
+		}
+		paired[found] = true
+		pairs = append(pairs, [2]low.ValueReference[P]{removed[i], added[found]})
+	}
+	var remainingAdded []low.ValueReference[P]
+	for j := range added {
+		if !paired[j] {
+			remainingAdded = append(remainingAdded, added[j])
+		}
+	}
+	return pairs, remainingRemoved, remainingAdded
+}
+
+// withoutProperty returns changes, without any change made to the supplied property.
+func withoutProperty[T any](changes []*Change[T], property string) []*Change[T] {
+	var filtered []*Change[T]
+	for i := range changes {
+		if changes[i].Property != property {
+			filtered = append(filtered, changes[i])
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,252 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func compareMoveSpecs(left, right string) *WhatChanged {
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+	return CompareOpenAPIDocuments(lDoc, rDoc)
+}
+
+func TestNormalizePathTemplate(t *testing.T) {
+	assert.Equal(t, "/pets/{}/toys/{}", normalizePathTemplate("/pets/{petId}/toys/{toyId}"))
+	assert.Equal(t, "/pets", normalizePathTemplate("/pets"))
+	assert.Equal(t, "{$request.body#/url}", normalizePathTemplate("{$request.body#/url}"))
+}
+
+func TestCompareOpenAPIDocuments_PathParameterRenamed(t *testing.T) {
+	left := `openapi: 3.0.1
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+      responses:
+        "200":
+          description: a pet`
+
+	right := `openapi: 3.0.1
+paths:
+  /pets/{petId}:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+      responses:
+        "200":
+          description: a pet`
+
+	changes := compareMoveSpecs(left, right)
+	assert.Equal(t, 1, changes.ModifiedAndMoved)
+	assert.Equal(t, 0, changes.Added)
+	assert.Equal(t, 0, changes.Removed)
+	assert.Equal(t, 1, changes.Moved)
+	assert.Equal(t, 0, changes.TotalBreakingChanges)
+
+	pc := changes.Changes.PathsChanges
+	assert.Len(t, pc.Changes, 1)
+	assert.Equal(t, ModifiedAndMoved, pc.Changes[0].ChangeType)
+	assert.Equal(t, "/pets/{id}", pc.Changes[0].Original)
+	assert.Equal(t, "/pets/{petId}", pc.Changes[0].New)
+	assert.False(t, pc.Changes[0].Breaking)
+	assert.NotNil(t, pc.PathItemsChanges["/pets/{petId}"])
+
+	// the path parameter was renamed along with the path.
+	oc := pc.PathItemsChanges["/pets/{petId}"].GetChanges
+	assert.Len(t, oc.Changes, 1)
+	assert.Equal(t, Moved, oc.Changes[0].ChangeType)
+	assert.Equal(t, "id", oc.Changes[0].Original)
+	assert.Equal(t, "petId", oc.Changes[0].New)
+	assert.Empty(t, oc.ParameterChanges)
+}
+
+func TestPathParameterRenames(t *testing.T) {
+	assert.Equal(t, map[string]string{"id": "petId", "tid": "toyId"},
+		pathParameterRenames("/pets/{id}/toys/{tid}", "/pets/{petId}/toys/{toyId}"))
+	assert.Equal(t, map[string]string{"id": "petId"}, pathParameterRenames("/pets/{id}/{x}", "/pets/{petId}/{x}"))
+	assert.Nil(t, pathParameterRenames("/pets/{id}", "/pets/{id}"))
+	assert.Nil(t, pathParameterRenames("/pets/{id}", "/animals/{petId}"))
+	assert.Nil(t, pathParameterRenames("", "/pets/{petId}"))
+}
+
+func TestCompareOpenAPIDocuments_PathParameterRenamed_Position(t *testing.T) {
+	left := `openapi: 3.0.1
+paths:
+  /pets/{a}/toys/{b}:
+    get:
+      parameters:
+        - name: a
+          in: path
+          required: true
+        - name: b
+          in: path
+          required: true
+          description: the toy
+      responses:
+        "200":
+          description: a toy`
+
+	// the parameters are defined in a different order to the placeholders.
+	right := `openapi: 3.0.1
+paths:
+  /pets/{petId}/toys/{toyId}:
+    get:
+      parameters:
+        - name: toyId
+          in: path
+          required: true
+          description: the toy
+        - name: petId
+          in: path
+          required: true
+      responses:
+        "200":
+          description: a toy`
+
+	changes := compareMoveSpecs(left, right)
+	assert.Equal(t, 0, changes.TotalBreakingChanges)
+
+	// each parameter is paired with the placeholder in the same position, so nothing else changed.
+	oc := changes.Changes.PathsChanges.PathItemsChanges["/pets/{petId}/toys/{toyId}"].GetChanges
+	assert.Len(t, oc.Changes, 2)
+	assert.Equal(t, "a", oc.Changes[0].Original)
+	assert.Equal(t, "petId", oc.Changes[0].New)
+	assert.Equal(t, "b", oc.Changes[1].Original)
+	assert.Equal(t, "toyId", oc.Changes[1].New)
+	assert.Empty(t, oc.ParameterChanges)
+}
+
+func TestCompareOpenAPIDocuments_PathParameterRenamed_PathNotRenamed(t *testing.T) {
+	left := `openapi: 3.0.1
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+      responses:
+        "200":
+          description: a pet`
+
+	right := `openapi: 3.0.1
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+      responses:
+        "200":
+          description: a pet`
+
+	// the path was not renamed, so the parameter was removed and another one added.
+	changes := compareMoveSpecs(left, right)
+	assert.Equal(t, 0, changes.Moved)
+	assert.Equal(t, 1, changes.Removed)
+	assert.Equal(t, 1, changes.Added)
+	assert.Equal(t, 2, changes.TotalBreakingChanges)
+}
+
+func TestCompareOpenAPIDocuments_PathNotMoved(t *testing.T) {
+	left := `openapi: 3.0.1
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: some pets`
+
+	right := `openapi: 3.0.1
+paths:
+  /animals:
+    get:
+      responses:
+        "200":
+          description: some pets`
+
+	// only path templates that differ by parameter names are the same path, anything else is a different path.
+	changes := compareMoveSpecs(left, right)
+	assert.Equal(t, 2, changes.TotalChanges)
+	assert.Equal(t, 0, changes.Moved)
+	assert.Equal(t, 1, changes.Removed)
+	assert.Equal(t, 1, changes.Added)
+	assert.Equal(t, 1, changes.TotalBreakingChanges)
+}
+
+func TestCompareOpenAPIDocuments_SchemaRenamed(t *testing.T) {
+	left := `openapi: 3.0.1
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    Toy:
+      type: string`
+
+	right := `openapi: 3.0.1
+components:
+  schemas:
+    Animal:
+      type: object
+      properties:
+        name:
+          type: string
+    Toy:
+      type: string
+    Bone:
+      type: integer`
+
+	changes := compareMoveSpecs(left, right)
+	assert.Equal(t, 2, changes.TotalChanges)
+	assert.Equal(t, 1, changes.Moved)
+	assert.Equal(t, 1, changes.Added)
+
+	cc := changes.Changes.ComponentsChanges
+	assert.Equal(t, Moved, cc.Changes[0].ChangeType)
+	assert.Equal(t, "Pet", cc.Changes[0].Original)
+	assert.Equal(t, "Animal", cc.Changes[0].New)
+	assert.Equal(t, ObjectAdded, cc.Changes[1].ChangeType)
+	assert.Equal(t, "Bone", cc.Changes[1].Property)
+}
+
+func TestCompareOpenAPIDocuments_ResponseCodeChanged(t *testing.T) {
+	left := `openapi: 3.0.1
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: some pets`
+
+	right := `openapi: 3.0.1
+paths:
+  /pets:
+    get:
+      responses:
+        "201":
+          description: some pets`
+
+	// response codes are not renamed, changing one removes a code and adds another.
+	changes := compareMoveSpecs(left, right)
+	assert.Equal(t, 0, changes.Moved)
+	assert.Equal(t, 1, changes.Removed)
+	assert.Equal(t, 1, changes.Added)
+	assert.Equal(t, 1, changes.TotalBreakingChanges)
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// OAuthFlowsChanges represents changes found between two OpenAPI OAuthFlows objects.
+type OAuthFlowsChanges struct {
+	PropertyChanges[*v3.OAuthFlows]
+	ImplicitChanges          *OAuthFlowChanges
+	PasswordChanges          *OAuthFlowChanges
+	ClientCredentialsChanges *OAuthFlowChanges
+	AuthorizationCodeChanges *OAuthFlowChanges
+	ExtensionChanges         *ExtensionChanges
+}
+
+// TotalChanges returns the number of changes made between two OAuthFlows instances.
+func (o *OAuthFlowsChanges) TotalChanges() int {
+	c := o.PropertyChanges.TotalChanges()
+	if o.ImplicitChanges != nil {
+		c += o.ImplicitChanges.TotalChanges()
+	}
+	if o.PasswordChanges != nil {
+		c += o.PasswordChanges.TotalChanges()
+	}
+	if o.ClientCredentialsChanges != nil {
+		c += o.ClientCredentialsChanges.TotalChanges()
+	}
+	if o.AuthorizationCodeChanges != nil {
+		c += o.AuthorizationCodeChanges.TotalChanges()
+	}
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the number of breaking changes made between two OAuthFlows objects.
+func (o *OAuthFlowsChanges) TotalBreakingChanges() int {
+	c := o.PropertyChanges.TotalBreakingChanges()
+	if o.ImplicitChanges != nil {
+		c += o.ImplicitChanges.TotalBreakingChanges()
+	}
+	if o.PasswordChanges != nil {
+		c += o.PasswordChanges.TotalBreakingChanges()
+	}
+	if o.ClientCredentialsChanges != nil {
+		c += o.ClientCredentialsChanges.TotalBreakingChanges()
+	}
+	if o.AuthorizationCodeChanges != nil {
+		c += o.AuthorizationCodeChanges.TotalBreakingChanges()
+	}
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareOAuthFlows compares a left (original) and right (new) OAuthFlows object for changes. If changes
+// are found, a pointer to OAuthFlowsChanges is returned, otherwise nil is returned.
+func CompareOAuthFlows(l, r *v3.OAuthFlows) *OAuthFlowsChanges {
+	var changes []*Change[*v3.OAuthFlows]
+	oa := new(OAuthFlowsChanges)
+
+	oa.ImplicitChanges = checkOAuthFlow(l.Implicit, r.Implicit, v3.ImplicitLabel, &changes, l, r)
+	oa.PasswordChanges = checkOAuthFlow(l.Password, r.Password, v3.PasswordLabel, &changes, l, r)
+	oa.ClientCredentialsChanges = checkOAuthFlow(l.ClientCredentials, r.ClientCredentials,
+		v3.ClientCredentialsLabel, &changes, l, r)
+	oa.AuthorizationCodeChanges = checkOAuthFlow(l.AuthorizationCode, r.AuthorizationCode,
+		v3.AuthorizationCodeLabel, &changes, l, r)
+
+	oa.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	oa.Changes = changes
+	if oa.TotalChanges() <= 0 {
+		return nil
+	}
+	return oa
+}
+
+// checkOAuthFlow will check a single flow for addition, removal or modification.
+func checkOAuthFlow(l, r low.NodeReference[*v3.OAuthFlow], label string, changes *[]*Change[*v3.OAuthFlows],
+	lFlows, rFlows *v3.OAuthFlows) *OAuthFlowChanges {
+	if l.Value != nil && r.Value == nil {
+		CreateChange[*v3.OAuthFlows](changes, ObjectRemoved, label,
+			l.ValueNode, nil, true, lFlows, rFlows)
+		return nil
+	}
+	if l.Value == nil && r.Value != nil {
+		CreateChange[*v3.OAuthFlows](changes, ObjectAdded, label,
+			nil, r.ValueNode, false, lFlows, rFlows)
+		return nil
+	}
+	if l.Value != nil && r.Value != nil {
+		return CompareOAuthFlow(l.Value, r.Value)
+	}
+	return nil
+}
+
+// OAuthFlowChanges represents an OpenAPI OAuthFlow object.
+type OAuthFlowChanges struct {
+	PropertyChanges[*v3.OAuthFlow]
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes made between two OAuthFlow objects
+func (o *OAuthFlowChanges) TotalChanges() int {
+	c := o.PropertyChanges.TotalChanges()
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes made between two OAuthFlow objects
+func (o *OAuthFlowChanges) TotalBreakingChanges() int {
+	c := o.PropertyChanges.TotalBreakingChanges()
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareOAuthFlow compares a left (original) and right (new) OAuthFlow object for changes. Removing a scope
+// is a breaking change, adding one is not. If changes are found, a pointer to OAuthFlowChanges is returned,
+// otherwise nil is returned.
+func CompareOAuthFlow(l, r *v3.OAuthFlow) *OAuthFlowChanges {
+	var changes []*Change[*v3.OAuthFlow]
+	var props []*PropertyCheck[*v3.OAuthFlow]
+
+	// AuthorizationUrl
+	props = append(props, &PropertyCheck[*v3.OAuthFlow]{
+		LeftNode:  l.AuthorizationUrl.ValueNode,
+		RightNode: r.AuthorizationUrl.ValueNode,
+		Label:     v3.AuthorizationUrlLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// TokenUrl
+	props = append(props, &PropertyCheck[*v3.OAuthFlow]{
+		LeftNode:  l.TokenUrl.ValueNode,
+		RightNode: r.TokenUrl.ValueNode,
+		Label:     v3.TokenUrlLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// RefreshUrl
+	props = append(props, &PropertyCheck[*v3.OAuthFlow]{
+		LeftNode:  l.RefreshUrl.ValueNode,
+		RightNode: r.RefreshUrl.ValueNode,
+		Label:     v3.RefreshUrlLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// check scopes
+	lScopes := FlattenLowLevelMap[string](l.Scopes.Value)
+	rScopes := FlattenLowLevelMap[string](r.Scopes.Value)
+	for k := range lScopes {
+		if rScopes[k] == nil {
+			CreateChange[*v3.OAuthFlow](&changes, ObjectRemoved, v3.ScopesLabel,
+				lScopes[k].GetValueNode(), nil, true, l, r)
+			continue
+		}
+		if lScopes[k].Value != rScopes[k].Value {
+			CreateChange[*v3.OAuthFlow](&changes, Modified, v3.ScopesLabel,
+				lScopes[k].GetValueNode(), rScopes[k].GetValueNode(), false, l, r)
+		}
+	}
+	for k := range rScopes {
+		if lScopes[k] == nil {
+			CreateChange[*v3.OAuthFlow](&changes, ObjectAdded, v3.ScopesLabel,
+				nil, rScopes[k].GetValueNode(), false, l, r)
+		}
+	}
+
+	oa := new(OAuthFlowChanges)
+	oa.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	oa.Changes = changes
+	if oa.TotalChanges() <= 0 {
+		return nil
+	}
+	return oa
+}
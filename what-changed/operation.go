@@ -0,0 +1,210 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// OperationChanges represent changes made between two OpenAPI Operation objects.
+type OperationChanges struct {
+	PropertyChanges[*v3.Operation]
+	ExternalDocChanges         *ExternalDocChanges
+	ParameterChanges           []*ParameterChanges
+	RequestBodyChanges         *RequestBodyChanges
+	ResponsesChanges           *ResponsesChanges
+	CallbackChanges            map[string]*CallbackChanges
+	SecurityRequirementChanges *SecurityRequirementChanges
+	ServerChanges              []*ServerChanges
+	ExtensionChanges           *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes made between two OpenAPI Operation objects.
+func (o *OperationChanges) TotalChanges() int {
+	c := o.PropertyChanges.TotalChanges()
+	if o.ExternalDocChanges != nil {
+		c += o.ExternalDocChanges.TotalChanges()
+	}
+	for k := range o.ParameterChanges {
+		c += o.ParameterChanges[k].TotalChanges()
+	}
+	if o.RequestBodyChanges != nil {
+		c += o.RequestBodyChanges.TotalChanges()
+	}
+	if o.ResponsesChanges != nil {
+		c += o.ResponsesChanges.TotalChanges()
+	}
+	for k := range o.CallbackChanges {
+		c += o.CallbackChanges[k].TotalChanges()
+	}
+	if o.SecurityRequirementChanges != nil {
+		c += o.SecurityRequirementChanges.TotalChanges()
+	}
+	for k := range o.ServerChanges {
+		c += o.ServerChanges[k].TotalChanges()
+	}
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes made between two OpenAPI Operation objects.
+func (o *OperationChanges) TotalBreakingChanges() int {
+	c := o.PropertyChanges.TotalBreakingChanges()
+	if o.ExternalDocChanges != nil {
+		c += o.ExternalDocChanges.TotalBreakingChanges()
+	}
+	for k := range o.ParameterChanges {
+		c += o.ParameterChanges[k].TotalBreakingChanges()
+	}
+	if o.RequestBodyChanges != nil {
+		c += o.RequestBodyChanges.TotalBreakingChanges()
+	}
+	if o.ResponsesChanges != nil {
+		c += o.ResponsesChanges.TotalBreakingChanges()
+	}
+	for k := range o.CallbackChanges {
+		c += o.CallbackChanges[k].TotalBreakingChanges()
+	}
+	if o.SecurityRequirementChanges != nil {
+		c += o.SecurityRequirementChanges.TotalBreakingChanges()
+	}
+	for k := range o.ServerChanges {
+		c += o.ServerChanges[k].TotalBreakingChanges()
+	}
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareOperations compares a left (original) and right (new) Operation object for changes. If changes are found,
+// a pointer to OperationChanges is returned, otherwise nil is returned.
+func CompareOperations(l, r *v3.Operation) *OperationChanges {
+	return compareOperations(l, r, nil)
+}
+
+// compareOperations compares two Operation objects, path parameters renamed along with the path template of the
+// operation are paired using renames.
+func compareOperations(l, r *v3.Operation, renames map[string]string) *OperationChanges {
+	var changes []*Change[*v3.Operation]
+	var props []*PropertyCheck[*v3.Operation]
+
+	// Summary
+	props = append(props, &PropertyCheck[*v3.Operation]{
+		LeftNode:  l.Summary.ValueNode,
+		RightNode: r.Summary.ValueNode,
+		Label:     v3.SummaryLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.Operation]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// OperationId
+	props = append(props, &PropertyCheck[*v3.Operation]{
+		LeftNode:  l.OperationId.ValueNode,
+		RightNode: r.OperationId.ValueNode,
+		Label:     v3.OperationIdLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Deprecated
+	props = append(props, &PropertyCheck[*v3.Operation]{
+		LeftNode:  l.Deprecated.ValueNode,
+		RightNode: r.Deprecated.ValueNode,
+		Label:     v3.DeprecatedLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// tags
+	CheckStringSliceForChanges(l.Tags.Value, r.Tags.Value, v3.TagsLabel, &changes, false, false, l, r)
+
+	oc := new(OperationChanges)
+
+	// external docs
+	if l.ExternalDocs.Value != nil && r.ExternalDocs.Value != nil {
+		if !low.AreEqual(l.ExternalDocs.Value, r.ExternalDocs.Value) {
+			oc.ExternalDocChanges = CompareExternalDocs(l.ExternalDocs.Value, r.ExternalDocs.Value)
+		}
+	}
+	if l.ExternalDocs.Value != nil && r.ExternalDocs.Value == nil {
+		CreateChange[*v3.Operation](&changes, ObjectRemoved, v3.ExternalDocsLabel,
+			l.ExternalDocs.ValueNode, nil, false, l.ExternalDocs.Value, nil)
+	}
+	if l.ExternalDocs.Value == nil && r.ExternalDocs.Value != nil {
+		CreateChange[*v3.Operation](&changes, ObjectAdded, v3.ExternalDocsLabel,
+			nil, r.ExternalDocs.ValueNode, false, nil, r.ExternalDocs.Value)
+	}
+
+	// parameters
+	oc.ParameterChanges = checkParameters(l.Parameters.Value, r.Parameters.Value, &changes, renames)
+
+	// request body, adding a request body is only breaking if it's required.
+	if l.RequestBody.Value != nil && r.RequestBody.Value != nil {
+		oc.RequestBodyChanges = CompareRequestBodies(l.RequestBody.Value, r.RequestBody.Value)
+	}
+	if l.RequestBody.Value != nil && r.RequestBody.Value == nil {
+		CreateChange[*v3.Operation](&changes, ObjectRemoved, v3.RequestBodyLabel,
+			l.RequestBody.ValueNode, nil, true, l.RequestBody.Value, nil)
+	}
+	if l.RequestBody.Value == nil && r.RequestBody.Value != nil {
+		CreateChange[*v3.Operation](&changes, ObjectAdded, v3.RequestBodyLabel,
+			nil, r.RequestBody.ValueNode, r.RequestBody.Value.Required.Value, nil, r.RequestBody.Value)
+	}
+
+	// responses
+	if l.Responses.Value != nil && r.Responses.Value != nil {
+		oc.ResponsesChanges = CompareResponses(l.Responses.Value, r.Responses.Value)
+	}
+	if l.Responses.Value != nil && r.Responses.Value == nil {
+		CreateChange[*v3.Operation](&changes, ObjectRemoved, v3.ResponsesLabel,
+			l.Responses.ValueNode, nil, true, l.Responses.Value, nil)
+	}
+	if l.Responses.Value == nil && r.Responses.Value != nil {
+		CreateChange[*v3.Operation](&changes, ObjectAdded, v3.ResponsesLabel,
+			nil, r.Responses.ValueNode, false, nil, r.Responses.Value)
+	}
+
+	// callbacks
+	oc.CallbackChanges = CheckMapForChanges(l.Callbacks.Value, r.Callbacks.Value, &changes,
+		false, true, CompareCallback)
+
+	// security
+	if l.Security.Value != nil || r.Security.Value != nil {
+		oc.SecurityRequirementChanges = CompareSecurityRequirement(l.Security.Value, r.Security.Value)
+	}
+
+	// servers
+	oc.ServerChanges = checkServers(l.Servers.Value, r.Servers.Value, &changes)
+
+	oc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	oc.Changes = changes
+	if oc.TotalChanges() <= 0 {
+		return nil
+	}
+	return oc
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareOperations_Identical(t *testing.T) {
+
+	left := `operationId: getPizza
+summary: get a pizza
+tags:
+  - food
+parameters:
+  - name: size
+    in: query
+responses:
+  "200":
+    description: a pizza`
+
+	right := left
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Operation
+	var rDoc v3.Operation
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareOperations(&lDoc, &rDoc)
+	assert.Nil(t, extChanges)
+}
+
+func TestCompareOperations_PropertiesAndTags(t *testing.T) {
+
+	left := `operationId: getPizza
+summary: get a pizza
+tags:
+  - food
+  - cheese`
+
+	right := `operationId: fetchPizza
+summary: fetch a pizza
+tags:
+  - food
+  - tomato`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Operation
+	var rDoc v3.Operation
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareOperations(&lDoc, &rDoc)
+	assert.Equal(t, 4, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareOperations_ParametersChanged(t *testing.T) {
+
+	left := `operationId: getPizza
+parameters:
+  - name: size
+    in: query
+  - name: crust
+    in: query`
+
+	right := `operationId: getPizza
+parameters:
+  - name: size
+    in: query
+    description: how big?
+  - name: topping
+    in: query
+    required: true`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Operation
+	var rDoc v3.Operation
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareOperations(&lDoc, &rDoc)
+	assert.Equal(t, 3, extChanges.TotalChanges())
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+	assert.Len(t, extChanges.ParameterChanges, 1)
+	assert.Equal(t, ObjectRemoved, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, ObjectAdded, extChanges.Changes[1].ChangeType)
+}
+
+func TestCompareOperations_RequestBodyAndResponses(t *testing.T) {
+
+	left := `operationId: makePizza
+responses:
+  "200":
+    description: a pizza
+  "404":
+    description: no pizza`
+
+	right := `operationId: makePizza
+requestBody:
+  required: true
+  content:
+    application/json:
+      schema:
+        type: object
+responses:
+  "200":
+    description: a lovely pizza`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Operation
+	var rDoc v3.Operation
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareOperations(&lDoc, &rDoc)
+	assert.Equal(t, 3, extChanges.TotalChanges())
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+	assert.Equal(t, v3.RequestBodyLabel, extChanges.Changes[0].Property)
+	assert.Len(t, extChanges.ResponsesChanges.ResponseChanges, 1)
+}
+
+func TestCompareOperations_SecurityChanged(t *testing.T) {
+
+	left := `operationId: makePizza
+security:
+  - OAuth:
+      - read:pizza`
+
+	right := `operationId: makePizza
+security:
+  - OAuth:
+      - read:pizza
+      - write:pizza
+  - ApiKey: []`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Operation
+	var rDoc v3.Operation
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareOperations(&lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.SecurityRequirementChanges)
+}
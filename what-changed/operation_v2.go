@@ -0,0 +1,169 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// OperationChangesV2 represent changes made between two Swagger Operation objects.
+type OperationChangesV2 struct {
+	PropertyChanges[*v2.Operation]
+	ExternalDocChanges *ExternalDocChanges
+	ParameterChanges   []*ParameterChangesV2
+	ResponsesChanges   *ResponsesChangesV2
+	ExtensionChanges   *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes made between two Swagger Operation objects.
+func (o *OperationChangesV2) TotalChanges() int {
+	c := o.PropertyChanges.TotalChanges()
+	if o.ExternalDocChanges != nil {
+		c += o.ExternalDocChanges.TotalChanges()
+	}
+	for k := range o.ParameterChanges {
+		c += o.ParameterChanges[k].TotalChanges()
+	}
+	if o.ResponsesChanges != nil {
+		c += o.ResponsesChanges.TotalChanges()
+	}
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes made between two Swagger Operation objects.
+func (o *OperationChangesV2) TotalBreakingChanges() int {
+	c := o.PropertyChanges.TotalBreakingChanges()
+	if o.ExternalDocChanges != nil {
+		c += o.ExternalDocChanges.TotalBreakingChanges()
+	}
+	for k := range o.ParameterChanges {
+		c += o.ParameterChanges[k].TotalBreakingChanges()
+	}
+	if o.ResponsesChanges != nil {
+		c += o.ResponsesChanges.TotalBreakingChanges()
+	}
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareOperationsV2 compares a left (original) and right (new) Swagger Operation object for changes.
+//
+// Removing a mime type from consumes is a breaking change (clients can no longer send it), adding one is not.
+// Any change to produces is a breaking change, as clients may no longer receive a type they understand. Removing
+// a scheme is a breaking change, adding one is not.
+//
+// If changes are found, a pointer to OperationChangesV2 is returned, otherwise nil is returned.
+func CompareOperationsV2(l, r *v2.Operation) *OperationChangesV2 {
+	return compareOperationsV2(l, r, nil)
+}
+
+// compareOperationsV2 compares two Swagger Operation objects, path parameters renamed along with the path template
+// of the operation are paired using renames.
+func compareOperationsV2(l, r *v2.Operation, renames map[string]string) *OperationChangesV2 {
+	var changes []*Change[*v2.Operation]
+	var props []*PropertyCheck[*v2.Operation]
+
+	// Summary
+	props = append(props, &PropertyCheck[*v2.Operation]{
+		LeftNode:  l.Summary.ValueNode,
+		RightNode: r.Summary.ValueNode,
+		Label:     v3.SummaryLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v2.Operation]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// OperationId
+	props = append(props, &PropertyCheck[*v2.Operation]{
+		LeftNode:  l.OperationId.ValueNode,
+		RightNode: r.OperationId.ValueNode,
+		Label:     v3.OperationIdLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Deprecated
+	props = append(props, &PropertyCheck[*v2.Operation]{
+		LeftNode:  l.Deprecated.ValueNode,
+		RightNode: r.Deprecated.ValueNode,
+		Label:     v3.DeprecatedLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// tags, consumes, produces and schemes
+	CheckStringSliceForChanges(l.Tags.Value, r.Tags.Value, v3.TagsLabel, &changes, false, false, l, r)
+	CheckStringSliceForChanges(l.Consumes.Value, r.Consumes.Value, v2.ConsumesLabel, &changes, false, true, l, r)
+	CheckStringSliceForChanges(l.Produces.Value, r.Produces.Value, v2.ProducesLabel, &changes, true, true, l, r)
+	CheckStringSliceForChanges(l.Schemes.Value, r.Schemes.Value, v2.SchemesLabel, &changes, false, true, l, r)
+
+	oc := new(OperationChangesV2)
+
+	// external docs
+	if l.ExternalDocs.Value != nil && r.ExternalDocs.Value != nil {
+		if !low.AreEqual(l.ExternalDocs.Value, r.ExternalDocs.Value) {
+			oc.ExternalDocChanges = CompareExternalDocs(l.ExternalDocs.Value, r.ExternalDocs.Value)
+		}
+	}
+	if l.ExternalDocs.Value != nil && r.ExternalDocs.Value == nil {
+		CreateChange[*v2.Operation](&changes, ObjectRemoved, v3.ExternalDocsLabel,
+			l.ExternalDocs.ValueNode, nil, false, l.ExternalDocs.Value, nil)
+	}
+	if l.ExternalDocs.Value == nil && r.ExternalDocs.Value != nil {
+		CreateChange[*v2.Operation](&changes, ObjectAdded, v3.ExternalDocsLabel,
+			nil, r.ExternalDocs.ValueNode, false, nil, r.ExternalDocs.Value)
+	}
+
+	// parameters
+	oc.ParameterChanges = checkParametersV2(l.Parameters.Value, r.Parameters.Value, &changes, renames)
+
+	// responses
+	if l.Responses.Value != nil && r.Responses.Value != nil {
+		oc.ResponsesChanges = CompareResponsesV2(l.Responses.Value, r.Responses.Value)
+	}
+	if l.Responses.Value != nil && r.Responses.Value == nil {
+		CreateChange[*v2.Operation](&changes, ObjectRemoved, v2.ResponsesLabel,
+			l.Responses.ValueNode, nil, true, l.Responses.Value, nil)
+	}
+	if l.Responses.Value == nil && r.Responses.Value != nil {
+		CreateChange[*v2.Operation](&changes, ObjectAdded, v2.ResponsesLabel,
+			nil, r.Responses.ValueNode, false, nil, r.Responses.Value)
+	}
+
+	// security
+	checkSecurityRequirementsV2(l.Security.Value, r.Security.Value, &changes, l, r)
+
+	oc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	oc.Changes = changes
+	if oc.TotalChanges() <= 0 {
+		return nil
+	}
+	return oc
+}
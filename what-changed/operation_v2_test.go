@@ -0,0 +1,143 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareOperationsV2_Identical(t *testing.T) {
+
+	left := `operationId: bakePizza
+consumes:
+  - application/json
+produces:
+  - application/json
+responses:
+  "200":
+    description: pizza is baked`
+
+	right := left
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Operation
+	var rDoc v2.Operation
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	assert.Nil(t, CompareOperationsV2(&lDoc, &rDoc))
+}
+
+func TestCompareOperationsV2_ConsumesProduces(t *testing.T) {
+
+	left := `operationId: bakePizza
+consumes:
+  - application/json
+produces:
+  - application/json
+responses:
+  "200":
+    description: pizza is baked`
+
+	right := `operationId: bakePizza
+consumes:
+  - application/json
+  - application/xml
+produces:
+  - application/json
+  - application/xml
+responses:
+  "200":
+    description: pizza is baked`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Operation
+	var rDoc v2.Operation
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare, adding a consumed type is fine, adding a produced type is not.
+	extChanges := CompareOperationsV2(&lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, v2.ConsumesLabel, extChanges.Changes[0].Property)
+	assert.False(t, extChanges.Changes[0].Breaking)
+	assert.Equal(t, v2.ProducesLabel, extChanges.Changes[1].Property)
+	assert.True(t, extChanges.Changes[1].Breaking)
+
+	// reverse, removing a consumed type is breaking, as is removing a produced type.
+	extChanges = CompareOperationsV2(&rDoc, &lDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareOperationsV2_ResponsesAndParameters(t *testing.T) {
+
+	left := `operationId: bakePizza
+parameters:
+  - name: size
+    in: query
+    type: string
+responses:
+  "200":
+    description: pizza is baked
+    headers:
+      Oven-Temp:
+        type: integer`
+
+	right := `operationId: cookPizza
+parameters:
+  - name: size
+    in: query
+    type: integer
+  - name: crust
+    in: query
+    type: string
+responses:
+  "200":
+    description: pizza is baked
+    headers:
+      Oven-Temp:
+        type: integer
+        description: temperature of the oven
+  "404":
+    description: no pizza`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Operation
+	var rDoc v2.Operation
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareOperationsV2(&lDoc, &rDoc)
+	assert.Equal(t, 5, extChanges.TotalChanges())
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.ResponsesChanges)
+	assert.Equal(t, 2, extChanges.ResponsesChanges.TotalChanges())
+	assert.Len(t, extChanges.ParameterChanges, 1)
+}
@@ -0,0 +1,250 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// ParameterChanges represents changes found between two OpenAPI Parameter objects.
+type ParameterChanges struct {
+	PropertyChanges[*v3.Parameter]
+	SchemaChanges    *SchemaChanges
+	ExamplesChanges  map[string]*ExampleChanges
+	ContentChanges   map[string]*MediaTypeChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns a count of everything that changed
+func (p *ParameterChanges) TotalChanges() int {
+	c := p.PropertyChanges.TotalChanges()
+	if p.SchemaChanges != nil {
+		c += p.SchemaChanges.TotalChanges()
+	}
+	for k := range p.ExamplesChanges {
+		c += p.ExamplesChanges[k].TotalChanges()
+	}
+	for k := range p.ContentChanges {
+		c += p.ContentChanges[k].TotalChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges always returns the total number of breaking changes found between two Parameter objects.
+func (p *ParameterChanges) TotalBreakingChanges() int {
+	c := p.PropertyChanges.TotalBreakingChanges()
+	if p.SchemaChanges != nil {
+		c += p.SchemaChanges.TotalBreakingChanges()
+	}
+	for k := range p.ExamplesChanges {
+		c += p.ExamplesChanges[k].TotalBreakingChanges()
+	}
+	for k := range p.ContentChanges {
+		c += p.ContentChanges[k].TotalBreakingChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareParameters compares a left (original) and right (new) Parameter object for changes. If changes are found,
+// a pointer to ParameterChanges is returned, otherwise nil is returned.
+func CompareParameters(l, r *v3.Parameter) *ParameterChanges {
+	var changes []*Change[*v3.Parameter]
+	var props []*PropertyCheck[*v3.Parameter]
+
+	// Name
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.Name.ValueNode,
+		RightNode: r.Name.ValueNode,
+		Label:     v3.NameLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// In
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.In.ValueNode,
+		RightNode: r.In.ValueNode,
+		Label:     v3.InLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// Deprecated
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.Deprecated.ValueNode,
+		RightNode: r.Deprecated.ValueNode,
+		Label:     v3.DeprecatedLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// AllowEmptyValue
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.AllowEmptyValue.ValueNode,
+		RightNode: r.AllowEmptyValue.ValueNode,
+		Label:     v3.AllowEmptyValueLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Style
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.Style.ValueNode,
+		RightNode: r.Style.ValueNode,
+		Label:     v3.StyleLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Explode
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.Explode.ValueNode,
+		RightNode: r.Explode.ValueNode,
+		Label:     v3.ExplodeLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// AllowReserved
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.AllowReserved.ValueNode,
+		RightNode: r.AllowReserved.ValueNode,
+		Label:     v3.AllowReservedLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Example
+	props = append(props, &PropertyCheck[*v3.Parameter]{
+		LeftNode:  l.Example.ValueNode,
+		RightNode: r.Example.ValueNode,
+		Label:     v3.ExampleLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// required
+	checkRequired(l.Required.ValueNode, r.Required.ValueNode, &changes, l, r, SchemaDirectionRequest)
+
+	pc := new(ParameterChanges)
+
+	// schema
+	pc.SchemaChanges = checkSchema(l.Schema.Value, r.Schema.Value, l.Schema.ValueNode, r.Schema.ValueNode,
+		&changes, l, r, SchemaDirectionRequest)
+
+	// examples
+	pc.ExamplesChanges = CheckMapForChanges(l.Examples.Value, r.Examples.Value, &changes,
+		false, false, CompareExamples)
+
+	// content
+	pc.ContentChanges = CheckMapForChanges(l.Content.Value, r.Content.Value, &changes,
+		false, true, mediaTypeComparison(SchemaDirectionRequest))
+
+	pc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	pc.Changes = changes
+	if pc.TotalChanges() <= 0 {
+		return nil
+	}
+	return pc
+}
+
+// checkParameters will compare a left (original) and right (new) slice of Parameter objects. Parameters are
+// matched using their name and location. Removing a parameter is a breaking change, adding a parameter is only
+// breaking if the new parameter is required. Path parameters renamed along with the path template (renames, from
+// pathParameterRenames) are recorded as moved, which is not breaking.
+func checkParameters[T any](l, r []low.ValueReference[*v3.Parameter], changes *[]*Change[T],
+	renames map[string]string) []*ParameterChanges {
+	lParams := make(map[string]low.ValueReference[*v3.Parameter])
+	rParams := make(map[string]low.ValueReference[*v3.Parameter])
+	for i := range l {
+		lParams[parameterKey(l[i].Value)] = l[i]
+	}
+	for i := range r {
+		rParams[parameterKey(r[i].Value)] = r[i]
+	}
+	var removed, added []low.ValueReference[*v3.Parameter]
+	var paramChanges []*ParameterChanges
+	for i := range l {
+		k := parameterKey(l[i].Value)
+		if _, ok := rParams[k]; !ok {
+			removed = append(removed, l[i])
+			continue
+		}
+		if pc := CompareParameters(l[i].Value, rParams[k].Value); pc != nil {
+			paramChanges = append(paramChanges, pc)
+		}
+	}
+	for i := range r {
+		if _, ok := lParams[parameterKey(r[i].Value)]; !ok {
+			added = append(added, r[i])
+		}
+	}
+	renamed, removed, added := pairRenamedPathParameters(removed, added, renames,
+		func(p *v3.Parameter) string { return p.In.Value }, func(p *v3.Parameter) string { return p.Name.Value })
+	for i := range removed {
+		CreateChange[T](changes, ObjectRemoved, v3.ParametersLabel,
+			removed[i].ValueNode, nil, true, removed[i].Value, nil)
+	}
+	for _, pair := range renamed {
+		lp, rp := pair[0].Value, pair[1].Value
+		pc := CompareParameters(lp, rp)
+		if pc != nil {
+			pc.Changes = withoutProperty(pc.Changes, v3.NameLabel)
+		}
+		changeType := Moved
+		if pc != nil && pc.TotalChanges() > 0 {
+			changeType = ModifiedAndMoved
+			paramChanges = append(paramChanges, pc)
+		}
+		CreateChange[T](changes, changeType, v3.ParametersLabel,
+			lp.Name.ValueNode, rp.Name.ValueNode, false, lp, rp)
+	}
+	for i := range added {
+		CreateChange[T](changes, ObjectAdded, v3.ParametersLabel,
+			nil, added[i].ValueNode, added[i].Value.Required.Value, nil, added[i].Value)
+	}
+	return paramChanges
+}
+
+// parameterKey creates a unique key for a parameter using its location and name.
+func parameterKey(p *v3.Parameter) string {
+	return p.In.Value + ":" + p.Name.Value
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareParameters_Identical(t *testing.T) {
+
+	left := `name: pizza
+in: query
+description: what kind of pizza?
+required: true
+schema:
+  type: string`
+
+	right := left
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Parameter
+	var rDoc v3.Parameter
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareParameters(&lDoc, &rDoc)
+	assert.Nil(t, extChanges)
+}
+
+func TestCompareParameters_RequiredAndDescriptionChanged(t *testing.T) {
+
+	left := `name: pizza
+in: query
+description: what kind of pizza?
+required: false
+schema:
+  type: string`
+
+	right := `name: pizza
+in: query
+description: which pizza would you like?
+required: true
+schema:
+  type: string`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Parameter
+	var rDoc v3.Parameter
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareParameters(&lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	for _, c := range extChanges.Changes {
+		assert.Equal(t, Modified, c.ChangeType)
+		if c.Property == v3.RequiredLabel {
+			assert.True(t, c.Breaking)
+		}
+	}
+}
+
+func TestCompareParameters_SchemaChanged(t *testing.T) {
+
+	left := `name: pizza
+in: query
+schema:
+  type: string`
+
+	right := `name: pizza
+in: query
+schema:
+  type: integer`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Parameter
+	var rDoc v3.Parameter
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareParameters(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.NotNil(t, extChanges.SchemaChanges)
+	assert.Len(t, extChanges.Changes, 0)
+}
+
+func TestCompareParameters_ContentAdded(t *testing.T) {
+
+	left := `name: pizza
+in: query
+content:
+  application/json:
+    example: pepperoni`
+
+	right := `name: pizza
+in: query
+content:
+  application/json:
+    example: pepperoni
+  application/xml:
+    example: <pizza/>`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Parameter
+	var rDoc v3.Parameter
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareParameters(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+	assert.Equal(t, ObjectAdded, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, "application/xml", extChanges.Changes[0].Property)
+
+	// and the reverse, removing content is breaking.
+	extChanges = CompareParameters(&rDoc, &lDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, ObjectRemoved, extChanges.Changes[0].ChangeType)
+}
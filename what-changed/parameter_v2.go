@@ -0,0 +1,192 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// ParameterChangesV2 represents changes found between two Swagger Parameter objects.
+type ParameterChangesV2 struct {
+	PropertyChanges[*v2.Parameter]
+	SchemaChanges    *SchemaChanges
+	ItemsChanges     *ItemsChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger Parameter objects.
+func (p *ParameterChangesV2) TotalChanges() int {
+	c := p.PropertyChanges.TotalChanges()
+	if p.SchemaChanges != nil {
+		c += p.SchemaChanges.TotalChanges()
+	}
+	if p.ItemsChanges != nil {
+		c += p.ItemsChanges.TotalChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger Parameter objects.
+func (p *ParameterChangesV2) TotalBreakingChanges() int {
+	c := p.PropertyChanges.TotalBreakingChanges()
+	if p.SchemaChanges != nil {
+		c += p.SchemaChanges.TotalBreakingChanges()
+	}
+	if p.ItemsChanges != nil {
+		c += p.ItemsChanges.TotalBreakingChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareParametersV2 compares a left (original) and right (new) Swagger Parameter object for changes. Body
+// parameters are compared using their schema, all other parameters (including formData) are compared using their
+// type, format, collectionFormat and validation properties. If changes are found, a pointer to ParameterChangesV2
+// is returned, otherwise nil is returned.
+func CompareParametersV2(l, r *v2.Parameter) *ParameterChangesV2 {
+	var changes []*Change[*v2.Parameter]
+	var props []*PropertyCheck[*v2.Parameter]
+
+	// Name
+	props = append(props, &PropertyCheck[*v2.Parameter]{
+		LeftNode:  l.Name.ValueNode,
+		RightNode: r.Name.ValueNode,
+		Label:     v3.NameLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// In
+	props = append(props, &PropertyCheck[*v2.Parameter]{
+		LeftNode:  l.In.ValueNode,
+		RightNode: r.In.ValueNode,
+		Label:     v3.InLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v2.Parameter]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// AllowEmptyValue
+	props = append(props, &PropertyCheck[*v2.Parameter]{
+		LeftNode:  l.AllowEmptyValue.ValueNode,
+		RightNode: r.AllowEmptyValue.ValueNode,
+		Label:     v3.AllowEmptyValueLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// type, format, collectionFormat and validation properties
+	props = appendItemsChecks(props, itemsNodesFromParameter(l), itemsNodesFromParameter(r), &changes, l, r)
+
+	// check properties
+	CheckProperties(props)
+
+	// required
+	checkRequired(l.Required.ValueNode, r.Required.ValueNode, &changes, l, r, SchemaDirectionRequest)
+
+	// enum
+	CheckStringSliceForChanges(l.Enum.Value, r.Enum.Value, v3.EnumLabel, &changes, false, true, l, r)
+
+	pc := new(ParameterChangesV2)
+
+	// schema (body parameters)
+	pc.SchemaChanges = checkSchema(l.Schema.Value, r.Schema.Value, l.Schema.ValueNode, r.Schema.ValueNode,
+		&changes, l, r, SchemaDirectionRequest)
+
+	// items
+	pc.ItemsChanges = checkItems(l.Items, r.Items, &changes)
+
+	pc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	pc.Changes = changes
+	if pc.TotalChanges() <= 0 {
+		return nil
+	}
+	return pc
+}
+
+// checkParametersV2 will compare a left (original) and right (new) slice of Swagger Parameter objects. Parameters
+// are matched using their name and location. Removing a parameter is a breaking change, adding a parameter is only
+// breaking if the new parameter is required. Path parameters renamed along with the path template (renames, from
+// pathParameterRenames) are recorded as moved, which is not breaking.
+func checkParametersV2[T any](l, r []low.ValueReference[*v2.Parameter], changes *[]*Change[T],
+	renames map[string]string) []*ParameterChangesV2 {
+	lParams := make(map[string]low.ValueReference[*v2.Parameter])
+	rParams := make(map[string]low.ValueReference[*v2.Parameter])
+	for i := range l {
+		lParams[parameterKeyV2(l[i].Value)] = l[i]
+	}
+	for i := range r {
+		rParams[parameterKeyV2(r[i].Value)] = r[i]
+	}
+	var removed, added []low.ValueReference[*v2.Parameter]
+	var paramChanges []*ParameterChangesV2
+	for i := range l {
+		k := parameterKeyV2(l[i].Value)
+		if _, ok := rParams[k]; !ok {
+			removed = append(removed, l[i])
+			continue
+		}
+		if pc := CompareParametersV2(l[i].Value, rParams[k].Value); pc != nil {
+			paramChanges = append(paramChanges, pc)
+		}
+	}
+	for i := range r {
+		if _, ok := lParams[parameterKeyV2(r[i].Value)]; !ok {
+			added = append(added, r[i])
+		}
+	}
+	renamed, removed, added := pairRenamedPathParameters(removed, added, renames,
+		func(p *v2.Parameter) string { return p.In.Value }, func(p *v2.Parameter) string { return p.Name.Value })
+	for i := range removed {
+		CreateChange[T](changes, ObjectRemoved, v3.ParametersLabel,
+			removed[i].ValueNode, nil, true, removed[i].Value, nil)
+	}
+	for _, pair := range renamed {
+		lp, rp := pair[0].Value, pair[1].Value
+		pc := CompareParametersV2(lp, rp)
+		if pc != nil {
+			pc.Changes = withoutProperty(pc.Changes, v3.NameLabel)
+		}
+		changeType := Moved
+		if pc != nil && pc.TotalChanges() > 0 {
+			changeType = ModifiedAndMoved
+			paramChanges = append(paramChanges, pc)
+		}
+		CreateChange[T](changes, changeType, v3.ParametersLabel,
+			lp.Name.ValueNode, rp.Name.ValueNode, false, lp, rp)
+	}
+	for i := range added {
+		CreateChange[T](changes, ObjectAdded, v3.ParametersLabel,
+			nil, added[i].ValueNode, added[i].Value.Required.Value, nil, added[i].Value)
+	}
+	return paramChanges
+}
+
+// parameterKeyV2 creates a unique key for a Swagger parameter using its location and name.
+func parameterKeyV2(p *v2.Parameter) string {
+	return p.In.Value + ":" + p.Name.Value
+}
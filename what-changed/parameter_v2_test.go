@@ -0,0 +1,117 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareParametersV2_Identical(t *testing.T) {
+
+	left := `name: toppings
+in: formData
+type: array
+collectionFormat: csv
+items:
+  type: string`
+
+	right := left
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Parameter
+	var rDoc v2.Parameter
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	assert.Nil(t, CompareParametersV2(&lDoc, &rDoc))
+}
+
+func TestCompareParametersV2_FormDataChanged(t *testing.T) {
+
+	left := `name: toppings
+in: formData
+description: pizza toppings
+type: array
+collectionFormat: csv
+enum:
+  - cheese
+  - ham
+items:
+  type: string`
+
+	right := `name: toppings
+in: formData
+description: all the pizza toppings
+type: array
+collectionFormat: multi
+enum:
+  - cheese
+  - pineapple
+items:
+  type: integer`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Parameter
+	var rDoc v2.Parameter
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareParametersV2(&lDoc, &rDoc)
+	assert.Equal(t, 5, extChanges.TotalChanges())
+	assert.Equal(t, 3, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.ItemsChanges)
+	assert.Equal(t, v2.CollectionFormatLabel, extChanges.Changes[1].Property)
+	assert.True(t, extChanges.Changes[1].Breaking)
+}
+
+func TestCompareParametersV2_BodySchemaChanged(t *testing.T) {
+
+	left := `name: pizza
+in: body
+required: true
+schema:
+  type: object`
+
+	right := `name: pizza
+in: body
+required: true
+schema:
+  type: string`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Parameter
+	var rDoc v2.Parameter
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareParametersV2(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.SchemaChanges)
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// PathItemChanges represents changes found between to OpenAPI PathItem objects.
+type PathItemChanges struct {
+	PropertyChanges[*v3.PathItem]
+	GetChanges       *OperationChanges
+	PutChanges       *OperationChanges
+	PostChanges      *OperationChanges
+	DeleteChanges    *OperationChanges
+	OptionsChanges   *OperationChanges
+	HeadChanges      *OperationChanges
+	PatchChanges     *OperationChanges
+	TraceChanges     *OperationChanges
+	ServerChanges    []*ServerChanges
+	ParameterChanges []*ParameterChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two PathItem objects.
+func (p *PathItemChanges) TotalChanges() int {
+	c := p.PropertyChanges.TotalChanges()
+	for _, op := range p.operationChanges() {
+		c += op.TotalChanges()
+	}
+	for k := range p.ServerChanges {
+		c += p.ServerChanges[k].TotalChanges()
+	}
+	for k := range p.ParameterChanges {
+		c += p.ParameterChanges[k].TotalChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two PathItem objects.
+func (p *PathItemChanges) TotalBreakingChanges() int {
+	c := p.PropertyChanges.TotalBreakingChanges()
+	for _, op := range p.operationChanges() {
+		c += op.TotalBreakingChanges()
+	}
+	for k := range p.ServerChanges {
+		c += p.ServerChanges[k].TotalBreakingChanges()
+	}
+	for k := range p.ParameterChanges {
+		c += p.ParameterChanges[k].TotalBreakingChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// operationChanges returns all non-nil operation changes held by the PathItemChanges.
+func (p *PathItemChanges) operationChanges() []*OperationChanges {
+	var ops []*OperationChanges
+	for _, op := range []*OperationChanges{p.GetChanges, p.PutChanges, p.PostChanges, p.DeleteChanges,
+		p.OptionsChanges, p.HeadChanges, p.PatchChanges, p.TraceChanges} {
+		if op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// ComparePathItems compares a left (original) and right (new) PathItem object for changes. Removing an operation
+// is a breaking change, adding one is not. If changes are found, a pointer to PathItemChanges is returned,
+// otherwise nil is returned.
+func ComparePathItems(l, r *v3.PathItem) *PathItemChanges {
+	return comparePathItems(l, r, nil)
+}
+
+// comparePathItems compares two PathItem objects, path parameters renamed along with the path template of the
+// PathItem are paired using renames.
+func comparePathItems(l, r *v3.PathItem, renames map[string]string) *PathItemChanges {
+	var changes []*Change[*v3.PathItem]
+	var props []*PropertyCheck[*v3.PathItem]
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.PathItem]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// Summary
+	props = append(props, &PropertyCheck[*v3.PathItem]{
+		LeftNode:  l.Summary.ValueNode,
+		RightNode: r.Summary.ValueNode,
+		Label:     v3.SummaryLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	pc := new(PathItemChanges)
+
+	checkOperation := func(lOp, rOp low.NodeReference[*v3.Operation], label string) *OperationChanges {
+		if lOp.Value != nil && rOp.Value == nil {
+			CreateChange[*v3.PathItem](&changes, ObjectRemoved, label,
+				lOp.ValueNode, nil, true, lOp.Value, nil)
+			return nil
+		}
+		if lOp.Value == nil && rOp.Value != nil {
+			CreateChange[*v3.PathItem](&changes, ObjectAdded, label,
+				nil, rOp.ValueNode, false, nil, rOp.Value)
+			return nil
+		}
+		if lOp.Value != nil && rOp.Value != nil {
+			return compareOperations(lOp.Value, rOp.Value, renames)
+		}
+		return nil
+	}
+
+	// operations
+	pc.GetChanges = checkOperation(l.Get, r.Get, v3.GetLabel)
+	pc.PutChanges = checkOperation(l.Put, r.Put, v3.PutLabel)
+	pc.PostChanges = checkOperation(l.Post, r.Post, v3.PostLabel)
+	pc.DeleteChanges = checkOperation(l.Delete, r.Delete, v3.DeleteLabel)
+	pc.OptionsChanges = checkOperation(l.Options, r.Options, v3.OptionsLabel)
+	pc.HeadChanges = checkOperation(l.Head, r.Head, v3.HeadLabel)
+	pc.PatchChanges = checkOperation(l.Patch, r.Patch, v3.PatchLabel)
+	pc.TraceChanges = checkOperation(l.Trace, r.Trace, v3.TraceLabel)
+
+	// servers
+	pc.ServerChanges = checkServers(l.Servers.Value, r.Servers.Value, &changes)
+
+	// parameters
+	pc.ParameterChanges = checkParameters(l.Parameters.Value, r.Parameters.Value, &changes, renames)
+
+	pc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	pc.Changes = changes
+	if pc.TotalChanges() <= 0 {
+		return nil
+	}
+	return pc
+}
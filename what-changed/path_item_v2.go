@@ -0,0 +1,132 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// PathItemChangesV2 represents changes found between two Swagger PathItem objects.
+type PathItemChangesV2 struct {
+	PropertyChanges[*v2.PathItem]
+	GetChanges       *OperationChangesV2
+	PutChanges       *OperationChangesV2
+	PostChanges      *OperationChangesV2
+	DeleteChanges    *OperationChangesV2
+	OptionsChanges   *OperationChangesV2
+	HeadChanges      *OperationChangesV2
+	PatchChanges     *OperationChangesV2
+	ParameterChanges []*ParameterChangesV2
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger PathItem objects.
+func (p *PathItemChangesV2) TotalChanges() int {
+	c := p.PropertyChanges.TotalChanges()
+	for _, op := range p.operationChanges() {
+		c += op.TotalChanges()
+	}
+	for k := range p.ParameterChanges {
+		c += p.ParameterChanges[k].TotalChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger PathItem objects.
+func (p *PathItemChangesV2) TotalBreakingChanges() int {
+	c := p.PropertyChanges.TotalBreakingChanges()
+	for _, op := range p.operationChanges() {
+		c += op.TotalBreakingChanges()
+	}
+	for k := range p.ParameterChanges {
+		c += p.ParameterChanges[k].TotalBreakingChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// operationChanges returns all non-nil operation changes held by the PathItemChangesV2.
+func (p *PathItemChangesV2) operationChanges() []*OperationChangesV2 {
+	var ops []*OperationChangesV2
+	for _, op := range []*OperationChangesV2{p.GetChanges, p.PutChanges, p.PostChanges, p.DeleteChanges,
+		p.OptionsChanges, p.HeadChanges, p.PatchChanges} {
+		if op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// ComparePathItemsV2 compares a left (original) and right (new) Swagger PathItem object for changes. Removing an
+// operation is a breaking change, adding one is not. If changes are found, a pointer to PathItemChangesV2 is
+// returned, otherwise nil is returned.
+func ComparePathItemsV2(l, r *v2.PathItem) *PathItemChangesV2 {
+	return comparePathItemsV2(l, r, nil)
+}
+
+// comparePathItemsV2 compares two Swagger PathItem objects, path parameters renamed along with the path template of
+// the PathItem are paired using renames.
+func comparePathItemsV2(l, r *v2.PathItem, renames map[string]string) *PathItemChangesV2 {
+	var changes []*Change[*v2.PathItem]
+	var props []*PropertyCheck[*v2.PathItem]
+
+	// $ref
+	props = append(props, &PropertyCheck[*v2.PathItem]{
+		LeftNode:  l.Ref.ValueNode,
+		RightNode: r.Ref.ValueNode,
+		Label:     v3.RefLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	pc := new(PathItemChangesV2)
+
+	checkOperation := func(lOp, rOp low.NodeReference[*v2.Operation], label string) *OperationChangesV2 {
+		if lOp.Value != nil && rOp.Value == nil {
+			CreateChange[*v2.PathItem](&changes, ObjectRemoved, label,
+				lOp.ValueNode, nil, true, lOp.Value, nil)
+			return nil
+		}
+		if lOp.Value == nil && rOp.Value != nil {
+			CreateChange[*v2.PathItem](&changes, ObjectAdded, label,
+				nil, rOp.ValueNode, false, nil, rOp.Value)
+			return nil
+		}
+		if lOp.Value != nil && rOp.Value != nil {
+			return compareOperationsV2(lOp.Value, rOp.Value, renames)
+		}
+		return nil
+	}
+
+	// operations
+	pc.GetChanges = checkOperation(l.Get, r.Get, v2.GetLabel)
+	pc.PutChanges = checkOperation(l.Put, r.Put, v2.PutLabel)
+	pc.PostChanges = checkOperation(l.Post, r.Post, v2.PostLabel)
+	pc.DeleteChanges = checkOperation(l.Delete, r.Delete, v2.DeleteLabel)
+	pc.OptionsChanges = checkOperation(l.Options, r.Options, v2.OptionsLabel)
+	pc.HeadChanges = checkOperation(l.Head, r.Head, v2.HeadLabel)
+	pc.PatchChanges = checkOperation(l.Patch, r.Patch, v2.PatchLabel)
+
+	// parameters
+	pc.ParameterChanges = checkParametersV2(l.Parameters.Value, r.Parameters.Value, &changes, renames)
+
+	pc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	pc.Changes = changes
+	if pc.TotalChanges() <= 0 {
+		return nil
+	}
+	return pc
+}
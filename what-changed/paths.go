@@ -0,0 +1,60 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// PathsChanges represents changes found between two OpenAPI Paths Objects.
+type PathsChanges struct {
+	PropertyChanges[*v3.Paths]
+	PathItemsChanges map[string]*PathItemChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two OpenAPI Paths Objects
+func (p *PathsChanges) TotalChanges() int {
+	c := p.PropertyChanges.TotalChanges()
+	for k := range p.PathItemsChanges {
+		c += p.PathItemsChanges[k].TotalChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two OpenAPI Paths Objects
+func (p *PathsChanges) TotalBreakingChanges() int {
+	c := p.PropertyChanges.TotalBreakingChanges()
+	for k := range p.PathItemsChanges {
+		c += p.PathItemsChanges[k].TotalBreakingChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// ComparePaths compares a left (original) and right (new) Paths object for changes. Removing a path is a breaking
+// change, adding one is not. If changes are found, a pointer to PathsChanges is returned, otherwise nil is returned.
+func ComparePaths(l, r *v3.Paths) *PathsChanges {
+	var changes []*Change[*v3.Paths]
+
+	pc := new(PathsChanges)
+	// a renamed path template pairs the path parameters that were renamed with it.
+	lPaths, rPaths := pathTemplates(l.PathItems), pathTemplates(r.PathItems)
+	pc.PathItemsChanges = checkMapForChanges(l.PathItems, r.PathItems, &changes, false, true, templateMoves,
+		func(lp, rp *v3.PathItem) *PathItemChanges {
+			return comparePathItems(lp, rp, pathParameterRenames(lPaths[lp], rPaths[rp]))
+		})
+
+	pc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	pc.Changes = changes
+	if pc.TotalChanges() <= 0 {
+		return nil
+	}
+	return pc
+}
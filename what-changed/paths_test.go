@@ -0,0 +1,99 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestComparePaths_Identical(t *testing.T) {
+
+	left := `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+  /burgers:
+    get:
+      operationId: getBurgers`
+
+	right := left
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+	assert.Nil(t, changes)
+}
+
+func TestComparePaths_AddedAndRemoved(t *testing.T) {
+
+	left := `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+  /burgers:
+    get:
+      operationId: getBurgers`
+
+	right := `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+  /fries:
+    get:
+      operationId: getFries
+  x-menu: lunch`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Equal(t, ObjectRemoved, changes.Changes[0].ChangeType)
+	assert.Equal(t, "/burgers", changes.Changes[0].Property)
+	assert.Equal(t, ObjectAdded, changes.Changes[1].ChangeType)
+	assert.Equal(t, "/fries", changes.Changes[1].Property)
+	assert.NotNil(t, changes.ExtensionChanges)
+}
+
+func TestComparePaths_OperationModified(t *testing.T) {
+
+	left := `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      deprecated: false`
+
+	right := `openapi: 3.0.1
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      deprecated: true`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	// compare.
+	changes := ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	assert.NotNil(t, changes.PathItemsChanges["/pizza"].GetChanges)
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+)
+
+// PathsChangesV2 represents changes found between two Swagger Paths Objects.
+type PathsChangesV2 struct {
+	PropertyChanges[*v2.Paths]
+	PathItemsChanges map[string]*PathItemChangesV2
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger Paths Objects
+func (p *PathsChangesV2) TotalChanges() int {
+	c := p.PropertyChanges.TotalChanges()
+	for k := range p.PathItemsChanges {
+		c += p.PathItemsChanges[k].TotalChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger Paths Objects
+func (p *PathsChangesV2) TotalBreakingChanges() int {
+	c := p.PropertyChanges.TotalBreakingChanges()
+	for k := range p.PathItemsChanges {
+		c += p.PathItemsChanges[k].TotalBreakingChanges()
+	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// ComparePathsV2 compares a left (original) and right (new) Swagger Paths object for changes. Removing a path is a
+// breaking change, adding one is not. If changes are found, a pointer to PathsChangesV2 is returned, otherwise nil
+// is returned.
+func ComparePathsV2(l, r *v2.Paths) *PathsChangesV2 {
+	var changes []*Change[*v2.Paths]
+
+	pc := new(PathsChangesV2)
+	// a renamed path template pairs the path parameters that were renamed with it.
+	lPaths, rPaths := pathTemplates(l.PathItems), pathTemplates(r.PathItems)
+	pc.PathItemsChanges = checkMapForChanges(l.PathItems, r.PathItems, &changes, false, true, templateMoves,
+		func(lp, rp *v2.PathItem) *PathItemChangesV2 {
+			return comparePathItemsV2(lp, rp, pathParameterRenames(lPaths[lp], rPaths[rp]))
+		})
+
+	pc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	pc.Changes = changes
+	if pc.TotalChanges() <= 0 {
+		return nil
+	}
+	return pc
+}
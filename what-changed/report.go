@@ -0,0 +1,188 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ReportedChange is a single Change found in a tree of changes, flattened so it can be rendered into a report.
+// The Path holds the location of the object that owns the changed property, for example
+//
+//	paths, /pizza, get, responses, 200
+type ReportedChange struct {
+	Path       []string
+	Property   string
+	ChangeType int
+	Original   string
+	New        string
+	Breaking   bool
+	Context    *ChangeContext
+}
+
+// reportContainerTypes are change types that only hold a map of changes keyed by name, the name of the map is
+// not included in the path of a ReportedChange.
+var reportContainerTypes = map[string]bool{
+	"PathsChanges":                true,
+	"PathsChangesV2":              true,
+	"ResponsesChanges":            true,
+	"ResponsesChangesV2":          true,
+	"CallbackChanges":             true,
+	"DefinitionsChanges":          true,
+	"ParameterDefinitionsChanges": true,
+	"ResponsesDefinitionsChanges": true,
+	"SecurityDefinitionsChanges":  true,
+}
+
+// reportLabels maps the change fields that do not share a name with the property they represent.
+var reportLabels = map[string]string{
+	"ExtensionChanges":            "",
+	"OAuthFlowChanges":            "flows",
+	"ServerChanges":               "servers",
+	"ServerVariableChanges":       "variables",
+	"TagChanges":                  "tags",
+	"WebhookChanges":              "webhooks",
+	"CallbackChanges":             "callbacks",
+	"LinkChanges":                 "links",
+	"SecuritySchemeChanges":       "securitySchemes",
+	"SecurityRequirementChanges":  "security",
+	"ParameterChanges":            "parameters",
+	"ParameterDefinitionsChanges": "parameters",
+	"ResponsesDefinitionsChanges": "responses",
+	"HeaderChanges":               "headers",
+	"ExampleChanges":              "examples",
+	"SchemaPropertyChanges":       "properties",
+	"ExternalDocChanges":          "externalDocs",
+	"MappingChanges":              "mapping",
+}
+
+// reportMapLabels maps change fields that hold a map, when the field name is singular.
+var reportMapLabels = map[string]string{
+	"SchemaChanges":      "schemas",
+	"RequestBodyChanges": "requestBodies",
+	"ResponseChanges":    "responses",
+}
+
+// ReportedChanges will walk the entire tree of changes held by WhatChanged, and return every Change found as a
+// ReportedChange. The results are sorted by path and property, so they are stable between runs.
+func (w *WhatChanged) ReportedChanges() []*ReportedChange {
+	var reported []*ReportedChange
+	if w == nil {
+		return reported
+	}
+	if w.Changes != nil {
+		collectChanges(reflect.ValueOf(w.Changes), nil, &reported)
+	}
+	if w.SwaggerChanges != nil {
+		collectChanges(reflect.ValueOf(w.SwaggerChanges), nil, &reported)
+	}
+	sort.SliceStable(reported, func(i, j int) bool {
+		a, b := reported[i], reported[j]
+		if pa, pb := strings.Join(a.Path, "\x00"), strings.Join(b.Path, "\x00"); pa != pb {
+			return pa < pb
+		}
+		if a.Property != b.Property {
+			return a.Property < b.Property
+		}
+		if a.ChangeType != b.ChangeType {
+			return a.ChangeType < b.ChangeType
+		}
+		if a.Original != b.Original {
+			return a.Original < b.Original
+		}
+		return a.New < b.New
+	})
+	return reported
+}
+
+// collectChanges walks a tree of change objects and adds each Change found to reported, along with the path of
+// labels used to reach it. Like countChanges, a Change is never walked into.
+func collectChanges(v reflect.Value, path []string, reported *[]*ReportedChange) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectChanges(v.Elem(), path, reported)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			p := path
+			if v.Index(i).Kind() != reflect.Pointer || v.Index(i).Elem().Kind() != reflect.Struct ||
+				!isChange(v.Index(i).Elem()) {
+				p = appendPath(path, fmt.Sprint(i))
+			}
+			collectChanges(v.Index(i), p, reported)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, k := range keys {
+			collectChanges(v.MapIndex(k), appendPath(path, fmt.Sprint(k.Interface())), reported)
+		}
+	case reflect.Struct:
+		if isChange(v) {
+			r := &ReportedChange{
+				Path:       path,
+				Property:   v.FieldByName("Property").String(),
+				ChangeType: int(v.FieldByName("ChangeType").Int()),
+				Original:   v.FieldByName("Original").String(),
+				New:        v.FieldByName("New").String(),
+				Breaking:   v.FieldByName("Breaking").Bool(),
+			}
+			if ctx, ok := v.FieldByName("Context").Interface().(*ChangeContext); ok {
+				r.Context = ctx
+			}
+			*reported = append(*reported, r)
+			return
+		}
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if f.Anonymous {
+				collectChanges(v.Field(i), path, reported)
+				continue
+			}
+			if !strings.HasSuffix(f.Name, "Changes") {
+				continue
+			}
+			collectChanges(v.Field(i), appendPath(path, reportLabel(t.Name(), f, v.Field(i).Kind())), reported)
+		}
+	}
+}
+
+// isChange returns true if the value is a Change.
+func isChange(v reflect.Value) bool {
+	ct := v.FieldByName("ChangeType")
+	return ct.IsValid() && ct.Kind() == reflect.Int
+}
+
+// reportLabel returns the label used in a path for a field of a change object, an empty label is not added.
+func reportLabel(parent string, f reflect.StructField, kind reflect.Kind) string {
+	if kind == reflect.Map && reportContainerTypes[parent] {
+		return ""
+	}
+	if kind == reflect.Map {
+		if l, ok := reportMapLabels[f.Name]; ok {
+			return l
+		}
+	}
+	if l, ok := reportLabels[f.Name]; ok {
+		return l
+	}
+	return lowerCamel(strings.TrimSuffix(f.Name, "Changes"))
+}
+
+// appendPath returns a new path with the label added, the supplied path is never modified.
+func appendPath(path []string, label string) []string {
+	if label == "" {
+		return path
+	}
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, label)
+}
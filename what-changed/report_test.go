@@ -0,0 +1,76 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWhatChanged_ReportedChanges(t *testing.T) {
+
+	left := `openapi: 3.0.1
+info:
+  title: pizza shop
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string`
+
+	right := `openapi: 3.0.1
+info:
+  title: pizza shop
+  version: 1.0.1
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: integer`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+
+	reported := CompareOpenAPIDocuments(lDoc, rDoc).ReportedChanges()
+	assert.Len(t, reported, 2)
+
+	assert.Equal(t, []string{"info"}, reported[0].Path)
+	assert.Equal(t, "version", reported[0].Property)
+	assert.Equal(t, "1.0.1", reported[0].New)
+	assert.False(t, reported[0].Breaking)
+
+	assert.Equal(t, []string{"paths", "/pizza", "get", "responses", "200", "content", "application/json",
+		"schema", "properties", "name"}, reported[1].Path)
+	assert.Equal(t, "type", reported[1].Property)
+	assert.Equal(t, "string", reported[1].Original)
+	assert.True(t, reported[1].Breaking)
+	assert.Equal(t, 17, reported[1].Context.NewLine)
+}
+
+func TestWhatChanged_ReportedChanges_Nil(t *testing.T) {
+	var wc *WhatChanged
+	assert.Len(t, wc.ReportedChanges(), 0)
+	assert.Len(t, CreateWhatChanged(nil).ReportedChanges(), 0)
+}
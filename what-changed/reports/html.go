@@ -0,0 +1,183 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"html/template"
+	"strings"
+)
+
+// HTMLOptions configures how an HTML report is rendered.
+type HTMLOptions struct {
+	// Title is used as the title of the report, defaults to 'What Changed'.
+	Title string
+
+	// OriginalSpec and NewSpec are the bytes of the compared specifications. If supplied, the specification is
+	// embedded in the report, and every change links to the line in the embedded specification.
+	OriginalSpec []byte
+	NewSpec      []byte
+
+	// OriginalURL and NewURL are used to link to lines of specifications that are not embedded, '#L<line>' is
+	// appended to the URL (which works for GitHub and most source browsers).
+	OriginalURL string
+	NewURL      string
+}
+
+type htmlPosition struct {
+	Label string
+	Link  string
+}
+
+type htmlChange struct {
+	Location    string
+	Property    string
+	ChangeType  string
+	Description string
+	Breaking    bool
+	Original    *htmlPosition
+	New         *htmlPosition
+}
+
+type htmlLine struct {
+	ID     string
+	Number int
+	Text   string
+}
+
+type htmlSpec struct {
+	Title string
+	Lines []htmlLine
+}
+
+type htmlReport struct {
+	Title     string
+	Summary   *what_changed.WhatChanged
+	Breaking  []*htmlChange
+	Changes   []*htmlChange
+	Specs     []*htmlSpec
+	HasChange bool
+}
+
+// RenderHTML renders a WhatChanged result into a self-contained HTML report, with no external resources. Each change
+// links to the line and column of the original and new values, using the ChangeContext of the change.
+func RenderHTML(changes *what_changed.WhatChanged, options *HTMLOptions) ([]byte, error) {
+	if options == nil {
+		options = new(HTMLOptions)
+	}
+	if changes == nil {
+		changes = new(what_changed.WhatChanged)
+	}
+	report := &htmlReport{Title: options.Title, Summary: changes}
+	if report.Title == "" {
+		report.Title = "What Changed"
+	}
+	for _, c := range changes.ReportedChanges() {
+		hc := &htmlChange{
+			Location:    location(c),
+			Property:    c.Property,
+			ChangeType:  ChangeTypeName(c.ChangeType),
+			Description: describe(c),
+			Breaking:    c.Breaking,
+		}
+		if c.Context != nil {
+			hc.Original = htmlLink("original", c.Context.OriginalLine, c.Context.OriginalColumn,
+				options.OriginalSpec, options.OriginalURL)
+			hc.New = htmlLink("new", c.Context.NewLine, c.Context.NewColumn, options.NewSpec, options.NewURL)
+		}
+		if c.Breaking {
+			report.Breaking = append(report.Breaking, hc)
+		}
+		report.Changes = append(report.Changes, hc)
+	}
+	report.HasChange = len(report.Changes) > 0
+	if options.OriginalSpec != nil {
+		report.Specs = append(report.Specs, htmlSpecLines("original", "Original Specification", options.OriginalSpec))
+	}
+	if options.NewSpec != nil {
+		report.Specs = append(report.Specs, htmlSpecLines("new", "New Specification", options.NewSpec))
+	}
+
+	var b bytes.Buffer
+	if err := htmlTemplate.Execute(&b, report); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// htmlLink creates a position for a line and column, linked to an embedded specification or a URL.
+func htmlLink(id string, line, column int, spec []byte, url string) *htmlPosition {
+	if !hasLine(line) {
+		return nil
+	}
+	p := &htmlPosition{Label: fmt.Sprintf("%d:%d", line, column)}
+	if spec != nil {
+		p.Link = fmt.Sprintf("#%s-L%d", id, line)
+	} else if url != "" {
+		p.Link = fmt.Sprintf("%s#L%d", url, line)
+	}
+	return p
+}
+
+// htmlSpecLines splits a specification into lines, each line has an ID that changes link to.
+func htmlSpecLines(id, title string, spec []byte) *htmlSpec {
+	s := &htmlSpec{Title: title}
+	for i, l := range strings.Split(string(spec), "\n") {
+		s.Lines = append(s.Lines, htmlLine{ID: fmt.Sprintf("%s-L%d", id, i+1), Number: i + 1, Text: l})
+	}
+	return s
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+tr.breaking td { background: #fdecea; }
+.breaking-label { color: #b00020; font-weight: bold; }
+pre { background: #f8f8f8; padding: 0; overflow-x: auto; }
+pre span { display: block; padding: 0 0.6em; }
+pre span:target { background: #fff3b0; }
+pre span i { display: inline-block; width: 4em; color: #999; font-style: normal; user-select: none; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<table class="summary">
+<tr><th>Total</th><th>Breaking</th><th>Added</th><th>Modified</th><th>Removed</th><th>Moved</th><th>Modified and Moved</th></tr>
+<tr><td>{{ .Summary.TotalChanges }}</td><td>{{ .Summary.TotalBreakingChanges }}</td><td>{{ .Summary.Added }}</td><td>{{ .Summary.Modified }}</td><td>{{ .Summary.Removed }}</td><td>{{ .Summary.Moved }}</td><td>{{ .Summary.ModifiedAndMoved }}</td></tr>
+</table>
+{{- define "position" }}{{ if . }}{{ if .Link }}<a href="{{ .Link }}">{{ .Label }}</a>{{ else }}{{ .Label }}{{ end }}{{ end }}{{ end }}
+{{- define "changes" }}
+<table>
+<tr><th>Location</th><th>Property</th><th>Change</th><th>Description</th><th>Original</th><th>New</th></tr>
+{{- range . }}
+<tr{{ if .Breaking }} class="breaking"{{ end }}><td>{{ .Location }}</td><td>{{ .Property }}</td><td>{{ .ChangeType }}{{ if .Breaking }} <span class="breaking-label">breaking</span>{{ end }}</td><td>{{ .Description }}</td><td>{{ template "position" .Original }}</td><td>{{ template "position" .New }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if not .HasChange }}
+<p>No changes found.</p>
+{{- else }}
+{{- if .Breaking }}
+<h2>Breaking Changes</h2>
+{{- template "changes" .Breaking }}
+{{- end }}
+<h2>Changes</h2>
+{{- template "changes" .Changes }}
+{{- end }}
+{{- range .Specs }}
+<h2>{{ .Title }}</h2>
+<pre>{{ range .Lines }}<span id="{{ .ID }}"><i>{{ .Number }}</i>{{ .Text }}</span>{{ end }}</pre>
+{{- end }}
+</body>
+</html>
+`))
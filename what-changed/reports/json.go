@@ -0,0 +1,85 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"encoding/json"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+)
+
+// JSONReport is the document rendered by RenderJSON. The structure is stable and intended for machine consumption.
+type JSONReport struct {
+	Summary JSONSummary  `json:"summary"`
+	Changes []JSONChange `json:"changes"`
+}
+
+// JSONSummary holds the totals of a JSONReport.
+type JSONSummary struct {
+	Total            int `json:"total"`
+	Breaking         int `json:"breaking"`
+	Added            int `json:"added"`
+	Modified         int `json:"modified"`
+	Removed          int `json:"removed"`
+	Moved            int `json:"moved"`
+	ModifiedAndMoved int `json:"modifiedAndMoved"`
+}
+
+// JSONChange is a single change in a JSONReport. Lines and columns are omitted when they are not known.
+type JSONChange struct {
+	Path           []string `json:"path"`
+	Property       string   `json:"property"`
+	Change         string   `json:"change"`
+	Breaking       bool     `json:"breaking"`
+	Original       string   `json:"original,omitempty"`
+	New            string   `json:"new,omitempty"`
+	OriginalLine   int      `json:"originalLine,omitempty"`
+	OriginalColumn int      `json:"originalColumn,omitempty"`
+	NewLine        int      `json:"newLine,omitempty"`
+	NewColumn      int      `json:"newColumn,omitempty"`
+}
+
+// CreateJSONReport creates a JSONReport from a WhatChanged result.
+func CreateJSONReport(changes *what_changed.WhatChanged) *JSONReport {
+	report := &JSONReport{Changes: []JSONChange{}}
+	if changes == nil {
+		return report
+	}
+	report.Summary = JSONSummary{
+		Total:            changes.TotalChanges,
+		Breaking:         changes.TotalBreakingChanges,
+		Added:            changes.Added,
+		Modified:         changes.Modified,
+		Removed:          changes.Removed,
+		Moved:            changes.Moved,
+		ModifiedAndMoved: changes.ModifiedAndMoved,
+	}
+	for _, c := range changes.ReportedChanges() {
+		jc := JSONChange{
+			Path:     c.Path,
+			Property: c.Property,
+			Change:   changeTypeKey(c.ChangeType),
+			Breaking: c.Breaking,
+			Original: c.Original,
+			New:      c.New,
+		}
+		if jc.Path == nil {
+			jc.Path = []string{}
+		}
+		if c.Context != nil {
+			if hasLine(c.Context.OriginalLine) {
+				jc.OriginalLine, jc.OriginalColumn = c.Context.OriginalLine, c.Context.OriginalColumn
+			}
+			if hasLine(c.Context.NewLine) {
+				jc.NewLine, jc.NewColumn = c.Context.NewLine, c.Context.NewColumn
+			}
+		}
+		report.Changes = append(report.Changes, jc)
+	}
+	return report
+}
+
+// RenderJSON renders a WhatChanged result into an indented JSON document.
+func RenderJSON(changes *what_changed.WhatChanged) ([]byte, error) {
+	return json.MarshalIndent(CreateJSONReport(changes), "", "  ")
+}
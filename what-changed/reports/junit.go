@@ -0,0 +1,79 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"encoding/xml"
+	"fmt"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// RenderJUnit renders a WhatChanged result into JUnit XML. Every change is a test case, named by the change and
+// classed by the location of the change. Breaking changes are failing test cases, so CI systems that read JUnit
+// reports will fail a build that introduces breaking changes.
+func RenderJUnit(changes *what_changed.WhatChanged, suiteName string) ([]byte, error) {
+	if suiteName == "" {
+		suiteName = "what-changed"
+	}
+	suite := junitTestSuite{Name: suiteName, TestCases: []junitTestCase{}}
+	for _, c := range changes.ReportedChanges() {
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", c.Property, ChangeTypeName(c.ChangeType)),
+			ClassName: location(c),
+		}
+		if c.Breaking {
+			text := describe(c)
+			if c.Context != nil {
+				if hasLine(c.Context.NewLine) {
+					text += fmt.Sprintf(" (line %d, column %d)", c.Context.NewLine, c.Context.NewColumn)
+				} else if hasLine(c.Context.OriginalLine) {
+					text += fmt.Sprintf(" (original line %d, column %d)", c.Context.OriginalLine,
+						c.Context.OriginalColumn)
+				}
+			}
+			tc.Failure = &junitFailure{Message: "breaking change", Type: changeTypeKey(c.ChangeType), Text: text}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	suite.Tests = len(suite.TestCases)
+	suites := junitTestSuites{
+		Name:     suiteName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+	out, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
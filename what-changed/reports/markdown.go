@@ -0,0 +1,88 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"strings"
+)
+
+// RenderMarkdown renders a WhatChanged result into a Markdown changelog. The changelog opens with a summary table,
+// followed by every breaking change and then every change, grouped by the location of the change.
+func RenderMarkdown(changes *what_changed.WhatChanged) []byte {
+	var b bytes.Buffer
+	reported := changes.ReportedChanges()
+
+	b.WriteString("# What Changed\n\n")
+	b.WriteString("| Total | Breaking | Added | Modified | Removed | Moved |\n")
+	b.WriteString("|------:|---------:|------:|---------:|--------:|------:|\n")
+	if changes != nil {
+		b.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %d | %d |\n\n", changes.TotalChanges,
+			changes.TotalBreakingChanges, changes.Added, changes.Modified, changes.Removed,
+			changes.Moved+changes.ModifiedAndMoved))
+	} else {
+		b.WriteString("| 0 | 0 | 0 | 0 | 0 | 0 |\n\n")
+	}
+
+	if len(reported) == 0 {
+		b.WriteString("No changes found.\n")
+		return b.Bytes()
+	}
+
+	var breaking []*what_changed.ReportedChange
+	for _, c := range reported {
+		if c.Breaking {
+			breaking = append(breaking, c)
+		}
+	}
+	if len(breaking) > 0 {
+		b.WriteString("## Breaking Changes\n\n")
+		for _, c := range breaking {
+			b.WriteString(fmt.Sprintf("- **%s**: %s%s\n", escapeMarkdown(location(c)),
+				escapeMarkdown(describe(c)), markdownPosition(c.Context)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Changes\n")
+	current := ""
+	for i, c := range reported {
+		if l := location(c); i == 0 || l != current {
+			current = l
+			b.WriteString(fmt.Sprintf("\n### %s\n\n", escapeMarkdown(current)))
+		}
+		marker := ""
+		if c.Breaking {
+			marker = " **(breaking)**"
+		}
+		b.WriteString(fmt.Sprintf("- %s%s%s\n", escapeMarkdown(describe(c)), markdownPosition(c.Context), marker))
+	}
+	return b.Bytes()
+}
+
+// markdownPosition renders the line and column of the new value of a change, or the original value if the change
+// is a removal.
+func markdownPosition(ctx *what_changed.ChangeContext) string {
+	if ctx == nil {
+		return ""
+	}
+	if hasLine(ctx.NewLine) {
+		return fmt.Sprintf(" _(line %d, column %d)_", ctx.NewLine, ctx.NewColumn)
+	}
+	if hasLine(ctx.OriginalLine) {
+		return fmt.Sprintf(" _(original line %d, column %d)_", ctx.OriginalLine, ctx.OriginalColumn)
+	}
+	return ""
+}
+
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]", "<", "&lt;", ">", "&gt;", "|", "\\|",
+	"\n", " ")
+
+// escapeMarkdown escapes characters that would otherwise be rendered as Markdown.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package reports renders the results of a what-changed comparison into reports. Markdown, HTML, JSON and JUnit XML
+// are supported. Every renderer walks the same flattened and sorted list of changes, so the output of each is
+// stable between runs of the same comparison.
+package reports
+
+import (
+	"fmt"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"strings"
+)
+
+// PathSeparator is used to join the path of a change into a single string.
+const PathSeparator = " > "
+
+// ChangeTypeName returns a human-readable name for a change type.
+func ChangeTypeName(changeType int) string {
+	switch changeType {
+	case what_changed.Modified:
+		return "modified"
+	case what_changed.PropertyAdded:
+		return "property added"
+	case what_changed.ObjectAdded:
+		return "object added"
+	case what_changed.ObjectRemoved:
+		return "object removed"
+	case what_changed.PropertyRemoved:
+		return "property removed"
+	case what_changed.Moved:
+		return "moved"
+	case what_changed.ModifiedAndMoved:
+		return "modified and moved"
+	}
+	return "unknown"
+}
+
+// changeTypeKey returns a machine-readable name for a change type.
+func changeTypeKey(changeType int) string {
+	return strings.ReplaceAll(ChangeTypeName(changeType), " ", "_")
+}
+
+// location returns the path of a change as a single string, changes without a path belong to the document.
+func location(c *what_changed.ReportedChange) string {
+	if len(c.Path) == 0 {
+		return "document"
+	}
+	return strings.Join(c.Path, PathSeparator)
+}
+
+// describe returns a single sentence describing a change.
+func describe(c *what_changed.ReportedChange) string {
+	switch c.ChangeType {
+	case what_changed.Modified:
+		return fmt.Sprintf("'%s' changed from '%s' to '%s'", c.Property, c.Original, c.New)
+	case what_changed.PropertyAdded, what_changed.ObjectAdded:
+		if c.New != "" {
+			return fmt.Sprintf("'%s' added with value '%s'", c.Property, c.New)
+		}
+		return fmt.Sprintf("'%s' added", c.Property)
+	case what_changed.PropertyRemoved, what_changed.ObjectRemoved:
+		if c.Original != "" {
+			return fmt.Sprintf("'%s' removed, it was '%s'", c.Property, c.Original)
+		}
+		return fmt.Sprintf("'%s' removed", c.Property)
+	case what_changed.Moved:
+		return fmt.Sprintf("'%s' moved to '%s'", c.Original, c.New)
+	case what_changed.ModifiedAndMoved:
+		return fmt.Sprintf("'%s' modified and moved to '%s'", c.Original, c.New)
+	}
+	return fmt.Sprintf("'%s' changed", c.Property)
+}
+
+// hasLine returns true if the line is a known position.
+func hasLine(line int) bool {
+	return line > 0
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+var reportLeft = `openapi: 3.0.1
+info:
+  title: pizza shop
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      responses:
+        "200":
+          description: a pizza`
+
+var reportRight = `openapi: 3.0.1
+info:
+  title: pizza shop
+  version: 1.0.1
+paths:
+  /pizza:
+    get:
+      operationId: fetchPizza
+      responses:
+        "200":
+          description: a <tasty> pizza`
+
+func compareReportSpecs(left, right string) *what_changed.WhatChanged {
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv3.CreateDocument(lInfo)
+	rDoc, _ := lowv3.CreateDocument(rInfo)
+	return what_changed.CompareOpenAPIDocuments(lDoc, rDoc)
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	md := string(RenderMarkdown(compareReportSpecs(reportLeft, reportRight)))
+	assert.Contains(t, md, "| 3 | 1 | 0 | 3 | 0 | 0 |")
+	assert.Contains(t, md, "## Breaking Changes")
+	assert.Contains(t, md, "- **paths &gt; /pizza &gt; get**: 'operationId' changed from 'getPizza' to 'fetchPizza'"+
+		" _(line 8, column 20)_")
+	assert.Contains(t, md, "### info")
+	assert.Contains(t, md, "a &lt;tasty&gt; pizza")
+}
+
+func TestRenderMarkdown_NoChanges(t *testing.T) {
+	md := string(RenderMarkdown(compareReportSpecs(reportLeft, reportLeft)))
+	assert.Contains(t, md, "No changes found.")
+	assert.NotContains(t, md, "## Changes")
+}
+
+func TestRenderHTML_EmbeddedSpecs(t *testing.T) {
+	out, err := RenderHTML(compareReportSpecs(reportLeft, reportRight), &HTMLOptions{
+		Title:        "Pizza Changes",
+		OriginalSpec: []byte(reportLeft),
+		NewSpec:      []byte(reportRight),
+	})
+	assert.NoError(t, err)
+	html := string(out)
+	assert.Contains(t, html, "<title>Pizza Changes</title>")
+	assert.Contains(t, html, `<a href="#new-L8">8:20</a>`)
+	assert.Contains(t, html, `<a href="#original-L8">8:20</a>`)
+	assert.Contains(t, html, `<span id="new-L8">`)
+	assert.Contains(t, html, "a &lt;tasty&gt; pizza")
+	assert.Contains(t, html, "<h2>Breaking Changes</h2>")
+}
+
+func TestRenderHTML_URLs(t *testing.T) {
+	out, err := RenderHTML(compareReportSpecs(reportLeft, reportRight), &HTMLOptions{
+		NewURL: "https://github.com/pb33f/pizza/blob/main/openapi.yaml",
+	})
+	assert.NoError(t, err)
+	html := string(out)
+	assert.Contains(t, html, "<title>What Changed</title>")
+	assert.Contains(t, html, `<a href="https://github.com/pb33f/pizza/blob/main/openapi.yaml#L8">8:20</a>`)
+	assert.NotContains(t, html, `href="#original-L8"`)
+}
+
+func TestRenderHTML_Nil(t *testing.T) {
+	out, err := RenderHTML(nil, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "No changes found.")
+}
+
+func TestRenderJSON(t *testing.T) {
+	wc := compareReportSpecs(reportLeft, reportRight)
+	out, err := RenderJSON(wc)
+	assert.NoError(t, err)
+
+	var report JSONReport
+	assert.NoError(t, json.Unmarshal(out, &report))
+	assert.Equal(t, 3, report.Summary.Total)
+	assert.Equal(t, 1, report.Summary.Breaking)
+	assert.Len(t, report.Changes, 3)
+	assert.Equal(t, []string{"info"}, report.Changes[0].Path)
+	assert.Equal(t, "modified", report.Changes[0].Change)
+	assert.Equal(t, []string{"paths", "/pizza", "get"}, report.Changes[1].Path)
+	assert.Equal(t, "operationId", report.Changes[1].Property)
+	assert.True(t, report.Changes[1].Breaking)
+	assert.Equal(t, 8, report.Changes[1].NewLine)
+
+	// rendering the same comparison again is identical.
+	again, _ := RenderJSON(compareReportSpecs(reportLeft, reportRight))
+	assert.Equal(t, string(out), string(again))
+}
+
+func TestRenderJSON_Nil(t *testing.T) {
+	out, err := RenderJSON(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"changes": []`)
+}
+
+func TestRenderJUnit(t *testing.T) {
+	out, err := RenderJUnit(compareReportSpecs(reportLeft, reportRight), "")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), xml.Header))
+
+	var suites junitTestSuites
+	assert.NoError(t, xml.Unmarshal(out, &suites))
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 1, suites.Failures)
+	assert.Equal(t, "what-changed", suites.Suites[0].Name)
+
+	var failed []junitTestCase
+	for _, tc := range suites.Suites[0].TestCases {
+		if tc.Failure != nil {
+			failed = append(failed, tc)
+		}
+	}
+	assert.Len(t, failed, 1)
+	assert.Equal(t, "operationId modified", failed[0].Name)
+	assert.Equal(t, "paths > /pizza > get", failed[0].ClassName)
+	assert.Contains(t, failed[0].Failure.Text, "(line 8, column 20)")
+}
+
+func TestChangeTypeName(t *testing.T) {
+	assert.Equal(t, "object added", ChangeTypeName(what_changed.ObjectAdded))
+	assert.Equal(t, "property removed", ChangeTypeName(what_changed.PropertyRemoved))
+	assert.Equal(t, "unknown", ChangeTypeName(99))
+}
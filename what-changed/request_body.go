@@ -0,0 +1,76 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// RequestBodyChanges represents changes made between two OpenAPI RequestBody Objects
+type RequestBodyChanges struct {
+	PropertyChanges[*v3.RequestBody]
+	ContentChanges   map[string]*MediaTypeChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two OpenAPI RequestBody objects
+func (rb *RequestBodyChanges) TotalChanges() int {
+	c := rb.PropertyChanges.TotalChanges()
+	for k := range rb.ContentChanges {
+		c += rb.ContentChanges[k].TotalChanges()
+	}
+	if rb.ExtensionChanges != nil {
+		c += rb.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between OpenAPI RequestBody objects
+func (rb *RequestBodyChanges) TotalBreakingChanges() int {
+	c := rb.PropertyChanges.TotalBreakingChanges()
+	for k := range rb.ContentChanges {
+		c += rb.ContentChanges[k].TotalBreakingChanges()
+	}
+	if rb.ExtensionChanges != nil {
+		c += rb.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareRequestBodies compares a left (original) and right (new) RequestBody object for changes. If found returns
+// a pointer to a RequestBodyChanges instance. Returns nil if nothing was found.
+func CompareRequestBodies(l, r *v3.RequestBody) *RequestBodyChanges {
+	var changes []*Change[*v3.RequestBody]
+	var props []*PropertyCheck[*v3.RequestBody]
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.RequestBody]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// required
+	checkRequired(l.Required.ValueNode, r.Required.ValueNode, &changes, l, r, SchemaDirectionRequest)
+
+	rbc := new(RequestBodyChanges)
+
+	// content, removing a media type is a breaking change.
+	rbc.ContentChanges = CheckMapForChanges(l.Content.Value, r.Content.Value, &changes,
+		false, true, mediaTypeComparison(SchemaDirectionRequest))
+
+	rbc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	rbc.Changes = changes
+	if rbc.TotalChanges() <= 0 {
+		return nil
+	}
+	return rbc
+}
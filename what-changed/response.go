@@ -0,0 +1,153 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// ResponseChanges represents changes found between two OpenAPI Response objects.
+type ResponseChanges struct {
+	PropertyChanges[*v3.Response]
+	HeadersChanges   map[string]*HeaderChanges
+	ContentChanges   map[string]*MediaTypeChanges
+	LinkChanges      map[string]*LinkChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Response objects.
+func (r *ResponseChanges) TotalChanges() int {
+	c := r.PropertyChanges.TotalChanges()
+	for k := range r.HeadersChanges {
+		c += r.HeadersChanges[k].TotalChanges()
+	}
+	for k := range r.ContentChanges {
+		c += r.ContentChanges[k].TotalChanges()
+	}
+	for k := range r.LinkChanges {
+		c += r.LinkChanges[k].TotalChanges()
+	}
+	if r.ExtensionChanges != nil {
+		c += r.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Response objects.
+func (r *ResponseChanges) TotalBreakingChanges() int {
+	c := r.PropertyChanges.TotalBreakingChanges()
+	for k := range r.HeadersChanges {
+		c += r.HeadersChanges[k].TotalBreakingChanges()
+	}
+	for k := range r.ContentChanges {
+		c += r.ContentChanges[k].TotalBreakingChanges()
+	}
+	for k := range r.LinkChanges {
+		c += r.LinkChanges[k].TotalBreakingChanges()
+	}
+	if r.ExtensionChanges != nil {
+		c += r.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareResponse compares a left (original) and right (new) Response object for changes. If changes are found,
+// a pointer to ResponseChanges is returned, otherwise nil is returned.
+func CompareResponse(l, r *v3.Response) *ResponseChanges {
+	var changes []*Change[*v3.Response]
+	var props []*PropertyCheck[*v3.Response]
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.Response]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	rc := new(ResponseChanges)
+
+	// headers, removing a header is a breaking change.
+	rc.HeadersChanges = CheckMapForChanges(l.Headers.Value, r.Headers.Value, &changes,
+		false, true, headerComparison(SchemaDirectionResponse))
+
+	// content, removing a media type is a breaking change.
+	rc.ContentChanges = CheckMapForChanges(l.Content.Value, r.Content.Value, &changes,
+		false, true, mediaTypeComparison(SchemaDirectionResponse))
+
+	// links, removing a link is a breaking change.
+	rc.LinkChanges = CheckMapForChanges(l.Links.Value, r.Links.Value, &changes,
+		false, true, CompareLinks)
+
+	rc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	rc.Changes = changes
+	if rc.TotalChanges() <= 0 {
+		return nil
+	}
+	return rc
+}
+
+// ResponsesChanges represents changes made between two OpenAPI Responses objects.
+type ResponsesChanges struct {
+	PropertyChanges[*v3.Responses]
+	ResponseChanges map[string]*ResponseChanges
+	DefaultChanges  *ResponseChanges
+}
+
+// TotalChanges returns the total number of changes found between two Responses objects.
+func (r *ResponsesChanges) TotalChanges() int {
+	c := r.PropertyChanges.TotalChanges()
+	for k := range r.ResponseChanges {
+		c += r.ResponseChanges[k].TotalChanges()
+	}
+	if r.DefaultChanges != nil {
+		c += r.DefaultChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Responses objects.
+func (r *ResponsesChanges) TotalBreakingChanges() int {
+	c := r.PropertyChanges.TotalBreakingChanges()
+	for k := range r.ResponseChanges {
+		c += r.ResponseChanges[k].TotalBreakingChanges()
+	}
+	if r.DefaultChanges != nil {
+		c += r.DefaultChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareResponses compares a left (original) and right (new) Responses object for changes. Response codes that
+// have been removed are considered breaking, new codes are not. If changes are found, a pointer to
+// ResponsesChanges is returned, otherwise nil is returned.
+func CompareResponses(l, r *v3.Responses) *ResponsesChanges {
+	var changes []*Change[*v3.Responses]
+
+	rc := new(ResponsesChanges)
+
+	// codes (which include 'default' when the low-level model is built)
+	codeChanges := CheckMapForChanges(l.Codes, r.Codes, &changes, false, true, CompareResponse)
+	if codeChanges != nil {
+		if codeChanges[v3.DefaultLabel] != nil {
+			rc.DefaultChanges = codeChanges[v3.DefaultLabel]
+			delete(codeChanges, v3.DefaultLabel)
+		}
+		if len(codeChanges) > 0 {
+			rc.ResponseChanges = codeChanges
+		}
+	}
+
+	rc.Changes = changes
+	if rc.TotalChanges() <= 0 {
+		return nil
+	}
+	return rc
+}
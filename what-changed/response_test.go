@@ -0,0 +1,101 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareResponse_Modified(t *testing.T) {
+
+	left := `description: a pizza
+headers:
+  Pizza-Count:
+    schema:
+      type: integer
+content:
+  application/json:
+    schema:
+      type: object
+links:
+  getOven:
+    operationId: getOven`
+
+	right := `description: a hot pizza
+headers:
+  Pizza-Count:
+    required: true
+    schema:
+      type: integer
+content:
+  application/json:
+    schema:
+      type: object
+links:
+  getOven:
+    operationId: getOvenById`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Response
+	var rDoc v3.Response
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareResponse(&lDoc, &rDoc)
+	assert.Equal(t, 3, extChanges.TotalChanges())
+
+	// a response header becoming required is not breaking.
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.HeadersChanges["Pizza-Count"])
+	assert.NotNil(t, extChanges.LinkChanges["getOven"])
+	assert.Nil(t, extChanges.ContentChanges)
+}
+
+func TestCompareResponses_CodesAndDefault(t *testing.T) {
+
+	left := `"200":
+  description: a pizza
+"404":
+  description: no pizza
+default:
+  description: something went wrong`
+
+	right := `"200":
+  description: a pizza
+"201":
+  description: a new pizza
+default:
+  description: oh no`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Responses
+	var rDoc v3.Responses
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareResponses(&lDoc, &rDoc)
+	assert.Equal(t, 3, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.DefaultChanges)
+	assert.Equal(t, "201", extChanges.Changes[0].Property)
+	assert.Equal(t, "404", extChanges.Changes[1].Property)
+}
@@ -0,0 +1,240 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"gopkg.in/yaml.v3"
+	"sort"
+)
+
+// ResponseChangesV2 represents changes found between two Swagger Response objects.
+type ResponseChangesV2 struct {
+	PropertyChanges[*v2.Response]
+	SchemaChanges    *SchemaChanges
+	HeadersChanges   map[string]*HeaderChangesV2
+	ExamplesChanges  *ExamplesChangesV2
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger Response objects.
+func (r *ResponseChangesV2) TotalChanges() int {
+	c := r.PropertyChanges.TotalChanges()
+	if r.SchemaChanges != nil {
+		c += r.SchemaChanges.TotalChanges()
+	}
+	for k := range r.HeadersChanges {
+		c += r.HeadersChanges[k].TotalChanges()
+	}
+	if r.ExamplesChanges != nil {
+		c += r.ExamplesChanges.TotalChanges()
+	}
+	if r.ExtensionChanges != nil {
+		c += r.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger Response objects.
+func (r *ResponseChangesV2) TotalBreakingChanges() int {
+	c := r.PropertyChanges.TotalBreakingChanges()
+	if r.SchemaChanges != nil {
+		c += r.SchemaChanges.TotalBreakingChanges()
+	}
+	for k := range r.HeadersChanges {
+		c += r.HeadersChanges[k].TotalBreakingChanges()
+	}
+	if r.ExamplesChanges != nil {
+		c += r.ExamplesChanges.TotalBreakingChanges()
+	}
+	if r.ExtensionChanges != nil {
+		c += r.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareResponseV2 compares a left (original) and right (new) Swagger Response object for changes. If changes
+// are found, a pointer to ResponseChangesV2 is returned, otherwise nil is returned.
+func CompareResponseV2(l, r *v2.Response) *ResponseChangesV2 {
+	var changes []*Change[*v2.Response]
+	var props []*PropertyCheck[*v2.Response]
+
+	// Description
+	props = append(props, &PropertyCheck[*v2.Response]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	rc := new(ResponseChangesV2)
+
+	// schema
+	rc.SchemaChanges = checkSchema(l.Schema.Value, r.Schema.Value, l.Schema.ValueNode, r.Schema.ValueNode,
+		&changes, l, r, SchemaDirectionResponse)
+
+	// headers, removing a header is a breaking change.
+	rc.HeadersChanges = CheckMapForChanges(l.Headers.Value, r.Headers.Value, &changes,
+		false, true, CompareHeadersV2)
+
+	// examples
+	if l.Examples.Value != nil && r.Examples.Value != nil {
+		rc.ExamplesChanges = CompareExamplesV2(l.Examples.Value, r.Examples.Value)
+	}
+	if l.Examples.Value != nil && r.Examples.Value == nil {
+		CreateChange[*v2.Response](&changes, ObjectRemoved, v2.ExamplesLabel,
+			l.Examples.ValueNode, nil, false, l.Examples.Value, nil)
+	}
+	if l.Examples.Value == nil && r.Examples.Value != nil {
+		CreateChange[*v2.Response](&changes, ObjectAdded, v2.ExamplesLabel,
+			nil, r.Examples.ValueNode, false, nil, r.Examples.Value)
+	}
+
+	rc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	rc.Changes = changes
+	if rc.TotalChanges() <= 0 {
+		return nil
+	}
+	return rc
+}
+
+// ResponsesChangesV2 represents changes found between two Swagger Responses objects.
+type ResponsesChangesV2 struct {
+	PropertyChanges[*v2.Responses]
+	ResponseChanges  map[string]*ResponseChangesV2
+	DefaultChanges   *ResponseChangesV2
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger Responses objects.
+func (r *ResponsesChangesV2) TotalChanges() int {
+	c := r.PropertyChanges.TotalChanges()
+	for k := range r.ResponseChanges {
+		c += r.ResponseChanges[k].TotalChanges()
+	}
+	if r.DefaultChanges != nil {
+		c += r.DefaultChanges.TotalChanges()
+	}
+	if r.ExtensionChanges != nil {
+		c += r.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger Responses objects.
+func (r *ResponsesChangesV2) TotalBreakingChanges() int {
+	c := r.PropertyChanges.TotalBreakingChanges()
+	for k := range r.ResponseChanges {
+		c += r.ResponseChanges[k].TotalBreakingChanges()
+	}
+	if r.DefaultChanges != nil {
+		c += r.DefaultChanges.TotalBreakingChanges()
+	}
+	if r.ExtensionChanges != nil {
+		c += r.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareResponsesV2 compares a left (original) and right (new) Swagger Responses object for changes. Response
+// codes that have been removed are considered breaking, new codes are not. If changes are found, a pointer to
+// ResponsesChangesV2 is returned, otherwise nil is returned.
+func CompareResponsesV2(l, r *v2.Responses) *ResponsesChangesV2 {
+	var changes []*Change[*v2.Responses]
+
+	rc := new(ResponsesChangesV2)
+
+	// codes (which include 'default' when the low-level model is built)
+	codeChanges := CheckMapForChanges(l.Codes, r.Codes, &changes, false, true, CompareResponseV2)
+	if codeChanges != nil {
+		if codeChanges[v2.DefaultLabel] != nil {
+			rc.DefaultChanges = codeChanges[v2.DefaultLabel]
+			delete(codeChanges, v2.DefaultLabel)
+		}
+		if len(codeChanges) > 0 {
+			rc.ResponseChanges = codeChanges
+		}
+	}
+
+	rc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	rc.Changes = changes
+	if rc.TotalChanges() <= 0 {
+		return nil
+	}
+	return rc
+}
+
+// ExamplesChangesV2 represents changes found between two Swagger Examples objects.
+type ExamplesChangesV2 struct {
+	PropertyChanges[*v2.Examples]
+}
+
+// TotalChanges returns the total number of changes found between two Swagger Examples objects.
+func (e *ExamplesChangesV2) TotalChanges() int {
+	return e.PropertyChanges.TotalChanges()
+}
+
+// TotalBreakingChanges returns the number of breaking changes made to Swagger Examples. Examples cannot break
+// anything, so only changes marked as breaking by BreakingRules are counted.
+func (e *ExamplesChangesV2) TotalBreakingChanges() int {
+	return e.PropertyChanges.TotalBreakingChanges()
+}
+
+// CompareExamplesV2 compares a left (original) and right (new) Swagger Examples object for changes. Examples are
+// keyed by mime type, and an example is considered modified if its value has changed in any way. If changes are
+// found, a pointer to ExamplesChangesV2 is returned, otherwise nil is returned.
+func CompareExamplesV2(l, r *v2.Examples) *ExamplesChangesV2 {
+	var changes []*Change[*v2.Examples]
+
+	lValues := make(map[string]low.ValueReference[any])
+	rValues := make(map[string]low.ValueReference[any])
+	lKeys := make(map[string]*yaml.Node)
+	rKeys := make(map[string]*yaml.Node)
+	var keys []string
+	for k := range l.Values {
+		lValues[k.Value] = l.Values[k]
+		lKeys[k.Value] = k.KeyNode
+		keys = append(keys, k.Value)
+	}
+	for k := range r.Values {
+		rValues[k.Value] = r.Values[k]
+		rKeys[k.Value] = k.KeyNode
+		if _, ok := lValues[k.Value]; !ok {
+			keys = append(keys, k.Value)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		lv, lok := lValues[k]
+		rv, rok := rValues[k]
+		if lok && !rok {
+			CreateChange[*v2.Examples](&changes, ObjectRemoved, k, lKeys[k], nil, false, lv.Value, nil)
+			continue
+		}
+		if !lok && rok {
+			CreateChange[*v2.Examples](&changes, ObjectAdded, k, nil, rKeys[k], false, nil, rv.Value)
+			continue
+		}
+		if low.GenerateHashString(lv.Value) != low.GenerateHashString(rv.Value) {
+			CreateChange[*v2.Examples](&changes, Modified, k, lv.ValueNode, rv.ValueNode, false,
+				lv.Value, rv.Value)
+		}
+	}
+
+	ec := new(ExamplesChangesV2)
+	ec.Changes = changes
+	if ec.TotalChanges() <= 0 {
+		return nil
+	}
+	return ec
+}
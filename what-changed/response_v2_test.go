@@ -0,0 +1,82 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareResponseV2_Examples(t *testing.T) {
+
+	left := `description: a tasty pizza
+schema:
+  type: object
+examples:
+  application/json:
+    name: pepperoni
+  application/xml: <pizza/>`
+
+	right := `description: a tasty pizza
+schema:
+  type: object
+examples:
+  application/json:
+    name: margherita
+  text/plain: pizza`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Response
+	var rDoc v2.Response
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare, examples never break anything.
+	extChanges := CompareResponseV2(&lDoc, &rDoc)
+	assert.Equal(t, 3, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.ExamplesChanges)
+	assert.Nil(t, CompareResponseV2(&lDoc, &lDoc))
+}
+
+func TestCompareResponsesV2_DefaultAdded(t *testing.T) {
+
+	left := `"200":
+  description: pizza`
+
+	right := `"200":
+  description: pizza
+default:
+  description: no pizza`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Responses
+	var rDoc v2.Responses
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareResponsesV2(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, ObjectAdded, extChanges.Changes[0].ChangeType)
+
+	// removing the default response is breaking.
+	extChanges = CompareResponsesV2(&rDoc, &lDoc)
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+}
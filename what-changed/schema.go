@@ -25,6 +25,38 @@ type SchemaChanges struct {
 	ExternalDocChanges    *ExternalDocChanges
 	XMLChanges            *XMLChanges
 	ExtensionChanges      *ExtensionChanges
+
+	// JSON Schema 2020-12 keywords (3.1)
+	DefsChanges                  map[string]*SchemaChanges
+	DependentSchemasChanges      map[string]*SchemaChanges
+	PatternPropertiesChanges     map[string]*SchemaChanges
+	PrefixItemsChanges           []*SchemaChanges
+	ContainsChanges              *SchemaChanges
+	IfChanges                    *SchemaChanges
+	ThenChanges                  *SchemaChanges
+	ElseChanges                  *SchemaChanges
+	PropertyNamesChanges         *SchemaChanges
+	UnevaluatedItemsChanges      *SchemaChanges
+	UnevaluatedPropertiesChanges *SchemaChanges
+}
+
+// keywordChanges returns every SchemaChanges found for JSON Schema 2020-12 keywords.
+func (s *SchemaChanges) keywordChanges() []*SchemaChanges {
+	var all []*SchemaChanges
+	for _, m := range []map[string]*SchemaChanges{s.DefsChanges, s.DependentSchemasChanges,
+		s.PatternPropertiesChanges} {
+		for k := range m {
+			all = append(all, m[k])
+		}
+	}
+	all = append(all, s.PrefixItemsChanges...)
+	for _, c := range []*SchemaChanges{s.ContainsChanges, s.IfChanges, s.ThenChanges, s.ElseChanges,
+		s.PropertyNamesChanges, s.UnevaluatedItemsChanges, s.UnevaluatedPropertiesChanges} {
+		if c != nil {
+			all = append(all, c)
+		}
+	}
+	return all
 }
 
 func (s *SchemaChanges) TotalChanges() int {
@@ -71,6 +103,9 @@ func (s *SchemaChanges) TotalChanges() int {
 	if s.ExtensionChanges != nil {
 		t += s.ExtensionChanges.TotalChanges()
 	}
+	for _, c := range s.keywordChanges() {
+		t += c.TotalChanges()
+	}
 	return t
 }
 
@@ -109,18 +144,36 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
 			t += s.ItemsChanges[n].TotalBreakingChanges()
 		}
 	}
+	if s.ExternalDocChanges != nil {
+		t += s.ExternalDocChanges.TotalBreakingChanges()
+	}
 	if s.XMLChanges != nil {
 		t += s.XMLChanges.TotalBreakingChanges()
 	}
+	if s.ExtensionChanges != nil {
+		t += s.ExtensionChanges.TotalBreakingChanges()
+	}
 	if s.SchemaPropertyChanges != nil {
 		for n := range s.SchemaPropertyChanges {
 			t += s.SchemaPropertyChanges[n].TotalBreakingChanges()
 		}
 	}
+	for _, c := range s.keywordChanges() {
+		t += c.TotalBreakingChanges()
+	}
 	return t
 }
 
+// CompareSchemas will compare a left (original) and right (new) SchemaProxy for changes, without knowing if the
+// schema is used by a request or a response. If changes are found, a pointer to SchemaChanges is returned.
 func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
+	return CompareSchemasWithDirection(l, r, SchemaDirectionUnknown)
+}
+
+// CompareSchemasWithDirection will compare a left (original) and right (new) SchemaProxy for changes. The direction
+// determines if changes that narrow or widen the schema are breaking, and is passed down to every nested schema.
+// If changes are found, a pointer to SchemaChanges is returned.
+func CompareSchemasWithDirection(l, r *base.SchemaProxy, direction SchemaDirection) *SchemaChanges {
 	sc := new(SchemaChanges)
 	var changes []*Change[*base.Schema]
 
@@ -187,28 +240,32 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 		checkExamples(lSchema, rSchema, &changes)
 
 		// check schema core properties for changes.
-		checkSchemaPropertyChanges(lSchema, rSchema, &changes, sc)
+		checkSchemaPropertyChanges(lSchema, rSchema, &changes, sc, direction)
+
+		// check JSON Schema 2020-12 keywords for changes (3.1)
+		checkJSONSchemaKeywords(lSchema, rSchema, &changes, sc, direction)
 
 		// now for the confusing part, there is also a schema's 'properties' property to parse.
 		// inception, eat your heart out.
 		doneChan := make(chan bool)
-		totalProperties := checkPropertiesPropertyOfASchema(lSchema, rSchema, &changes, sc, doneChan)
+		totalProperties := checkPropertiesPropertyOfASchema(lSchema, rSchema, &changes, sc, doneChan, direction)
 
 		// check polymorphic and multi-values async for speed.
+		// adding to oneOf or anyOf widens a schema, adding to allOf, items or not narrows it.
 		go extractSchemaChanges(lSchema.OneOf.Value, rSchema.OneOf.Value, v3.OneOfLabel,
-			&sc.OneOfChanges, &changes, doneChan)
+			&sc.OneOfChanges, &changes, doneChan, direction, false)
 
 		go extractSchemaChanges(lSchema.AllOf.Value, rSchema.AllOf.Value, v3.AllOfLabel,
-			&sc.AllOfChanges, &changes, doneChan)
+			&sc.AllOfChanges, &changes, doneChan, direction, true)
 
 		go extractSchemaChanges(lSchema.AnyOf.Value, rSchema.AnyOf.Value, v3.AnyOfLabel,
-			&sc.AnyOfChanges, &changes, doneChan)
+			&sc.AnyOfChanges, &changes, doneChan, direction, false)
 
 		go extractSchemaChanges(lSchema.Items.Value, rSchema.Items.Value, v3.ItemsLabel,
-			&sc.ItemsChanges, &changes, doneChan)
+			&sc.ItemsChanges, &changes, doneChan, direction, true)
 
 		go extractSchemaChanges(lSchema.Not.Value, rSchema.Not.Value, v3.NotLabel,
-			&sc.NotChanges, &changes, doneChan)
+			&sc.NotChanges, &changes, doneChan, direction, true)
 
 		totalChecks := totalProperties + 5
 		completedChecks := 0
@@ -251,7 +308,8 @@ func checkPropertiesPropertyOfASchema(
 	rSchema *base.Schema,
 	changes *[]*Change[*base.Schema],
 	sc *SchemaChanges,
-	doneChan chan bool) int {
+	doneChan chan bool,
+	direction SchemaDirection) int {
 
 	propChanges := make(map[string]*SchemaChanges)
 
@@ -288,7 +346,7 @@ func checkPropertiesPropertyOfASchema(
 				done <- true
 				return
 			}
-			s := CompareSchemas(lp, rp)
+			s := CompareSchemasWithDirection(lp, rp, direction)
 			propLock.Lock()
 			propChanges[key] = s
 			propLock.Unlock()
@@ -354,7 +412,7 @@ func checkPropertiesPropertyOfASchema(
 func checkSchemaPropertyChanges(
 	lSchema *base.Schema,
 	rSchema *base.Schema,
-	changes *[]*Change[*base.Schema], sc *SchemaChanges) {
+	changes *[]*Change[*base.Schema], sc *SchemaChanges, direction SchemaDirection) {
 
 	var props []*PropertyCheck[*base.Schema]
 
@@ -369,28 +427,6 @@ func checkSchemaPropertyChanges(
 		New:       rSchema,
 	})
 
-	// ExclusiveMaximum
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.ExclusiveMaximum.ValueNode,
-		RightNode: rSchema.ExclusiveMaximum.ValueNode,
-		Label:     v3.ExclusiveMaximumLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// ExclusiveMinimum
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.ExclusiveMinimum.ValueNode,
-		RightNode: rSchema.ExclusiveMinimum.ValueNode,
-		Label:     v3.ExclusiveMinimumLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
 	// Type
 	props = append(props, &PropertyCheck[*base.Schema]{
 		LeftNode:  lSchema.Type.ValueNode,
@@ -413,72 +449,6 @@ func checkSchemaPropertyChanges(
 		New:       rSchema,
 	})
 
-	// MultipleOf
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.MultipleOf.ValueNode,
-		RightNode: rSchema.MultipleOf.ValueNode,
-		Label:     v3.MultipleOfLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// Maximum
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.Maximum.ValueNode,
-		RightNode: rSchema.Maximum.ValueNode,
-		Label:     v3.MaximumLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// Minimum
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.Minimum.ValueNode,
-		RightNode: rSchema.Minimum.ValueNode,
-		Label:     v3.MinimumLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// MaxLength
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.MaxLength.ValueNode,
-		RightNode: rSchema.MaxLength.ValueNode,
-		Label:     v3.MaxLengthLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// MinLength
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.MinLength.ValueNode,
-		RightNode: rSchema.MinLength.ValueNode,
-		Label:     v3.MinLengthLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// Pattern
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.Pattern.ValueNode,
-		RightNode: rSchema.Pattern.ValueNode,
-		Label:     v3.PatternLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
 	// Format
 	props = append(props, &PropertyCheck[*base.Schema]{
 		LeftNode:  lSchema.Format.ValueNode,
@@ -490,50 +460,6 @@ func checkSchemaPropertyChanges(
 		New:       rSchema,
 	})
 
-	// MaxItems
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.MaxItems.ValueNode,
-		RightNode: rSchema.MaxItems.ValueNode,
-		Label:     v3.MaxItemsLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// MinItems
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.MinItems.ValueNode,
-		RightNode: rSchema.MinItems.ValueNode,
-		Label:     v3.MinItemsLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// MaxProperties
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.MaxProperties.ValueNode,
-		RightNode: rSchema.MaxProperties.ValueNode,
-		Label:     v3.MaxPropertiesLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
-	// MinProperties
-	props = append(props, &PropertyCheck[*base.Schema]{
-		LeftNode:  lSchema.MinProperties.ValueNode,
-		RightNode: rSchema.MinProperties.ValueNode,
-		Label:     v3.MinPropertiesLabel,
-		Changes:   changes,
-		Breaking:  true,
-		Original:  lSchema,
-		New:       rSchema,
-	})
-
 	// UniqueItems
 	props = append(props, &PropertyCheck[*base.Schema]{
 		LeftNode:  lSchema.UniqueItems.ValueNode,
@@ -667,14 +593,15 @@ func checkSchemaPropertyChanges(
 	for g := range k {
 		if _, ok := j[g]; !ok {
 			CreateChange[*base.Schema](changes, PropertyAdded, v3.RequiredLabel,
-				nil, rSchema.Required.Value[k[g]].GetValueNode(), true, nil,
+				nil, rSchema.Required.Value[k[g]].GetValueNode(), direction.narrowing(true), nil,
 				rSchema.Required.Value[k[g]].GetValue)
 		}
 	}
 	for g := range j {
 		if _, ok := k[g]; !ok {
 			CreateChange[*base.Schema](changes, PropertyRemoved, v3.RequiredLabel,
-				lSchema.Required.Value[j[g]].GetValueNode(), nil, true, lSchema.Required.Value[j[g]].GetValue,
+				lSchema.Required.Value[j[g]].GetValueNode(), nil, direction.widening(true),
+				lSchema.Required.Value[j[g]].GetValue,
 				nil)
 		}
 	}
@@ -683,22 +610,23 @@ func checkSchemaPropertyChanges(
 	j = make(map[string]int)
 	k = make(map[string]int)
 	for i := range lSchema.Enum.Value {
-		j[lSchema.Enum.Value[i].Value] = i
+		j[low.GenerateTypedHashString(lSchema.Enum.Value[i].Value)] = i
 	}
 	for i := range rSchema.Enum.Value {
-		k[rSchema.Enum.Value[i].Value] = i
+		k[low.GenerateTypedHashString(rSchema.Enum.Value[i].Value)] = i
 	}
 	for g := range k {
 		if _, ok := j[g]; !ok {
 			CreateChange[*base.Schema](changes, PropertyAdded, v3.EnumLabel,
-				nil, rSchema.Enum.Value[k[g]].GetValueNode(), false, nil,
+				nil, rSchema.Enum.Value[k[g]].GetValueNode(), direction.widening(false), nil,
 				rSchema.Enum.Value[k[g]].GetValue)
 		}
 	}
 	for g := range j {
 		if _, ok := k[g]; !ok {
 			CreateChange[*base.Schema](changes, PropertyRemoved, v3.EnumLabel,
-				lSchema.Enum.Value[j[g]].GetValueNode(), nil, true, lSchema.Enum.Value[j[g]].GetValue,
+				lSchema.Enum.Value[j[g]].GetValueNode(), nil, direction.narrowing(true),
+				lSchema.Enum.Value[j[g]].GetValue,
 				nil)
 		}
 	}
@@ -744,6 +672,29 @@ func checkSchemaPropertyChanges(
 
 	// check core properties
 	CheckProperties(props)
+
+	// check constraints, these narrow or widen the schema.
+	constraints := []struct {
+		label string
+		bound schemaBound
+		l, r  *yaml.Node
+	}{
+		{v3.MultipleOfLabel, noBound, lSchema.MultipleOf.ValueNode, rSchema.MultipleOf.ValueNode},
+		{v3.MaximumLabel, upperBound, lSchema.Maximum.ValueNode, rSchema.Maximum.ValueNode},
+		{v3.MinimumLabel, lowerBound, lSchema.Minimum.ValueNode, rSchema.Minimum.ValueNode},
+		{v3.ExclusiveMaximumLabel, upperBound, lSchema.ExclusiveMaximum.ValueNode, rSchema.ExclusiveMaximum.ValueNode},
+		{v3.ExclusiveMinimumLabel, lowerBound, lSchema.ExclusiveMinimum.ValueNode, rSchema.ExclusiveMinimum.ValueNode},
+		{v3.MaxLengthLabel, upperBound, lSchema.MaxLength.ValueNode, rSchema.MaxLength.ValueNode},
+		{v3.MinLengthLabel, lowerBound, lSchema.MinLength.ValueNode, rSchema.MinLength.ValueNode},
+		{v3.PatternLabel, noBound, lSchema.Pattern.ValueNode, rSchema.Pattern.ValueNode},
+		{v3.MaxItemsLabel, upperBound, lSchema.MaxItems.ValueNode, rSchema.MaxItems.ValueNode},
+		{v3.MinItemsLabel, lowerBound, lSchema.MinItems.ValueNode, rSchema.MinItems.ValueNode},
+		{v3.MaxPropertiesLabel, upperBound, lSchema.MaxProperties.ValueNode, rSchema.MaxProperties.ValueNode},
+		{v3.MinPropertiesLabel, lowerBound, lSchema.MinProperties.ValueNode, rSchema.MinProperties.ValueNode},
+	}
+	for _, c := range constraints {
+		checkSchemaConstraint(c.l, c.r, c.label, c.bound, changes, lSchema, rSchema, direction)
+	}
 }
 
 func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Change[*base.Schema]) {
@@ -822,7 +773,9 @@ func extractSchemaChanges(
 	label string,
 	sc *[]*SchemaChanges,
 	changes *[]*Change[*base.Schema],
-	done chan bool) {
+	done chan bool,
+	direction SchemaDirection,
+	addNarrows bool) {
 
 	// if there is nothing here, there is nothing to do.
 	if lSchema == nil && rSchema == nil {
@@ -830,6 +783,11 @@ func extractSchemaChanges(
 		return
 	}
 
+	breakingAdd, breakingRemove := direction.widening(false), direction.narrowing(true)
+	if addNarrows {
+		breakingAdd, breakingRemove = direction.narrowing(false), direction.widening(true)
+	}
+
 	x := "%x"
 	// create hash key maps to check equality
 	lKeys := make([]string, 0, len(lSchema))
@@ -864,7 +822,7 @@ func extractSchemaChanges(
 		for w := range lKeys {
 			// keys are different, which means there are changes.
 			if lKeys[w] != rKeys[w] {
-				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
+				*sc = append(*sc, CompareSchemasWithDirection(lEntities[lKeys[w]], rEntities[rKeys[w]], direction))
 			}
 		}
 	}
@@ -873,11 +831,11 @@ func extractSchemaChanges(
 	if len(lKeys) > len(rKeys) {
 		for w := range lKeys {
 			if w < len(rKeys) && lKeys[w] != rKeys[w] {
-				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
+				*sc = append(*sc, CompareSchemasWithDirection(lEntities[lKeys[w]], rEntities[rKeys[w]], direction))
 			}
 			if w >= len(rKeys) {
 				CreateChange[*base.Schema](changes, ObjectRemoved, label,
-					lEntities[lKeys[w]].GetValueNode(), nil, true, lEntities[lKeys[w]], nil)
+					lEntities[lKeys[w]].GetValueNode(), nil, breakingRemove, lEntities[lKeys[w]], nil)
 			}
 		}
 	}
@@ -886,13 +844,36 @@ func extractSchemaChanges(
 	if len(rKeys) > len(lKeys) {
 		for w := range rKeys {
 			if w < len(lKeys) && rKeys[w] != lKeys[w] {
-				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
+				*sc = append(*sc, CompareSchemasWithDirection(lEntities[lKeys[w]], rEntities[rKeys[w]], direction))
 			}
 			if w >= len(lKeys) {
 				CreateChange[*base.Schema](changes, ObjectAdded, label,
-					nil, rEntities[rKeys[w]].GetValueNode(), false, nil, rEntities[rKeys[w]])
+					nil, rEntities[rKeys[w]].GetValueNode(), breakingAdd, nil, rEntities[rKeys[w]])
 			}
 		}
 	}
 	done <- true
 }
+
+// checkSchema will check a left (original) and right (new) SchemaProxy for additions and removals, which are
+// recorded in the supplied changes. If the schema exists on both sides, the two are compared in the supplied
+// direction and any changes are returned. If there are no changes, nil is returned.
+func checkSchema[T any](l, r *base.SchemaProxy, lNode, rNode *yaml.Node, changes *[]*Change[T],
+	original, new any, direction SchemaDirection) *SchemaChanges {
+	if l != nil && r == nil {
+		CreateChange[T](changes, ObjectRemoved, v3.SchemaLabel,
+			lNode, nil, true, original, new)
+		return nil
+	}
+	if l == nil && r != nil {
+		CreateChange[T](changes, ObjectAdded, v3.SchemaLabel,
+			nil, rNode, true, original, new)
+		return nil
+	}
+	if l != nil && r != nil {
+		if sc := CompareSchemasWithDirection(l, r, direction); sc != nil && sc.TotalChanges() > 0 {
+			return sc
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+	"math/big"
+	"strconv"
+)
+
+// SchemaDirection determines which way data described by a schema flows, which changes what a breaking change is.
+//
+// A schema used by a request describes what a server accepts, so any change that narrows what is valid (adding a
+// required property, removing an enum value, lowering a maximum) breaks clients. A schema used by a response
+// describes what a client receives, so any change that widens what is valid (adding an enum value, removing a
+// required property, raising a maximum) breaks clients.
+type SchemaDirection int
+
+const (
+	// SchemaDirectionUnknown is used when it's not known how a schema is used (for example a component schema),
+	// the original breaking rules for a schema are applied.
+	SchemaDirectionUnknown SchemaDirection = iota
+
+	// SchemaDirectionRequest is used for schemas that describe request bodies and parameters.
+	SchemaDirectionRequest
+
+	// SchemaDirectionResponse is used for schemas that describe response bodies and headers.
+	SchemaDirectionResponse
+)
+
+// narrowing returns true if a change that reduces the values accepted by a schema is breaking. When the direction
+// is unknown, the unknown value is returned.
+func (d SchemaDirection) narrowing(unknown bool) bool {
+	switch d {
+	case SchemaDirectionRequest:
+		return true
+	case SchemaDirectionResponse:
+		return false
+	}
+	return unknown
+}
+
+// widening returns true if a change that increases the values accepted by a schema is breaking. When the direction
+// is unknown, the unknown value is returned.
+func (d SchemaDirection) widening(unknown bool) bool {
+	switch d {
+	case SchemaDirectionRequest:
+		return false
+	case SchemaDirectionResponse:
+		return true
+	}
+	return unknown
+}
+
+// schemaBound determines if a numeric schema constraint is an upper or lower limit.
+type schemaBound int
+
+const (
+	noBound schemaBound = iota
+	upperBound
+	lowerBound
+)
+
+// checkSchemaConstraint will check a left (original) and right (new) schema constraint node for changes. Adding a
+// constraint narrows a schema, removing one widens it. Modifying a bound narrows or widens the schema depending on
+// the direction of the new value, any other modification is breaking. Numbers are compared by value, so changing
+// how a number is written (for example '10' to '10.0') is not a change.
+func checkSchemaConstraint(l, r *yaml.Node, label string, bound schemaBound, changes *[]*Change[*base.Schema],
+	lSchema, rSchema *base.Schema, direction SchemaDirection) {
+
+	CheckForRemoval(l, r, label, changes, direction.widening(true), lSchema, rSchema)
+	CheckForAddition(l, r, label, changes, direction.narrowing(true), lSchema, rSchema)
+
+	breaking := true
+	if l != nil && r != nil {
+		lv, lOk := parseNumber(l)
+		rv, rOk := parseNumber(r)
+		if lOk && rOk {
+			if lv.Cmp(rv) == 0 {
+				return
+			}
+			if bound != noBound {
+				if (bound == upperBound) == (rv.Cmp(lv) < 0) {
+					breaking = direction.narrowing(true)
+				} else {
+					breaking = direction.widening(true)
+				}
+			}
+		}
+	}
+	CheckForModification(l, r, label, changes, breaking, lSchema, rSchema)
+}
+
+// checkRequired will check a left (original) and right (new) 'required' node of a parameter, header or request body
+// for changes. Becoming required narrows what is valid and no longer being required widens it, so only one of them
+// is breaking depending on the direction. A missing value is not required, so adding or removing 'required: false'
+// is never breaking. When the direction is unknown, only becoming required is breaking.
+func checkRequired[T any](l, r *yaml.Node, changes *[]*Change[T], orig, new T, direction SchemaDirection) {
+	lRequired, rRequired := isTrue(l), isTrue(r)
+	CheckForRemoval(l, r, v3.RequiredLabel, changes, lRequired && direction.widening(false), orig, new)
+	CheckForAddition(l, r, v3.RequiredLabel, changes, rRequired && direction.narrowing(true), orig, new)
+	if lRequired == rRequired {
+		return
+	}
+	breaking := direction.widening(false)
+	if rRequired {
+		breaking = direction.narrowing(true)
+	}
+	CheckForModification(l, r, v3.RequiredLabel, changes, breaking, orig, new)
+}
+
+// isTrue returns true if a node holds a true boolean value.
+func isTrue(node *yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+	b, err := strconv.ParseBool(node.Value)
+	return err == nil && b
+}
+
+// parseNumber reads the exact value of a numeric node, without any loss of precision.
+func parseNumber(node *yaml.Node) (*big.Rat, bool) {
+	if !utils.IsNodeIntValue(node) && !utils.IsNodeFloatValue(node) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(node.Value)
+}
@@ -0,0 +1,298 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareSchemasWithDirection_Required(t *testing.T) {
+	left := `components:
+  schemas:
+    Pizza:
+      type: object
+      required: [name]`
+
+	right := `components:
+  schemas:
+    Pizza:
+      type: object
+      required: [name, toppings]`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("Pizza").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("Pizza").Value
+
+	// adding a required property breaks requests, but not responses.
+	changes := CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionRequest)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	changes = CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionResponse)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+
+	// removing a required property breaks responses, but not requests.
+	changes = CompareSchemasWithDirection(rSchemaProxy, lSchemaProxy, SchemaDirectionRequest)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	changes = CompareSchemasWithDirection(rSchemaProxy, lSchemaProxy, SchemaDirectionResponse)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	// without a direction, both are breaking.
+	assert.Equal(t, 1, CompareSchemas(lSchemaProxy, rSchemaProxy).TotalBreakingChanges())
+	assert.Equal(t, 1, CompareSchemas(rSchemaProxy, lSchemaProxy).TotalBreakingChanges())
+}
+
+func TestCompareSchemasWithDirection_Enum(t *testing.T) {
+	left := `components:
+  schemas:
+    Pizza:
+      type: string
+      enum: [cheese, ham]`
+
+	right := `components:
+  schemas:
+    Pizza:
+      type: string
+      enum: [cheese]`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("Pizza").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("Pizza").Value
+
+	// narrowing an enum breaks requests, but not responses.
+	changes := CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionRequest)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	changes = CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionResponse)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+
+	// widening an enum breaks responses, but not requests.
+	changes = CompareSchemasWithDirection(rSchemaProxy, lSchemaProxy, SchemaDirectionRequest)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	changes = CompareSchemasWithDirection(rSchemaProxy, lSchemaProxy, SchemaDirectionResponse)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
+
+func TestCompareSchemasWithDirection_Bounds(t *testing.T) {
+	left := `components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        name:
+          type: string
+          maxLength: 20
+          minLength: 2
+        slices:
+          type: integer`
+
+	right := `components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        name:
+          type: string
+          maxLength: 10
+          minLength: 1
+        slices:
+          type: integer
+          maximum: 8`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("Pizza").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("Pizza").Value
+
+	// lowering maxLength and adding a maximum narrow, lowering minLength widens.
+	changes := CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionRequest)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+	changes = CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionResponse)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Equal(t, 3, CompareSchemas(lSchemaProxy, rSchemaProxy).TotalBreakingChanges())
+}
+
+func TestCompareSchemasWithDirection_DecimalConstraints(t *testing.T) {
+	left := `openapi: 3.1.0
+components:
+  schemas:
+    Price:
+      type: number
+      maximum: 99.99
+      minimum: 10
+      multipleOf: 0.01
+      exclusiveMaximum: 100.5`
+
+	right := `openapi: 3.1.0
+components:
+  schemas:
+    Price:
+      type: number
+      maximum: 99.9
+      minimum: 10.0
+      multipleOf: 1e-2
+      exclusiveMaximum: 100.75`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("Price").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("Price").Value
+
+	// minimum and multipleOf are written differently, but have the same value.
+	changes := CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionRequest)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Equal(t, v3.MaximumLabel, changes.Changes[0].Property)
+	assert.True(t, changes.Changes[0].Breaking)
+	assert.Equal(t, v3.ExclusiveMaximumLabel, changes.Changes[1].Property)
+	assert.False(t, changes.Changes[1].Breaking)
+
+	changes = CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionResponse)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.True(t, changes.Changes[1].Breaking)
+}
+
+func TestCompareSchemasWithDirection_OneOf(t *testing.T) {
+	left := `components:
+  schemas:
+    Pizza:
+      oneOf:
+        - type: string`
+
+	right := `components:
+  schemas:
+    Pizza:
+      oneOf:
+        - type: string
+        - type: integer`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("Pizza").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("Pizza").Value
+
+	changes := CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionRequest)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	changes = CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionResponse)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
+
+func TestCompareResponse_SchemaDirection(t *testing.T) {
+
+	left := `description: a pizza
+content:
+  application/json:
+    schema:
+      type: string
+      enum: [cheese]`
+
+	right := `description: a pizza
+content:
+  application/json:
+    schema:
+      type: string
+      enum: [cheese, pineapple]`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Response
+	var rDoc v3.Response
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// a new enum value in a response is something clients may not understand.
+	extChanges := CompareResponse(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareRequestBodies_SchemaDirection(t *testing.T) {
+
+	left := `content:
+  application/json:
+    schema:
+      type: string
+      enum: [cheese]`
+
+	right := `content:
+  application/json:
+    schema:
+      type: string
+      enum: [cheese, pineapple]`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.RequestBody
+	var rDoc v3.RequestBody
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// a new enum value in a request is accepted by the server, nothing breaks.
+	extChanges := CompareRequestBodies(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+}
+
+func TestCheckRequired_Direction(t *testing.T) {
+	left := `components:
+  parameters:
+    Size:
+      name: size
+      in: query
+      required: false
+  requestBodies:
+    Pizza:
+      description: a pizza
+  responses:
+    Pizza:
+      description: a pizza
+      headers:
+        Pizza-Count:
+          required: true`
+
+	right := `components:
+  parameters:
+    Size:
+      name: size
+      in: query
+      required: true
+  requestBodies:
+    Pizza:
+      description: a pizza
+      required: false
+  responses:
+    Pizza:
+      description: a pizza
+      headers:
+        Pizza-Count:
+          description: how many pizzas`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lComponents, rComponents := leftDoc.Components.Value, rightDoc.Components.Value
+	lParam, rParam := lComponents.FindParameter("Size").Value, rComponents.FindParameter("Size").Value
+	lBody, rBody := lComponents.FindRequestBody("Pizza").Value, rComponents.FindRequestBody("Pizza").Value
+	lResp, rResp := lComponents.FindResponse("Pizza").Value, rComponents.FindResponse("Pizza").Value
+
+	// a parameter becoming required breaks requests, becoming optional does not.
+	assert.Equal(t, 1, CompareParameters(lParam, rParam).TotalBreakingChanges())
+	assert.Equal(t, 0, CompareParameters(rParam, lParam).TotalBreakingChanges())
+
+	// adding or removing 'required: false' changes nothing that is valid.
+	assert.Equal(t, 1, CompareRequestBodies(lBody, rBody).TotalChanges())
+	assert.Equal(t, 0, CompareRequestBodies(lBody, rBody).TotalBreakingChanges())
+	assert.Equal(t, 0, CompareRequestBodies(rBody, lBody).TotalBreakingChanges())
+
+	// a response header no longer being required breaks clients, becoming required does not.
+	assert.Equal(t, 1, CompareResponse(lResp, rResp).TotalBreakingChanges())
+	assert.Equal(t, 0, CompareResponse(rResp, lResp).TotalBreakingChanges())
+}
@@ -0,0 +1,243 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"sort"
+)
+
+// checkJSONSchemaKeywords checks a left (original) and right (new) schema for changes made to JSON Schema 2020-12
+// keywords, which are only available in OpenAPI 3.1. Any changes to sub-schemas are recorded in the supplied
+// SchemaChanges.
+func checkJSONSchemaKeywords(lSchema, rSchema *base.Schema, changes *[]*Change[*base.Schema], sc *SchemaChanges,
+	direction SchemaDirection) {
+
+	var props []*PropertyCheck[*base.Schema]
+
+	// identifiers can be used by references, changing them is a breaking change.
+	identifiers := []struct {
+		label string
+		l, r  low.NodeReference[string]
+	}{
+		{v3.IdLabel, lSchema.Id, rSchema.Id},
+		{v3.AnchorLabel, lSchema.Anchor, rSchema.Anchor},
+		{v3.DynamicAnchorLabel, lSchema.DynamicAnchor, rSchema.DynamicAnchor},
+		{v3.DynamicRefLabel, lSchema.DynamicRef, rSchema.DynamicRef},
+	}
+	for _, id := range identifiers {
+		props = append(props, &PropertyCheck[*base.Schema]{
+			LeftNode:  id.l.ValueNode,
+			RightNode: id.r.ValueNode,
+			Label:     id.label,
+			Changes:   changes,
+			Breaking:  true,
+			Original:  lSchema,
+			New:       rSchema,
+		})
+	}
+	CheckProperties(props)
+
+	// const and contains constraints narrow or widen the schema, const may be any value (including objects).
+	if lSchema.Const.ValueNode == nil || rSchema.Const.ValueNode == nil ||
+		low.GenerateTypedHashString(lSchema.Const.Value) != low.GenerateTypedHashString(rSchema.Const.Value) {
+		checkKeywordValue(lSchema.Const.ValueNode, rSchema.Const.ValueNode, v3.ConstLabel, changes, true,
+			lSchema, rSchema, direction)
+	}
+	checkSchemaConstraint(lSchema.MinContains.ValueNode, rSchema.MinContains.ValueNode, v3.MinContainsLabel,
+		lowerBound, changes, lSchema, rSchema, direction)
+	checkSchemaConstraint(lSchema.MaxContains.ValueNode, rSchema.MaxContains.ValueNode, v3.MaxContainsLabel,
+		upperBound, changes, lSchema, rSchema, direction)
+
+	// sub-schemas
+	sc.ContainsChanges = checkSubSchema(lSchema.Contains, rSchema.Contains, v3.ContainsLabel,
+		changes, direction)
+	sc.IfChanges = checkSubSchema(lSchema.If, rSchema.If, v3.IfLabel, changes, direction)
+	sc.ThenChanges = checkSubSchema(lSchema.Then, rSchema.Then, v3.ThenLabel, changes, direction)
+	sc.ElseChanges = checkSubSchema(lSchema.Else, rSchema.Else, v3.ElseLabel, changes, direction)
+	sc.PropertyNamesChanges = checkSubSchema(lSchema.PropertyNames, rSchema.PropertyNames, v3.PropertyNamesLabel,
+		changes, direction)
+	sc.UnevaluatedItemsChanges = checkDynamicSubSchema(lSchema.UnevaluatedItems, rSchema.UnevaluatedItems,
+		v3.UnevaluatedItemsLabel, changes, lSchema, rSchema, direction)
+	sc.UnevaluatedPropertiesChanges = checkDynamicSubSchema(lSchema.UnevaluatedProperties,
+		rSchema.UnevaluatedProperties, v3.UnevaluatedPropertiesLabel, changes, lSchema, rSchema, direction)
+	sc.PrefixItemsChanges = checkPrefixItems(lSchema.PrefixItems.Value, rSchema.PrefixItems.Value, changes, direction)
+
+	// $defs are not part of the schema itself, only removing a definition can break something (a reference).
+	sc.DefsChanges = checkSchemaMap(lSchema.Defs.Value, rSchema.Defs.Value, v3.DefsLabel, changes,
+		false, true, direction)
+	sc.DependentSchemasChanges = checkSchemaMap(lSchema.DependentSchemas.Value, rSchema.DependentSchemas.Value,
+		v3.DependentSchemasLabel, changes, direction.narrowing(true), direction.widening(true), direction)
+	sc.PatternPropertiesChanges = checkSchemaMap(lSchema.PatternProperties.Value, rSchema.PatternProperties.Value,
+		v3.PatternPropertiesLabel, changes, direction.narrowing(true), direction.widening(true), direction)
+
+	checkDependentRequired(lSchema, rSchema, changes, direction)
+}
+
+// checkSubSchema checks a sub-schema of a keyword (for example 'contains') for changes. Adding a sub-schema narrows
+// the schema, removing a sub-schema widens it. If the sub-schema exists on both sides, any changes are returned.
+func checkSubSchema(l, r low.NodeReference[*base.SchemaProxy], label string, changes *[]*Change[*base.Schema],
+	direction SchemaDirection) *SchemaChanges {
+	if l.Value != nil && r.Value == nil {
+		CreateChange[*base.Schema](changes, ObjectRemoved, label,
+			l.ValueNode, nil, direction.widening(true), l.Value, nil)
+		return nil
+	}
+	if l.Value == nil && r.Value != nil {
+		CreateChange[*base.Schema](changes, ObjectAdded, label,
+			nil, r.ValueNode, direction.narrowing(true), nil, r.Value)
+		return nil
+	}
+	if l.Value != nil && r.Value != nil {
+		if sc := CompareSchemasWithDirection(l.Value, r.Value, direction); sc != nil && sc.TotalChanges() > 0 {
+			return sc
+		}
+	}
+	return nil
+}
+
+// checkDynamicSubSchema checks a keyword that is either a sub-schema or a boolean (for example
+// 'unevaluatedProperties') for changes. Changing a boolean to false narrows the schema, changing it to true widens it.
+func checkDynamicSubSchema(l, r low.NodeReference[base.SchemaDynamicValue[*base.SchemaProxy, bool]], label string,
+	changes *[]*Change[*base.Schema], lSchema, rSchema *base.Schema, direction SchemaDirection) *SchemaChanges {
+
+	// both are schemas, or at least one side is missing.
+	lSchemaValue := l.ValueNode != nil && l.Value.IsA()
+	rSchemaValue := r.ValueNode != nil && r.Value.IsA()
+	if (l.ValueNode == nil || lSchemaValue) && (r.ValueNode == nil || rSchemaValue) {
+		return checkSubSchema(low.NodeReference[*base.SchemaProxy]{Value: l.Value.A, ValueNode: l.ValueNode},
+			low.NodeReference[*base.SchemaProxy]{Value: r.Value.A, ValueNode: r.ValueNode}, label, changes, direction)
+	}
+	breaking := true
+	if l.ValueNode != nil && r.ValueNode != nil && l.Value.IsB() && r.Value.IsB() {
+		if l.Value.B == r.Value.B {
+			return nil
+		}
+		if r.Value.B {
+			breaking = direction.widening(true)
+		} else {
+			breaking = direction.narrowing(true)
+		}
+	}
+	checkKeywordValue(l.ValueNode, r.ValueNode, label, changes, breaking, lSchema, rSchema, direction)
+	return nil
+}
+
+// checkKeywordValue records a keyword value (that may not be a scalar) as removed, added or modified. Removing a
+// keyword widens the schema, adding a keyword narrows it.
+func checkKeywordValue(l, r *yaml.Node, label string, changes *[]*Change[*base.Schema], breaking bool,
+	lSchema, rSchema *base.Schema, direction SchemaDirection) {
+	switch {
+	case l != nil && r == nil:
+		CreateChange[*base.Schema](changes, PropertyRemoved, label, l, nil, direction.widening(true), lSchema, rSchema)
+	case l == nil && r != nil:
+		CreateChange[*base.Schema](changes, PropertyAdded, label, nil, r, direction.narrowing(true), lSchema, rSchema)
+	case l != nil && r != nil:
+		CreateChange[*base.Schema](changes, Modified, label, l, r, breaking, lSchema, rSchema)
+	}
+}
+
+// checkPrefixItems checks prefixItems for changes. Each item validates the array item in the same position, so items
+// are compared by position. Adding an item narrows the schema, removing an item widens it.
+func checkPrefixItems(l, r []low.ValueReference[*base.SchemaProxy], changes *[]*Change[*base.Schema],
+	direction SchemaDirection) []*SchemaChanges {
+	var sc []*SchemaChanges
+	for i := 0; i < len(l) || i < len(r); i++ {
+		var lItem, rItem low.NodeReference[*base.SchemaProxy]
+		if i < len(l) {
+			lItem = low.NodeReference[*base.SchemaProxy]{Value: l[i].Value, ValueNode: l[i].ValueNode}
+		}
+		if i < len(r) {
+			rItem = low.NodeReference[*base.SchemaProxy]{Value: r[i].Value, ValueNode: r[i].ValueNode}
+		}
+		if c := checkSubSchema(lItem, rItem, v3.PrefixItemsLabel, changes, direction); c != nil {
+			sc = append(sc, c)
+		}
+	}
+	return sc
+}
+
+// checkSchemaMap checks a map of sub-schemas (for example 'patternProperties') for changes. Sub-schemas are matched
+// using their keys, any changes to sub-schemas that exist on both sides are returned, mapped to the key.
+func checkSchemaMap(l, r map[low.KeyReference[string]]low.ValueReference[*base.SchemaProxy], label string,
+	changes *[]*Change[*base.Schema], breakingAdd, breakingRemove bool,
+	direction SchemaDirection) map[string]*SchemaChanges {
+
+	lValues := make(map[string]low.ValueReference[*base.SchemaProxy])
+	rValues := make(map[string]low.ValueReference[*base.SchemaProxy])
+	var keys []string
+	for k := range l {
+		lValues[k.Value] = l[k]
+		keys = append(keys, k.Value)
+	}
+	for k := range r {
+		rValues[k.Value] = r[k]
+		if _, ok := lValues[k.Value]; !ok {
+			keys = append(keys, k.Value)
+		}
+	}
+	sort.Strings(keys)
+
+	var sc map[string]*SchemaChanges
+	for _, k := range keys {
+		lv, lok := lValues[k]
+		rv, rok := rValues[k]
+		switch {
+		case lok && !rok:
+			CreateChange[*base.Schema](changes, ObjectRemoved, label,
+				lv.ValueNode, nil, breakingRemove, lv.Value, nil)
+		case !lok && rok:
+			CreateChange[*base.Schema](changes, ObjectAdded, label,
+				nil, rv.ValueNode, breakingAdd, nil, rv.Value)
+		default:
+			if c := CompareSchemasWithDirection(lv.Value, rv.Value, direction); c != nil && c.TotalChanges() > 0 {
+				if sc == nil {
+					sc = make(map[string]*SchemaChanges)
+				}
+				sc[k] = c
+			}
+		}
+	}
+	return sc
+}
+
+// checkDependentRequired checks dependentRequired for changes. Adding a dependency narrows the schema, removing a
+// dependency widens it. Changing the properties required by a dependency is always breaking.
+func checkDependentRequired(lSchema, rSchema *base.Schema, changes *[]*Change[*base.Schema],
+	direction SchemaDirection) {
+
+	lValues := make(map[string]low.ValueReference[[]string])
+	rValues := make(map[string]low.ValueReference[[]string])
+	var keys []string
+	for k, v := range lSchema.DependentRequired.Value {
+		lValues[k.Value] = v
+		keys = append(keys, k.Value)
+	}
+	for k, v := range rSchema.DependentRequired.Value {
+		rValues[k.Value] = v
+		if _, ok := lValues[k.Value]; !ok {
+			keys = append(keys, k.Value)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lv, lok := lValues[k]
+		rv, rok := rValues[k]
+		switch {
+		case lok && !rok:
+			CreateChange[*base.Schema](changes, PropertyRemoved, v3.DependentRequiredLabel,
+				lv.ValueNode, nil, direction.widening(true), k, nil)
+		case !lok && rok:
+			CreateChange[*base.Schema](changes, PropertyAdded, v3.DependentRequiredLabel,
+				nil, rv.ValueNode, direction.narrowing(true), nil, k)
+		case !reflect.DeepEqual(lv.Value, rv.Value):
+			CreateChange[*base.Schema](changes, Modified, v3.DependentRequiredLabel,
+				lv.ValueNode, rv.ValueNode, true, lv.Value, rv.Value)
+		}
+	}
+}
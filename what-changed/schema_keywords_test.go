@@ -0,0 +1,151 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompareSchemas_JSONSchemaKeywords_Identical(t *testing.T) {
+	left := `openapi: 3.1.0
+components:
+  schemas:
+    OK:
+      $id: https://pb33f.io/ok
+      const: {a: b}
+      contains:
+        type: string
+      prefixItems:
+        - type: string
+      $defs:
+        tag:
+          type: string
+      dependentRequired:
+        a: [b]
+      unevaluatedProperties: false`
+
+	leftDoc, rightDoc := test_BuildDoc(left, left)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+	assert.Nil(t, CompareSchemas(lSchemaProxy, rSchemaProxy))
+}
+
+func TestCompareSchemas_JSONSchemaKeywords_Modified(t *testing.T) {
+	left := `openapi: 3.1.0
+components:
+  schemas:
+    OK:
+      $id: https://pb33f.io/ok
+      const: pickles
+      contains:
+        type: string
+      minContains: 1
+      if:
+        required: [cat]
+      prefixItems:
+        - type: string
+      $defs:
+        tag:
+          type: string
+      patternProperties:
+        ^x-:
+          type: string
+      dependentRequired:
+        a: [b]
+      unevaluatedProperties: true`
+
+	right := `openapi: 3.1.0
+components:
+  schemas:
+    OK:
+      $id: https://pb33f.io/okay
+      const: chicken
+      contains:
+        type: integer
+      minContains: 2
+      then:
+        required: [meow]
+      prefixItems:
+        - type: string
+        - type: integer
+      $defs:
+        tag:
+          type: integer
+        name:
+          type: string
+      patternProperties:
+        ^x-:
+          type: string
+      dependentRequired:
+        a: [b, c]
+        d: [e]
+      unevaluatedProperties: false`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+
+	// $id, const, minContains, if, then, prefixItems, $defs, dependentRequired (x2), unevaluatedProperties
+	assert.Len(t, changes.Changes, 10)
+	assert.Equal(t, 12, changes.TotalChanges())
+
+	assert.NotNil(t, changes.ContainsChanges)
+	assert.Equal(t, v3.TypeLabel, changes.ContainsChanges.Changes[0].Property)
+	assert.Len(t, changes.DefsChanges, 1)
+	assert.NotNil(t, changes.DefsChanges["tag"])
+	assert.Nil(t, changes.PatternPropertiesChanges)
+	assert.Nil(t, changes.PrefixItemsChanges)
+
+	props := make(map[string]int)
+	for _, c := range changes.Changes {
+		props[c.Property]++
+	}
+	assert.Equal(t, 1, props[v3.IdLabel])
+	assert.Equal(t, 1, props[v3.ConstLabel])
+	assert.Equal(t, 1, props[v3.MinContainsLabel])
+	assert.Equal(t, 1, props[v3.IfLabel])
+	assert.Equal(t, 1, props[v3.ThenLabel])
+	assert.Equal(t, 1, props[v3.PrefixItemsLabel])
+	assert.Equal(t, 1, props[v3.DefsLabel])
+	assert.Equal(t, 2, props[v3.DependentRequiredLabel])
+	assert.Equal(t, 1, props[v3.UnevaluatedPropertiesLabel])
+}
+
+func TestCompareSchemas_JSONSchemaKeywords_Direction(t *testing.T) {
+	left := `openapi: 3.1.0
+components:
+  schemas:
+    OK:
+      unevaluatedProperties: true
+      prefixItems:
+        - type: string
+        - type: integer`
+
+	right := `openapi: 3.1.0
+components:
+  schemas:
+    OK:
+      unevaluatedProperties: false
+      prefixItems:
+        - type: string`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	// narrowing unevaluatedProperties breaks requests, removing a prefix item does not.
+	changes := CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionRequest)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	// removing a prefix item widens a response.
+	changes = CompareSchemasWithDirection(lSchemaProxy, rSchemaProxy, SchemaDirectionResponse)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
@@ -398,6 +398,45 @@ func TestCompareSchemas_EnumRemoved(t *testing.T) {
 	assert.Equal(t, v3.EnumLabel, changes.Changes[0].Property)
 }
 
+func TestCompareSchemas_EnumTyped(t *testing.T) {
+	left := `components:
+  schemas:
+    OK:
+      enum: [1, true, null, {a: b}, "2"]`
+
+	right := `components:
+  schemas:
+    OK:
+      enum: [1.0, "true", null, {a: b}, 2]`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	// the boolean and string values swap types, the numbers and object are the same.
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Len(t, changes.Changes, 4)
+
+	added := make(map[string]bool)
+	removed := make(map[string]bool)
+	for _, c := range changes.Changes {
+		assert.Equal(t, v3.EnumLabel, c.Property)
+		if c.ChangeType == PropertyAdded {
+			added[c.New] = true
+		} else {
+			removed[c.Original] = true
+		}
+	}
+	assert.True(t, added["true"])
+	assert.True(t, added["2"])
+	assert.True(t, removed["true"])
+	assert.True(t, removed["2"])
+
+	assert.Nil(t, CompareSchemas(lSchemaProxy, lSchemaProxy))
+}
+
 func TestCompareSchemas_PropertyAdded(t *testing.T) {
 	left := `components:
   schemas:
@@ -0,0 +1,85 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"gopkg.in/yaml.v3"
+	"sort"
+)
+
+// ScopesChanges represents changes found between two Swagger Scopes objects.
+type ScopesChanges struct {
+	PropertyChanges[*v2.Scopes]
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger Scopes objects.
+func (s *ScopesChanges) TotalChanges() int {
+	c := s.PropertyChanges.TotalChanges()
+	if s.ExtensionChanges != nil {
+		c += s.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger Scopes objects.
+func (s *ScopesChanges) TotalBreakingChanges() int {
+	c := s.PropertyChanges.TotalBreakingChanges()
+	if s.ExtensionChanges != nil {
+		c += s.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareScopes compares a left (original) and right (new) Swagger Scopes object for changes. Removing a scope is
+// a breaking change, adding a scope or changing the description of a scope is not. If changes are found, a pointer
+// to ScopesChanges is returned, otherwise nil is returned.
+func CompareScopes(l, r *v2.Scopes) *ScopesChanges {
+	var changes []*Change[*v2.Scopes]
+
+	lValues := make(map[string]low.ValueReference[string])
+	rValues := make(map[string]low.ValueReference[string])
+	lKeys := make(map[string]*yaml.Node)
+	rKeys := make(map[string]*yaml.Node)
+	var keys []string
+	for k := range l.Values {
+		lValues[k.Value] = l.Values[k]
+		lKeys[k.Value] = k.KeyNode
+		keys = append(keys, k.Value)
+	}
+	for k := range r.Values {
+		rValues[k.Value] = r.Values[k]
+		rKeys[k.Value] = k.KeyNode
+		if _, ok := lValues[k.Value]; !ok {
+			keys = append(keys, k.Value)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		lv, lok := lValues[k]
+		rv, rok := rValues[k]
+		if lok && !rok {
+			CreateChange[*v2.Scopes](&changes, ObjectRemoved, k, lKeys[k], nil, true, lv.Value, nil)
+			continue
+		}
+		if !lok && rok {
+			CreateChange[*v2.Scopes](&changes, ObjectAdded, k, nil, rKeys[k], false, nil, rv.Value)
+			continue
+		}
+		if lv.Value != rv.Value {
+			CreateChange[*v2.Scopes](&changes, Modified, k, lv.ValueNode, rv.ValueNode, false, lv.Value, rv.Value)
+		}
+	}
+
+	sc := new(ScopesChanges)
+	sc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	sc.Changes = changes
+	if sc.TotalChanges() <= 0 {
+		return nil
+	}
+	return sc
+}
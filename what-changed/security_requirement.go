@@ -0,0 +1,130 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"gopkg.in/yaml.v3"
+	"sort"
+	"strings"
+)
+
+// SecurityRequirementChanges represents changes found between two SecurityRequirement Objects.
+type SecurityRequirementChanges struct {
+	PropertyChanges[*v3.SecurityRequirement]
+}
+
+// TotalChanges returns the total number of changes between two SecurityRequirement Objects.
+func (s *SecurityRequirementChanges) TotalChanges() int {
+	return s.PropertyChanges.TotalChanges()
+}
+
+// TotalBreakingChanges returns the total number of breaking changes between two SecurityRequirement Objects.
+func (s *SecurityRequirementChanges) TotalBreakingChanges() int {
+	return s.PropertyChanges.TotalBreakingChanges()
+}
+
+// CompareSecurityRequirement compares left (original) and right (new) SecurityRequirement objects for changes.
+//
+// Requirements are matched using the names of the security schemes they contain. Removing a requirement is a
+// breaking change (clients using it can no longer authenticate), adding one is not. For requirements that exist
+// on both sides, adding a scope is a breaking change and removing a scope is not.
+//
+// If changes are found, a pointer to SecurityRequirementChanges is returned, otherwise nil is returned.
+func CompareSecurityRequirement(l, r *v3.SecurityRequirement) *SecurityRequirementChanges {
+	var changes []*Change[*v3.SecurityRequirement]
+
+	var lReqs, rReqs []low.ValueReference[map[low.KeyReference[string]][]low.ValueReference[string]]
+	if l != nil {
+		lReqs = l.ValueRequirements
+	}
+	if r != nil {
+		rReqs = r.ValueRequirements
+	}
+	checkSecurityRequirements(lReqs, rReqs, &changes, l, r)
+
+	sc := new(SecurityRequirementChanges)
+	sc.Changes = changes
+	if sc.TotalChanges() <= 0 {
+		return nil
+	}
+	return sc
+}
+
+// checkSecurityRequirements will compare a left (original) and right (new) slice of security requirements and
+// add any changes found to the supplied slice of changes. The rules are described by CompareSecurityRequirement.
+func checkSecurityRequirements[T any](l, r []low.ValueReference[map[low.KeyReference[string]][]low.ValueReference[string]],
+	changes *[]*Change[T], original, new any) {
+
+	lReqs, lKeys := flattenSecurityRequirements(l)
+	rReqs, rKeys := flattenSecurityRequirements(r)
+
+	for _, k := range lKeys {
+		if _, ok := rReqs[k]; !ok {
+			CreateChange[T](changes, ObjectRemoved, v3.SecurityLabel,
+				lReqs[k].ValueNode, nil, true, original, new)
+			continue
+		}
+		lScopes := flattenSecurityScopes(lReqs[k].Value)
+		rScopes := flattenSecurityScopes(rReqs[k].Value)
+		for _, s := range sortedKeys(lScopes) {
+			if rScopes[s] == nil {
+				CreateChange[T](changes, PropertyRemoved, v3.ScopesLabel,
+					lScopes[s], nil, false, original, new)
+			}
+		}
+		for _, s := range sortedKeys(rScopes) {
+			if lScopes[s] == nil {
+				CreateChange[T](changes, PropertyAdded, v3.ScopesLabel,
+					nil, rScopes[s], true, original, new)
+			}
+		}
+	}
+	for _, k := range rKeys {
+		if _, ok := lReqs[k]; !ok {
+			CreateChange[T](changes, ObjectAdded, v3.SecurityLabel,
+				nil, rReqs[k].ValueNode, false, original, new)
+		}
+	}
+}
+
+// flattenSecurityRequirements creates a map of requirements, keyed by the sorted names of schemes they contain.
+func flattenSecurityRequirements(req []low.ValueReference[map[low.KeyReference[string]][]low.ValueReference[string]]) (
+	map[string]low.ValueReference[map[low.KeyReference[string]][]low.ValueReference[string]], []string) {
+	reqs := make(map[string]low.ValueReference[map[low.KeyReference[string]][]low.ValueReference[string]])
+	var keys []string
+	for i := range req {
+		var names []string
+		for k := range req[i].Value {
+			names = append(names, k.Value)
+		}
+		sort.Strings(names)
+		key := strings.Join(names, "|")
+		reqs[key] = req[i]
+		keys = append(keys, key)
+	}
+	return reqs, keys
+}
+
+// flattenSecurityScopes creates a map of scope value nodes, keyed by scheme name and scope.
+func flattenSecurityScopes(req map[low.KeyReference[string]][]low.ValueReference[string]) map[string]*yaml.Node {
+	scopes := make(map[string]*yaml.Node)
+	for k := range req {
+		for i := range req[k] {
+			scopes[k.Value+"|"+req[k][i].Value] = req[k][i].ValueNode
+		}
+	}
+	return scopes
+}
+
+// sortedKeys returns the keys of a map of nodes in a predictable order.
+func sortedKeys(m map[string]*yaml.Node) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
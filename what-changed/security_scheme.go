@@ -0,0 +1,148 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// SecuritySchemeChanges represents changes made between two OpenAPI SecurityScheme objects.
+type SecuritySchemeChanges struct {
+	PropertyChanges[*v3.SecurityScheme]
+	OAuthFlowChanges *OAuthFlowsChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges represents the total number of changes made between two SecurityScheme objects.
+func (ss *SecuritySchemeChanges) TotalChanges() int {
+	c := ss.PropertyChanges.TotalChanges()
+	if ss.OAuthFlowChanges != nil {
+		c += ss.OAuthFlowChanges.TotalChanges()
+	}
+	if ss.ExtensionChanges != nil {
+		c += ss.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges represents the total number of breaking changes made between two SecurityScheme objects.
+func (ss *SecuritySchemeChanges) TotalBreakingChanges() int {
+	c := ss.PropertyChanges.TotalBreakingChanges()
+	if ss.OAuthFlowChanges != nil {
+		c += ss.OAuthFlowChanges.TotalBreakingChanges()
+	}
+	if ss.ExtensionChanges != nil {
+		c += ss.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareSecuritySchemes compares left (original) and right (new) SecurityScheme objects for changes. If anything
+// is found, a pointer to SecuritySchemeChanges is returned, otherwise nil is returned.
+func CompareSecuritySchemes(l, r *v3.SecurityScheme) *SecuritySchemeChanges {
+	var changes []*Change[*v3.SecurityScheme]
+	var props []*PropertyCheck[*v3.SecurityScheme]
+
+	// Type
+	props = append(props, &PropertyCheck[*v3.SecurityScheme]{
+		LeftNode:  l.Type.ValueNode,
+		RightNode: r.Type.ValueNode,
+		Label:     v3.TypeLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.SecurityScheme]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// Name
+	props = append(props, &PropertyCheck[*v3.SecurityScheme]{
+		LeftNode:  l.Name.ValueNode,
+		RightNode: r.Name.ValueNode,
+		Label:     v3.NameLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// In
+	props = append(props, &PropertyCheck[*v3.SecurityScheme]{
+		LeftNode:  l.In.ValueNode,
+		RightNode: r.In.ValueNode,
+		Label:     v3.InLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Scheme
+	props = append(props, &PropertyCheck[*v3.SecurityScheme]{
+		LeftNode:  l.Scheme.ValueNode,
+		RightNode: r.Scheme.ValueNode,
+		Label:     v3.SchemeLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// BearerFormat
+	props = append(props, &PropertyCheck[*v3.SecurityScheme]{
+		LeftNode:  l.BearerFormat.ValueNode,
+		RightNode: r.BearerFormat.ValueNode,
+		Label:     v3.BearerFormatLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// OpenIdConnectUrl
+	props = append(props, &PropertyCheck[*v3.SecurityScheme]{
+		LeftNode:  l.OpenIdConnectUrl.ValueNode,
+		RightNode: r.OpenIdConnectUrl.ValueNode,
+		Label:     v3.OpenIdConnectUrlLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	ss := new(SecuritySchemeChanges)
+
+	// flows
+	if l.Flows.Value != nil && r.Flows.Value != nil {
+		ss.OAuthFlowChanges = CompareOAuthFlows(l.Flows.Value, r.Flows.Value)
+	}
+	if l.Flows.Value != nil && r.Flows.Value == nil {
+		CreateChange[*v3.SecurityScheme](&changes, ObjectRemoved, v3.OAuthFlowsLabel,
+			l.Flows.ValueNode, nil, true, l.Flows.Value, nil)
+	}
+	if l.Flows.Value == nil && r.Flows.Value != nil {
+		CreateChange[*v3.SecurityScheme](&changes, ObjectAdded, v3.OAuthFlowsLabel,
+			nil, r.Flows.ValueNode, false, nil, r.Flows.Value)
+	}
+
+	ss.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	ss.Changes = changes
+	if ss.TotalChanges() <= 0 {
+		return nil
+	}
+	return ss
+}
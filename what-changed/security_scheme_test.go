@@ -0,0 +1,80 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareSecuritySchemes_Modified(t *testing.T) {
+
+	left := `type: http
+scheme: basic
+description: basic auth`
+
+	right := `type: http
+scheme: bearer
+bearerFormat: JWT
+description: bearer auth`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.SecurityScheme
+	var rDoc v3.SecurityScheme
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareSecuritySchemes(&lDoc, &rDoc)
+	assert.Equal(t, 3, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareSecuritySchemes_FlowsChanged(t *testing.T) {
+
+	left := `type: oauth2
+flows:
+  implicit:
+    authorizationUrl: https://pb33f.io/auth
+    scopes:
+      read:pizza: read pizza
+      write:pizza: write pizza`
+
+	right := `type: oauth2
+flows:
+  implicit:
+    authorizationUrl: https://pb33f.io/oauth
+    scopes:
+      read:pizza: read all the pizza
+  password:
+    tokenUrl: https://pb33f.io/token
+    scopes: {}`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.SecurityScheme
+	var rDoc v3.SecurityScheme
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareSecuritySchemes(&lDoc, &rDoc)
+	assert.Equal(t, 4, extChanges.TotalChanges())
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.OAuthFlowChanges.ImplicitChanges)
+}
@@ -0,0 +1,179 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// SecuritySchemeChangesV2 represents changes found between two Swagger SecurityScheme objects.
+type SecuritySchemeChangesV2 struct {
+	PropertyChanges[*v2.SecurityScheme]
+	ScopesChanges    *ScopesChanges
+	ExtensionChanges *ExtensionChanges
+}
+
+// TotalChanges returns the total number of changes found between two Swagger SecurityScheme objects.
+func (ss *SecuritySchemeChangesV2) TotalChanges() int {
+	c := ss.PropertyChanges.TotalChanges()
+	if ss.ScopesChanges != nil {
+		c += ss.ScopesChanges.TotalChanges()
+	}
+	if ss.ExtensionChanges != nil {
+		c += ss.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes found between two Swagger SecurityScheme objects.
+func (ss *SecuritySchemeChangesV2) TotalBreakingChanges() int {
+	c := ss.PropertyChanges.TotalBreakingChanges()
+	if ss.ScopesChanges != nil {
+		c += ss.ScopesChanges.TotalBreakingChanges()
+	}
+	if ss.ExtensionChanges != nil {
+		c += ss.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareSecuritySchemesV2 compares a left (original) and right (new) Swagger SecurityScheme object for changes.
+// If changes are found, a pointer to SecuritySchemeChangesV2 is returned, otherwise nil is returned.
+func CompareSecuritySchemesV2(l, r *v2.SecurityScheme) *SecuritySchemeChangesV2 {
+	var changes []*Change[*v2.SecurityScheme]
+	var props []*PropertyCheck[*v2.SecurityScheme]
+
+	// Type
+	props = append(props, &PropertyCheck[*v2.SecurityScheme]{
+		LeftNode:  l.Type.ValueNode,
+		RightNode: r.Type.ValueNode,
+		Label:     v3.TypeLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v2.SecurityScheme]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// Name
+	props = append(props, &PropertyCheck[*v2.SecurityScheme]{
+		LeftNode:  l.Name.ValueNode,
+		RightNode: r.Name.ValueNode,
+		Label:     v3.NameLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// In
+	props = append(props, &PropertyCheck[*v2.SecurityScheme]{
+		LeftNode:  l.In.ValueNode,
+		RightNode: r.In.ValueNode,
+		Label:     v3.InLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Flow
+	props = append(props, &PropertyCheck[*v2.SecurityScheme]{
+		LeftNode:  l.Flow.ValueNode,
+		RightNode: r.Flow.ValueNode,
+		Label:     v2.FlowLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// AuthorizationUrl
+	props = append(props, &PropertyCheck[*v2.SecurityScheme]{
+		LeftNode:  l.AuthorizationUrl.ValueNode,
+		RightNode: r.AuthorizationUrl.ValueNode,
+		Label:     v3.AuthorizationUrlLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// TokenUrl
+	props = append(props, &PropertyCheck[*v2.SecurityScheme]{
+		LeftNode:  l.TokenUrl.ValueNode,
+		RightNode: r.TokenUrl.ValueNode,
+		Label:     v3.TokenUrlLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	sc := new(SecuritySchemeChangesV2)
+
+	// scopes
+	if l.Scopes.Value != nil && r.Scopes.Value != nil {
+		sc.ScopesChanges = CompareScopes(l.Scopes.Value, r.Scopes.Value)
+	}
+	if l.Scopes.Value != nil && r.Scopes.Value == nil {
+		CreateChange[*v2.SecurityScheme](&changes, ObjectRemoved, v2.ScopesLabel,
+			l.Scopes.ValueNode, nil, true, l.Scopes.Value, nil)
+	}
+	if l.Scopes.Value == nil && r.Scopes.Value != nil {
+		CreateChange[*v2.SecurityScheme](&changes, ObjectAdded, v2.ScopesLabel,
+			nil, r.Scopes.ValueNode, false, nil, r.Scopes.Value)
+	}
+
+	sc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	sc.Changes = changes
+	if sc.TotalChanges() <= 0 {
+		return nil
+	}
+	return sc
+}
+
+// checkSecurityRequirementsV2 will compare a left (original) and right (new) slice of Swagger SecurityRequirement
+// objects, using the same rules as CompareSecurityRequirement. Any changes found are added to the supplied slice
+// of changes.
+func checkSecurityRequirementsV2[T any](l, r []low.ValueReference[*v2.SecurityRequirement], changes *[]*Change[T],
+	original, new any) {
+	checkSecurityRequirements(flattenSecurityRequirementsV2(l), flattenSecurityRequirementsV2(r),
+		changes, original, new)
+}
+
+// flattenSecurityRequirementsV2 converts a slice of Swagger SecurityRequirement objects into the same shape used
+// by OpenAPI 3 SecurityRequirement objects.
+func flattenSecurityRequirementsV2(reqs []low.ValueReference[*v2.SecurityRequirement]) []low.ValueReference[map[low.KeyReference[string]][]low.ValueReference[string]] {
+	var flat []low.ValueReference[map[low.KeyReference[string]][]low.ValueReference[string]]
+	for i := range reqs {
+		if reqs[i].Value == nil {
+			continue
+		}
+		m := make(map[low.KeyReference[string]][]low.ValueReference[string])
+		for k, v := range reqs[i].Value.Values.Value {
+			m[k] = v.Value
+		}
+		flat = append(flat, low.ValueReference[map[low.KeyReference[string]][]low.ValueReference[string]]{
+			Value:     m,
+			ValueNode: reqs[i].ValueNode,
+		})
+	}
+	return flat
+}
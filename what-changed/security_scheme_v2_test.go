@@ -0,0 +1,48 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareSecuritySchemesV2_Scopes(t *testing.T) {
+
+	left := `type: oauth2
+flow: implicit
+authorizationUrl: https://pb33f.io/auth
+scopes:
+  read:pizza: read pizza
+  write:pizza: bake pizza`
+
+	right := `type: oauth2
+flow: implicit
+authorizationUrl: https://pb33f.io/oauth
+scopes:
+  read:pizza: look at pizza
+  eat:pizza: eat pizza`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.SecurityScheme
+	var rDoc v2.SecurityScheme
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare, the URL change and scope removal break, the rest do not.
+	extChanges := CompareSecuritySchemesV2(&lDoc, &rDoc)
+	assert.Equal(t, 4, extChanges.TotalChanges())
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+	assert.Equal(t, 3, extChanges.ScopesChanges.TotalChanges())
+	assert.Nil(t, CompareSecuritySchemesV2(&lDoc, &lDoc))
+}
@@ -0,0 +1,110 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// ServerChanges represents changes made to a Server object, part of an OpenAPI 3+ document.
+type ServerChanges struct {
+	PropertyChanges[*v3.Server]
+	ServerVariableChanges map[string]*ServerVariableChanges
+}
+
+// TotalChanges returns the total number of changes made to a Server object and its variables.
+func (s *ServerChanges) TotalChanges() int {
+	c := s.PropertyChanges.TotalChanges()
+	for k := range s.ServerVariableChanges {
+		c += s.ServerVariableChanges[k].TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns the total number of breaking changes made to a Server object and its variables.
+func (s *ServerChanges) TotalBreakingChanges() int {
+	c := s.PropertyChanges.TotalBreakingChanges()
+	for k := range s.ServerVariableChanges {
+		c += s.ServerVariableChanges[k].TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareServers compares a left (original) and right (new) Server object for changes. If there are any,
+// a pointer to ServerChanges is returned, otherwise nil is returned.
+func CompareServers(l, r *v3.Server) *ServerChanges {
+	var changes []*Change[*v3.Server]
+	var props []*PropertyCheck[*v3.Server]
+
+	// URL
+	props = append(props, &PropertyCheck[*v3.Server]{
+		LeftNode:  l.URL.ValueNode,
+		RightNode: r.URL.ValueNode,
+		Label:     v3.URLLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.Server]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	sc := new(ServerChanges)
+
+	// check variables
+	sc.ServerVariableChanges = CheckMapForChanges(l.Variables.Value, r.Variables.Value, &changes,
+		false, true, CompareServerVariables)
+
+	sc.Changes = changes
+	if sc.TotalChanges() <= 0 {
+		return nil
+	}
+	return sc
+}
+
+// checkServers will compare a left (original) and right (new) slice of Server objects, servers are matched
+// by URL. Servers that have been added or removed are recorded in the supplied changes, servers that exist on
+// both sides are compared and any changes are returned.
+func checkServers[T any](l, r []low.ValueReference[*v3.Server], changes *[]*Change[T]) []*ServerChanges {
+	lServers := make(map[string]low.ValueReference[*v3.Server])
+	rServers := make(map[string]low.ValueReference[*v3.Server])
+	for i := range l {
+		lServers[l[i].Value.URL.Value] = l[i]
+	}
+	for i := range r {
+		rServers[r[i].Value.URL.Value] = r[i]
+	}
+	var serverChanges []*ServerChanges
+	for i := range l {
+		u := l[i].Value.URL.Value
+		if _, ok := rServers[u]; !ok {
+			CreateChange[T](changes, ObjectRemoved, v3.ServersLabel, l[i].ValueNode, nil,
+				true, l[i].Value, nil)
+			continue
+		}
+		if sc := CompareServers(l[i].Value, rServers[u].Value); sc != nil {
+			serverChanges = append(serverChanges, sc)
+		}
+	}
+	for i := range r {
+		if _, ok := lServers[r[i].Value.URL.Value]; !ok {
+			CreateChange[T](changes, ObjectAdded, v3.ServersLabel, nil, r[i].ValueNode,
+				false, nil, r[i].Value)
+		}
+	}
+	return serverChanges
+}
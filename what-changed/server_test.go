@@ -0,0 +1,72 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+func TestCompareServers_Modified(t *testing.T) {
+
+	left := `url: https://pb33f.io/{version}
+description: a server
+variables:
+  version:
+    default: v1
+    enum:
+      - v1
+      - v2`
+
+	right := `url: https://pb33f.io/{version}
+description: the server
+variables:
+  version:
+    default: v2
+    enum:
+      - v2
+      - v3`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Server
+	var rDoc v3.Server
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareServers(&lDoc, &rDoc)
+	assert.Equal(t, 4, extChanges.TotalChanges())
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+	assert.NotNil(t, extChanges.ServerVariableChanges["version"])
+}
+
+func TestCompareServers_Identical(t *testing.T) {
+
+	left := `url: https://pb33f.io
+description: a server`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(left), &rNode)
+
+	// create low level objects
+	var lDoc v3.Server
+	var rDoc v3.Server
+	_ = low.BuildModel(&lNode, &lDoc)
+	_ = low.BuildModel(&rNode, &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	assert.Nil(t, CompareServers(&lDoc, &rDoc))
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// ServerVariableChanges represents changes found between two OpenAPI ServerVariable Objects
+type ServerVariableChanges struct {
+	PropertyChanges[*v3.ServerVariable]
+}
+
+// TotalChanges returns the total number of changes made to a ServerVariable object.
+func (s *ServerVariableChanges) TotalChanges() int {
+	return s.PropertyChanges.TotalChanges()
+}
+
+// TotalBreakingChanges returns the number of breaking changes made to a ServerVariable object.
+func (s *ServerVariableChanges) TotalBreakingChanges() int {
+	return s.PropertyChanges.TotalBreakingChanges()
+}
+
+// CompareServerVariables compares a left (original) and right (new) ServerVariable object for changes.
+// If changes are found, a pointer to ServerVariableChanges is returned, otherwise nil is returned.
+func CompareServerVariables(l, r *v3.ServerVariable) *ServerVariableChanges {
+	var changes []*Change[*v3.ServerVariable]
+	var props []*PropertyCheck[*v3.ServerVariable]
+
+	// Default
+	props = append(props, &PropertyCheck[*v3.ServerVariable]{
+		LeftNode:  l.Default.ValueNode,
+		RightNode: r.Default.ValueNode,
+		Label:     v3.DefaultLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// Description
+	props = append(props, &PropertyCheck[*v3.ServerVariable]{
+		LeftNode:  l.Description.ValueNode,
+		RightNode: r.Description.ValueNode,
+		Label:     v3.DescriptionLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// Enum values, removing a value is a breaking change.
+	lEnum := make(map[string]int)
+	rEnum := make(map[string]int)
+	for i := range l.Enum {
+		lEnum[l.Enum[i].Value] = i
+	}
+	for i := range r.Enum {
+		rEnum[r.Enum[i].Value] = i
+	}
+	for i := range l.Enum {
+		if _, ok := rEnum[l.Enum[i].Value]; !ok {
+			CreateChange[*v3.ServerVariable](&changes, PropertyRemoved, v3.EnumLabel,
+				l.Enum[i].ValueNode, nil, true, l, r)
+		}
+	}
+	for i := range r.Enum {
+		if _, ok := lEnum[r.Enum[i].Value]; !ok {
+			CreateChange[*v3.ServerVariable](&changes, PropertyAdded, v3.EnumLabel,
+				nil, r.Enum[i].ValueNode, false, l, r)
+		}
+	}
+
+	sc := new(ServerVariableChanges)
+	sc.Changes = changes
+	if sc.TotalChanges() <= 0 {
+		return nil
+	}
+	return sc
+}
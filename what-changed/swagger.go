@@ -0,0 +1,135 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// CompareSwaggerDocuments will compare a left (original) and right (new) Swagger document for changes. Every
+// top-level object is compared, and the results are summarized into a WhatChanged object. A WhatChanged object is
+// always returned, if nothing changed then the TotalChanges will be zero and SwaggerChanges will be nil.
+func CompareSwaggerDocuments(l, r *v2.Swagger) *WhatChanged {
+	return CompareSwaggerDocumentsWithOptions(l, r, nil)
+}
+
+// CompareSwaggerDocumentsWithOptions will compare a left (original) and right (new) Swagger document for changes,
+// the same as CompareSwaggerDocuments. Any BreakingRules in the options decide which changes are breaking.
+func CompareSwaggerDocumentsWithOptions(l, r *v2.Swagger, options *CompareOptions) *WhatChanged {
+	changes := compareSwaggerDocuments(l, r)
+	if options != nil && options.BreakingRules != nil {
+		options.BreakingRules.Apply(changes)
+	}
+	return CreateSwaggerWhatChanged(changes)
+}
+
+// compareSwaggerDocuments will compare every top-level object of a left (original) and right (new) Swagger
+// document. If changes are found, a pointer to SwaggerChanges is returned, otherwise nil is returned.
+//
+// Changing the host or basePath is a breaking change. Removing a scheme or a consumed mime type is a breaking
+// change, adding one is not. Any change to produced mime types is a breaking change.
+func compareSwaggerDocuments(l, r *v2.Swagger) *SwaggerChanges {
+	var changes []*Change[any]
+	var props []*PropertyCheck[any]
+
+	// Swagger version, the version is held against the root node, so the actual value node needs locating.
+	if l.Swagger.Value != r.Swagger.Value {
+		CreateChange[any](&changes, Modified, v2.SwaggerLabel,
+			findVersionNode(l.Swagger.ValueNode), findVersionNode(r.Swagger.ValueNode), true, l, r)
+	}
+
+	// Host
+	props = append(props, &PropertyCheck[any]{
+		LeftNode:  l.Host.ValueNode,
+		RightNode: r.Host.ValueNode,
+		Label:     v2.HostLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// BasePath
+	props = append(props, &PropertyCheck[any]{
+		LeftNode:  l.BasePath.ValueNode,
+		RightNode: r.BasePath.ValueNode,
+		Label:     v2.BasePathLabel,
+		Changes:   &changes,
+		Breaking:  true,
+		Original:  l,
+		New:       r,
+	})
+
+	// check properties
+	CheckProperties(props)
+
+	// schemes, consumes and produces
+	CheckStringSliceForChanges(l.Schemes.Value, r.Schemes.Value, v2.SchemesLabel, &changes, false, true, l, r)
+	CheckStringSliceForChanges(l.Consumes.Value, r.Consumes.Value, v2.ConsumesLabel, &changes, false, true, l, r)
+	CheckStringSliceForChanges(l.Produces.Value, r.Produces.Value, v2.ProducesLabel, &changes, true, true, l, r)
+
+	sc := new(SwaggerChanges)
+
+	// info
+	if l.Info.Value != nil && r.Info.Value != nil {
+		sc.InfoChanges = CompareInfo(l.Info.Value, r.Info.Value)
+	}
+	checkDocumentObject(l.Info, r.Info, base.InfoLabel, &changes, false, true)
+
+	// paths
+	if l.Paths.Value != nil && r.Paths.Value != nil {
+		sc.PathsChanges = ComparePathsV2(l.Paths.Value, r.Paths.Value)
+	}
+	checkDocumentObject(l.Paths, r.Paths, v2.PathsLabel, &changes, false, true)
+
+	// definitions
+	if l.Definitions.Value != nil && r.Definitions.Value != nil {
+		sc.DefinitionsChanges = CompareDefinitions(l.Definitions.Value, r.Definitions.Value)
+	}
+	checkDocumentObject(l.Definitions, r.Definitions, v2.DefinitionsLabel, &changes, false, true)
+
+	// parameter definitions
+	if l.Parameters.Value != nil && r.Parameters.Value != nil {
+		sc.ParameterDefinitionsChanges = CompareParameterDefinitions(l.Parameters.Value, r.Parameters.Value)
+	}
+	checkDocumentObject(l.Parameters, r.Parameters, v2.ParametersLabel, &changes, false, true)
+
+	// responses definitions
+	if l.Responses.Value != nil && r.Responses.Value != nil {
+		sc.ResponsesDefinitionsChanges = CompareResponsesDefinitions(l.Responses.Value, r.Responses.Value)
+	}
+	checkDocumentObject(l.Responses, r.Responses, v2.ResponsesLabel, &changes, false, true)
+
+	// security definitions
+	if l.SecurityDefinitions.Value != nil && r.SecurityDefinitions.Value != nil {
+		sc.SecurityDefinitionsChanges = CompareSecurityDefinitions(l.SecurityDefinitions.Value,
+			r.SecurityDefinitions.Value)
+	}
+	checkDocumentObject(l.SecurityDefinitions, r.SecurityDefinitions, v2.SecurityDefinitionsLabel,
+		&changes, false, true)
+
+	// security
+	checkSecurityRequirementsV2(l.Security.Value, r.Security.Value, &changes, l, r)
+
+	// tags
+	sc.TagChanges = CompareTags(l.Tags.Value, r.Tags.Value)
+
+	// external docs
+	if l.ExternalDocs.Value != nil && r.ExternalDocs.Value != nil {
+		if !low.AreEqual(l.ExternalDocs.Value, r.ExternalDocs.Value) {
+			sc.ExternalDocChanges = CompareExternalDocs(l.ExternalDocs.Value, r.ExternalDocs.Value)
+		}
+	}
+	checkDocumentObject(l.ExternalDocs, r.ExternalDocs, v3.ExternalDocsLabel, &changes, false, false)
+
+	sc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	sc.Changes = changes
+	if sc.TotalChanges() <= 0 {
+		return nil
+	}
+	return sc
+}
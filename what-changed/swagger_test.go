@@ -0,0 +1,105 @@
+// Copyright 2022 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func TestCompareSwaggerDocuments_Identical(t *testing.T) {
+
+	spec, _ := ioutil.ReadFile("../test_specs/petstorev2-complete.yaml")
+
+	lInfo, _ := datamodel.ExtractSpecInfo(spec)
+	rInfo, _ := datamodel.ExtractSpecInfo(spec)
+	lDoc, _ := lowv2.CreateDocument(lInfo)
+	rDoc, _ := lowv2.CreateDocument(rInfo)
+
+	// compare.
+	changes := CompareSwaggerDocuments(lDoc, rDoc)
+	assert.Equal(t, 0, changes.TotalChanges)
+	assert.Nil(t, changes.SwaggerChanges)
+}
+
+func TestCompareSwaggerDocuments_Modified(t *testing.T) {
+
+	left := `swagger: 2.0
+info:
+  title: pizza shop
+host: pb33f.io
+basePath: /api
+schemes:
+  - http
+  - https
+consumes:
+  - application/json
+produces:
+  - application/json
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      responses:
+        "200":
+          description: a pizza
+definitions:
+  Pizza:
+    type: object
+securityDefinitions:
+  ApiKey:
+    type: apiKey
+    name: X-API-KEY
+    in: header`
+
+	right := `swagger: 2.0
+info:
+  title: pizza shop
+host: pb33f.io
+basePath: /api/v2
+schemes:
+  - https
+consumes:
+  - application/json
+  - application/xml
+produces:
+  - application/json
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      responses:
+        "200":
+          description: a lovely pizza
+definitions:
+  Pizza:
+    type: object
+  Burger:
+    type: object
+securityDefinitions:
+  ApiKey:
+    type: apiKey
+    name: X-PIZZA-KEY
+    in: header`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := lowv2.CreateDocument(lInfo)
+	rDoc, _ := lowv2.CreateDocument(rInfo)
+
+	// compare.
+	changes := CompareSwaggerDocuments(lDoc, rDoc)
+	assert.Equal(t, 6, changes.TotalChanges)
+	assert.Equal(t, 3, changes.TotalBreakingChanges)
+	assert.Equal(t, 3, changes.Modified)
+	assert.Equal(t, 2, changes.Added)
+	assert.Equal(t, 1, changes.Removed)
+	assert.NotNil(t, changes.SwaggerChanges.PathsChanges)
+	assert.NotNil(t, changes.SwaggerChanges.DefinitionsChanges)
+	assert.NotNil(t, changes.SwaggerChanges.SecurityDefinitionsChanges)
+	assert.Nil(t, changes.SwaggerChanges.InfoChanges)
+}
@@ -31,7 +31,14 @@ func (t *TagChanges) TotalChanges() int {
 
 // TotalBreakingChanges returns the number of breaking changes made by Tags
 func (t *TagChanges) TotalBreakingChanges() int {
-	return t.PropertyChanges.TotalBreakingChanges()
+	c := t.PropertyChanges.TotalBreakingChanges()
+	if t.ExternalDocs != nil {
+		c += t.ExternalDocs.TotalBreakingChanges()
+	}
+	if t.ExtensionChanges != nil {
+		c += t.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
 }
 
 // CompareTags will compare a left (original) and a right (new) slice of ValueReference nodes for
@@ -90,8 +97,19 @@ func CompareTags(l, r []low.ValueReference[*base.Tag]) *TagChanges {
 			CheckProperties(props)
 
 			// compare external docs
-			tc.ExternalDocs = CompareExternalDocs(seenLeft[i].Value.ExternalDocs.Value,
-				seenRight[i].Value.ExternalDocs.Value)
+			lExt := seenLeft[i].Value.ExternalDocs
+			rExt := seenRight[i].Value.ExternalDocs
+			if lExt.Value != nil && rExt.Value != nil {
+				tc.ExternalDocs = CompareExternalDocs(lExt.Value, rExt.Value)
+			}
+			if lExt.Value != nil && rExt.Value == nil {
+				CreateChange[*base.Tag](&changes, ObjectRemoved, v3.ExternalDocsLabel,
+					lExt.ValueNode, nil, false, lExt.Value, nil)
+			}
+			if lExt.Value == nil && rExt.Value != nil {
+				CreateChange[*base.Tag](&changes, ObjectAdded, v3.ExternalDocsLabel,
+					nil, rExt.ValueNode, false, nil, rExt.Value)
+			}
 
 			// check extensions
 			tc.ExtensionChanges = CheckExtensions(seenLeft[i].GetValue(), seenRight[i].GetValue())
@@ -3,3 +3,75 @@
 
 package what_changed
 
+import (
+	"reflect"
+)
+
+// CreateWhatChanged will summarize a pointer to Changes into a WhatChanged object. Every Change found throughout
+// the entire tree of changes is counted against the type of change it represents. If changes is nil, an empty
+// WhatChanged is returned.
+func CreateWhatChanged(changes *Changes) *WhatChanged {
+	wc := new(WhatChanged)
+	if changes == nil {
+		return wc
+	}
+	wc.Changes = changes
+	wc.TotalChanges = changes.TotalChanges()
+	wc.TotalBreakingChanges = changes.TotalBreakingChanges()
+	countChanges(reflect.ValueOf(changes), wc)
+	return wc
+}
+
+// CreateSwaggerWhatChanged will summarize a pointer to SwaggerChanges into a WhatChanged object. Every Change found
+// throughout the entire tree of changes is counted against the type of change it represents. If changes is nil,
+// an empty WhatChanged is returned.
+func CreateSwaggerWhatChanged(changes *SwaggerChanges) *WhatChanged {
+	wc := new(WhatChanged)
+	if changes == nil {
+		return wc
+	}
+	wc.SwaggerChanges = changes
+	wc.TotalChanges = changes.TotalChanges()
+	wc.TotalBreakingChanges = changes.TotalBreakingChanges()
+	countChanges(reflect.ValueOf(changes), wc)
+	return wc
+}
+
+// countChanges walks a tree of change objects and counts each Change found against a WhatChanged summary.
+// Change objects hold references to the low-level models that changed, so they are never walked into.
+func countChanges(v reflect.Value, wc *WhatChanged) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			countChanges(v.Elem(), wc)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			countChanges(v.Index(i), wc)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			countChanges(iter.Value(), wc)
+		}
+	case reflect.Struct:
+		if ct := v.FieldByName("ChangeType"); ct.IsValid() && ct.Kind() == reflect.Int {
+			switch int(ct.Int()) {
+			case Modified:
+				wc.Modified++
+			case PropertyAdded, ObjectAdded:
+				wc.Added++
+			case PropertyRemoved, ObjectRemoved:
+				wc.Removed++
+			case Moved:
+				wc.Moved++
+			case ModifiedAndMoved:
+				wc.ModifiedAndMoved++
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			countChanges(v.Field(i), wc)
+		}
+	}
+}
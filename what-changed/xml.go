@@ -25,7 +25,11 @@ func (x *XMLChanges) TotalChanges() int {
 
 // TotalBreakingChanges returns the number of breaking changes made by the XML object.
 func (x *XMLChanges) TotalBreakingChanges() int {
-	return x.PropertyChanges.TotalBreakingChanges()
+	c := x.PropertyChanges.TotalBreakingChanges()
+	if x.ExtensionChanges != nil {
+		c += x.ExtensionChanges.TotalBreakingChanges()
+	}
+	return c
 }
 
 // CompareXML will compare a left (original) and a right (new) XML instance, and check for